@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"runtime"
+
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/golfdata"
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/logging"
+	"github.com/Jacobbrewer1/uhttp"
+	"github.com/google/subcommands"
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+type golfDataCmd struct {
+	// port is the port to listen on
+	port string
+
+	// coursesDir is the directory that the course files are loaded from
+	coursesDir string
+}
+
+func (g *golfDataCmd) Name() string {
+	return "golfdata"
+}
+
+func (g *golfDataCmd) Synopsis() string {
+	return "Start a golf data service that serves courses from local files"
+}
+
+func (g *golfDataCmd) Usage() string {
+	return `golfdata:
+  Start a golf data service that serves the courses defined in the JSON and YAML files in a directory.
+`
+}
+
+func (g *golfDataCmd) SetFlags(f *flag.FlagSet) {
+	f.StringVar(&g.port, "port", "8081", "The port to listen on")
+	f.StringVar(&g.coursesDir, "courses", "courses", "The directory that the course files are loaded from")
+}
+
+func (g *golfDataCmd) Execute(ctx context.Context, _ *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
+	r := mux.NewRouter()
+	err := g.setup(r)
+	if err != nil {
+		slog.Error("Error setting up server", slog.String(logging.KeyError, err.Error()))
+		return subcommands.ExitFailure
+	}
+
+	slog.Info(
+		"Starting golf data service",
+		slog.String("version", Commit),
+		slog.String("runtime", fmt.Sprintf("%s %s/%s", runtime.Version(), runtime.GOOS, runtime.GOARCH)),
+		slog.String("build_date", Date),
+		slog.String("courses", g.coursesDir),
+	)
+
+	srv := &http.Server{
+		Addr:    ":" + g.port,
+		Handler: r,
+	}
+
+	// Start the server in a goroutine, so we can listen for the context to be done.
+	go func(srv *http.Server) {
+		err := srv.ListenAndServe()
+		if errors.Is(err, http.ErrServerClosed) {
+			slog.Info("Server closed gracefully")
+			os.Exit(0)
+		} else if err != nil {
+			slog.Error("Error serving requests", slog.String(logging.KeyError, err.Error()))
+			os.Exit(1)
+		}
+	}(srv)
+
+	<-ctx.Done()
+	slog.Info("Shutting down golf data service")
+	if err := srv.Shutdown(ctx); err != nil {
+		slog.Error("Error shutting down golf data service", slog.String(logging.KeyError, err.Error()))
+		return subcommands.ExitFailure
+	}
+
+	return subcommands.ExitSuccess
+}
+
+func (g *golfDataCmd) setup(r *mux.Router) error {
+	client, err := golfdata.NewFileClient(g.coursesDir)
+	if err != nil {
+		return fmt.Errorf("error loading courses: %w", err)
+	}
+
+	r.HandleFunc("/metrics", uhttp.InternalOnly(promhttp.Handler())).Methods(http.MethodGet)
+
+	r.NotFoundHandler = uhttp.NotFoundHandler()
+	r.MethodNotAllowedHandler = uhttp.MethodNotAllowedHandler()
+
+	golfdata.RegisterHandlers(r, client)
+
+	return nil
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/logging"
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/migrations"
+	"github.com/google/subcommands"
+)
+
+type migrateCmd struct {
+	// configLocation is the location of the config file
+	configLocation string
+
+	// steps is the number of migrations to apply or revert
+	steps int
+
+	// lockTimeout is the time to wait for another run of the migrations to finish
+	lockTimeout time.Duration
+}
+
+func (m *migrateCmd) Name() string {
+	return "migrate"
+}
+
+func (m *migrateCmd) Synopsis() string {
+	return "Apply, revert or list the database schema migrations"
+}
+
+func (m *migrateCmd) Usage() string {
+	return `migrate [flags] up|down|status:
+  up      Apply the migrations that have not been applied, or at most -steps of them.
+  down    Revert the most recently applied migration, or the last -steps of them.
+  status  List the migrations and when they were applied.
+`
+}
+
+func (m *migrateCmd) SetFlags(f *flag.FlagSet) {
+	f.StringVar(&m.configLocation, "config", "config.json", "The location of the config file")
+	f.IntVar(&m.steps, "steps", 0, "The number of migrations to apply or revert, all for up and one for down if not set")
+	f.DurationVar(&m.lockTimeout, "lock-timeout", 30*time.Second, "The time to wait for another run of the migrations to finish")
+}
+
+func (m *migrateCmd) Execute(ctx context.Context, f *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
+	if f.NArg() != 1 {
+		fmt.Fprint(os.Stderr, m.Usage())
+		return subcommands.ExitUsageError
+	}
+
+	action := f.Arg(0)
+	switch action {
+	case "up", "down", "status":
+	default:
+		fmt.Fprint(os.Stderr, m.Usage())
+		return subcommands.ExitUsageError
+	}
+
+	if err := m.run(ctx, action); err != nil {
+		slog.Error("Error running migrations", slog.String("action", action), slog.String(logging.KeyError, err.Error()))
+		return subcommands.ExitFailure
+	}
+
+	return subcommands.ExitSuccess
+}
+
+func (m *migrateCmd) run(ctx context.Context, action string) error {
+	v, err := loadConfig(m.configLocation)
+	if err != nil {
+		return err
+	}
+
+	if driver := databaseDriver(v); driver != databaseDriverMySQL {
+		return fmt.Errorf("migrations only apply to %s databases, %s databases have their schema created when opened", databaseDriverMySQL, driver)
+	}
+
+	vc, err := newVaultClient(ctx, v)
+	if err != nil {
+		return err
+	}
+
+	db, err := connectDatabase(ctx, v, vc)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	all, err := migrations.All()
+	if err != nil {
+		return err
+	}
+
+	migrator := migrations.NewMigrator(db.DB.DB, all, migrations.WithLockTimeout(m.lockTimeout))
+
+	switch action {
+	case "up":
+		applied, err := migrator.Up(ctx, m.steps)
+		if err != nil {
+			return err
+		}
+		slog.Info("Applied migrations", slog.Int("count", len(applied)))
+	case "down":
+		reverted, err := migrator.Down(ctx, m.steps)
+		if err != nil {
+			return err
+		}
+		slog.Info("Reverted migrations", slog.Int("count", len(reverted)))
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		return printMigrationStatuses(statuses)
+	default:
+		return errors.New("unknown action")
+	}
+
+	return nil
+}
+
+// printMigrationStatuses prints the migrations as a table to stdout.
+func printMigrationStatuses(statuses []*migrations.MigrationStatus) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT\tNOTES")
+
+	for _, status := range statuses {
+		appliedAt := "pending"
+		if status.AppliedAt != nil {
+			appliedAt = status.AppliedAt.UTC().Format(time.RFC3339)
+		}
+
+		notes := ""
+		switch {
+		case !status.Known:
+			notes = "unknown migration"
+		case status.ChecksumMismatch:
+			notes = "checksum mismatch"
+		}
+
+		fmt.Fprintf(w, "%04d\t%s\t%s\t%s\n", status.Version, status.Name, appliedAt, notes)
+	}
+
+	return w.Flush()
+}
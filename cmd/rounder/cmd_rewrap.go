@@ -0,0 +1,333 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/logging"
+	repo "github.com/Jacobbrewer1/golf-stats-tracker/pkg/repositories/rounder"
+	"github.com/Jacobbrewer1/vaulty"
+	"github.com/google/subcommands"
+	"github.com/spf13/viper"
+)
+
+const (
+	// defaultRewrapBatchSize is the default number of values rewrapped with each request to vault.
+	defaultRewrapBatchSize = 100
+)
+
+type rewrapPasswordsCmd struct {
+	// configLocation is the location of the config file
+	configLocation string
+
+	// batchSize is the number of values read and rewrapped at a time
+	batchSize int
+
+	// dryRun reports the values that would be rewrapped without changing them
+	dryRun bool
+}
+
+func (c *rewrapPasswordsCmd) Name() string {
+	return "rewrap-passwords"
+}
+
+func (c *rewrapPasswordsCmd) Synopsis() string {
+	return "Rewrap the encrypted passwords with the latest vault transit key version"
+}
+
+func (c *rewrapPasswordsCmd) Usage() string {
+	return `rewrap-passwords:
+  Rewrap the vault encrypted passwords and TOTP secrets that are not encrypted with the latest version of the
+  vault.transit.key, so that the minimum decryption version of the key can be raised after it is rotated.
+`
+}
+
+func (c *rewrapPasswordsCmd) SetFlags(f *flag.FlagSet) {
+	f.StringVar(&c.configLocation, "config", "config.json", "The location of the config file")
+	f.IntVar(&c.batchSize, "batch-size", defaultRewrapBatchSize, "The number of values rewrapped at a time")
+	f.BoolVar(&c.dryRun, "dry-run", false, "Report the values that would be rewrapped without changing them")
+}
+
+func (c *rewrapPasswordsCmd) Execute(ctx context.Context, _ *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
+	if err := c.run(ctx); err != nil {
+		slog.Error("Error rewrapping passwords", slog.String(logging.KeyError, err.Error()))
+		return subcommands.ExitFailure
+	}
+
+	return subcommands.ExitSuccess
+}
+
+// rewrapTarget is a column of vault encrypted values to rewrap.
+type rewrapTarget struct {
+	// name describes the values in the output.
+	name string
+
+	// get gets a batch of values after the given ID.
+	get func(ctx context.Context, afterId, limit int) ([]*repo.EncryptedValue, error)
+
+	// replace replaces a value, returning false if it changed since it was read.
+	replace func(ctx context.Context, id int, oldValue, newValue string) (bool, error)
+}
+
+// rewrapResult counts what happened to the values of a target.
+type rewrapResult struct {
+	scanned   int
+	outdated  int
+	rewrapped int
+	changed   int
+	failed    int
+}
+
+func (c *rewrapPasswordsCmd) run(ctx context.Context) error {
+	if c.batchSize <= 0 {
+		return errors.New("batch size must be positive")
+	}
+
+	v, err := loadConfig(c.configLocation)
+	if err != nil {
+		return err
+	}
+
+	if provider := encryptionProvider(v); provider != providerVault {
+		return fmt.Errorf("only %s encrypted secrets can be rewrapped, the secrets are encrypted with %s", providerVault, provider)
+	}
+
+	vc, err := newVaultClient(ctx, v)
+	if err != nil {
+		return err
+	}
+
+	db, err := openDatabase(ctx, v, vc)
+	if err != nil {
+		return err
+	}
+
+	r := repo.NewRepository(queryTimeoutDB(v, db))
+	key := newTransitKey(vc, v)
+
+	latest, err := key.latestVersion(ctx)
+	if err != nil {
+		return fmt.Errorf("error getting latest key version: %w", err)
+	}
+
+	slog.Info("Rewrapping to latest key version", slog.Int("version", latest), slog.Bool("dry_run", c.dryRun))
+
+	targets := []rewrapTarget{
+		{name: "passwords", get: r.GetUserPasswords, replace: r.ReplaceUserPassword},
+		{name: "totp secrets", get: r.GetTotpSecrets, replace: r.ReplaceTotpSecret},
+	}
+
+	failed := 0
+	for _, target := range targets {
+		res, err := c.rewrap(ctx, key, target, latest)
+		if err != nil {
+			return fmt.Errorf("error rewrapping %s: %w", target.name, err)
+		}
+
+		slog.Info(
+			"Finished rewrapping "+target.name,
+			slog.Int("scanned", res.scanned),
+			slog.Int("outdated", res.outdated),
+			slog.Int("rewrapped", res.rewrapped),
+			slog.Int("changed", res.changed),
+			slog.Int("failed", res.failed),
+		)
+
+		failed += res.failed
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d values could not be rewrapped", failed)
+	}
+
+	return nil
+}
+
+// rewrap rewraps the values of the target that are not on the latest key version, a batch at a time.
+func (c *rewrapPasswordsCmd) rewrap(ctx context.Context, key *transitKey, target rewrapTarget, latest int) (*rewrapResult, error) {
+	res := new(rewrapResult)
+	afterId := 0
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		values, err := target.get(ctx, afterId, c.batchSize)
+		if err != nil {
+			return nil, err
+		} else if len(values) == 0 {
+			return res, nil
+		}
+
+		afterId = values[len(values)-1].Id
+		res.scanned += len(values)
+
+		outdated := make([]*repo.EncryptedValue, 0, len(values))
+		for _, value := range values {
+			version, err := ciphertextKeyVersion(value.Ciphertext)
+			if err != nil {
+				slog.Warn("Skipping value that is not vault ciphertext", slog.String("target", target.name), slog.Int("id", value.Id))
+				res.failed++
+				continue
+			}
+
+			if version < latest {
+				outdated = append(outdated, value)
+			}
+		}
+
+		res.outdated += len(outdated)
+
+		if !c.dryRun && len(outdated) > 0 {
+			err = c.rewrapBatch(ctx, key, target, outdated, res)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		slog.Info(
+			"Processed batch of "+target.name,
+			slog.Int("last_id", afterId),
+			slog.Int("scanned", res.scanned),
+			slog.Int("outdated", res.outdated),
+			slog.Int("rewrapped", res.rewrapped),
+		)
+	}
+}
+
+// rewrapBatch rewraps the values with a single request to vault and stores the new ciphertexts.
+func (c *rewrapPasswordsCmd) rewrapBatch(ctx context.Context, key *transitKey, target rewrapTarget, values []*repo.EncryptedValue, res *rewrapResult) error {
+	ciphertexts := make([]string, len(values))
+	for i, value := range values {
+		ciphertexts[i] = value.Ciphertext
+	}
+
+	rewrapped, err := key.rewrap(ctx, ciphertexts)
+	if err != nil {
+		return err
+	}
+
+	for i, value := range values {
+		if rewrapped[i] == "" {
+			res.failed++
+			continue
+		}
+
+		ok, err := target.replace(ctx, value.Id, value.Ciphertext, rewrapped[i])
+		if err != nil {
+			return err
+		}
+
+		if ok {
+			res.rewrapped++
+		} else {
+			// The value was changed while it was being rewrapped, so the new value is already on the latest version.
+			res.changed++
+		}
+	}
+
+	return nil
+}
+
+// transitKey is the vault transit key that secrets are encrypted with.
+type transitKey struct {
+	vc vaulty.Client
+
+	// mount is the path the transit engine is mounted at.
+	mount string
+
+	// name is the name of the key.
+	name string
+}
+
+func newTransitKey(vc vaulty.Client, v *viper.Viper) *transitKey {
+	return &transitKey{
+		vc:    vc,
+		mount: v.GetString("vault.transit.name"),
+		name:  v.GetString("vault.transit.key"),
+	}
+}
+
+// latestVersion gets the latest version of the key, which new ciphertexts are encrypted with.
+func (k *transitKey) latestVersion(ctx context.Context) (int, error) {
+	secret, err := k.vc.Client().Logical().ReadWithContext(ctx, fmt.Sprintf("%s/keys/%s", k.mount, k.name))
+	if err != nil {
+		return 0, err
+	} else if secret == nil {
+		return 0, vaulty.ErrSecretNotFound
+	}
+
+	return intFromSecretData(secret.Data["latest_version"])
+}
+
+// rewrap rewraps the ciphertexts with the latest version of the key. The result for a ciphertext that vault could not
+// rewrap is empty, so that the rest of the batch can still be stored.
+func (k *transitKey) rewrap(ctx context.Context, ciphertexts []string) ([]string, error) {
+	input := make([]map[string]any, len(ciphertexts))
+	for i, ciphertext := range ciphertexts {
+		input[i] = map[string]any{vaulty.TransitCipherText: ciphertext}
+	}
+
+	secret, err := k.vc.Client().Logical().WriteWithContext(ctx, fmt.Sprintf("%s/rewrap/%s", k.mount, k.name), map[string]any{
+		"batch_input": input,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error rewrapping batch: %w", err)
+	} else if secret == nil {
+		return nil, errors.New("no response from vault")
+	}
+
+	results, ok := secret.Data["batch_results"].([]any)
+	if !ok || len(results) != len(ciphertexts) {
+		return nil, errors.New("unexpected batch results from vault")
+	}
+
+	rewrapped := make([]string, len(results))
+	for i, result := range results {
+		item, ok := result.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		if msg, ok := item["error"].(string); ok && msg != "" {
+			slog.Warn("Vault could not rewrap value", slog.String(logging.KeyError, msg))
+			continue
+		}
+
+		rewrapped[i], _ = item[vaulty.TransitCipherText].(string)
+	}
+
+	return rewrapped, nil
+}
+
+// ciphertextKeyVersion gets the key version from a vault transit ciphertext, which is in the format "vault:v1:...".
+func ciphertextKeyVersion(ciphertext string) (int, error) {
+	parts := strings.SplitN(ciphertext, ":", 3)
+	if len(parts) != 3 || parts[0] != "vault" || !strings.HasPrefix(parts[1], "v") {
+		return 0, errors.New("invalid vault ciphertext")
+	}
+
+	return strconv.Atoi(strings.TrimPrefix(parts[1], "v"))
+}
+
+// intFromSecretData converts a number from the data of a vault secret, which is decoded as a json.Number.
+func intFromSecretData(value any) (int, error) {
+	switch n := value.(type) {
+	case json.Number:
+		i, err := n.Int64()
+		return int(i), err
+	case float64:
+		return int(n), nil
+	case int:
+		return n, nil
+	default:
+		return 0, fmt.Errorf("unexpected number type %T", value)
+	}
+}
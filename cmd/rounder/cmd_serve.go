@@ -6,20 +6,27 @@ import (
 	"flag"
 	"fmt"
 	"log/slog"
+	"math"
 	"net/http"
 	"os"
 	"runtime"
 
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/auth"
 	api "github.com/Jacobbrewer1/golf-stats-tracker/pkg/codegen/apis/rounder"
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/golfdata"
 	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/logging"
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/models"
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/notify"
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/oidc"
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/policy"
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/ratelimit"
 	repo "github.com/Jacobbrewer1/golf-stats-tracker/pkg/repositories/rounder"
 	svc "github.com/Jacobbrewer1/golf-stats-tracker/pkg/services/rounder"
 	"github.com/Jacobbrewer1/uhttp"
-	"github.com/Jacobbrewer1/vaulty"
-	"github.com/Jacobbrewer1/vaulty/repositories"
 	"github.com/google/subcommands"
 	"github.com/gorilla/mux"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/spf13/cast"
 	"github.com/spf13/viper"
 )
 
@@ -93,68 +100,86 @@ func (s *serveCmd) Execute(ctx context.Context, f *flag.FlagSet, _ ...interface{
 }
 
 func (s *serveCmd) setup(ctx context.Context, r *mux.Router) (err error) {
-	v := viper.New()
-	v.SetConfigFile(s.configLocation)
-	if err := v.ReadInConfig(); err != nil {
-		return fmt.Errorf("error reading config file: %w", err)
+	v, err := loadConfig(s.configLocation)
+	if err != nil {
+		return err
 	}
 
-	if !v.IsSet("hosts.golfdata") {
+	if !v.IsSet("hosts.golfdata") && !v.IsSet("golfdata.courses_dir") {
 		return errors.New("golfdata host configuration not found")
 	}
 
-	if !v.IsSet("vault") {
-		return errors.New("vault configuration not found")
+	vc, err := newVaultClient(ctx, v)
+	if err != nil {
+		return err
+	}
+
+	db, err := openDatabase(ctx, v, vc)
+	if err != nil {
+		return err
 	}
 
-	slog.Info("Vault configuration found, attempting to connect")
+	queryDB := queryTimeoutDB(v, db)
 
-	vc, err := vaulty.NewClient(
-		vaulty.WithContext(ctx),
-		vaulty.WithGeneratedVaultClient(v.GetString("vault.address")),
-		vaulty.WithUserPassAuth(
-			v.GetString("vault.auth.username"),
-			v.GetString("vault.auth.password"),
-		),
-		vaulty.WithKvv2Mount(v.GetString("vault.kvv2_mount")),
-	)
+	encrypter, err := newEncrypter(v, vc)
 	if err != nil {
-		return fmt.Errorf("error creating vault client: %w", err)
+		return fmt.Errorf("error creating encrypter: %w", err)
 	}
 
-	slog.Debug("Vault client created")
+	gd, err := golfDataClient(v, queryDB)
+	if err != nil {
+		return fmt.Errorf("error creating golf data client: %w", err)
+	}
 
-	vs, err := vc.Path(v.GetString("vault.database.role"), vaulty.WithPrefix(v.GetString("vault.database.path"))).GetSecret(ctx)
-	if errors.Is(err, vaulty.ErrSecretNotFound) {
-		return fmt.Errorf("secrets not found in vault: %s", v.GetString("vault.database.path"))
-	} else if err != nil {
-		return fmt.Errorf("error getting secrets from vault: %w", err)
+	tokens, err := accessTokens(v)
+	if err != nil {
+		return fmt.Errorf("error creating access tokens: %w", err)
 	}
 
-	dbConnector, err := repositories.NewDatabaseConnector(
-		repositories.WithContext(ctx),
-		repositories.WithVaultClient(vc),
-		repositories.WithCurrentSecrets(vs),
-		repositories.WithViper(v),
-	)
+	notifier, err := newNotifier(v)
 	if err != nil {
-		return fmt.Errorf("error creating database connector: %w", err)
+		return fmt.Errorf("error creating notifier: %w", err)
 	}
 
-	db, err := dbConnector.ConnectDB()
+	lockout := loginLockout(v)
+
+	credentials, err := credentialCache(v)
 	if err != nil {
-		return fmt.Errorf("error connecting to database: %w", err)
+		return fmt.Errorf("error creating credential cache: %w", err)
 	}
 
-	slog.Info("Database connection generate from vault secrets")
+	oidcProviders, err := newOidcProviders(v)
+	if err != nil {
+		return fmt.Errorf("error creating oidc providers: %w", err)
+	}
 
-	repository := repo.NewRepository(db)
-	service := svc.NewService(repository, vc, v.GetString("hosts.golfdata"), v)
-	svcAuthz := svc.NewAuthz(service, repository, vc, v)
+	rateLimitStore, err := newRateLimitStore(v, queryDB)
+	if err != nil {
+		return fmt.Errorf("error creating rate limit store: %w", err)
+	}
+
+	proxies, err := auth.ParseTrustedProxies(v.GetStringSlice("trusted_proxies"))
+	if err != nil {
+		return fmt.Errorf("error parsing trusted proxies: %w", err)
+	}
+
+	rateLimiter := svc.NewRateLimiter(lockout, tokens, rateLimitStore, rateLimitPolicies(v))
+
+	passwords, err := passwordHasher(v)
+	if err != nil {
+		return fmt.Errorf("error creating password hasher: %w", err)
+	}
+
+	repository := repo.NewRepository(queryDB)
+	service := svc.NewService(repository, gd, tokens, notifier, lockout, encrypter, credentials, passwords, oidcProviders, policy.NewPolicy(repository), v)
+	svcAuthz := svc.NewAuthz(service, repository, tokens, lockout)
 
 	r.HandleFunc("/metrics", uhttp.InternalOnly(promhttp.Handler())).Methods(http.MethodGet)
 	r.HandleFunc("/health", uhttp.InternalOnly(healthHandler(db))).Methods(http.MethodGet)
 
+	// The client IP must be known before the rate limiter runs, as it locks out and limits clients by their IP.
+	r.Use(svc.ClientIPMiddleware(proxies))
+
 	r.NotFoundHandler = uhttp.NotFoundHandler()
 	r.MethodNotAllowedHandler = uhttp.MethodNotAllowedHandler()
 
@@ -162,6 +187,7 @@ func (s *serveCmd) setup(ctx context.Context, r *mux.Router) (err error) {
 		r,
 		service,
 		api.WithAuthorization(svcAuthz),
+		api.WithRateLimiter(rateLimiter),
 		api.WithMetricsMiddleware(metricsMiddleware),
 		api.WithErrorHandlerFunc(uhttp.GenericErrorHandler),
 	)
@@ -169,9 +195,261 @@ func (s *serveCmd) setup(ctx context.Context, r *mux.Router) (err error) {
 	api.RegisterUnauthedHandlers(
 		r,
 		service,
+		api.WithRateLimiter(rateLimiter),
 		api.WithMetricsMiddleware(metricsMiddleware),
 		api.WithErrorHandlerFunc(uhttp.GenericErrorHandler),
 	)
 
 	return nil
 }
+
+// accessTokens creates the access tokens from the config, falling back to the defaults for any settings that are not
+// configured.
+func accessTokens(v *viper.Viper) (auth.Tokens, error) {
+	if !v.IsSet("auth.token.signing_key") {
+		return nil, errors.New("token signing key configuration not found")
+	}
+
+	opts := make([]auth.TokenOption, 0)
+
+	if v.IsSet("auth.token.ttl") {
+		opts = append(opts, auth.WithTokenTTL(v.GetDuration("auth.token.ttl")))
+	}
+
+	if v.IsSet("auth.token.issuer") {
+		opts = append(opts, auth.WithIssuer(v.GetString("auth.token.issuer")))
+	}
+
+	return auth.NewTokens([]byte(v.GetString("auth.token.signing_key")), opts...)
+}
+
+// loginLockout creates the lockout for failed authentication attempts from the config, falling back to the defaults
+// for any settings that are not configured.
+func loginLockout(v *viper.Viper) auth.Lockout {
+	opts := make([]auth.LockoutOption, 0)
+
+	if v.IsSet("auth.lockout.threshold") {
+		opts = append(opts, auth.WithLockoutThreshold(v.GetInt("auth.lockout.threshold")))
+	}
+
+	if v.IsSet("auth.lockout.base_delay") || v.IsSet("auth.lockout.max_delay") {
+		opts = append(opts, auth.WithLockoutDelay(
+			v.GetDuration("auth.lockout.base_delay"),
+			v.GetDuration("auth.lockout.max_delay"),
+		))
+	}
+
+	if v.IsSet("auth.lockout.reset_after") {
+		opts = append(opts, auth.WithLockoutResetAfter(v.GetDuration("auth.lockout.reset_after")))
+	}
+
+	return auth.NewLockout(opts...)
+}
+
+// credentialCache creates the cache of verified passwords from the config, falling back to the defaults for any
+// settings that are not configured. Nothing is cached if auth.credential_cache.enabled is false.
+func credentialCache(v *viper.Viper) (auth.CredentialCache, error) {
+	opts := make([]auth.CredentialCacheOption, 0)
+
+	if v.IsSet("auth.credential_cache.enabled") && !v.GetBool("auth.credential_cache.enabled") {
+		opts = append(opts, auth.WithCredentialCacheTTL(0))
+	} else if v.IsSet("auth.credential_cache.ttl") {
+		opts = append(opts, auth.WithCredentialCacheTTL(v.GetDuration("auth.credential_cache.ttl")))
+	}
+
+	if v.IsSet("auth.credential_cache.max_entries") {
+		opts = append(opts, auth.WithCredentialCacheMaxEntries(v.GetInt("auth.credential_cache.max_entries")))
+	}
+
+	return auth.NewCredentialCache(opts...)
+}
+
+// passwordHasher creates the hasher of passwords from the config, falling back to the defaults for any argon2id
+// parameters that are not configured. Raising the parameters rehashes the password of each user on their next login.
+func passwordHasher(v *viper.Viper) (auth.PasswordHasher, error) {
+	memory, err := configUint(v, "auth.password.argon2id.memory", math.MaxUint32)
+	if err != nil {
+		return nil, err
+	}
+
+	iterations, err := configUint(v, "auth.password.argon2id.iterations", math.MaxUint32)
+	if err != nil {
+		return nil, err
+	}
+
+	parallelism, err := configUint(v, "auth.password.argon2id.parallelism", math.MaxUint8)
+	if err != nil {
+		return nil, err
+	}
+
+	return auth.NewPasswordHasher(
+		auth.WithArgon2idMemory(uint32(memory)),
+		auth.WithArgon2idIterations(uint32(iterations)),
+		auth.WithArgon2idParallelism(uint8(parallelism)),
+	), nil
+}
+
+// configUint gets a whole number from the config, returning an error if it is not a number, or is negative or larger
+// than max, rather than reading it as zero or letting it wrap around when it is converted to a smaller type.
+func configUint(v *viper.Viper, key string, max uint64) (uint64, error) {
+	value, err := cast.ToInt64E(v.Get(key))
+	if err != nil {
+		return 0, fmt.Errorf("%s must be a whole number: %w", key, err)
+	}
+
+	if value < 0 || uint64(value) > max {
+		return 0, fmt.Errorf("%s must be between 0 and %d, got %d", key, max, value)
+	}
+
+	return uint64(value), nil
+}
+
+// newOidcProviders creates the OpenID Connect providers that users can log in with from the config, keyed by the
+// name of the provider under auth.oidc.providers.
+func newOidcProviders(v *viper.Viper) (map[string]oidc.Provider, error) {
+	providers := make(map[string]oidc.Provider)
+
+	for name := range v.GetStringMap("auth.oidc.providers") {
+		key := "auth.oidc.providers." + name
+
+		provider, err := oidc.NewProvider(oidc.Config{
+			Issuer:       v.GetString(key + ".issuer"),
+			ClientId:     v.GetString(key + ".client_id"),
+			ClientSecret: v.GetString(key + ".client_secret"),
+			RedirectURL:  v.GetString(key + ".redirect_url"),
+			Scopes:       v.GetStringSlice(key + ".scopes"),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error creating oidc provider %s: %w", name, err)
+		}
+
+		slog.Info("OIDC provider configured", slog.String("provider", name), slog.String("issuer", v.GetString(key+".issuer")))
+		providers[name] = provider
+	}
+
+	return providers, nil
+}
+
+// newRateLimitStore creates the store for the rate limit buckets from the config. The buckets are held in memory if no
+// store is configured, and nil is returned if rate limiting is disabled.
+func newRateLimitStore(v *viper.Viper, db models.DBTransactioner) (ratelimit.Store, error) {
+	if v.IsSet("rate_limit.enabled") && !v.GetBool("rate_limit.enabled") {
+		return nil, nil
+	}
+
+	switch storeType := v.GetString("rate_limit.store"); storeType {
+	case "", "memory":
+		return ratelimit.NewMemoryStore(), nil
+	case "database":
+		return ratelimit.NewDatabaseStore(db), nil
+	default:
+		return nil, fmt.Errorf("unknown rate limit store: %s", storeType)
+	}
+}
+
+// rateLimitPolicies returns the limits of the rate limit policies from the config, falling back to the defaults for
+// any policies or settings that are not configured.
+func rateLimitPolicies(v *viper.Viper) map[string]ratelimit.Limit {
+	policies := svc.DefaultRateLimitPolicies()
+
+	for name := range v.GetStringMap("rate_limit.policies") {
+		limit := policies[name]
+		if limit == (ratelimit.Limit{}) {
+			limit = policies[svc.RateLimitPolicyDefault]
+		}
+
+		key := "rate_limit.policies." + name
+		if v.IsSet(key + ".rate") {
+			limit.Rate = v.GetFloat64(key + ".rate")
+		}
+
+		if v.IsSet(key + ".burst") {
+			limit.Burst = v.GetInt(key + ".burst")
+		}
+
+		policies[name] = limit
+	}
+
+	return policies
+}
+
+// newNotifier creates the notifier used to send messages to users from the config. A notifier must be configured, as
+// the messages include password reset tokens, which the stdout notifier would write to the logs of a deployment that
+// forgot to configure one.
+func newNotifier(v *viper.Viper) (notify.Notifier, error) {
+	switch notifierType := v.GetString("notifier.type"); notifierType {
+	case "":
+		return nil, errors.New("notifier type configuration not found")
+	case "stdout":
+		slog.Warn("Notifications, including password reset tokens, are written to stdout, which is only suitable for development")
+		return notify.NewWriterNotifier(os.Stdout), nil
+	case "file":
+		if !v.IsSet("notifier.file.path") {
+			return nil, errors.New("notifier file path configuration not found")
+		}
+
+		return notify.NewFileNotifier(v.GetString("notifier.file.path")), nil
+	default:
+		return nil, fmt.Errorf("unknown notifier type: %s", notifierType)
+	}
+}
+
+// golfDataClient creates the cached golf data client from the config, falling back to the client defaults for any
+// settings that are not configured. If a courses directory is configured, the courses are served from the files in
+// it instead of the golf data service.
+func golfDataClient(v *viper.Viper, db models.DB) (golfdata.Client, error) {
+	if v.IsSet("golfdata.courses_dir") {
+		slog.Info("Serving golf data from local files", slog.String("courses", v.GetString("golfdata.courses_dir")))
+		return golfdata.NewFileClient(v.GetString("golfdata.courses_dir"))
+	}
+
+	opts := make([]golfdata.ClientOption, 0)
+
+	if v.IsSet("golfdata.timeout") {
+		opts = append(opts, golfdata.WithTimeout(v.GetDuration("golfdata.timeout")))
+	}
+
+	if v.IsSet("golfdata.max_retries") {
+		opts = append(opts, golfdata.WithMaxRetries(v.GetUint64("golfdata.max_retries")))
+	}
+
+	if v.IsSet("golfdata.backoff") {
+		opts = append(opts, golfdata.WithBackoff(
+			v.GetDuration("golfdata.backoff.initial"),
+			v.GetDuration("golfdata.backoff.max"),
+		))
+	}
+
+	if v.IsSet("golfdata.circuit_breaker") {
+		opts = append(opts, golfdata.WithCircuitBreaker(
+			v.GetInt("golfdata.circuit_breaker.failure_threshold"),
+			v.GetDuration("golfdata.circuit_breaker.cooldown"),
+		))
+	}
+
+	client := golfdata.NewClient(v.GetString("hosts.golfdata"), opts...)
+
+	if v.IsSet("golfdata.cache.enabled") && !v.GetBool("golfdata.cache.enabled") {
+		return client, nil
+	}
+
+	cacheOpts := make([]golfdata.CacheOption, 0)
+
+	if v.IsSet("golfdata.cache.ttl") {
+		cacheOpts = append(cacheOpts, golfdata.WithCacheTTL(v.GetDuration("golfdata.cache.ttl")))
+	}
+
+	if v.IsSet("golfdata.cache.max_stale") {
+		cacheOpts = append(cacheOpts, golfdata.WithCacheMaxStale(v.GetDuration("golfdata.cache.max_stale")))
+	}
+
+	if v.IsSet("golfdata.cache.max_entries") {
+		cacheOpts = append(cacheOpts, golfdata.WithCacheMaxEntries(v.GetInt("golfdata.cache.max_entries")))
+	}
+
+	if v.GetBool("golfdata.cache.persist") {
+		cacheOpts = append(cacheOpts, golfdata.WithCacheStore(golfdata.NewDatabaseStore(db)))
+	}
+
+	return golfdata.NewCachedClient(client, cacheOpts...), nil
+}
@@ -1,6 +1,223 @@
 package main
 
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/models"
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/secrets"
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/sqlite"
+	"github.com/Jacobbrewer1/vaulty"
+	"github.com/Jacobbrewer1/vaulty/repositories"
+	"github.com/spf13/viper"
+)
+
 const (
 	// appName is the name of the application.
 	appName = "rounder"
+
+	// envPrefix is the prefix of the environment variables that override the config, such as ROUNDER_DATABASE_PASSWORD
+	// for database.password.
+	envPrefix = "ROUNDER"
+
+	// databaseDriverMySQL is the database driver for MariaDB.
+	databaseDriverMySQL = "mysql"
+
+	// databaseDriverSQLite is the database driver for a local SQLite file, for development and tests.
+	databaseDriverSQLite = "sqlite"
+
+	// providerVault gets secrets from vault. It is the default for the database credentials and the encryption.
+	providerVault = "vault"
+
+	// providerStatic uses the database credentials from the config or the environment.
+	providerStatic = "static"
+
+	// providerLocal encrypts with a key from a local file.
+	providerLocal = "local"
 )
+
+// loadConfig reads the config file at the given location. Any setting can be overridden with an environment variable,
+// so that secrets such as the database password do not need to be in the file.
+func loadConfig(location string) (*viper.Viper, error) {
+	v := viper.New()
+	v.SetEnvPrefix(envPrefix)
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	v.AutomaticEnv()
+
+	v.SetConfigFile(location)
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("error reading config file: %w", err)
+	}
+
+	return v, nil
+}
+
+// databaseDriver gets the database driver from the config, which is MariaDB unless database.driver says otherwise.
+func databaseDriver(v *viper.Viper) string {
+	return providerOrDefault(v, "database.driver", databaseDriverMySQL)
+}
+
+// databaseCredentialsProvider gets where the MariaDB credentials come from, which is vault unless
+// database.credentials says otherwise.
+func databaseCredentialsProvider(v *viper.Viper) string {
+	return providerOrDefault(v, "database.credentials", providerVault)
+}
+
+// encryptionProvider gets what encrypts the stored secrets, which is vault unless secrets.encryption says otherwise.
+func encryptionProvider(v *viper.Viper) string {
+	return providerOrDefault(v, "secrets.encryption", providerVault)
+}
+
+func providerOrDefault(v *viper.Viper, key, defaultProvider string) string {
+	if !v.IsSet(key) {
+		return defaultProvider
+	}
+
+	return v.GetString(key)
+}
+
+// vaultRequired returns whether any of the configured providers need vault.
+func vaultRequired(v *viper.Viper) bool {
+	if encryptionProvider(v) == providerVault {
+		return true
+	}
+
+	return databaseDriver(v) == databaseDriverMySQL && databaseCredentialsProvider(v) == providerVault
+}
+
+// newVaultClient creates a vault client that is logged in with the credentials in the config. No client is created
+// if none of the configured providers need vault.
+func newVaultClient(ctx context.Context, v *viper.Viper) (vaulty.Client, error) {
+	if !vaultRequired(v) {
+		slog.Info("Vault is not required by the configured providers")
+		return nil, nil
+	}
+
+	if !v.IsSet("vault") {
+		return nil, errors.New("vault configuration not found")
+	}
+
+	slog.Info("Vault configuration found, attempting to connect")
+
+	vc, err := vaulty.NewClient(
+		vaulty.WithContext(ctx),
+		vaulty.WithGeneratedVaultClient(v.GetString("vault.address")),
+		vaulty.WithUserPassAuth(
+			v.GetString("vault.auth.username"),
+			v.GetString("vault.auth.password"),
+		),
+		vaulty.WithKvv2Mount(v.GetString("vault.kvv2_mount")),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error creating vault client: %w", err)
+	}
+
+	slog.Debug("Vault client created")
+
+	return vc, nil
+}
+
+// newEncrypter creates the encrypter of the stored secrets selected by secrets.encryption. Vault encrypts with the
+// transit key at vault.transit, and local encrypts with the key in the file at secrets.local.key_file.
+func newEncrypter(v *viper.Viper, vc vaulty.Client) (secrets.Encrypter, error) {
+	switch provider := encryptionProvider(v); provider {
+	case providerVault:
+		return secrets.NewVaultEncrypter(vc, v.GetString("vault.transit.name"), v.GetString("vault.transit.key")), nil
+	case providerLocal:
+		if !v.IsSet("secrets.local.key_file") {
+			return nil, errors.New("secrets.local.key_file is required for local encryption")
+		}
+
+		slog.Info("Encrypting secrets with a local key", slog.String("key_file", v.GetString("secrets.local.key_file")))
+		return secrets.NewLocalEncrypterFromFile(v.GetString("secrets.local.key_file"))
+	default:
+		return nil, fmt.Errorf("unknown encryption provider %q", provider)
+	}
+}
+
+// openDatabase opens the database selected by database.driver. A SQLite database is opened from database.sqlite.path,
+// or in memory if no path is set, and has its schema created if it does not exist.
+func openDatabase(ctx context.Context, v *viper.Viper, vc vaulty.Client) (*repositories.Database, error) {
+	switch driver := databaseDriver(v); driver {
+	case databaseDriverMySQL:
+		return connectDatabase(ctx, v, vc)
+	case databaseDriverSQLite:
+		path := v.GetString("database.sqlite.path")
+		if path == "" {
+			path = sqlite.MemoryPath
+		}
+
+		slog.Info("Using sqlite database", slog.String("path", path))
+		return sqlite.Open(path)
+	default:
+		return nil, fmt.Errorf("unknown database driver %q", driver)
+	}
+}
+
+// queryTimeoutDB wraps the database so that each query and each transaction has the deadline set by
+// database.query_timeout. They are only bounded by the request that runs them if no timeout is set.
+func queryTimeoutDB(v *viper.Viper, db *repositories.Database) *models.TimeoutDB {
+	timeout := v.GetDuration("database.query_timeout")
+	if timeout > 0 {
+		slog.Info("Database queries have a timeout", slog.Duration("timeout", timeout))
+	}
+
+	return models.NewTimeoutDB(db, timeout)
+}
+
+// connectDatabase connects to MariaDB with the credentials from the provider selected by database.credentials. Vault
+// generates credentials for vault.database.role, while static uses database.username and database.password.
+func connectDatabase(ctx context.Context, v *viper.Viper, vc vaulty.Client) (*repositories.Database, error) {
+	var connector repositories.DatabaseConnector
+
+	switch provider := databaseCredentialsProvider(v); provider {
+	case providerVault:
+		var err error
+		connector, err = vaultDatabaseConnector(ctx, v, vc)
+		if err != nil {
+			return nil, err
+		}
+	case providerStatic:
+		connector = secrets.NewStaticDatabaseConnector(
+			v.GetString("database.host"),
+			v.GetString("database.schema"),
+			v.GetString("database.username"),
+			v.GetString("database.password"),
+		)
+	default:
+		return nil, fmt.Errorf("unknown database credentials provider %q", provider)
+	}
+
+	db, err := connector.ConnectDB()
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to database: %w", err)
+	}
+
+	return db, nil
+}
+
+// vaultDatabaseConnector creates a connector that uses credentials generated by vault, which are renewed for as long as
+// the context is not done.
+func vaultDatabaseConnector(ctx context.Context, v *viper.Viper, vc vaulty.Client) (repositories.DatabaseConnector, error) {
+	vs, err := vc.Path(v.GetString("vault.database.role"), vaulty.WithPrefix(v.GetString("vault.database.path"))).GetSecret(ctx)
+	if errors.Is(err, vaulty.ErrSecretNotFound) {
+		return nil, fmt.Errorf("secrets not found in vault: %s", v.GetString("vault.database.path"))
+	} else if err != nil {
+		return nil, fmt.Errorf("error getting secrets from vault: %w", err)
+	}
+
+	dbConnector, err := repositories.NewDatabaseConnector(
+		repositories.WithContext(ctx),
+		repositories.WithVaultClient(vc),
+		repositories.WithCurrentSecrets(vs),
+		repositories.WithViper(v),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error creating database connector: %w", err)
+	}
+
+	return dbConnector, nil
+}
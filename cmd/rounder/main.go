@@ -8,6 +8,7 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	_ "time/tzdata" // Embed the timezone database so that user timezones load without it installed in the image.
 
 	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/logging"
 	"github.com/google/subcommands"
@@ -25,6 +26,9 @@ func main() {
 
 	subcommands.Register(new(versionCmd), "")
 	subcommands.Register(new(serveCmd), "")
+	subcommands.Register(new(golfDataCmd), "")
+	subcommands.Register(new(rewrapPasswordsCmd), "")
+	subcommands.Register(new(migrateCmd), "")
 
 	flag.Parse()
 
@@ -0,0 +1,202 @@
+package auth
+
+import (
+	"container/list"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultCredentialCacheTTL is the default time a verified password is remembered for.
+	defaultCredentialCacheTTL = time.Minute
+
+	// defaultCredentialCacheMaxEntries is the default number of verified passwords remembered.
+	defaultCredentialCacheMaxEntries = 10000
+
+	// credentialCacheKeyLength is the length in bytes of the key used to hash the credentials.
+	credentialCacheKeyLength = 32
+)
+
+// CredentialCache remembers passwords that have recently been verified, so that verifying the same password again
+// does not need to decrypt the stored password and compare the hash. Only a keyed hash of the credentials is held, with
+// a key that is generated when the cache is created and never leaves the process.
+type CredentialCache interface {
+	// Verified returns whether the password was recently verified against the stored password of the user.
+	Verified(userId int, password, storedPassword string) bool
+
+	// Add remembers that the password has been verified against the stored password of the user.
+	Add(userId int, password, storedPassword string)
+
+	// Invalidate forgets the verified passwords of the user, such as when their password changes.
+	Invalidate(userId int)
+}
+
+type credentialEntry struct {
+	// key is the keyed hash of the credentials.
+	key string
+
+	// userId is the ID of the user the credentials belong to.
+	userId int
+
+	// expiresAt is when the entry is no longer used.
+	expiresAt time.Time
+}
+
+type credentialCache struct {
+	mut sync.Mutex
+
+	// hashKey is the key used to hash the credentials.
+	hashKey []byte
+
+	// ttl is the time a verified password is remembered for. Nothing is remembered if it is not positive.
+	ttl time.Duration
+
+	// maxEntries is the number of verified passwords remembered.
+	maxEntries int
+
+	// entries indexes the elements of lru by key.
+	entries map[string]*list.Element
+
+	// lru holds the entries, with the most recently used at the front.
+	lru *list.List
+
+	// now is the clock used by the cache.
+	now func() time.Time
+}
+
+// CredentialCacheOption is a function that configures the credential cache.
+type CredentialCacheOption func(c *credentialCache)
+
+// WithCredentialCacheTTL sets the time a verified password is remembered for. Passwords are not remembered if the
+// ttl is not positive.
+func WithCredentialCacheTTL(ttl time.Duration) CredentialCacheOption {
+	return func(c *credentialCache) {
+		c.ttl = ttl
+	}
+}
+
+// WithCredentialCacheMaxEntries sets the number of verified passwords remembered.
+func WithCredentialCacheMaxEntries(maxEntries int) CredentialCacheOption {
+	return func(c *credentialCache) {
+		c.maxEntries = maxEntries
+	}
+}
+
+// NewCredentialCache creates a credential cache that holds the verified passwords in memory.
+func NewCredentialCache(opts ...CredentialCacheOption) (CredentialCache, error) {
+	hashKey := make([]byte, credentialCacheKeyLength)
+	if _, err := rand.Read(hashKey); err != nil {
+		return nil, err
+	}
+
+	c := &credentialCache{
+		hashKey:    hashKey,
+		ttl:        defaultCredentialCacheTTL,
+		maxEntries: defaultCredentialCacheMaxEntries,
+		entries:    make(map[string]*list.Element),
+		lru:        list.New(),
+		now:        time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c, nil
+}
+
+func (c *credentialCache) Verified(userId int, password, storedPassword string) bool {
+	if c.ttl <= 0 {
+		return false
+	}
+
+	key := c.key(userId, password, storedPassword)
+
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		credentialCacheRequests.WithLabelValues(cacheResultMiss).Inc()
+		return false
+	}
+
+	entry := elem.Value.(*credentialEntry)
+	if !c.now().Before(entry.expiresAt) {
+		c.remove(elem)
+		credentialCacheRequests.WithLabelValues(cacheResultMiss).Inc()
+		return false
+	}
+
+	c.lru.MoveToFront(elem)
+	credentialCacheRequests.WithLabelValues(cacheResultHit).Inc()
+	return true
+}
+
+func (c *credentialCache) Add(userId int, password, storedPassword string) {
+	if c.ttl <= 0 || c.maxEntries <= 0 {
+		return
+	}
+
+	key := c.key(userId, password, storedPassword)
+
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	expiresAt := c.now().Add(c.ttl)
+
+	if elem, ok := c.entries[key]; ok {
+		elem.Value.(*credentialEntry).expiresAt = expiresAt
+		c.lru.MoveToFront(elem)
+		return
+	}
+
+	c.entries[key] = c.lru.PushFront(&credentialEntry{
+		key:       key,
+		userId:    userId,
+		expiresAt: expiresAt,
+	})
+
+	for c.lru.Len() > c.maxEntries {
+		c.remove(c.lru.Back())
+	}
+
+	credentialCacheEntries.Set(float64(c.lru.Len()))
+}
+
+func (c *credentialCache) Invalidate(userId int) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	for elem := c.lru.Front(); elem != nil; {
+		next := elem.Next()
+		if elem.Value.(*credentialEntry).userId == userId {
+			c.remove(elem)
+		}
+		elem = next
+	}
+}
+
+// remove removes the element from the cache.
+func (c *credentialCache) remove(elem *list.Element) {
+	c.lru.Remove(elem)
+	delete(c.entries, elem.Value.(*credentialEntry).key)
+	credentialCacheEntries.Set(float64(c.lru.Len()))
+}
+
+// key returns the keyed hash of the credentials. The stored password is included so that an entry is not used once
+// the password has changed, even if the cache was not invalidated.
+func (c *credentialCache) key(userId int, password, storedPassword string) string {
+	mac := hmac.New(sha256.New, c.hashKey)
+	mac.Write([]byte(strconv.Itoa(userId)))
+	mac.Write([]byte{0})
+	mac.Write([]byte(storedPassword))
+	mac.Write([]byte{0})
+	mac.Write([]byte(password))
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,118 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCredentialCache(t *testing.T) {
+	now := time.Now()
+	cc, err := NewCredentialCache(
+		WithCredentialCacheTTL(time.Minute),
+		WithCredentialCacheMaxEntries(2),
+	)
+	require.NoError(t, err)
+
+	c := cc.(*credentialCache)
+	c.now = func() time.Time { return now }
+
+	tests := []struct {
+		name           string
+		action         func()
+		userId         int
+		password       string
+		storedPassword string
+		want           bool
+	}{
+		{
+			name:           "unknown credentials",
+			action:         func() {},
+			userId:         1,
+			password:       "password",
+			storedPassword: "stored",
+			want:           false,
+		},
+		{
+			name:           "verified credentials",
+			action:         func() { c.Add(1, "password", "stored") },
+			userId:         1,
+			password:       "password",
+			storedPassword: "stored",
+			want:           true,
+		},
+		{
+			name:           "wrong password",
+			action:         func() {},
+			userId:         1,
+			password:       "wrong",
+			storedPassword: "stored",
+			want:           false,
+		},
+		{
+			name:           "changed stored password",
+			action:         func() {},
+			userId:         1,
+			password:       "password",
+			storedPassword: "changed",
+			want:           false,
+		},
+		{
+			name:           "other user",
+			action:         func() {},
+			userId:         2,
+			password:       "password",
+			storedPassword: "stored",
+			want:           false,
+		},
+		{
+			name:           "expired",
+			action:         func() { now = now.Add(2 * time.Minute) },
+			userId:         1,
+			password:       "password",
+			storedPassword: "stored",
+			want:           false,
+		},
+		{
+			name: "invalidated",
+			action: func() {
+				c.Add(1, "password", "stored")
+				c.Invalidate(1)
+			},
+			userId:         1,
+			password:       "password",
+			storedPassword: "stored",
+			want:           false,
+		},
+		{
+			name: "least recently used is evicted",
+			action: func() {
+				c.Add(1, "password", "stored")
+				c.Add(2, "password", "stored")
+				c.Add(3, "password", "stored")
+			},
+			userId:         1,
+			password:       "password",
+			storedPassword: "stored",
+			want:           false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.action()
+			require.Equal(t, tt.want, c.Verified(tt.userId, tt.password, tt.storedPassword))
+		})
+	}
+
+	require.Len(t, c.entries, 2)
+}
+
+func TestCredentialCacheDisabled(t *testing.T) {
+	c, err := NewCredentialCache(WithCredentialCacheTTL(0))
+	require.NoError(t, err)
+
+	c.Add(1, "password", "stored")
+	require.False(t, c.Verified(1, "password", "stored"))
+}
@@ -0,0 +1,183 @@
+package auth
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// defaultLockoutThreshold is the default number of consecutive failures before a key is locked out.
+	defaultLockoutThreshold = 5
+
+	// defaultLockoutBaseDelay is the default time a key is locked out for when it first reaches the threshold.
+	defaultLockoutBaseDelay = time.Second
+
+	// defaultLockoutMaxDelay is the default maximum time a key is locked out for.
+	defaultLockoutMaxDelay = 15 * time.Minute
+
+	// defaultLockoutResetAfter is the default time without a failure after which the failures of a key are forgotten.
+	defaultLockoutResetAfter = time.Hour
+)
+
+// Lockout tracks failed authentication attempts and locks out keys, such as usernames and IP addresses, that fail
+// too often. Each failure past the threshold doubles the time the key is locked out for.
+type Lockout interface {
+	// LockedFor returns how long the key is locked out for, or zero if it is not locked out.
+	LockedFor(key string) time.Duration
+
+	// Failure records a failed attempt for the keys.
+	Failure(keys ...string)
+
+	// Success clears the failed attempts for the keys.
+	Success(keys ...string)
+}
+
+type lockoutEntry struct {
+	// failures is the number of consecutive failures.
+	failures int
+
+	// lastFailure is when the last failure happened.
+	lastFailure time.Time
+
+	// lockedUntil is when the key is no longer locked out.
+	lockedUntil time.Time
+}
+
+type lockout struct {
+	mut sync.Mutex
+
+	// threshold is the number of consecutive failures before a key is locked out.
+	threshold int
+
+	// baseDelay is the time a key is locked out for when it first reaches the threshold.
+	baseDelay time.Duration
+
+	// maxDelay is the maximum time a key is locked out for.
+	maxDelay time.Duration
+
+	// resetAfter is the time without a failure after which the failures of a key are forgotten.
+	resetAfter time.Duration
+
+	// entries holds the failures of each key.
+	entries map[string]*lockoutEntry
+
+	// lastSweep is when forgotten entries were last removed.
+	lastSweep time.Time
+
+	// now is the clock used by the lockout.
+	now func() time.Time
+}
+
+// LockoutOption is a function that configures the lockout.
+type LockoutOption func(l *lockout)
+
+// WithLockoutThreshold sets the number of consecutive failures before a key is locked out.
+func WithLockoutThreshold(threshold int) LockoutOption {
+	return func(l *lockout) {
+		l.threshold = threshold
+	}
+}
+
+// WithLockoutDelay sets the time a key is first locked out for and the maximum time it can be locked out for.
+func WithLockoutDelay(base, maximum time.Duration) LockoutOption {
+	return func(l *lockout) {
+		l.baseDelay = base
+		l.maxDelay = maximum
+	}
+}
+
+// WithLockoutResetAfter sets the time without a failure after which the failures of a key are forgotten.
+func WithLockoutResetAfter(resetAfter time.Duration) LockoutOption {
+	return func(l *lockout) {
+		l.resetAfter = resetAfter
+	}
+}
+
+// NewLockout creates a lockout that holds the failures in memory.
+func NewLockout(opts ...LockoutOption) Lockout {
+	l := &lockout{
+		threshold:  defaultLockoutThreshold,
+		baseDelay:  defaultLockoutBaseDelay,
+		maxDelay:   defaultLockoutMaxDelay,
+		resetAfter: defaultLockoutResetAfter,
+		entries:    make(map[string]*lockoutEntry),
+		now:        time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(l)
+	}
+
+	l.lastSweep = l.now()
+
+	return l
+}
+
+func (l *lockout) LockedFor(key string) time.Duration {
+	l.mut.Lock()
+	defer l.mut.Unlock()
+
+	entry, ok := l.entries[key]
+	if !ok {
+		return 0
+	}
+
+	return max(entry.lockedUntil.Sub(l.now()), 0)
+}
+
+func (l *lockout) Failure(keys ...string) {
+	l.mut.Lock()
+	defer l.mut.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	for _, key := range keys {
+		entry, ok := l.entries[key]
+		if !ok || now.Sub(entry.lastFailure) > l.resetAfter {
+			entry = new(lockoutEntry)
+			l.entries[key] = entry
+		}
+
+		entry.failures++
+		entry.lastFailure = now
+
+		if entry.failures >= l.threshold {
+			entry.lockedUntil = now.Add(l.delay(entry.failures - l.threshold))
+		}
+	}
+}
+
+func (l *lockout) Success(keys ...string) {
+	l.mut.Lock()
+	defer l.mut.Unlock()
+
+	for _, key := range keys {
+		delete(l.entries, key)
+	}
+}
+
+// delay returns the time a key is locked out for after the given number of failures past the threshold.
+func (l *lockout) delay(excess int) time.Duration {
+	d := l.baseDelay
+	for i := 0; i < excess && d < l.maxDelay; i++ {
+		d *= 2
+	}
+
+	return min(d, l.maxDelay)
+}
+
+// sweep removes the entries that have been forgotten so that the entries do not grow without bound.
+func (l *lockout) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < l.resetAfter {
+		return
+	}
+
+	for key, entry := range l.entries {
+		if now.Sub(entry.lastFailure) > l.resetAfter && now.After(entry.lockedUntil) {
+			delete(l.entries, key)
+		}
+	}
+
+	l.lastSweep = now
+}
@@ -0,0 +1,84 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLockout(t *testing.T) {
+	now := time.Now()
+	l := NewLockout(
+		WithLockoutThreshold(3),
+		WithLockoutDelay(time.Second, 5*time.Second),
+		WithLockoutResetAfter(time.Hour),
+	).(*lockout)
+	l.now = func() time.Time { return now }
+
+	tests := []struct {
+		name    string
+		action  func()
+		key     string
+		wantFor time.Duration
+	}{
+		{
+			name:    "unknown key is not locked",
+			action:  func() {},
+			key:     "user:a",
+			wantFor: 0,
+		},
+		{
+			name:    "failures below the threshold do not lock",
+			action:  func() { l.Failure("user:a", "ip:1"); l.Failure("user:a", "ip:1") },
+			key:     "user:a",
+			wantFor: 0,
+		},
+		{
+			name:    "reaching the threshold locks for the base delay",
+			action:  func() { l.Failure("user:a", "ip:1") },
+			key:     "user:a",
+			wantFor: time.Second,
+		},
+		{
+			name:    "each failure past the threshold doubles the delay",
+			action:  func() { l.Failure("user:a"); l.Failure("user:a") },
+			key:     "user:a",
+			wantFor: 4 * time.Second,
+		},
+		{
+			name:    "delay is capped",
+			action:  func() { l.Failure("user:a") },
+			key:     "user:a",
+			wantFor: 5 * time.Second,
+		},
+		{
+			name:    "lock expires",
+			action:  func() { now = now.Add(10 * time.Second) },
+			key:     "user:a",
+			wantFor: 0,
+		},
+		{
+			name:    "success clears the failures",
+			action:  func() { l.Success("user:a"); l.Failure("user:a") },
+			key:     "user:a",
+			wantFor: 0,
+		},
+		{
+			name:    "failures are forgotten after the reset",
+			action:  func() { now = now.Add(2 * time.Hour); l.Failure("ip:1") },
+			key:     "ip:1",
+			wantFor: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.action()
+			require.Equal(t, tt.wantFor, l.LockedFor(tt.key))
+		})
+	}
+
+	// The sweep removes the entries that have been forgotten.
+	require.NotContains(t, l.entries, "user:a")
+}
@@ -0,0 +1,30 @@
+package auth
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	cacheResultHit  = "hit"
+	cacheResultMiss = "miss"
+)
+
+var (
+	// credentialCacheRequests is the number of credential cache lookups by result.
+	credentialCacheRequests = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "auth_credential_cache_requests_total",
+			Help: "Total number of credential cache lookups by result",
+		},
+		[]string{"result"},
+	)
+
+	// credentialCacheEntries is the number of verified passwords held in the credential cache.
+	credentialCacheEntries = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "auth_credential_cache_entries",
+			Help: "Number of verified passwords held in the credential cache",
+		},
+	)
+)
@@ -0,0 +1,50 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// opaqueTokenLength is the number of random bytes in an opaque token.
+const opaqueTokenLength = 32
+
+// NewOpaqueToken generates a new random token, such as a refresh or password reset token, returning the token to give
+// to the client and the hash of the token to store.
+func NewOpaqueToken() (token string, hash string, err error) {
+	b := make([]byte, opaqueTokenLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", fmt.Errorf("failed to generate token: %w", err)
+	}
+
+	token = base64.RawURLEncoding.EncodeToString(b)
+	return token, HashOpaqueToken(token), nil
+}
+
+// HashOpaqueToken hashes the opaque token so that it can be looked up without storing the token itself.
+func HashOpaqueToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// APIKeyPrefix is the prefix of API keys, so that they can be told apart from access tokens.
+const APIKeyPrefix = "rk_"
+
+// NewAPIKey generates a new API key, returning the key to give to the client and the hash of the key to store.
+func NewAPIKey() (key string, hash string, err error) {
+	token, _, err := NewOpaqueToken()
+	if err != nil {
+		return "", "", err
+	}
+
+	key = APIKeyPrefix + token
+	return key, HashOpaqueToken(key), nil
+}
+
+// IsAPIKey returns whether the token is an API key rather than an access token.
+func IsAPIKey(token string) bool {
+	return strings.HasPrefix(token, APIKeyPrefix)
+}
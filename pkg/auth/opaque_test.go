@@ -0,0 +1,29 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewOpaqueToken(t *testing.T) {
+	token, hash, err := NewOpaqueToken()
+	require.NoError(t, err)
+	require.NotEmpty(t, token)
+	require.Len(t, hash, 64)
+	require.Equal(t, hash, HashOpaqueToken(token))
+	require.NotEqual(t, token, hash)
+
+	other, otherHash, err := NewOpaqueToken()
+	require.NoError(t, err)
+	require.NotEqual(t, token, other)
+	require.NotEqual(t, hash, otherHash)
+}
+
+func TestNewAPIKey(t *testing.T) {
+	key, hash, err := NewAPIKey()
+	require.NoError(t, err)
+	require.True(t, IsAPIKey(key))
+	require.Equal(t, hash, HashOpaqueToken(key))
+	require.False(t, IsAPIKey("eyJhbGciOiJIUzI1NiJ9.e30.sig"))
+}
@@ -0,0 +1,228 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	// defaultArgon2idMemory is the default memory used by argon2id in KiB, as recommended by RFC 9106.
+	defaultArgon2idMemory = 64 * 1024
+
+	// defaultArgon2idIterations is the default number of passes over the memory, as recommended by RFC 9106.
+	defaultArgon2idIterations = 3
+
+	// defaultArgon2idParallelism is the default number of threads used, as recommended by RFC 9106.
+	defaultArgon2idParallelism = 4
+
+	// argon2idSaltLength is the length in bytes of the random salt of each hash.
+	argon2idSaltLength = 16
+
+	// argon2idKeyLength is the length in bytes of the derived key.
+	argon2idKeyLength = 32
+
+	// argon2idPrefix is the prefix of hashes in the PHC string format produced for argon2id.
+	argon2idPrefix = "$argon2id$"
+)
+
+var (
+	// ErrUnknownPasswordHash is returned when a stored password hash is not in a recognised format.
+	ErrUnknownPasswordHash = errors.New("unknown password hash format")
+
+	// bcryptPrefixes are the prefixes of the bcrypt hash versions that can be verified.
+	bcryptPrefixes = []string{"$2a$", "$2b$", "$2y$"}
+
+	// passwordHashEncoding is the encoding of the salt and key in argon2id hashes.
+	passwordHashEncoding = base64.RawStdEncoding
+)
+
+// PasswordHasher hashes passwords for storage and verifies passwords against stored hashes. Hashes are stored in a
+// self-describing format, so that hashes created with older algorithms or parameters can still be verified and then
+// replaced with a new hash the next time the password is known.
+type PasswordHasher interface {
+	// Hash hashes the password with the current algorithm and parameters.
+	Hash(password string) (string, error)
+
+	// Verify returns whether the password matches the hash. An error is returned if the hash cannot be parsed.
+	Verify(hash, password string) (bool, error)
+
+	// NeedsRehash returns whether the hash was not created with the current algorithm and parameters.
+	NeedsRehash(hash string) bool
+}
+
+// argon2idParams are the tunable parameters of argon2id.
+type argon2idParams struct {
+	// memory is the memory used in KiB.
+	memory uint32
+
+	// iterations is the number of passes over the memory.
+	iterations uint32
+
+	// parallelism is the number of threads used.
+	parallelism uint8
+}
+
+type passwordHasher struct {
+	// params are the parameters new hashes are created with.
+	params argon2idParams
+}
+
+// PasswordHasherOption is a function that configures the password hasher.
+type PasswordHasherOption func(h *passwordHasher)
+
+// WithArgon2idMemory sets the memory in KiB used to hash passwords. A value of zero keeps the default.
+func WithArgon2idMemory(memory uint32) PasswordHasherOption {
+	return func(h *passwordHasher) {
+		if memory > 0 {
+			h.params.memory = memory
+		}
+	}
+}
+
+// WithArgon2idIterations sets the number of passes over the memory used to hash passwords. A value of zero keeps the
+// default.
+func WithArgon2idIterations(iterations uint32) PasswordHasherOption {
+	return func(h *passwordHasher) {
+		if iterations > 0 {
+			h.params.iterations = iterations
+		}
+	}
+}
+
+// WithArgon2idParallelism sets the number of threads used to hash passwords. A value of zero keeps the default.
+func WithArgon2idParallelism(parallelism uint8) PasswordHasherOption {
+	return func(h *passwordHasher) {
+		if parallelism > 0 {
+			h.params.parallelism = parallelism
+		}
+	}
+}
+
+// NewPasswordHasher creates a password hasher that hashes passwords with argon2id and verifies both argon2id and
+// bcrypt hashes.
+func NewPasswordHasher(opts ...PasswordHasherOption) PasswordHasher {
+	h := &passwordHasher{
+		params: argon2idParams{
+			memory:      defaultArgon2idMemory,
+			iterations:  defaultArgon2idIterations,
+			parallelism: defaultArgon2idParallelism,
+		},
+	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	return h
+}
+
+func (h *passwordHasher) Hash(password string) (string, error) {
+	salt := make([]byte, argon2idSaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	key := argon2.IDKey([]byte(password), salt, h.params.iterations, h.params.memory, h.params.parallelism, argon2idKeyLength)
+
+	return fmt.Sprintf(
+		"%sv=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2idPrefix,
+		argon2.Version,
+		h.params.memory,
+		h.params.iterations,
+		h.params.parallelism,
+		passwordHashEncoding.EncodeToString(salt),
+		passwordHashEncoding.EncodeToString(key),
+	), nil
+}
+
+func (h *passwordHasher) Verify(hash, password string) (bool, error) {
+	switch {
+	case strings.HasPrefix(hash, argon2idPrefix):
+		params, salt, key, err := parseArgon2idHash(hash)
+		if err != nil {
+			return false, err
+		}
+
+		got := argon2.IDKey([]byte(password), salt, params.iterations, params.memory, params.parallelism, uint32(len(key)))
+		return subtle.ConstantTimeCompare(got, key) == 1, nil
+	case isBcryptHash(hash):
+		err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+		switch {
+		case err == nil:
+			return true, nil
+		case errors.Is(err, bcrypt.ErrMismatchedHashAndPassword):
+			return false, nil
+		default:
+			return false, fmt.Errorf("failed to compare bcrypt hash: %w", err)
+		}
+	default:
+		return false, ErrUnknownPasswordHash
+	}
+}
+
+func (h *passwordHasher) NeedsRehash(hash string) bool {
+	if !strings.HasPrefix(hash, argon2idPrefix) {
+		return true
+	}
+
+	params, _, key, err := parseArgon2idHash(hash)
+	if err != nil {
+		return true
+	}
+
+	return params != h.params || len(key) != argon2idKeyLength
+}
+
+// parseArgon2idHash parses a hash in the format "$argon2id$v=19$m=65536,t=3,p=4$<salt>$<key>".
+func parseArgon2idHash(hash string) (params argon2idParams, salt, key []byte, err error) {
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 {
+		return params, nil, nil, ErrUnknownPasswordHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil {
+		return params, nil, nil, fmt.Errorf("failed to parse argon2id version: %w", err)
+	} else if version != argon2.Version {
+		return params, nil, nil, fmt.Errorf("unsupported argon2id version %d", version)
+	}
+
+	_, err = fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.memory, &params.iterations, &params.parallelism)
+	if err != nil {
+		return params, nil, nil, fmt.Errorf("failed to parse argon2id parameters: %w", err)
+	} else if params.iterations == 0 || params.parallelism == 0 {
+		return params, nil, nil, errors.New("invalid argon2id parameters")
+	}
+
+	salt, err = passwordHashEncoding.DecodeString(parts[4])
+	if err != nil {
+		return params, nil, nil, fmt.Errorf("failed to decode argon2id salt: %w", err)
+	}
+
+	key, err = passwordHashEncoding.DecodeString(parts[5])
+	if err != nil {
+		return params, nil, nil, fmt.Errorf("failed to decode argon2id key: %w", err)
+	} else if len(key) == 0 {
+		return params, nil, nil, ErrUnknownPasswordHash
+	}
+
+	return params, salt, key, nil
+}
+
+// isBcryptHash returns whether the hash is a bcrypt hash.
+func isBcryptHash(hash string) bool {
+	for _, prefix := range bcryptPrefixes {
+		if strings.HasPrefix(hash, prefix) {
+			return true
+		}
+	}
+	return false
+}
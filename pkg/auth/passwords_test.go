@@ -0,0 +1,103 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestPasswordHasher(t *testing.T) {
+	h := NewPasswordHasher(
+		WithArgon2idMemory(64),
+		WithArgon2idIterations(1),
+		WithArgon2idParallelism(1),
+	)
+
+	argon2idHash, err := h.Hash("password")
+	require.NoError(t, err)
+
+	stronger := NewPasswordHasher(
+		WithArgon2idMemory(128),
+		WithArgon2idIterations(1),
+		WithArgon2idParallelism(1),
+	)
+
+	strongerHash, err := stronger.Hash("password")
+	require.NoError(t, err)
+
+	bcryptHash, err := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		hash        string
+		password    string
+		want        bool
+		wantErr     bool
+		needsRehash bool
+	}{
+		{
+			name:        "argon2id match",
+			hash:        argon2idHash,
+			password:    "password",
+			want:        true,
+			needsRehash: false,
+		},
+		{
+			name:        "argon2id mismatch",
+			hash:        argon2idHash,
+			password:    "wrong",
+			want:        false,
+			needsRehash: false,
+		},
+		{
+			name:        "argon2id with other parameters",
+			hash:        strongerHash,
+			password:    "password",
+			want:        true,
+			needsRehash: true,
+		},
+		{
+			name:        "bcrypt match",
+			hash:        string(bcryptHash),
+			password:    "password",
+			want:        true,
+			needsRehash: true,
+		},
+		{
+			name:        "bcrypt mismatch",
+			hash:        string(bcryptHash),
+			password:    "wrong",
+			want:        false,
+			needsRehash: true,
+		},
+		{
+			name:        "malformed argon2id",
+			hash:        "$argon2id$v=19$m=64,t=1,p=1$c2FsdA",
+			password:    "password",
+			wantErr:     true,
+			needsRehash: true,
+		},
+		{
+			name:        "unknown format",
+			hash:        "plaintext",
+			password:    "plaintext",
+			wantErr:     true,
+			needsRehash: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := h.Verify(tt.hash, tt.password)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tt.want, got)
+			require.Equal(t, tt.needsRehash, h.NeedsRehash(tt.hash))
+		})
+	}
+}
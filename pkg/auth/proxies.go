@@ -0,0 +1,88 @@
+package auth
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// TrustedProxies are the networks of the proxies in front of the API, which are trusted to append the address that a
+// request came from to X-Forwarded-For.
+type TrustedProxies []*net.IPNet
+
+// ParseTrustedProxies parses the networks of the trusted proxies, which are either CIDRs or single IP addresses.
+func ParseTrustedProxies(networks []string) (TrustedProxies, error) {
+	proxies := make(TrustedProxies, 0, len(networks))
+	for _, network := range networks {
+		network = strings.TrimSpace(network)
+
+		if !strings.Contains(network, "/") {
+			ip := net.ParseIP(network)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid trusted proxy %q", network)
+			}
+
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+
+			proxies = append(proxies, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+
+		_, ipNet, err := net.ParseCIDR(network)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q: %w", network, err)
+		}
+
+		proxies = append(proxies, ipNet)
+	}
+
+	return proxies, nil
+}
+
+// trusted returns whether the address is one of the trusted proxies.
+func (p TrustedProxies) trusted(address string) bool {
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return false
+	}
+
+	for _, network := range p {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ClientIP returns the IP address of the client that made the request. X-Forwarded-For is only used if the request
+// came from a trusted proxy, in which case it is read from the end, skipping the addresses of trusted proxies, as the
+// addresses before them could have been set by the client.
+func (p TrustedProxies) ClientIP(r *http.Request) string {
+	address, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		address = r.RemoteAddr
+	}
+
+	if !p.trusted(address) {
+		return address
+	}
+
+	forwarded := make([]string, 0)
+	for _, header := range r.Header.Values("X-Forwarded-For") {
+		forwarded = append(forwarded, strings.Split(header, ",")...)
+	}
+
+	for i := len(forwarded) - 1; i >= 0; i-- {
+		address = strings.TrimSpace(forwarded[i])
+		if !p.trusted(address) {
+			return address
+		}
+	}
+
+	return address
+}
@@ -0,0 +1,97 @@
+package auth
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTrustedProxies(t *testing.T) {
+	_, err := ParseTrustedProxies([]string{"10.0.0.0/8", "192.168.1.1", "::1"})
+	require.NoError(t, err)
+
+	_, err = ParseTrustedProxies([]string{"not an ip"})
+	require.Error(t, err)
+
+	_, err = ParseTrustedProxies([]string{"10.0.0.0/33"})
+	require.Error(t, err)
+}
+
+func TestClientIP(t *testing.T) {
+	proxies, err := ParseTrustedProxies([]string{"10.0.0.0/8", "192.168.1.1"})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		proxies    TrustedProxies
+		remoteAddr string
+		forwarded  []string
+		want       string
+	}{
+		{
+			name:       "no proxies",
+			remoteAddr: "203.0.113.1:1234",
+			want:       "203.0.113.1",
+		},
+		{
+			name:       "forwarded header from an untrusted client is ignored",
+			proxies:    proxies,
+			remoteAddr: "203.0.113.1:1234",
+			forwarded:  []string{"198.51.100.7"},
+			want:       "203.0.113.1",
+		},
+		{
+			name:       "forwarded header is ignored when no proxies are trusted",
+			remoteAddr: "10.0.0.1:1234",
+			forwarded:  []string{"198.51.100.7"},
+			want:       "10.0.0.1",
+		},
+		{
+			name:       "address added by a trusted proxy",
+			proxies:    proxies,
+			remoteAddr: "10.0.0.1:1234",
+			forwarded:  []string{"198.51.100.7"},
+			want:       "198.51.100.7",
+		},
+		{
+			name:       "addresses set by the client are skipped",
+			proxies:    proxies,
+			remoteAddr: "10.0.0.1:1234",
+			forwarded:  []string{"1.2.3.4, 198.51.100.7, 192.168.1.1"},
+			want:       "198.51.100.7",
+		},
+		{
+			name:       "multiple headers",
+			proxies:    proxies,
+			remoteAddr: "10.0.0.1:1234",
+			forwarded:  []string{"1.2.3.4", "198.51.100.7"},
+			want:       "198.51.100.7",
+		},
+		{
+			name:       "every address is a trusted proxy",
+			proxies:    proxies,
+			remoteAddr: "10.0.0.1:1234",
+			forwarded:  []string{"10.0.0.2"},
+			want:       "10.0.0.2",
+		},
+		{
+			name:       "trusted proxy without a forwarded header",
+			proxies:    proxies,
+			remoteAddr: "10.0.0.1:1234",
+			want:       "10.0.0.1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/", nil)
+			r.RemoteAddr = tt.remoteAddr
+			for _, f := range tt.forwarded {
+				r.Header.Add("X-Forwarded-For", f)
+			}
+
+			require.Equal(t, tt.want, tt.proxies.ClientIP(r))
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package auth
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Role is the role of a user, deciding what they can access beyond their own data.
+type Role string
+
+const (
+	// RolePlayer can only access their own data. This is the role of new users.
+	RolePlayer Role = "player"
+
+	// RoleCoach can also read the rounds and stats of the players that have granted them access.
+	RoleCoach Role = "coach"
+
+	// RoleAdmin can also read the data of all users and manage users.
+	RoleAdmin Role = "admin"
+)
+
+// AllRoles returns all the roles a user can have.
+func AllRoles() []Role {
+	return []Role{RolePlayer, RoleCoach, RoleAdmin}
+}
+
+// ParseRole parses the role, returning an error if it is unknown.
+func ParseRole(s string) (Role, error) {
+	role := Role(s)
+	if !slices.Contains(AllRoles(), role) {
+		return "", fmt.Errorf("unknown role: %s", s)
+	}
+
+	return role, nil
+}
@@ -0,0 +1,68 @@
+package auth
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Scope is a permission that can be granted to an API key.
+type Scope string
+
+const (
+	// ScopeReadRounds allows reading rounds, their holes, hole stats and round stats, and the courses they are played on.
+	ScopeReadRounds Scope = "read:rounds"
+
+	// ScopeWriteStats allows updating the stats of holes.
+	ScopeWriteStats Scope = "write:stats"
+
+	// ScopeReadCharts allows reading the chart averages.
+	ScopeReadCharts Scope = "read:charts"
+)
+
+// Scopes are the permissions granted to an API key.
+type Scopes []Scope
+
+// AllScopes returns all the scopes that can be granted to an API key.
+func AllScopes() Scopes {
+	return Scopes{ScopeReadRounds, ScopeWriteStats, ScopeReadCharts}
+}
+
+// ParseScopes parses the space separated scopes, returning an error if any of them are unknown.
+func ParseScopes(s string) (Scopes, error) {
+	return NewScopes(strings.Fields(s)...)
+}
+
+// NewScopes creates scopes from their names, returning an error if any of them are unknown. Duplicates are removed
+// and the scopes are sorted.
+func NewScopes(names ...string) (Scopes, error) {
+	all := AllScopes()
+
+	scopes := make(Scopes, 0, len(names))
+	for _, name := range names {
+		scope := Scope(name)
+		if !slices.Contains(all, scope) {
+			return nil, fmt.Errorf("unknown scope: %s", name)
+		}
+
+		scopes = append(scopes, scope)
+	}
+
+	slices.Sort(scopes)
+	return slices.Compact(scopes), nil
+}
+
+// Has returns whether the scope has been granted.
+func (s Scopes) Has(scope Scope) bool {
+	return slices.Contains(s, scope)
+}
+
+// String returns the scopes separated by spaces, as they are stored.
+func (s Scopes) String() string {
+	names := make([]string, len(s))
+	for i, scope := range s {
+		names[i] = string(scope)
+	}
+
+	return strings.Join(names, " ")
+}
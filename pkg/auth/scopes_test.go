@@ -0,0 +1,58 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseScopes(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    Scopes
+		wantErr bool
+	}{
+		{
+			name:  "empty",
+			input: "",
+			want:  Scopes{},
+		},
+		{
+			name:  "single",
+			input: "read:charts",
+			want:  Scopes{ScopeReadCharts},
+		},
+		{
+			name:  "sorted and deduplicated",
+			input: "write:stats read:rounds  write:stats",
+			want:  Scopes{ScopeReadRounds, ScopeWriteStats},
+		},
+		{
+			name:    "unknown",
+			input:   "read:rounds admin",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseScopes(tt.input)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+			require.Equal(t, tt.input != "", got.String() != "")
+		})
+	}
+}
+
+func TestScopesHas(t *testing.T) {
+	scopes := Scopes{ScopeReadCharts}
+	require.True(t, scopes.Has(ScopeReadCharts))
+	require.False(t, scopes.Has(ScopeWriteStats))
+	require.Equal(t, "read:charts", scopes.String())
+}
@@ -0,0 +1,199 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+	"github.com/google/uuid"
+)
+
+const (
+	// defaultTokenTTL is the default time an access token is valid for.
+	defaultTokenTTL = 15 * time.Minute
+
+	// defaultIssuer is the default issuer of the access tokens.
+	defaultIssuer = "rounder"
+
+	// minSigningKeyLength is the minimum length of the signing key in bytes, as required by HS256.
+	minSigningKeyLength = 32
+
+	// clockSkew is the leeway given when validating the expiry of a token.
+	clockSkew = 30 * time.Second
+
+	// TokenTypeBearer is the type of the access tokens.
+	TokenTypeBearer = "Bearer"
+)
+
+var (
+	// ErrInvalidToken is returned when a token is malformed, has an invalid signature or has expired.
+	ErrInvalidToken = errors.New("invalid token")
+)
+
+// Token is a signed access token.
+type Token struct {
+	// Value is the serialized token.
+	Value string
+
+	// ExpiresAt is when the token expires.
+	ExpiresAt time.Time
+}
+
+// Claims are the verified claims of an access token.
+type Claims struct {
+	// Id is the unique ID of the token.
+	Id string
+
+	// UserId is the ID of the user the token was issued to.
+	UserId int
+
+	// SessionId is the ID of the session the token was issued for.
+	SessionId int
+
+	// IssuedAt is when the token was issued.
+	IssuedAt time.Time
+
+	// ExpiresAt is when the token expires.
+	ExpiresAt time.Time
+}
+
+// Tokens issues and verifies signed access tokens.
+type Tokens interface {
+	// Issue issues a new access token for the user's session.
+	Issue(userId int, sessionId int) (*Token, error)
+
+	// Verify verifies the access token, returning ErrInvalidToken if it is not valid.
+	Verify(token string) (*Claims, error)
+}
+
+// privateClaims are the claims of an access token that are not registered JWT claims.
+type privateClaims struct {
+	// SessionId is the ID of the session the token was issued for.
+	SessionId int `json:"sid"`
+}
+
+type tokens struct {
+	// key is the key used to sign and verify the tokens.
+	key []byte
+
+	// signer signs the tokens.
+	signer jose.Signer
+
+	// ttl is the time a token is valid for.
+	ttl time.Duration
+
+	// issuer is the issuer of the tokens.
+	issuer string
+
+	// now is the clock used when issuing and verifying tokens.
+	now func() time.Time
+}
+
+// TokenOption is a function that configures the tokens.
+type TokenOption func(t *tokens)
+
+// WithTokenTTL sets the time a token is valid for.
+func WithTokenTTL(ttl time.Duration) TokenOption {
+	return func(t *tokens) {
+		t.ttl = ttl
+	}
+}
+
+// WithIssuer sets the issuer of the tokens.
+func WithIssuer(issuer string) TokenOption {
+	return func(t *tokens) {
+		t.issuer = issuer
+	}
+}
+
+// NewTokens creates tokens that are signed with HS256 using the signing key.
+func NewTokens(signingKey []byte, opts ...TokenOption) (Tokens, error) {
+	if len(signingKey) < minSigningKeyLength {
+		return nil, fmt.Errorf("signing key must be at least %d bytes", minSigningKeyLength)
+	}
+
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.HS256, Key: signingKey}, (&jose.SignerOptions{}).WithType("JWT"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create signer: %w", err)
+	}
+
+	t := &tokens{
+		key:    signingKey,
+		signer: signer,
+		ttl:    defaultTokenTTL,
+		issuer: defaultIssuer,
+		now:    time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(t)
+	}
+
+	return t, nil
+}
+
+func (t *tokens) Issue(userId int, sessionId int) (*Token, error) {
+	now := t.now()
+	expiresAt := now.Add(t.ttl)
+
+	value, err := jwt.Signed(t.signer).Claims(jwt.Claims{
+		ID:        uuid.NewString(),
+		Issuer:    t.issuer,
+		Subject:   strconv.Itoa(userId),
+		IssuedAt:  jwt.NewNumericDate(now),
+		NotBefore: jwt.NewNumericDate(now),
+		Expiry:    jwt.NewNumericDate(expiresAt),
+	}).Claims(privateClaims{
+		SessionId: sessionId,
+	}).Serialize()
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign token: %w", err)
+	}
+
+	return &Token{
+		Value:     value,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+func (t *tokens) Verify(token string) (*Claims, error) {
+	parsed, err := jwt.ParseSigned(token, []jose.SignatureAlgorithm{jose.HS256})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidToken, err)
+	}
+
+	claims := new(jwt.Claims)
+	private := new(privateClaims)
+	if err := parsed.Claims(t.key, claims, private); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidToken, err)
+	}
+
+	err = claims.ValidateWithLeeway(jwt.Expected{
+		Issuer: t.issuer,
+		Time:   t.now(),
+	}, clockSkew)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidToken, err)
+	}
+
+	// Tokens without an expiry are never issued, so must not be accepted.
+	if claims.Expiry == nil {
+		return nil, fmt.Errorf("%w: missing expiry", ErrInvalidToken)
+	}
+
+	userId, err := strconv.Atoi(claims.Subject)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid subject", ErrInvalidToken)
+	}
+
+	return &Claims{
+		Id:        claims.ID,
+		UserId:    userId,
+		SessionId: private.SessionId,
+		IssuedAt:  claims.IssuedAt.Time(),
+		ExpiresAt: claims.Expiry.Time(),
+	}, nil
+}
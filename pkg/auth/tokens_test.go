@@ -0,0 +1,105 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+var testSigningKey = []byte("0123456789abcdef0123456789abcdef")
+
+func TestNewTokens(t *testing.T) {
+	_, err := NewTokens([]byte("too short"))
+	require.Error(t, err)
+
+	_, err = NewTokens(testSigningKey)
+	require.NoError(t, err)
+}
+
+func TestTokens_Verify(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	newTokens := func(t *testing.T, key []byte, opts ...TokenOption) *tokens {
+		t.Helper()
+
+		tk, err := NewTokens(key, opts...)
+		require.NoError(t, err)
+
+		tk.(*tokens).now = func() time.Time { return now }
+		return tk.(*tokens)
+	}
+
+	tests := []struct {
+		name    string
+		token   func(t *testing.T) string
+		advance time.Duration
+		wantErr bool
+	}{
+		{
+			name: "valid token",
+			token: func(t *testing.T) string {
+				tk, err := newTokens(t, testSigningKey).Issue(42, 7)
+				require.NoError(t, err)
+				return tk.Value
+			},
+			advance: 10 * time.Minute,
+		},
+		{
+			name: "expired token",
+			token: func(t *testing.T) string {
+				tk, err := newTokens(t, testSigningKey).Issue(42, 7)
+				require.NoError(t, err)
+				return tk.Value
+			},
+			advance: time.Hour,
+			wantErr: true,
+		},
+		{
+			name: "token signed with another key",
+			token: func(t *testing.T) string {
+				tk, err := newTokens(t, []byte("fedcba9876543210fedcba9876543210")).Issue(42, 7)
+				require.NoError(t, err)
+				return tk.Value
+			},
+			wantErr: true,
+		},
+		{
+			name: "token from another issuer",
+			token: func(t *testing.T) string {
+				tk, err := newTokens(t, testSigningKey, WithIssuer("other")).Issue(42, 7)
+				require.NoError(t, err)
+				return tk.Value
+			},
+			wantErr: true,
+		},
+		{
+			name: "malformed token",
+			token: func(t *testing.T) string {
+				return "not-a-token"
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token := tt.token(t)
+
+			verifier := newTokens(t, testSigningKey)
+			verifier.now = func() time.Time { return now.Add(tt.advance) }
+
+			claims, err := verifier.Verify(token)
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrInvalidToken)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, 42, claims.UserId)
+			require.Equal(t, 7, claims.SessionId)
+			require.NotEmpty(t, claims.Id)
+			require.True(t, now.Add(defaultTokenTTL).Equal(claims.ExpiresAt))
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// totpSecretLength is the number of random bytes in a TOTP secret, as recommended by RFC 4226.
+	totpSecretLength = 20
+
+	// totpDigits is the number of digits in a TOTP code.
+	totpDigits = 6
+
+	// totpPeriod is the time each TOTP code is valid for.
+	totpPeriod = 30 * time.Second
+
+	// totpSkew is the number of periods either side of the current one that a code is accepted for, to allow for
+	// clock drift between the server and the authenticator app.
+	totpSkew = 1
+
+	// recoveryCodeLength is the number of random bytes in a recovery code.
+	recoveryCodeLength = 5
+
+	// RecoveryCodeCount is the number of recovery codes generated when two-factor authentication is enabled.
+	RecoveryCodeCount = 10
+)
+
+var (
+	// ErrInvalidTOTPCode is returned when a TOTP code does not match the secret at the current time.
+	ErrInvalidTOTPCode = errors.New("invalid totp code")
+
+	// totpEncoding is the encoding of TOTP secrets expected by authenticator apps.
+	totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+)
+
+// NewTOTPSecret generates a new random TOTP secret, encoded as base32.
+func NewTOTPSecret() (string, error) {
+	b := make([]byte, totpSecretLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate totp secret: %w", err)
+	}
+
+	return totpEncoding.EncodeToString(b), nil
+}
+
+// TOTPURI returns the otpauth URI that authenticator apps use to add the secret for the account.
+func TOTPURI(issuer, account, secret string) string {
+	params := url.Values{}
+	params.Set("secret", secret)
+	params.Set("issuer", issuer)
+	params.Set("algorithm", "SHA1")
+	params.Set("digits", fmt.Sprint(totpDigits))
+	params.Set("period", fmt.Sprint(int(totpPeriod.Seconds())))
+
+	label := url.PathEscape(issuer) + ":" + url.PathEscape(account)
+	return "otpauth://totp/" + label + "?" + params.Encode()
+}
+
+// VerifyTOTP verifies the code against the secret at the given time, returning the time step the code was for so
+// that the caller can reject a code that has already been used.
+func VerifyTOTP(secret, code string, t time.Time) (int64, error) {
+	key, err := totpEncoding.DecodeString(strings.ToUpper(strings.TrimRight(secret, "=")))
+	if err != nil {
+		return 0, fmt.Errorf("invalid totp secret: %w", err)
+	}
+
+	code = strings.ReplaceAll(code, " ", "")
+	if len(code) != totpDigits {
+		return 0, ErrInvalidTOTPCode
+	}
+
+	current := t.Unix() / int64(totpPeriod.Seconds())
+	for step := current - totpSkew; step <= current+totpSkew; step++ {
+		if subtle.ConstantTimeCompare([]byte(totpCode(key, step)), []byte(code)) == 1 {
+			return step, nil
+		}
+	}
+
+	return 0, ErrInvalidTOTPCode
+}
+
+// totpCode returns the code for the time step, as described in RFC 4226.
+func totpCode(key []byte, step int64) string {
+	msg := make([]byte, 8)
+	binary.BigEndian.PutUint64(msg, uint64(step))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for i := 0; i < totpDigits; i++ {
+		mod *= 10
+	}
+
+	return fmt.Sprintf("%0*d", totpDigits, value%mod)
+}
+
+// NewRecoveryCodes generates recovery codes that can each be used once in place of a TOTP code, returning the codes
+// to give to the user and the hashes of the codes to store.
+func NewRecoveryCodes(n int) (codes []string, hashes []string, err error) {
+	codes = make([]string, 0, n)
+	hashes = make([]string, 0, n)
+
+	for i := 0; i < n; i++ {
+		b := make([]byte, recoveryCodeLength)
+		if _, err := rand.Read(b); err != nil {
+			return nil, nil, fmt.Errorf("failed to generate recovery code: %w", err)
+		}
+
+		encoded := strings.ToLower(totpEncoding.EncodeToString(b))
+		code := encoded[:len(encoded)/2] + "-" + encoded[len(encoded)/2:]
+
+		codes = append(codes, code)
+		hashes = append(hashes, HashRecoveryCode(code))
+	}
+
+	return codes, hashes, nil
+}
+
+// HashRecoveryCode hashes the recovery code so that it can be looked up without storing the code itself. Case,
+// spaces and dashes are ignored so that the code can be typed however it is read.
+func HashRecoveryCode(code string) string {
+	code = strings.ToLower(code)
+	code = strings.NewReplacer("-", "", " ", "").Replace(code)
+	return HashOpaqueToken(code)
+}
@@ -0,0 +1,126 @@
+package auth
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// rfc6238Secret is the SHA1 secret used by the test vectors in RFC 6238, encoded as base32.
+const rfc6238Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestVerifyTOTP(t *testing.T) {
+	tests := []struct {
+		name     string
+		code     string
+		time     time.Time
+		wantStep int64
+		wantErr  error
+	}{
+		{
+			name:     "rfc 6238 59",
+			code:     "287082",
+			time:     time.Unix(59, 0),
+			wantStep: 1,
+		},
+		{
+			name:     "rfc 6238 1111111109",
+			code:     "081804",
+			time:     time.Unix(1111111109, 0),
+			wantStep: 37037036,
+		},
+		{
+			name:     "rfc 6238 1234567890",
+			code:     "005924",
+			time:     time.Unix(1234567890, 0),
+			wantStep: 41152263,
+		},
+		{
+			name:     "rfc 6238 2000000000",
+			code:     "279037",
+			time:     time.Unix(2000000000, 0),
+			wantStep: 66666666,
+		},
+		{
+			name:     "previous period",
+			code:     "287082",
+			time:     time.Unix(89, 0),
+			wantStep: 1,
+		},
+		{
+			name:    "too old",
+			code:    "287082",
+			time:    time.Unix(120, 0),
+			wantErr: ErrInvalidTOTPCode,
+		},
+		{
+			name:    "wrong code",
+			code:    "123456",
+			time:    time.Unix(59, 0),
+			wantErr: ErrInvalidTOTPCode,
+		},
+		{
+			name:    "wrong length",
+			code:    "28708",
+			time:    time.Unix(59, 0),
+			wantErr: ErrInvalidTOTPCode,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step, err := VerifyTOTP(rfc6238Secret, tt.code, tt.time)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.wantStep, step)
+		})
+	}
+}
+
+func TestNewTOTPSecret(t *testing.T) {
+	secret, err := NewTOTPSecret()
+	require.NoError(t, err)
+
+	now := time.Now()
+	_, err = VerifyTOTP(secret, totpCode(mustDecodeTOTPSecret(t, secret), now.Unix()/30), now)
+	require.NoError(t, err)
+}
+
+func TestTOTPURI(t *testing.T) {
+	uri, err := url.Parse(TOTPURI("rounder", "jane@example.com", rfc6238Secret))
+	require.NoError(t, err)
+
+	require.Equal(t, "otpauth", uri.Scheme)
+	require.Equal(t, "totp", uri.Host)
+	require.Equal(t, "/rounder:jane@example.com", uri.Path)
+	require.Equal(t, rfc6238Secret, uri.Query().Get("secret"))
+	require.Equal(t, "rounder", uri.Query().Get("issuer"))
+}
+
+func TestNewRecoveryCodes(t *testing.T) {
+	codes, hashes, err := NewRecoveryCodes(RecoveryCodeCount)
+	require.NoError(t, err)
+	require.Len(t, codes, RecoveryCodeCount)
+	require.Len(t, hashes, RecoveryCodeCount)
+
+	for i, code := range codes {
+		require.Regexp(t, `^[a-z2-7]{4}-[a-z2-7]{4}$`, code)
+		require.Equal(t, hashes[i], HashRecoveryCode(code))
+	}
+
+	require.Equal(t, HashRecoveryCode("abcd-efgh"), HashRecoveryCode("ABCD EFGH"))
+}
+
+func mustDecodeTOTPSecret(t *testing.T, secret string) []byte {
+	t.Helper()
+
+	key, err := totpEncoding.DecodeString(secret)
+	require.NoError(t, err)
+	return key
+}
@@ -18,6 +18,12 @@ type Message struct {
 	Message *string `json:"message,omitempty"`
 }
 
+// PageCursor defines the model for page_cursor.
+type PageCursor struct {
+	LastId  string `json:"last_id"`
+	LastVal string `json:"last_val"`
+}
+
 // FromDate defines the model for from_date.
 type FromDate = openapi_types.Date
 
@@ -28,7 +34,7 @@ type LastId = string
 type LastValue = string
 
 // LimitParam defines the model for limit_param.
-type LimitParam = string
+type LimitParam = int
 
 // Since defines the model for since.
 type Since = string
@@ -4,6 +4,7 @@
 package rounder
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 
@@ -14,12 +15,54 @@ import (
 
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// Get audit events
+	// (GET /admin/audit-events)
+	GetAuditEvents(w http.ResponseWriter, r *http.Request, params GetAuditEventsParams)
+	// Get all users
+	// (GET /admin/users)
+	GetUsers(w http.ResponseWriter, r *http.Request)
+	// Update a user
+	// (PATCH /admin/users/{user_id})
+	UpdateUser(w http.ResponseWriter, r *http.Request, userId PathUserId)
+	// Reset the two-factor authentication of a user
+	// (DELETE /admin/users/{user_id}/totp)
+	ResetUserTotp(w http.ResponseWriter, r *http.Request, userId PathUserId)
+	// Get the custom courses for the user
+	// (GET /courses)
+	GetCourses(w http.ResponseWriter, r *http.Request)
+	// Create a custom course
+	// (POST /courses)
+	CreateCourse(w http.ResponseWriter, r *http.Request)
+	// Delete a custom course
+	// (DELETE /courses/{course_id})
+	DeleteCourse(w http.ResponseWriter, r *http.Request, courseId PathCourseId)
+	// Get a custom course
+	// (GET /courses/{course_id})
+	GetCourse(w http.ResponseWriter, r *http.Request, courseId PathCourseId)
+	// Update a custom course
+	// (PUT /courses/{course_id})
+	UpdateCourse(w http.ResponseWriter, r *http.Request, courseId PathCourseId)
 	// Login
 	// (POST /login)
 	Login(w http.ResponseWriter, r *http.Request)
+	// Start logging in with an OpenID Connect provider
+	// (GET /login/oidc/{provider})
+	StartOidcLogin(w http.ResponseWriter, r *http.Request, provider PathOidcProvider)
+	// Complete logging in with an OpenID Connect provider
+	// (GET /login/oidc/{provider}/callback)
+	OidcCallback(w http.ResponseWriter, r *http.Request, provider PathOidcProvider, params OidcCallbackParams)
+	// Logout
+	// (POST /logout)
+	Logout(w http.ResponseWriter, r *http.Request)
+	// Request a password reset
+	// (POST /password/reset)
+	RequestPasswordReset(w http.ResponseWriter, r *http.Request)
+	// Reset a password
+	// (POST /password/reset/confirm)
+	ConfirmPasswordReset(w http.ResponseWriter, r *http.Request)
 	// Get rounds
 	// (GET /rounds)
-	GetRounds(w http.ResponseWriter, r *http.Request)
+	GetRounds(w http.ResponseWriter, r *http.Request, params GetRoundsParams)
 	// Create a round
 	// (POST /rounds)
 	CreateRound(w http.ResponseWriter, r *http.Request)
@@ -29,26 +72,92 @@ type ServerInterface interface {
 	// Get the marker used for a round
 	// (GET /rounds/new/marker/{course_id})
 	GetNewRoundMarker(w http.ResponseWriter, r *http.Request, courseId PathCourseId)
+	// Get round stats
+	// (GET /rounds/stats)
+	GetRoundStats(w http.ResponseWriter, r *http.Request, params GetRoundStatsParams)
 	// Get the stats for all rounds
 	// (GET /rounds/stats/charts/line/averages)
 	GetLineChartAverages(w http.ResponseWriter, r *http.Request, params GetLineChartAveragesParams)
 	// Get the stats for all rounds
 	// (GET /rounds/stats/charts/pie/averages)
 	GetPieChartAverages(w http.ResponseWriter, r *http.Request, params GetPieChartAveragesParams)
+	// Delete a round
+	// (DELETE /rounds/{round_id})
+	DeleteRound(w http.ResponseWriter, r *http.Request, roundId PathRoundId)
 	// Get the holes for a round
 	// (GET /rounds/{round_id}/holes)
-	GetRoundHoles(w http.ResponseWriter, r *http.Request, roundId PathRoundId)
+	GetRoundHoles(w http.ResponseWriter, r *http.Request, roundId PathRoundId, params GetRoundHolesParams)
 	// Get the stats for a hole
 	// (GET /rounds/{round_id}/holes/{hole_id}/stats)
 	GetHoleStats(w http.ResponseWriter, r *http.Request, roundId PathRoundId, holeId PathHoleId)
 	// Update the stats for a hole
 	// (POST /rounds/{round_id}/holes/{hole_id}/stats)
 	UpdateHoleStats(w http.ResponseWriter, r *http.Request, roundId PathRoundId, holeId PathHoleId)
+	// Refresh an access token
+	// (POST /token/refresh)
+	RefreshToken(w http.ResponseWriter, r *http.Request)
 	// Create a user
 	// (POST /users)
 	CreateUser(w http.ResponseWriter, r *http.Request)
+	// Get the profile of the user
+	// (GET /users/me)
+	GetProfile(w http.ResponseWriter, r *http.Request)
+	// Update the profile of the user
+	// (PATCH /users/me)
+	UpdateProfile(w http.ResponseWriter, r *http.Request)
+	// Get the API keys of the user
+	// (GET /users/me/api-keys)
+	GetApiKeys(w http.ResponseWriter, r *http.Request)
+	// Create an API key for the user
+	// (POST /users/me/api-keys)
+	CreateApiKey(w http.ResponseWriter, r *http.Request)
+	// Revoke an API key of the user
+	// (DELETE /users/me/api-keys/{api_key_id})
+	RevokeApiKey(w http.ResponseWriter, r *http.Request, apiKeyId PathApiKeyId)
+	// Get the coaches the user has granted access to
+	// (GET /users/me/coaches)
+	GetCoaches(w http.ResponseWriter, r *http.Request)
+	// Grant a coach read access to the rounds and stats of the user
+	// (POST /users/me/coaches)
+	GrantCoach(w http.ResponseWriter, r *http.Request)
+	// Revoke the access of a coach to the rounds and stats of the user
+	// (DELETE /users/me/coaches/{coach_id})
+	RevokeCoach(w http.ResponseWriter, r *http.Request, coachId PathCoachId)
+	// Get the OpenID Connect identities linked to the user
+	// (GET /users/me/identities)
+	GetIdentities(w http.ResponseWriter, r *http.Request)
+	// Start linking an OpenID Connect identity to the user
+	// (POST /users/me/identities/{provider})
+	LinkIdentity(w http.ResponseWriter, r *http.Request, provider PathOidcProvider)
+	// Change the password of the user
+	// (POST /users/me/password)
+	ChangePassword(w http.ResponseWriter, r *http.Request)
+	// Get the players that have granted the user access as their coach
+	// (GET /users/me/players)
+	GetPlayers(w http.ResponseWriter, r *http.Request)
+	// Get the preferences of the user
+	// (GET /users/me/preferences)
+	GetPreferences(w http.ResponseWriter, r *http.Request)
+	// Set the preferences of the user
+	// (PUT /users/me/preferences)
+	UpdatePreferences(w http.ResponseWriter, r *http.Request)
+	// Get the active sessions of the user
+	// (GET /users/me/sessions)
+	GetSessions(w http.ResponseWriter, r *http.Request)
+	// Revoke a session of the user
+	// (DELETE /users/me/sessions/{session_id})
+	RevokeSession(w http.ResponseWriter, r *http.Request, sessionId PathSessionId)
+	// Enrol in two-factor authentication
+	// (POST /users/me/totp)
+	EnrolTotp(w http.ResponseWriter, r *http.Request)
+	// Confirm two-factor authentication
+	// (POST /users/me/totp/confirm)
+	ConfirmTotp(w http.ResponseWriter, r *http.Request)
 }
 
+// RateLimiterFunc is called before the handler of routes with x-global-rate-limit. If it returns an error, it must
+// have written the response and the handler is not called. The route being limited can be read from the request
+// context with RateLimitRouteFromContext.
 type RateLimiterFunc = func(http.ResponseWriter, *http.Request) error
 type MetricsMiddlewareFunc = http.HandlerFunc
 type ErrorHandlerFunc = func(http.ResponseWriter, *http.Request, error)
@@ -93,6 +202,454 @@ func WithMetricsMiddleware(middleware MetricsMiddlewareFunc) ServerOption {
 // ServerOption represents an optional feature applied to the server.
 type ServerOption func(s *ServerInterfaceWrapper)
 
+// RateLimitRoute is the route that a request is being rate limited for.
+type RateLimitRoute struct {
+	// OperationId is the ID of the operation being called.
+	OperationId string
+
+	// Policy is the x-global-rate-limit policy of the operation, "default" if the extension is set to true.
+	Policy string
+}
+
+type rateLimitRouteKey struct{}
+
+// RateLimitRouteFromContext returns the route that the request is being rate limited for.
+func RateLimitRouteFromContext(ctx context.Context) (*RateLimitRoute, bool) {
+	route, ok := ctx.Value(rateLimitRouteKey{}).(*RateLimitRoute)
+	return route, ok
+}
+
+// GetAuditEvents operation middleware
+func (siw *ServerInterfaceWrapper) GetAuditEvents(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	cw := uhttp.NewResponseWriter(w,
+		uhttp.WithDefaultStatusCode(http.StatusOK),
+		uhttp.WithDefaultHeader("X-Request-ID", uhttp.RequestIDFromContext(ctx)),
+		uhttp.WithDefaultHeader(uhttp.HeaderContentType, uhttp.ContentTypeJSON),
+	)
+
+	defer func() {
+		if siw.metricsMiddleware != nil {
+			siw.metricsMiddleware(cw, r)
+		}
+	}()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetAuditEventsParams
+
+	// ------------- Optional query parameter "actor_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "actor_id", r.URL.Query(), &params.ActorId)
+	if err != nil {
+		siw.errorHandlerFunc(cw, r, &InvalidParamFormatError{ParamName: "actor_id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "action" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "action", r.URL.Query(), &params.Action)
+	if err != nil {
+		siw.errorHandlerFunc(cw, r, &InvalidParamFormatError{ParamName: "action", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "target_type" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "target_type", r.URL.Query(), &params.TargetType)
+	if err != nil {
+		siw.errorHandlerFunc(cw, r, &InvalidParamFormatError{ParamName: "target_type", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "target_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "target_id", r.URL.Query(), &params.TargetId)
+	if err != nil {
+		siw.errorHandlerFunc(cw, r, &InvalidParamFormatError{ParamName: "target_id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "since" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "since", r.URL.Query(), &params.Since)
+	if err != nil {
+		siw.errorHandlerFunc(cw, r, &InvalidParamFormatError{ParamName: "since", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "until" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "until", r.URL.Query(), &params.Until)
+	if err != nil {
+		siw.errorHandlerFunc(cw, r, &InvalidParamFormatError{ParamName: "until", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.errorHandlerFunc(cw, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	if siw.rateLimiter != nil {
+		route := &RateLimitRoute{
+			OperationId: "GetAuditEvents",
+			Policy:      "default",
+		}
+		if err := siw.rateLimiter(cw, r.WithContext(context.WithValue(ctx, rateLimitRouteKey{}, route))); err != nil {
+			return
+		}
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if siw.authz != nil {
+			siw.authz.GetAuditEvents(cw, r.WithContext(ctx), params)
+			return
+		}
+	}))
+
+	handler.ServeHTTP(cw, r.WithContext(ctx))
+}
+
+// GetUsers operation middleware
+func (siw *ServerInterfaceWrapper) GetUsers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	cw := uhttp.NewResponseWriter(w,
+		uhttp.WithDefaultStatusCode(http.StatusOK),
+		uhttp.WithDefaultHeader("X-Request-ID", uhttp.RequestIDFromContext(ctx)),
+		uhttp.WithDefaultHeader(uhttp.HeaderContentType, uhttp.ContentTypeJSON),
+	)
+
+	defer func() {
+		if siw.metricsMiddleware != nil {
+			siw.metricsMiddleware(cw, r)
+		}
+	}()
+
+	if siw.rateLimiter != nil {
+		route := &RateLimitRoute{
+			OperationId: "GetUsers",
+			Policy:      "default",
+		}
+		if err := siw.rateLimiter(cw, r.WithContext(context.WithValue(ctx, rateLimitRouteKey{}, route))); err != nil {
+			return
+		}
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if siw.authz != nil {
+			siw.authz.GetUsers(cw, r.WithContext(ctx))
+			return
+		}
+	}))
+
+	handler.ServeHTTP(cw, r.WithContext(ctx))
+}
+
+// UpdateUser operation middleware
+func (siw *ServerInterfaceWrapper) UpdateUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	cw := uhttp.NewResponseWriter(w,
+		uhttp.WithDefaultStatusCode(http.StatusOK),
+		uhttp.WithDefaultHeader("X-Request-ID", uhttp.RequestIDFromContext(ctx)),
+		uhttp.WithDefaultHeader(uhttp.HeaderContentType, uhttp.ContentTypeJSON),
+	)
+
+	defer func() {
+		if siw.metricsMiddleware != nil {
+			siw.metricsMiddleware(cw, r)
+		}
+	}()
+
+	var err error
+
+	// ------------- Path parameter "user_id" -------------
+	var userId PathUserId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", mux.Vars(r)["user_id"], &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.errorHandlerFunc(cw, r, &InvalidParamFormatError{ParamName: "user_id", Err: err})
+		return
+	}
+
+	if siw.rateLimiter != nil {
+		route := &RateLimitRoute{
+			OperationId: "UpdateUser",
+			Policy:      "default",
+		}
+		if err := siw.rateLimiter(cw, r.WithContext(context.WithValue(ctx, rateLimitRouteKey{}, route))); err != nil {
+			return
+		}
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if siw.authz != nil {
+			siw.authz.UpdateUser(cw, r.WithContext(ctx), userId)
+			return
+		}
+	}))
+
+	handler.ServeHTTP(cw, r.WithContext(ctx))
+}
+
+// ResetUserTotp operation middleware
+func (siw *ServerInterfaceWrapper) ResetUserTotp(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	cw := uhttp.NewResponseWriter(w,
+		uhttp.WithDefaultStatusCode(http.StatusOK),
+		uhttp.WithDefaultHeader("X-Request-ID", uhttp.RequestIDFromContext(ctx)),
+		uhttp.WithDefaultHeader(uhttp.HeaderContentType, uhttp.ContentTypeJSON),
+	)
+
+	defer func() {
+		if siw.metricsMiddleware != nil {
+			siw.metricsMiddleware(cw, r)
+		}
+	}()
+
+	var err error
+
+	// ------------- Path parameter "user_id" -------------
+	var userId PathUserId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", mux.Vars(r)["user_id"], &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.errorHandlerFunc(cw, r, &InvalidParamFormatError{ParamName: "user_id", Err: err})
+		return
+	}
+
+	if siw.rateLimiter != nil {
+		route := &RateLimitRoute{
+			OperationId: "ResetUserTotp",
+			Policy:      "default",
+		}
+		if err := siw.rateLimiter(cw, r.WithContext(context.WithValue(ctx, rateLimitRouteKey{}, route))); err != nil {
+			return
+		}
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if siw.authz != nil {
+			siw.authz.ResetUserTotp(cw, r.WithContext(ctx), userId)
+			return
+		}
+	}))
+
+	handler.ServeHTTP(cw, r.WithContext(ctx))
+}
+
+// GetCourses operation middleware
+func (siw *ServerInterfaceWrapper) GetCourses(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	cw := uhttp.NewResponseWriter(w,
+		uhttp.WithDefaultStatusCode(http.StatusOK),
+		uhttp.WithDefaultHeader("X-Request-ID", uhttp.RequestIDFromContext(ctx)),
+		uhttp.WithDefaultHeader(uhttp.HeaderContentType, uhttp.ContentTypeJSON),
+	)
+
+	defer func() {
+		if siw.metricsMiddleware != nil {
+			siw.metricsMiddleware(cw, r)
+		}
+	}()
+
+	if siw.rateLimiter != nil {
+		route := &RateLimitRoute{
+			OperationId: "GetCourses",
+			Policy:      "default",
+		}
+		if err := siw.rateLimiter(cw, r.WithContext(context.WithValue(ctx, rateLimitRouteKey{}, route))); err != nil {
+			return
+		}
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if siw.authz != nil {
+			siw.authz.GetCourses(cw, r.WithContext(ctx))
+			return
+		}
+	}))
+
+	handler.ServeHTTP(cw, r.WithContext(ctx))
+}
+
+// CreateCourse operation middleware
+func (siw *ServerInterfaceWrapper) CreateCourse(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	cw := uhttp.NewResponseWriter(w,
+		uhttp.WithDefaultStatusCode(http.StatusOK),
+		uhttp.WithDefaultHeader("X-Request-ID", uhttp.RequestIDFromContext(ctx)),
+		uhttp.WithDefaultHeader(uhttp.HeaderContentType, uhttp.ContentTypeJSON),
+	)
+
+	defer func() {
+		if siw.metricsMiddleware != nil {
+			siw.metricsMiddleware(cw, r)
+		}
+	}()
+
+	if siw.rateLimiter != nil {
+		route := &RateLimitRoute{
+			OperationId: "CreateCourse",
+			Policy:      "default",
+		}
+		if err := siw.rateLimiter(cw, r.WithContext(context.WithValue(ctx, rateLimitRouteKey{}, route))); err != nil {
+			return
+		}
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if siw.authz != nil {
+			siw.authz.CreateCourse(cw, r.WithContext(ctx))
+			return
+		}
+	}))
+
+	handler.ServeHTTP(cw, r.WithContext(ctx))
+}
+
+// DeleteCourse operation middleware
+func (siw *ServerInterfaceWrapper) DeleteCourse(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	cw := uhttp.NewResponseWriter(w,
+		uhttp.WithDefaultStatusCode(http.StatusOK),
+		uhttp.WithDefaultHeader("X-Request-ID", uhttp.RequestIDFromContext(ctx)),
+		uhttp.WithDefaultHeader(uhttp.HeaderContentType, uhttp.ContentTypeJSON),
+	)
+
+	defer func() {
+		if siw.metricsMiddleware != nil {
+			siw.metricsMiddleware(cw, r)
+		}
+	}()
+
+	var err error
+
+	// ------------- Path parameter "course_id" -------------
+	var courseId PathCourseId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "course_id", mux.Vars(r)["course_id"], &courseId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.errorHandlerFunc(cw, r, &InvalidParamFormatError{ParamName: "course_id", Err: err})
+		return
+	}
+
+	if siw.rateLimiter != nil {
+		route := &RateLimitRoute{
+			OperationId: "DeleteCourse",
+			Policy:      "default",
+		}
+		if err := siw.rateLimiter(cw, r.WithContext(context.WithValue(ctx, rateLimitRouteKey{}, route))); err != nil {
+			return
+		}
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if siw.authz != nil {
+			siw.authz.DeleteCourse(cw, r.WithContext(ctx), courseId)
+			return
+		}
+	}))
+
+	handler.ServeHTTP(cw, r.WithContext(ctx))
+}
+
+// GetCourse operation middleware
+func (siw *ServerInterfaceWrapper) GetCourse(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	cw := uhttp.NewResponseWriter(w,
+		uhttp.WithDefaultStatusCode(http.StatusOK),
+		uhttp.WithDefaultHeader("X-Request-ID", uhttp.RequestIDFromContext(ctx)),
+		uhttp.WithDefaultHeader(uhttp.HeaderContentType, uhttp.ContentTypeJSON),
+	)
+
+	defer func() {
+		if siw.metricsMiddleware != nil {
+			siw.metricsMiddleware(cw, r)
+		}
+	}()
+
+	var err error
+
+	// ------------- Path parameter "course_id" -------------
+	var courseId PathCourseId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "course_id", mux.Vars(r)["course_id"], &courseId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.errorHandlerFunc(cw, r, &InvalidParamFormatError{ParamName: "course_id", Err: err})
+		return
+	}
+
+	if siw.rateLimiter != nil {
+		route := &RateLimitRoute{
+			OperationId: "GetCourse",
+			Policy:      "default",
+		}
+		if err := siw.rateLimiter(cw, r.WithContext(context.WithValue(ctx, rateLimitRouteKey{}, route))); err != nil {
+			return
+		}
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if siw.authz != nil {
+			siw.authz.GetCourse(cw, r.WithContext(ctx), courseId)
+			return
+		}
+	}))
+
+	handler.ServeHTTP(cw, r.WithContext(ctx))
+}
+
+// UpdateCourse operation middleware
+func (siw *ServerInterfaceWrapper) UpdateCourse(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	cw := uhttp.NewResponseWriter(w,
+		uhttp.WithDefaultStatusCode(http.StatusOK),
+		uhttp.WithDefaultHeader("X-Request-ID", uhttp.RequestIDFromContext(ctx)),
+		uhttp.WithDefaultHeader(uhttp.HeaderContentType, uhttp.ContentTypeJSON),
+	)
+
+	defer func() {
+		if siw.metricsMiddleware != nil {
+			siw.metricsMiddleware(cw, r)
+		}
+	}()
+
+	var err error
+
+	// ------------- Path parameter "course_id" -------------
+	var courseId PathCourseId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "course_id", mux.Vars(r)["course_id"], &courseId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.errorHandlerFunc(cw, r, &InvalidParamFormatError{ParamName: "course_id", Err: err})
+		return
+	}
+
+	if siw.rateLimiter != nil {
+		route := &RateLimitRoute{
+			OperationId: "UpdateCourse",
+			Policy:      "default",
+		}
+		if err := siw.rateLimiter(cw, r.WithContext(context.WithValue(ctx, rateLimitRouteKey{}, route))); err != nil {
+			return
+		}
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if siw.authz != nil {
+			siw.authz.UpdateCourse(cw, r.WithContext(ctx), courseId)
+			return
+		}
+	}))
+
+	handler.ServeHTTP(cw, r.WithContext(ctx))
+}
+
 // Login operation middleware
 func (siw *ServerInterfaceWrapper) Login(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -108,15 +665,1256 @@ func (siw *ServerInterfaceWrapper) Login(w http.ResponseWriter, r *http.Request)
 		}
 	}()
 
+	if siw.rateLimiter != nil {
+		route := &RateLimitRoute{
+			OperationId: "Login",
+			Policy:      "auth",
+		}
+		if err := siw.rateLimiter(cw, r.WithContext(context.WithValue(ctx, rateLimitRouteKey{}, route))); err != nil {
+			return
+		}
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.handler.Login(cw, r)
+	}))
+
+	handler.ServeHTTP(cw, r.WithContext(ctx))
+}
+
+// StartOidcLogin operation middleware
+func (siw *ServerInterfaceWrapper) StartOidcLogin(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	cw := uhttp.NewResponseWriter(w,
+		uhttp.WithDefaultStatusCode(http.StatusOK),
+		uhttp.WithDefaultHeader("X-Request-ID", uhttp.RequestIDFromContext(ctx)),
+		uhttp.WithDefaultHeader(uhttp.HeaderContentType, uhttp.ContentTypeJSON),
+	)
+
+	defer func() {
+		if siw.metricsMiddleware != nil {
+			siw.metricsMiddleware(cw, r)
+		}
+	}()
+
+	var err error
+
+	// ------------- Path parameter "provider" -------------
+	var provider PathOidcProvider
+
+	err = runtime.BindStyledParameterWithOptions("simple", "provider", mux.Vars(r)["provider"], &provider, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.errorHandlerFunc(cw, r, &InvalidParamFormatError{ParamName: "provider", Err: err})
+		return
+	}
+
+	if siw.rateLimiter != nil {
+		route := &RateLimitRoute{
+			OperationId: "StartOidcLogin",
+			Policy:      "auth",
+		}
+		if err := siw.rateLimiter(cw, r.WithContext(context.WithValue(ctx, rateLimitRouteKey{}, route))); err != nil {
+			return
+		}
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.handler.StartOidcLogin(cw, r, provider)
+	}))
+
+	handler.ServeHTTP(cw, r.WithContext(ctx))
+}
+
+// OidcCallback operation middleware
+func (siw *ServerInterfaceWrapper) OidcCallback(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	cw := uhttp.NewResponseWriter(w,
+		uhttp.WithDefaultStatusCode(http.StatusOK),
+		uhttp.WithDefaultHeader("X-Request-ID", uhttp.RequestIDFromContext(ctx)),
+		uhttp.WithDefaultHeader(uhttp.HeaderContentType, uhttp.ContentTypeJSON),
+	)
+
+	defer func() {
+		if siw.metricsMiddleware != nil {
+			siw.metricsMiddleware(cw, r)
+		}
+	}()
+
+	var err error
+
+	// ------------- Path parameter "provider" -------------
+	var provider PathOidcProvider
+
+	err = runtime.BindStyledParameterWithOptions("simple", "provider", mux.Vars(r)["provider"], &provider, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.errorHandlerFunc(cw, r, &InvalidParamFormatError{ParamName: "provider", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params OidcCallbackParams
+
+	// ------------- Required query parameter "state" -------------
+
+	if paramValue := r.URL.Query().Get("state"); paramValue != "" {
+
+	} else {
+		siw.errorHandlerFunc(cw, r, &RequiredParamError{ParamName: "state"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "state", r.URL.Query(), &params.State)
+	if err != nil {
+		siw.errorHandlerFunc(cw, r, &InvalidParamFormatError{ParamName: "state", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "code" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "code", r.URL.Query(), &params.Code)
+	if err != nil {
+		siw.errorHandlerFunc(cw, r, &InvalidParamFormatError{ParamName: "code", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "error" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "error", r.URL.Query(), &params.Error)
+	if err != nil {
+		siw.errorHandlerFunc(cw, r, &InvalidParamFormatError{ParamName: "error", Err: err})
+		return
+	}
+
+	if siw.rateLimiter != nil {
+		route := &RateLimitRoute{
+			OperationId: "OidcCallback",
+			Policy:      "auth",
+		}
+		if err := siw.rateLimiter(cw, r.WithContext(context.WithValue(ctx, rateLimitRouteKey{}, route))); err != nil {
+			return
+		}
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.handler.OidcCallback(cw, r, provider, params)
+	}))
+
+	handler.ServeHTTP(cw, r.WithContext(ctx))
+}
+
+// Logout operation middleware
+func (siw *ServerInterfaceWrapper) Logout(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	cw := uhttp.NewResponseWriter(w,
+		uhttp.WithDefaultStatusCode(http.StatusOK),
+		uhttp.WithDefaultHeader("X-Request-ID", uhttp.RequestIDFromContext(ctx)),
+		uhttp.WithDefaultHeader(uhttp.HeaderContentType, uhttp.ContentTypeJSON),
+	)
+
+	defer func() {
+		if siw.metricsMiddleware != nil {
+			siw.metricsMiddleware(cw, r)
+		}
+	}()
+
+	if siw.rateLimiter != nil {
+		route := &RateLimitRoute{
+			OperationId: "Logout",
+			Policy:      "default",
+		}
+		if err := siw.rateLimiter(cw, r.WithContext(context.WithValue(ctx, rateLimitRouteKey{}, route))); err != nil {
+			return
+		}
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if siw.authz != nil {
+			siw.authz.Logout(cw, r.WithContext(ctx))
+			return
+		}
+	}))
+
+	handler.ServeHTTP(cw, r.WithContext(ctx))
+}
+
+// RequestPasswordReset operation middleware
+func (siw *ServerInterfaceWrapper) RequestPasswordReset(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	cw := uhttp.NewResponseWriter(w,
+		uhttp.WithDefaultStatusCode(http.StatusOK),
+		uhttp.WithDefaultHeader("X-Request-ID", uhttp.RequestIDFromContext(ctx)),
+		uhttp.WithDefaultHeader(uhttp.HeaderContentType, uhttp.ContentTypeJSON),
+	)
+
+	defer func() {
+		if siw.metricsMiddleware != nil {
+			siw.metricsMiddleware(cw, r)
+		}
+	}()
+
+	if siw.rateLimiter != nil {
+		route := &RateLimitRoute{
+			OperationId: "RequestPasswordReset",
+			Policy:      "auth",
+		}
+		if err := siw.rateLimiter(cw, r.WithContext(context.WithValue(ctx, rateLimitRouteKey{}, route))); err != nil {
+			return
+		}
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.handler.RequestPasswordReset(cw, r)
+	}))
+
+	handler.ServeHTTP(cw, r.WithContext(ctx))
+}
+
+// ConfirmPasswordReset operation middleware
+func (siw *ServerInterfaceWrapper) ConfirmPasswordReset(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	cw := uhttp.NewResponseWriter(w,
+		uhttp.WithDefaultStatusCode(http.StatusOK),
+		uhttp.WithDefaultHeader("X-Request-ID", uhttp.RequestIDFromContext(ctx)),
+		uhttp.WithDefaultHeader(uhttp.HeaderContentType, uhttp.ContentTypeJSON),
+	)
+
+	defer func() {
+		if siw.metricsMiddleware != nil {
+			siw.metricsMiddleware(cw, r)
+		}
+	}()
+
+	if siw.rateLimiter != nil {
+		route := &RateLimitRoute{
+			OperationId: "ConfirmPasswordReset",
+			Policy:      "auth",
+		}
+		if err := siw.rateLimiter(cw, r.WithContext(context.WithValue(ctx, rateLimitRouteKey{}, route))); err != nil {
+			return
+		}
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.handler.ConfirmPasswordReset(cw, r)
+	}))
+
+	handler.ServeHTTP(cw, r.WithContext(ctx))
+}
+
+// GetRounds operation middleware
+func (siw *ServerInterfaceWrapper) GetRounds(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	cw := uhttp.NewResponseWriter(w,
+		uhttp.WithDefaultStatusCode(http.StatusOK),
+		uhttp.WithDefaultHeader("X-Request-ID", uhttp.RequestIDFromContext(ctx)),
+		uhttp.WithDefaultHeader(uhttp.HeaderContentType, uhttp.ContentTypeJSON),
+	)
+
+	defer func() {
+		if siw.metricsMiddleware != nil {
+			siw.metricsMiddleware(cw, r)
+		}
+	}()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetRoundsParams
+
+	// ------------- Optional query parameter "user_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "user_id", r.URL.Query(), &params.UserId)
+	if err != nil {
+		siw.errorHandlerFunc(cw, r, &InvalidParamFormatError{ParamName: "user_id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.errorHandlerFunc(cw, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "last_val" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "last_val", r.URL.Query(), &params.LastVal)
+	if err != nil {
+		siw.errorHandlerFunc(cw, r, &InvalidParamFormatError{ParamName: "last_val", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "last_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "last_id", r.URL.Query(), &params.LastId)
+	if err != nil {
+		siw.errorHandlerFunc(cw, r, &InvalidParamFormatError{ParamName: "last_id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sort_by" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort_by", r.URL.Query(), &params.SortBy)
+	if err != nil {
+		siw.errorHandlerFunc(cw, r, &InvalidParamFormatError{ParamName: "sort_by", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sort_dir" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort_dir", r.URL.Query(), &params.SortDir)
+	if err != nil {
+		siw.errorHandlerFunc(cw, r, &InvalidParamFormatError{ParamName: "sort_dir", Err: err})
+		return
+	}
+
+	if siw.rateLimiter != nil {
+		route := &RateLimitRoute{
+			OperationId: "GetRounds",
+			Policy:      "default",
+		}
+		if err := siw.rateLimiter(cw, r.WithContext(context.WithValue(ctx, rateLimitRouteKey{}, route))); err != nil {
+			return
+		}
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if siw.authz != nil {
+			siw.authz.GetRounds(cw, r.WithContext(ctx), params)
+			return
+		}
+	}))
+
+	handler.ServeHTTP(cw, r.WithContext(ctx))
+}
+
+// CreateRound operation middleware
+func (siw *ServerInterfaceWrapper) CreateRound(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	cw := uhttp.NewResponseWriter(w,
+		uhttp.WithDefaultStatusCode(http.StatusOK),
+		uhttp.WithDefaultHeader("X-Request-ID", uhttp.RequestIDFromContext(ctx)),
+		uhttp.WithDefaultHeader(uhttp.HeaderContentType, uhttp.ContentTypeJSON),
+	)
+
+	defer func() {
+		if siw.metricsMiddleware != nil {
+			siw.metricsMiddleware(cw, r)
+		}
+	}()
+
+	if siw.rateLimiter != nil {
+		route := &RateLimitRoute{
+			OperationId: "CreateRound",
+			Policy:      "default",
+		}
+		if err := siw.rateLimiter(cw, r.WithContext(context.WithValue(ctx, rateLimitRouteKey{}, route))); err != nil {
+			return
+		}
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if siw.authz != nil {
+			siw.authz.CreateRound(cw, r.WithContext(ctx))
+			return
+		}
+	}))
+
+	handler.ServeHTTP(cw, r.WithContext(ctx))
+}
+
+// GetNewRoundCourses operation middleware
+func (siw *ServerInterfaceWrapper) GetNewRoundCourses(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	cw := uhttp.NewResponseWriter(w,
+		uhttp.WithDefaultStatusCode(http.StatusOK),
+		uhttp.WithDefaultHeader("X-Request-ID", uhttp.RequestIDFromContext(ctx)),
+		uhttp.WithDefaultHeader(uhttp.HeaderContentType, uhttp.ContentTypeJSON),
+	)
+
+	defer func() {
+		if siw.metricsMiddleware != nil {
+			siw.metricsMiddleware(cw, r)
+		}
+	}()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetNewRoundCoursesParams
+
+	// ------------- Optional query parameter "name" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "name", r.URL.Query(), &params.Name)
+	if err != nil {
+		siw.errorHandlerFunc(cw, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	if siw.rateLimiter != nil {
+		route := &RateLimitRoute{
+			OperationId: "GetNewRoundCourses",
+			Policy:      "default",
+		}
+		if err := siw.rateLimiter(cw, r.WithContext(context.WithValue(ctx, rateLimitRouteKey{}, route))); err != nil {
+			return
+		}
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if siw.authz != nil {
+			siw.authz.GetNewRoundCourses(cw, r.WithContext(ctx), params)
+			return
+		}
+	}))
+
+	handler.ServeHTTP(cw, r.WithContext(ctx))
+}
+
+// GetNewRoundMarker operation middleware
+func (siw *ServerInterfaceWrapper) GetNewRoundMarker(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	cw := uhttp.NewResponseWriter(w,
+		uhttp.WithDefaultStatusCode(http.StatusOK),
+		uhttp.WithDefaultHeader("X-Request-ID", uhttp.RequestIDFromContext(ctx)),
+		uhttp.WithDefaultHeader(uhttp.HeaderContentType, uhttp.ContentTypeJSON),
+	)
+
+	defer func() {
+		if siw.metricsMiddleware != nil {
+			siw.metricsMiddleware(cw, r)
+		}
+	}()
+
+	var err error
+
+	// ------------- Path parameter "course_id" -------------
+	var courseId PathCourseId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "course_id", mux.Vars(r)["course_id"], &courseId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.errorHandlerFunc(cw, r, &InvalidParamFormatError{ParamName: "course_id", Err: err})
+		return
+	}
+
+	if siw.rateLimiter != nil {
+		route := &RateLimitRoute{
+			OperationId: "GetNewRoundMarker",
+			Policy:      "default",
+		}
+		if err := siw.rateLimiter(cw, r.WithContext(context.WithValue(ctx, rateLimitRouteKey{}, route))); err != nil {
+			return
+		}
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if siw.authz != nil {
+			siw.authz.GetNewRoundMarker(cw, r.WithContext(ctx), courseId)
+			return
+		}
+	}))
+
+	handler.ServeHTTP(cw, r.WithContext(ctx))
+}
+
+// GetRoundStats operation middleware
+func (siw *ServerInterfaceWrapper) GetRoundStats(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	cw := uhttp.NewResponseWriter(w,
+		uhttp.WithDefaultStatusCode(http.StatusOK),
+		uhttp.WithDefaultHeader("X-Request-ID", uhttp.RequestIDFromContext(ctx)),
+		uhttp.WithDefaultHeader(uhttp.HeaderContentType, uhttp.ContentTypeJSON),
+	)
+
+	defer func() {
+		if siw.metricsMiddleware != nil {
+			siw.metricsMiddleware(cw, r)
+		}
+	}()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetRoundStatsParams
+
+	// ------------- Optional query parameter "user_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "user_id", r.URL.Query(), &params.UserId)
+	if err != nil {
+		siw.errorHandlerFunc(cw, r, &InvalidParamFormatError{ParamName: "user_id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.errorHandlerFunc(cw, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "last_val" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "last_val", r.URL.Query(), &params.LastVal)
+	if err != nil {
+		siw.errorHandlerFunc(cw, r, &InvalidParamFormatError{ParamName: "last_val", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "last_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "last_id", r.URL.Query(), &params.LastId)
+	if err != nil {
+		siw.errorHandlerFunc(cw, r, &InvalidParamFormatError{ParamName: "last_id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sort_by" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort_by", r.URL.Query(), &params.SortBy)
+	if err != nil {
+		siw.errorHandlerFunc(cw, r, &InvalidParamFormatError{ParamName: "sort_by", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sort_dir" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort_dir", r.URL.Query(), &params.SortDir)
+	if err != nil {
+		siw.errorHandlerFunc(cw, r, &InvalidParamFormatError{ParamName: "sort_dir", Err: err})
+		return
+	}
+
+	if siw.rateLimiter != nil {
+		route := &RateLimitRoute{
+			OperationId: "GetRoundStats",
+			Policy:      "default",
+		}
+		if err := siw.rateLimiter(cw, r.WithContext(context.WithValue(ctx, rateLimitRouteKey{}, route))); err != nil {
+			return
+		}
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if siw.authz != nil {
+			siw.authz.GetRoundStats(cw, r.WithContext(ctx), params)
+			return
+		}
+	}))
+
+	handler.ServeHTTP(cw, r.WithContext(ctx))
+}
+
+// GetLineChartAverages operation middleware
+func (siw *ServerInterfaceWrapper) GetLineChartAverages(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	cw := uhttp.NewResponseWriter(w,
+		uhttp.WithDefaultStatusCode(http.StatusOK),
+		uhttp.WithDefaultHeader("X-Request-ID", uhttp.RequestIDFromContext(ctx)),
+		uhttp.WithDefaultHeader(uhttp.HeaderContentType, uhttp.ContentTypeJSON),
+	)
+
+	defer func() {
+		if siw.metricsMiddleware != nil {
+			siw.metricsMiddleware(cw, r)
+		}
+	}()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetLineChartAveragesParams
+
+	// ------------- Optional query parameter "user_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "user_id", r.URL.Query(), &params.UserId)
+	if err != nil {
+		siw.errorHandlerFunc(cw, r, &InvalidParamFormatError{ParamName: "user_id", Err: err})
+		return
+	}
+
+	// ------------- Required query parameter "average_type" -------------
+
+	if paramValue := r.URL.Query().Get("average_type"); paramValue != "" {
+
+	} else {
+		siw.errorHandlerFunc(cw, r, &RequiredParamError{ParamName: "average_type"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "average_type", r.URL.Query(), &params.AverageType)
+	if err != nil {
+		siw.errorHandlerFunc(cw, r, &InvalidParamFormatError{ParamName: "average_type", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "from_date" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from_date", r.URL.Query(), &params.FromDate)
+	if err != nil {
+		siw.errorHandlerFunc(cw, r, &InvalidParamFormatError{ParamName: "from_date", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "since" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "since", r.URL.Query(), &params.Since)
+	if err != nil {
+		siw.errorHandlerFunc(cw, r, &InvalidParamFormatError{ParamName: "since", Err: err})
+		return
+	}
+
+	if siw.rateLimiter != nil {
+		route := &RateLimitRoute{
+			OperationId: "GetLineChartAverages",
+			Policy:      "charts",
+		}
+		if err := siw.rateLimiter(cw, r.WithContext(context.WithValue(ctx, rateLimitRouteKey{}, route))); err != nil {
+			return
+		}
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if siw.authz != nil {
+			siw.authz.GetLineChartAverages(cw, r.WithContext(ctx), params)
+			return
+		}
+	}))
+
+	handler.ServeHTTP(cw, r.WithContext(ctx))
+}
+
+// GetPieChartAverages operation middleware
+func (siw *ServerInterfaceWrapper) GetPieChartAverages(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	cw := uhttp.NewResponseWriter(w,
+		uhttp.WithDefaultStatusCode(http.StatusOK),
+		uhttp.WithDefaultHeader("X-Request-ID", uhttp.RequestIDFromContext(ctx)),
+		uhttp.WithDefaultHeader(uhttp.HeaderContentType, uhttp.ContentTypeJSON),
+	)
+
+	defer func() {
+		if siw.metricsMiddleware != nil {
+			siw.metricsMiddleware(cw, r)
+		}
+	}()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetPieChartAveragesParams
+
+	// ------------- Optional query parameter "user_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "user_id", r.URL.Query(), &params.UserId)
+	if err != nil {
+		siw.errorHandlerFunc(cw, r, &InvalidParamFormatError{ParamName: "user_id", Err: err})
+		return
+	}
+
+	// ------------- Required query parameter "average_type" -------------
+
+	if paramValue := r.URL.Query().Get("average_type"); paramValue != "" {
+
+	} else {
+		siw.errorHandlerFunc(cw, r, &RequiredParamError{ParamName: "average_type"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "average_type", r.URL.Query(), &params.AverageType)
+	if err != nil {
+		siw.errorHandlerFunc(cw, r, &InvalidParamFormatError{ParamName: "average_type", Err: err})
+		return
+	}
+
+	if siw.rateLimiter != nil {
+		route := &RateLimitRoute{
+			OperationId: "GetPieChartAverages",
+			Policy:      "charts",
+		}
+		if err := siw.rateLimiter(cw, r.WithContext(context.WithValue(ctx, rateLimitRouteKey{}, route))); err != nil {
+			return
+		}
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if siw.authz != nil {
+			siw.authz.GetPieChartAverages(cw, r.WithContext(ctx), params)
+			return
+		}
+	}))
+
+	handler.ServeHTTP(cw, r.WithContext(ctx))
+}
+
+// DeleteRound operation middleware
+func (siw *ServerInterfaceWrapper) DeleteRound(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	cw := uhttp.NewResponseWriter(w,
+		uhttp.WithDefaultStatusCode(http.StatusOK),
+		uhttp.WithDefaultHeader("X-Request-ID", uhttp.RequestIDFromContext(ctx)),
+		uhttp.WithDefaultHeader(uhttp.HeaderContentType, uhttp.ContentTypeJSON),
+	)
+
+	defer func() {
+		if siw.metricsMiddleware != nil {
+			siw.metricsMiddleware(cw, r)
+		}
+	}()
+
+	var err error
+
+	// ------------- Path parameter "round_id" -------------
+	var roundId PathRoundId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "round_id", mux.Vars(r)["round_id"], &roundId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.errorHandlerFunc(cw, r, &InvalidParamFormatError{ParamName: "round_id", Err: err})
+		return
+	}
+
+	if siw.rateLimiter != nil {
+		route := &RateLimitRoute{
+			OperationId: "DeleteRound",
+			Policy:      "default",
+		}
+		if err := siw.rateLimiter(cw, r.WithContext(context.WithValue(ctx, rateLimitRouteKey{}, route))); err != nil {
+			return
+		}
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if siw.authz != nil {
+			siw.authz.DeleteRound(cw, r.WithContext(ctx), roundId)
+			return
+		}
+	}))
+
+	handler.ServeHTTP(cw, r.WithContext(ctx))
+}
+
+// GetRoundHoles operation middleware
+func (siw *ServerInterfaceWrapper) GetRoundHoles(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	cw := uhttp.NewResponseWriter(w,
+		uhttp.WithDefaultStatusCode(http.StatusOK),
+		uhttp.WithDefaultHeader("X-Request-ID", uhttp.RequestIDFromContext(ctx)),
+		uhttp.WithDefaultHeader(uhttp.HeaderContentType, uhttp.ContentTypeJSON),
+	)
+
+	defer func() {
+		if siw.metricsMiddleware != nil {
+			siw.metricsMiddleware(cw, r)
+		}
+	}()
+
+	var err error
+
+	// ------------- Path parameter "round_id" -------------
+	var roundId PathRoundId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "round_id", mux.Vars(r)["round_id"], &roundId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.errorHandlerFunc(cw, r, &InvalidParamFormatError{ParamName: "round_id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetRoundHolesParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.errorHandlerFunc(cw, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "last_val" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "last_val", r.URL.Query(), &params.LastVal)
+	if err != nil {
+		siw.errorHandlerFunc(cw, r, &InvalidParamFormatError{ParamName: "last_val", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "last_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "last_id", r.URL.Query(), &params.LastId)
+	if err != nil {
+		siw.errorHandlerFunc(cw, r, &InvalidParamFormatError{ParamName: "last_id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sort_by" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort_by", r.URL.Query(), &params.SortBy)
+	if err != nil {
+		siw.errorHandlerFunc(cw, r, &InvalidParamFormatError{ParamName: "sort_by", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sort_dir" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort_dir", r.URL.Query(), &params.SortDir)
+	if err != nil {
+		siw.errorHandlerFunc(cw, r, &InvalidParamFormatError{ParamName: "sort_dir", Err: err})
+		return
+	}
+
+	if siw.rateLimiter != nil {
+		route := &RateLimitRoute{
+			OperationId: "GetRoundHoles",
+			Policy:      "default",
+		}
+		if err := siw.rateLimiter(cw, r.WithContext(context.WithValue(ctx, rateLimitRouteKey{}, route))); err != nil {
+			return
+		}
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if siw.authz != nil {
+			siw.authz.GetRoundHoles(cw, r.WithContext(ctx), roundId, params)
+			return
+		}
+	}))
+
+	handler.ServeHTTP(cw, r.WithContext(ctx))
+}
+
+// GetHoleStats operation middleware
+func (siw *ServerInterfaceWrapper) GetHoleStats(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	cw := uhttp.NewResponseWriter(w,
+		uhttp.WithDefaultStatusCode(http.StatusOK),
+		uhttp.WithDefaultHeader("X-Request-ID", uhttp.RequestIDFromContext(ctx)),
+		uhttp.WithDefaultHeader(uhttp.HeaderContentType, uhttp.ContentTypeJSON),
+	)
+
+	defer func() {
+		if siw.metricsMiddleware != nil {
+			siw.metricsMiddleware(cw, r)
+		}
+	}()
+
+	var err error
+
+	// ------------- Path parameter "round_id" -------------
+	var roundId PathRoundId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "round_id", mux.Vars(r)["round_id"], &roundId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.errorHandlerFunc(cw, r, &InvalidParamFormatError{ParamName: "round_id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "hole_id" -------------
+	var holeId PathHoleId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "hole_id", mux.Vars(r)["hole_id"], &holeId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.errorHandlerFunc(cw, r, &InvalidParamFormatError{ParamName: "hole_id", Err: err})
+		return
+	}
+
+	if siw.rateLimiter != nil {
+		route := &RateLimitRoute{
+			OperationId: "GetHoleStats",
+			Policy:      "default",
+		}
+		if err := siw.rateLimiter(cw, r.WithContext(context.WithValue(ctx, rateLimitRouteKey{}, route))); err != nil {
+			return
+		}
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if siw.authz != nil {
+			siw.authz.GetHoleStats(cw, r.WithContext(ctx), roundId, holeId)
+			return
+		}
+	}))
+
+	handler.ServeHTTP(cw, r.WithContext(ctx))
+}
+
+// UpdateHoleStats operation middleware
+func (siw *ServerInterfaceWrapper) UpdateHoleStats(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	cw := uhttp.NewResponseWriter(w,
+		uhttp.WithDefaultStatusCode(http.StatusOK),
+		uhttp.WithDefaultHeader("X-Request-ID", uhttp.RequestIDFromContext(ctx)),
+		uhttp.WithDefaultHeader(uhttp.HeaderContentType, uhttp.ContentTypeJSON),
+	)
+
+	defer func() {
+		if siw.metricsMiddleware != nil {
+			siw.metricsMiddleware(cw, r)
+		}
+	}()
+
+	var err error
+
+	// ------------- Path parameter "round_id" -------------
+	var roundId PathRoundId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "round_id", mux.Vars(r)["round_id"], &roundId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.errorHandlerFunc(cw, r, &InvalidParamFormatError{ParamName: "round_id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "hole_id" -------------
+	var holeId PathHoleId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "hole_id", mux.Vars(r)["hole_id"], &holeId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.errorHandlerFunc(cw, r, &InvalidParamFormatError{ParamName: "hole_id", Err: err})
+		return
+	}
+
+	if siw.rateLimiter != nil {
+		route := &RateLimitRoute{
+			OperationId: "UpdateHoleStats",
+			Policy:      "default",
+		}
+		if err := siw.rateLimiter(cw, r.WithContext(context.WithValue(ctx, rateLimitRouteKey{}, route))); err != nil {
+			return
+		}
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if siw.authz != nil {
+			siw.authz.UpdateHoleStats(cw, r.WithContext(ctx), roundId, holeId)
+			return
+		}
+	}))
+
+	handler.ServeHTTP(cw, r.WithContext(ctx))
+}
+
+// RefreshToken operation middleware
+func (siw *ServerInterfaceWrapper) RefreshToken(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	cw := uhttp.NewResponseWriter(w,
+		uhttp.WithDefaultStatusCode(http.StatusOK),
+		uhttp.WithDefaultHeader("X-Request-ID", uhttp.RequestIDFromContext(ctx)),
+		uhttp.WithDefaultHeader(uhttp.HeaderContentType, uhttp.ContentTypeJSON),
+	)
+
+	defer func() {
+		if siw.metricsMiddleware != nil {
+			siw.metricsMiddleware(cw, r)
+		}
+	}()
+
+	if siw.rateLimiter != nil {
+		route := &RateLimitRoute{
+			OperationId: "RefreshToken",
+			Policy:      "auth",
+		}
+		if err := siw.rateLimiter(cw, r.WithContext(context.WithValue(ctx, rateLimitRouteKey{}, route))); err != nil {
+			return
+		}
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.handler.RefreshToken(cw, r)
+	}))
+
+	handler.ServeHTTP(cw, r.WithContext(ctx))
+}
+
+// CreateUser operation middleware
+func (siw *ServerInterfaceWrapper) CreateUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	cw := uhttp.NewResponseWriter(w,
+		uhttp.WithDefaultStatusCode(http.StatusOK),
+		uhttp.WithDefaultHeader("X-Request-ID", uhttp.RequestIDFromContext(ctx)),
+		uhttp.WithDefaultHeader(uhttp.HeaderContentType, uhttp.ContentTypeJSON),
+	)
+
+	defer func() {
+		if siw.metricsMiddleware != nil {
+			siw.metricsMiddleware(cw, r)
+		}
+	}()
+
+	if siw.rateLimiter != nil {
+		route := &RateLimitRoute{
+			OperationId: "CreateUser",
+			Policy:      "auth",
+		}
+		if err := siw.rateLimiter(cw, r.WithContext(context.WithValue(ctx, rateLimitRouteKey{}, route))); err != nil {
+			return
+		}
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.handler.CreateUser(cw, r)
+	}))
+
+	handler.ServeHTTP(cw, r.WithContext(ctx))
+}
+
+// GetProfile operation middleware
+func (siw *ServerInterfaceWrapper) GetProfile(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	cw := uhttp.NewResponseWriter(w,
+		uhttp.WithDefaultStatusCode(http.StatusOK),
+		uhttp.WithDefaultHeader("X-Request-ID", uhttp.RequestIDFromContext(ctx)),
+		uhttp.WithDefaultHeader(uhttp.HeaderContentType, uhttp.ContentTypeJSON),
+	)
+
+	defer func() {
+		if siw.metricsMiddleware != nil {
+			siw.metricsMiddleware(cw, r)
+		}
+	}()
+
+	if siw.rateLimiter != nil {
+		route := &RateLimitRoute{
+			OperationId: "GetProfile",
+			Policy:      "default",
+		}
+		if err := siw.rateLimiter(cw, r.WithContext(context.WithValue(ctx, rateLimitRouteKey{}, route))); err != nil {
+			return
+		}
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if siw.authz != nil {
+			siw.authz.GetProfile(cw, r.WithContext(ctx))
+			return
+		}
+	}))
+
+	handler.ServeHTTP(cw, r.WithContext(ctx))
+}
+
+// UpdateProfile operation middleware
+func (siw *ServerInterfaceWrapper) UpdateProfile(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	cw := uhttp.NewResponseWriter(w,
+		uhttp.WithDefaultStatusCode(http.StatusOK),
+		uhttp.WithDefaultHeader("X-Request-ID", uhttp.RequestIDFromContext(ctx)),
+		uhttp.WithDefaultHeader(uhttp.HeaderContentType, uhttp.ContentTypeJSON),
+	)
+
+	defer func() {
+		if siw.metricsMiddleware != nil {
+			siw.metricsMiddleware(cw, r)
+		}
+	}()
+
+	if siw.rateLimiter != nil {
+		route := &RateLimitRoute{
+			OperationId: "UpdateProfile",
+			Policy:      "default",
+		}
+		if err := siw.rateLimiter(cw, r.WithContext(context.WithValue(ctx, rateLimitRouteKey{}, route))); err != nil {
+			return
+		}
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if siw.authz != nil {
+			siw.authz.UpdateProfile(cw, r.WithContext(ctx))
+			return
+		}
+	}))
+
+	handler.ServeHTTP(cw, r.WithContext(ctx))
+}
+
+// GetApiKeys operation middleware
+func (siw *ServerInterfaceWrapper) GetApiKeys(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	cw := uhttp.NewResponseWriter(w,
+		uhttp.WithDefaultStatusCode(http.StatusOK),
+		uhttp.WithDefaultHeader("X-Request-ID", uhttp.RequestIDFromContext(ctx)),
+		uhttp.WithDefaultHeader(uhttp.HeaderContentType, uhttp.ContentTypeJSON),
+	)
+
+	defer func() {
+		if siw.metricsMiddleware != nil {
+			siw.metricsMiddleware(cw, r)
+		}
+	}()
+
+	if siw.rateLimiter != nil {
+		route := &RateLimitRoute{
+			OperationId: "GetApiKeys",
+			Policy:      "default",
+		}
+		if err := siw.rateLimiter(cw, r.WithContext(context.WithValue(ctx, rateLimitRouteKey{}, route))); err != nil {
+			return
+		}
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if siw.authz != nil {
+			siw.authz.GetApiKeys(cw, r.WithContext(ctx))
+			return
+		}
+	}))
+
+	handler.ServeHTTP(cw, r.WithContext(ctx))
+}
+
+// CreateApiKey operation middleware
+func (siw *ServerInterfaceWrapper) CreateApiKey(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	cw := uhttp.NewResponseWriter(w,
+		uhttp.WithDefaultStatusCode(http.StatusOK),
+		uhttp.WithDefaultHeader("X-Request-ID", uhttp.RequestIDFromContext(ctx)),
+		uhttp.WithDefaultHeader(uhttp.HeaderContentType, uhttp.ContentTypeJSON),
+	)
+
+	defer func() {
+		if siw.metricsMiddleware != nil {
+			siw.metricsMiddleware(cw, r)
+		}
+	}()
+
+	if siw.rateLimiter != nil {
+		route := &RateLimitRoute{
+			OperationId: "CreateApiKey",
+			Policy:      "auth",
+		}
+		if err := siw.rateLimiter(cw, r.WithContext(context.WithValue(ctx, rateLimitRouteKey{}, route))); err != nil {
+			return
+		}
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if siw.authz != nil {
+			siw.authz.CreateApiKey(cw, r.WithContext(ctx))
+			return
+		}
+	}))
+
+	handler.ServeHTTP(cw, r.WithContext(ctx))
+}
+
+// RevokeApiKey operation middleware
+func (siw *ServerInterfaceWrapper) RevokeApiKey(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	cw := uhttp.NewResponseWriter(w,
+		uhttp.WithDefaultStatusCode(http.StatusOK),
+		uhttp.WithDefaultHeader("X-Request-ID", uhttp.RequestIDFromContext(ctx)),
+		uhttp.WithDefaultHeader(uhttp.HeaderContentType, uhttp.ContentTypeJSON),
+	)
+
+	defer func() {
+		if siw.metricsMiddleware != nil {
+			siw.metricsMiddleware(cw, r)
+		}
+	}()
+
+	var err error
+
+	// ------------- Path parameter "api_key_id" -------------
+	var apiKeyId PathApiKeyId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "api_key_id", mux.Vars(r)["api_key_id"], &apiKeyId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.errorHandlerFunc(cw, r, &InvalidParamFormatError{ParamName: "api_key_id", Err: err})
+		return
+	}
+
+	if siw.rateLimiter != nil {
+		route := &RateLimitRoute{
+			OperationId: "RevokeApiKey",
+			Policy:      "default",
+		}
+		if err := siw.rateLimiter(cw, r.WithContext(context.WithValue(ctx, rateLimitRouteKey{}, route))); err != nil {
+			return
+		}
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if siw.authz != nil {
+			siw.authz.RevokeApiKey(cw, r.WithContext(ctx), apiKeyId)
+			return
+		}
+	}))
+
+	handler.ServeHTTP(cw, r.WithContext(ctx))
+}
+
+// GetCoaches operation middleware
+func (siw *ServerInterfaceWrapper) GetCoaches(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	cw := uhttp.NewResponseWriter(w,
+		uhttp.WithDefaultStatusCode(http.StatusOK),
+		uhttp.WithDefaultHeader("X-Request-ID", uhttp.RequestIDFromContext(ctx)),
+		uhttp.WithDefaultHeader(uhttp.HeaderContentType, uhttp.ContentTypeJSON),
+	)
+
+	defer func() {
+		if siw.metricsMiddleware != nil {
+			siw.metricsMiddleware(cw, r)
+		}
+	}()
+
+	if siw.rateLimiter != nil {
+		route := &RateLimitRoute{
+			OperationId: "GetCoaches",
+			Policy:      "default",
+		}
+		if err := siw.rateLimiter(cw, r.WithContext(context.WithValue(ctx, rateLimitRouteKey{}, route))); err != nil {
+			return
+		}
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if siw.authz != nil {
+			siw.authz.GetCoaches(cw, r.WithContext(ctx))
+			return
+		}
+	}))
+
+	handler.ServeHTTP(cw, r.WithContext(ctx))
+}
+
+// GrantCoach operation middleware
+func (siw *ServerInterfaceWrapper) GrantCoach(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	cw := uhttp.NewResponseWriter(w,
+		uhttp.WithDefaultStatusCode(http.StatusOK),
+		uhttp.WithDefaultHeader("X-Request-ID", uhttp.RequestIDFromContext(ctx)),
+		uhttp.WithDefaultHeader(uhttp.HeaderContentType, uhttp.ContentTypeJSON),
+	)
+
+	defer func() {
+		if siw.metricsMiddleware != nil {
+			siw.metricsMiddleware(cw, r)
+		}
+	}()
+
+	if siw.rateLimiter != nil {
+		route := &RateLimitRoute{
+			OperationId: "GrantCoach",
+			Policy:      "default",
+		}
+		if err := siw.rateLimiter(cw, r.WithContext(context.WithValue(ctx, rateLimitRouteKey{}, route))); err != nil {
+			return
+		}
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.handler.Login(cw, r)
+		if siw.authz != nil {
+			siw.authz.GrantCoach(cw, r.WithContext(ctx))
+			return
+		}
 	}))
 
 	handler.ServeHTTP(cw, r.WithContext(ctx))
 }
 
-// GetRounds operation middleware
-func (siw *ServerInterfaceWrapper) GetRounds(w http.ResponseWriter, r *http.Request) {
+// RevokeCoach operation middleware
+func (siw *ServerInterfaceWrapper) RevokeCoach(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	cw := uhttp.NewResponseWriter(w,
 		uhttp.WithDefaultStatusCode(http.StatusOK),
@@ -130,9 +1928,30 @@ func (siw *ServerInterfaceWrapper) GetRounds(w http.ResponseWriter, r *http.Requ
 		}
 	}()
 
+	var err error
+
+	// ------------- Path parameter "coach_id" -------------
+	var coachId PathCoachId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "coach_id", mux.Vars(r)["coach_id"], &coachId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.errorHandlerFunc(cw, r, &InvalidParamFormatError{ParamName: "coach_id", Err: err})
+		return
+	}
+
+	if siw.rateLimiter != nil {
+		route := &RateLimitRoute{
+			OperationId: "RevokeCoach",
+			Policy:      "default",
+		}
+		if err := siw.rateLimiter(cw, r.WithContext(context.WithValue(ctx, rateLimitRouteKey{}, route))); err != nil {
+			return
+		}
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if siw.authz != nil {
-			siw.authz.GetRounds(cw, r.WithContext(ctx))
+			siw.authz.RevokeCoach(cw, r.WithContext(ctx), coachId)
 			return
 		}
 	}))
@@ -140,8 +1959,8 @@ func (siw *ServerInterfaceWrapper) GetRounds(w http.ResponseWriter, r *http.Requ
 	handler.ServeHTTP(cw, r.WithContext(ctx))
 }
 
-// CreateRound operation middleware
-func (siw *ServerInterfaceWrapper) CreateRound(w http.ResponseWriter, r *http.Request) {
+// GetIdentities operation middleware
+func (siw *ServerInterfaceWrapper) GetIdentities(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	cw := uhttp.NewResponseWriter(w,
 		uhttp.WithDefaultStatusCode(http.StatusOK),
@@ -155,9 +1974,19 @@ func (siw *ServerInterfaceWrapper) CreateRound(w http.ResponseWriter, r *http.Re
 		}
 	}()
 
+	if siw.rateLimiter != nil {
+		route := &RateLimitRoute{
+			OperationId: "GetIdentities",
+			Policy:      "default",
+		}
+		if err := siw.rateLimiter(cw, r.WithContext(context.WithValue(ctx, rateLimitRouteKey{}, route))); err != nil {
+			return
+		}
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if siw.authz != nil {
-			siw.authz.CreateRound(cw, r.WithContext(ctx))
+			siw.authz.GetIdentities(cw, r.WithContext(ctx))
 			return
 		}
 	}))
@@ -165,8 +1994,8 @@ func (siw *ServerInterfaceWrapper) CreateRound(w http.ResponseWriter, r *http.Re
 	handler.ServeHTTP(cw, r.WithContext(ctx))
 }
 
-// GetNewRoundCourses operation middleware
-func (siw *ServerInterfaceWrapper) GetNewRoundCourses(w http.ResponseWriter, r *http.Request) {
+// LinkIdentity operation middleware
+func (siw *ServerInterfaceWrapper) LinkIdentity(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	cw := uhttp.NewResponseWriter(w,
 		uhttp.WithDefaultStatusCode(http.StatusOK),
@@ -182,20 +2011,28 @@ func (siw *ServerInterfaceWrapper) GetNewRoundCourses(w http.ResponseWriter, r *
 
 	var err error
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params GetNewRoundCoursesParams
-
-	// ------------- Optional query parameter "name" -------------
+	// ------------- Path parameter "provider" -------------
+	var provider PathOidcProvider
 
-	err = runtime.BindQueryParameter("form", true, false, "name", r.URL.Query(), &params.Name)
+	err = runtime.BindStyledParameterWithOptions("simple", "provider", mux.Vars(r)["provider"], &provider, runtime.BindStyledParameterOptions{Explode: false, Required: true})
 	if err != nil {
-		siw.errorHandlerFunc(cw, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		siw.errorHandlerFunc(cw, r, &InvalidParamFormatError{ParamName: "provider", Err: err})
 		return
 	}
 
+	if siw.rateLimiter != nil {
+		route := &RateLimitRoute{
+			OperationId: "LinkIdentity",
+			Policy:      "auth",
+		}
+		if err := siw.rateLimiter(cw, r.WithContext(context.WithValue(ctx, rateLimitRouteKey{}, route))); err != nil {
+			return
+		}
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if siw.authz != nil {
-			siw.authz.GetNewRoundCourses(cw, r.WithContext(ctx), params)
+			siw.authz.LinkIdentity(cw, r.WithContext(ctx), provider)
 			return
 		}
 	}))
@@ -203,8 +2040,8 @@ func (siw *ServerInterfaceWrapper) GetNewRoundCourses(w http.ResponseWriter, r *
 	handler.ServeHTTP(cw, r.WithContext(ctx))
 }
 
-// GetNewRoundMarker operation middleware
-func (siw *ServerInterfaceWrapper) GetNewRoundMarker(w http.ResponseWriter, r *http.Request) {
+// ChangePassword operation middleware
+func (siw *ServerInterfaceWrapper) ChangePassword(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	cw := uhttp.NewResponseWriter(w,
 		uhttp.WithDefaultStatusCode(http.StatusOK),
@@ -218,20 +2055,19 @@ func (siw *ServerInterfaceWrapper) GetNewRoundMarker(w http.ResponseWriter, r *h
 		}
 	}()
 
-	var err error
-
-	// ------------- Path parameter "course_id" -------------
-	var courseId PathCourseId
-
-	err = runtime.BindStyledParameterWithOptions("simple", "course_id", mux.Vars(r)["course_id"], &courseId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
-	if err != nil {
-		siw.errorHandlerFunc(cw, r, &InvalidParamFormatError{ParamName: "course_id", Err: err})
-		return
+	if siw.rateLimiter != nil {
+		route := &RateLimitRoute{
+			OperationId: "ChangePassword",
+			Policy:      "auth",
+		}
+		if err := siw.rateLimiter(cw, r.WithContext(context.WithValue(ctx, rateLimitRouteKey{}, route))); err != nil {
+			return
+		}
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if siw.authz != nil {
-			siw.authz.GetNewRoundMarker(cw, r.WithContext(ctx), courseId)
+			siw.authz.ChangePassword(cw, r.WithContext(ctx))
 			return
 		}
 	}))
@@ -239,8 +2075,8 @@ func (siw *ServerInterfaceWrapper) GetNewRoundMarker(w http.ResponseWriter, r *h
 	handler.ServeHTTP(cw, r.WithContext(ctx))
 }
 
-// GetLineChartAverages operation middleware
-func (siw *ServerInterfaceWrapper) GetLineChartAverages(w http.ResponseWriter, r *http.Request) {
+// GetPlayers operation middleware
+func (siw *ServerInterfaceWrapper) GetPlayers(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	cw := uhttp.NewResponseWriter(w,
 		uhttp.WithDefaultStatusCode(http.StatusOK),
@@ -254,45 +2090,54 @@ func (siw *ServerInterfaceWrapper) GetLineChartAverages(w http.ResponseWriter, r
 		}
 	}()
 
-	var err error
-
-	// Parameter object where we will unmarshal all parameters from the context
-	var params GetLineChartAveragesParams
-
-	// ------------- Required query parameter "average_type" -------------
-
-	if paramValue := r.URL.Query().Get("average_type"); paramValue != "" {
-
-	} else {
-		siw.errorHandlerFunc(cw, r, &RequiredParamError{ParamName: "average_type"})
-		return
+	if siw.rateLimiter != nil {
+		route := &RateLimitRoute{
+			OperationId: "GetPlayers",
+			Policy:      "default",
+		}
+		if err := siw.rateLimiter(cw, r.WithContext(context.WithValue(ctx, rateLimitRouteKey{}, route))); err != nil {
+			return
+		}
 	}
 
-	err = runtime.BindQueryParameter("form", true, true, "average_type", r.URL.Query(), &params.AverageType)
-	if err != nil {
-		siw.errorHandlerFunc(cw, r, &InvalidParamFormatError{ParamName: "average_type", Err: err})
-		return
-	}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if siw.authz != nil {
+			siw.authz.GetPlayers(cw, r.WithContext(ctx))
+			return
+		}
+	}))
 
-	// ------------- Optional query parameter "from_date" -------------
+	handler.ServeHTTP(cw, r.WithContext(ctx))
+}
 
-	err = runtime.BindQueryParameter("form", true, false, "from_date", r.URL.Query(), &params.FromDate)
-	if err != nil {
-		siw.errorHandlerFunc(cw, r, &InvalidParamFormatError{ParamName: "from_date", Err: err})
-		return
-	}
+// GetPreferences operation middleware
+func (siw *ServerInterfaceWrapper) GetPreferences(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	cw := uhttp.NewResponseWriter(w,
+		uhttp.WithDefaultStatusCode(http.StatusOK),
+		uhttp.WithDefaultHeader("X-Request-ID", uhttp.RequestIDFromContext(ctx)),
+		uhttp.WithDefaultHeader(uhttp.HeaderContentType, uhttp.ContentTypeJSON),
+	)
 
-	// ------------- Optional query parameter "since" -------------
+	defer func() {
+		if siw.metricsMiddleware != nil {
+			siw.metricsMiddleware(cw, r)
+		}
+	}()
 
-	err = runtime.BindQueryParameter("form", true, false, "since", r.URL.Query(), &params.Since)
-	if err != nil {
-		siw.errorHandlerFunc(cw, r, &InvalidParamFormatError{ParamName: "since", Err: err})
-		return
+	if siw.rateLimiter != nil {
+		route := &RateLimitRoute{
+			OperationId: "GetPreferences",
+			Policy:      "default",
+		}
+		if err := siw.rateLimiter(cw, r.WithContext(context.WithValue(ctx, rateLimitRouteKey{}, route))); err != nil {
+			return
+		}
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if siw.authz != nil {
-			siw.authz.GetLineChartAverages(cw, r.WithContext(ctx), params)
+			siw.authz.GetPreferences(cw, r.WithContext(ctx))
 			return
 		}
 	}))
@@ -300,8 +2145,8 @@ func (siw *ServerInterfaceWrapper) GetLineChartAverages(w http.ResponseWriter, r
 	handler.ServeHTTP(cw, r.WithContext(ctx))
 }
 
-// GetPieChartAverages operation middleware
-func (siw *ServerInterfaceWrapper) GetPieChartAverages(w http.ResponseWriter, r *http.Request) {
+// UpdatePreferences operation middleware
+func (siw *ServerInterfaceWrapper) UpdatePreferences(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	cw := uhttp.NewResponseWriter(w,
 		uhttp.WithDefaultStatusCode(http.StatusOK),
@@ -315,29 +2160,19 @@ func (siw *ServerInterfaceWrapper) GetPieChartAverages(w http.ResponseWriter, r
 		}
 	}()
 
-	var err error
-
-	// Parameter object where we will unmarshal all parameters from the context
-	var params GetPieChartAveragesParams
-
-	// ------------- Required query parameter "average_type" -------------
-
-	if paramValue := r.URL.Query().Get("average_type"); paramValue != "" {
-
-	} else {
-		siw.errorHandlerFunc(cw, r, &RequiredParamError{ParamName: "average_type"})
-		return
-	}
-
-	err = runtime.BindQueryParameter("form", true, true, "average_type", r.URL.Query(), &params.AverageType)
-	if err != nil {
-		siw.errorHandlerFunc(cw, r, &InvalidParamFormatError{ParamName: "average_type", Err: err})
-		return
+	if siw.rateLimiter != nil {
+		route := &RateLimitRoute{
+			OperationId: "UpdatePreferences",
+			Policy:      "default",
+		}
+		if err := siw.rateLimiter(cw, r.WithContext(context.WithValue(ctx, rateLimitRouteKey{}, route))); err != nil {
+			return
+		}
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if siw.authz != nil {
-			siw.authz.GetPieChartAverages(cw, r.WithContext(ctx), params)
+			siw.authz.UpdatePreferences(cw, r.WithContext(ctx))
 			return
 		}
 	}))
@@ -345,8 +2180,8 @@ func (siw *ServerInterfaceWrapper) GetPieChartAverages(w http.ResponseWriter, r
 	handler.ServeHTTP(cw, r.WithContext(ctx))
 }
 
-// GetRoundHoles operation middleware
-func (siw *ServerInterfaceWrapper) GetRoundHoles(w http.ResponseWriter, r *http.Request) {
+// GetSessions operation middleware
+func (siw *ServerInterfaceWrapper) GetSessions(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	cw := uhttp.NewResponseWriter(w,
 		uhttp.WithDefaultStatusCode(http.StatusOK),
@@ -360,20 +2195,19 @@ func (siw *ServerInterfaceWrapper) GetRoundHoles(w http.ResponseWriter, r *http.
 		}
 	}()
 
-	var err error
-
-	// ------------- Path parameter "round_id" -------------
-	var roundId PathRoundId
-
-	err = runtime.BindStyledParameterWithOptions("simple", "round_id", mux.Vars(r)["round_id"], &roundId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
-	if err != nil {
-		siw.errorHandlerFunc(cw, r, &InvalidParamFormatError{ParamName: "round_id", Err: err})
-		return
+	if siw.rateLimiter != nil {
+		route := &RateLimitRoute{
+			OperationId: "GetSessions",
+			Policy:      "default",
+		}
+		if err := siw.rateLimiter(cw, r.WithContext(context.WithValue(ctx, rateLimitRouteKey{}, route))); err != nil {
+			return
+		}
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if siw.authz != nil {
-			siw.authz.GetRoundHoles(cw, r.WithContext(ctx), roundId)
+			siw.authz.GetSessions(cw, r.WithContext(ctx))
 			return
 		}
 	}))
@@ -381,8 +2215,8 @@ func (siw *ServerInterfaceWrapper) GetRoundHoles(w http.ResponseWriter, r *http.
 	handler.ServeHTTP(cw, r.WithContext(ctx))
 }
 
-// GetHoleStats operation middleware
-func (siw *ServerInterfaceWrapper) GetHoleStats(w http.ResponseWriter, r *http.Request) {
+// RevokeSession operation middleware
+func (siw *ServerInterfaceWrapper) RevokeSession(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	cw := uhttp.NewResponseWriter(w,
 		uhttp.WithDefaultStatusCode(http.StatusOK),
@@ -398,27 +2232,28 @@ func (siw *ServerInterfaceWrapper) GetHoleStats(w http.ResponseWriter, r *http.R
 
 	var err error
 
-	// ------------- Path parameter "round_id" -------------
-	var roundId PathRoundId
+	// ------------- Path parameter "session_id" -------------
+	var sessionId PathSessionId
 
-	err = runtime.BindStyledParameterWithOptions("simple", "round_id", mux.Vars(r)["round_id"], &roundId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "session_id", mux.Vars(r)["session_id"], &sessionId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
 	if err != nil {
-		siw.errorHandlerFunc(cw, r, &InvalidParamFormatError{ParamName: "round_id", Err: err})
+		siw.errorHandlerFunc(cw, r, &InvalidParamFormatError{ParamName: "session_id", Err: err})
 		return
 	}
 
-	// ------------- Path parameter "hole_id" -------------
-	var holeId PathHoleId
-
-	err = runtime.BindStyledParameterWithOptions("simple", "hole_id", mux.Vars(r)["hole_id"], &holeId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
-	if err != nil {
-		siw.errorHandlerFunc(cw, r, &InvalidParamFormatError{ParamName: "hole_id", Err: err})
-		return
+	if siw.rateLimiter != nil {
+		route := &RateLimitRoute{
+			OperationId: "RevokeSession",
+			Policy:      "default",
+		}
+		if err := siw.rateLimiter(cw, r.WithContext(context.WithValue(ctx, rateLimitRouteKey{}, route))); err != nil {
+			return
+		}
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if siw.authz != nil {
-			siw.authz.GetHoleStats(cw, r.WithContext(ctx), roundId, holeId)
+			siw.authz.RevokeSession(cw, r.WithContext(ctx), sessionId)
 			return
 		}
 	}))
@@ -426,8 +2261,8 @@ func (siw *ServerInterfaceWrapper) GetHoleStats(w http.ResponseWriter, r *http.R
 	handler.ServeHTTP(cw, r.WithContext(ctx))
 }
 
-// UpdateHoleStats operation middleware
-func (siw *ServerInterfaceWrapper) UpdateHoleStats(w http.ResponseWriter, r *http.Request) {
+// EnrolTotp operation middleware
+func (siw *ServerInterfaceWrapper) EnrolTotp(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	cw := uhttp.NewResponseWriter(w,
 		uhttp.WithDefaultStatusCode(http.StatusOK),
@@ -441,29 +2276,19 @@ func (siw *ServerInterfaceWrapper) UpdateHoleStats(w http.ResponseWriter, r *htt
 		}
 	}()
 
-	var err error
-
-	// ------------- Path parameter "round_id" -------------
-	var roundId PathRoundId
-
-	err = runtime.BindStyledParameterWithOptions("simple", "round_id", mux.Vars(r)["round_id"], &roundId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
-	if err != nil {
-		siw.errorHandlerFunc(cw, r, &InvalidParamFormatError{ParamName: "round_id", Err: err})
-		return
-	}
-
-	// ------------- Path parameter "hole_id" -------------
-	var holeId PathHoleId
-
-	err = runtime.BindStyledParameterWithOptions("simple", "hole_id", mux.Vars(r)["hole_id"], &holeId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
-	if err != nil {
-		siw.errorHandlerFunc(cw, r, &InvalidParamFormatError{ParamName: "hole_id", Err: err})
-		return
+	if siw.rateLimiter != nil {
+		route := &RateLimitRoute{
+			OperationId: "EnrolTotp",
+			Policy:      "auth",
+		}
+		if err := siw.rateLimiter(cw, r.WithContext(context.WithValue(ctx, rateLimitRouteKey{}, route))); err != nil {
+			return
+		}
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if siw.authz != nil {
-			siw.authz.UpdateHoleStats(cw, r.WithContext(ctx), roundId, holeId)
+			siw.authz.EnrolTotp(cw, r.WithContext(ctx))
 			return
 		}
 	}))
@@ -471,8 +2296,8 @@ func (siw *ServerInterfaceWrapper) UpdateHoleStats(w http.ResponseWriter, r *htt
 	handler.ServeHTTP(cw, r.WithContext(ctx))
 }
 
-// CreateUser operation middleware
-func (siw *ServerInterfaceWrapper) CreateUser(w http.ResponseWriter, r *http.Request) {
+// ConfirmTotp operation middleware
+func (siw *ServerInterfaceWrapper) ConfirmTotp(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	cw := uhttp.NewResponseWriter(w,
 		uhttp.WithDefaultStatusCode(http.StatusOK),
@@ -486,8 +2311,21 @@ func (siw *ServerInterfaceWrapper) CreateUser(w http.ResponseWriter, r *http.Req
 		}
 	}()
 
+	if siw.rateLimiter != nil {
+		route := &RateLimitRoute{
+			OperationId: "ConfirmTotp",
+			Policy:      "auth",
+		}
+		if err := siw.rateLimiter(cw, r.WithContext(context.WithValue(ctx, rateLimitRouteKey{}, route))); err != nil {
+			return
+		}
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.handler.CreateUser(cw, r)
+		if siw.authz != nil {
+			siw.authz.ConfirmTotp(cw, r.WithContext(ctx))
+			return
+		}
 	}))
 
 	handler.ServeHTTP(cw, r.WithContext(ctx))
@@ -591,6 +2429,26 @@ func RegisterHandlers(router *mux.Router, si ServerInterface, opts ...ServerOpti
 	router.Use(uhttp.AuthHeaderToContextMux())
 	router.Use(uhttp.GenerateOrCopyRequestIDMux())
 
+	router.Methods(http.MethodGet).Path("/admin/audit-events").Handler(wrapHandler(wrapper.GetAuditEvents))
+
+	router.Methods(http.MethodGet).Path("/admin/users").Handler(wrapHandler(wrapper.GetUsers))
+
+	router.Methods(http.MethodPatch).Path("/admin/users/{user_id}").Handler(wrapHandler(wrapper.UpdateUser))
+
+	router.Methods(http.MethodDelete).Path("/admin/users/{user_id}/totp").Handler(wrapHandler(wrapper.ResetUserTotp))
+
+	router.Methods(http.MethodGet).Path("/courses").Handler(wrapHandler(wrapper.GetCourses))
+
+	router.Methods(http.MethodPost).Path("/courses").Handler(wrapHandler(wrapper.CreateCourse))
+
+	router.Methods(http.MethodDelete).Path("/courses/{course_id}").Handler(wrapHandler(wrapper.DeleteCourse))
+
+	router.Methods(http.MethodGet).Path("/courses/{course_id}").Handler(wrapHandler(wrapper.GetCourse))
+
+	router.Methods(http.MethodPut).Path("/courses/{course_id}").Handler(wrapHandler(wrapper.UpdateCourse))
+
+	router.Methods(http.MethodPost).Path("/logout").Handler(wrapHandler(wrapper.Logout))
+
 	router.Methods(http.MethodGet).Path("/rounds").Handler(wrapHandler(wrapper.GetRounds))
 
 	router.Methods(http.MethodPost).Path("/rounds").Handler(wrapHandler(wrapper.CreateRound))
@@ -599,15 +2457,55 @@ func RegisterHandlers(router *mux.Router, si ServerInterface, opts ...ServerOpti
 
 	router.Methods(http.MethodGet).Path("/rounds/new/marker/{course_id}").Handler(wrapHandler(wrapper.GetNewRoundMarker))
 
+	router.Methods(http.MethodGet).Path("/rounds/stats").Handler(wrapHandler(wrapper.GetRoundStats))
+
 	router.Methods(http.MethodGet).Path("/rounds/stats/charts/line/averages").Handler(wrapHandler(wrapper.GetLineChartAverages))
 
 	router.Methods(http.MethodGet).Path("/rounds/stats/charts/pie/averages").Handler(wrapHandler(wrapper.GetPieChartAverages))
 
+	router.Methods(http.MethodDelete).Path("/rounds/{round_id}").Handler(wrapHandler(wrapper.DeleteRound))
+
 	router.Methods(http.MethodGet).Path("/rounds/{round_id}/holes").Handler(wrapHandler(wrapper.GetRoundHoles))
 
 	router.Methods(http.MethodGet).Path("/rounds/{round_id}/holes/{hole_id}/stats").Handler(wrapHandler(wrapper.GetHoleStats))
 
 	router.Methods(http.MethodPost).Path("/rounds/{round_id}/holes/{hole_id}/stats").Handler(wrapHandler(wrapper.UpdateHoleStats))
+
+	router.Methods(http.MethodGet).Path("/users/me").Handler(wrapHandler(wrapper.GetProfile))
+
+	router.Methods(http.MethodPatch).Path("/users/me").Handler(wrapHandler(wrapper.UpdateProfile))
+
+	router.Methods(http.MethodGet).Path("/users/me/api-keys").Handler(wrapHandler(wrapper.GetApiKeys))
+
+	router.Methods(http.MethodPost).Path("/users/me/api-keys").Handler(wrapHandler(wrapper.CreateApiKey))
+
+	router.Methods(http.MethodDelete).Path("/users/me/api-keys/{api_key_id}").Handler(wrapHandler(wrapper.RevokeApiKey))
+
+	router.Methods(http.MethodGet).Path("/users/me/coaches").Handler(wrapHandler(wrapper.GetCoaches))
+
+	router.Methods(http.MethodPost).Path("/users/me/coaches").Handler(wrapHandler(wrapper.GrantCoach))
+
+	router.Methods(http.MethodDelete).Path("/users/me/coaches/{coach_id}").Handler(wrapHandler(wrapper.RevokeCoach))
+
+	router.Methods(http.MethodGet).Path("/users/me/identities").Handler(wrapHandler(wrapper.GetIdentities))
+
+	router.Methods(http.MethodPost).Path("/users/me/identities/{provider}").Handler(wrapHandler(wrapper.LinkIdentity))
+
+	router.Methods(http.MethodPost).Path("/users/me/password").Handler(wrapHandler(wrapper.ChangePassword))
+
+	router.Methods(http.MethodGet).Path("/users/me/players").Handler(wrapHandler(wrapper.GetPlayers))
+
+	router.Methods(http.MethodGet).Path("/users/me/preferences").Handler(wrapHandler(wrapper.GetPreferences))
+
+	router.Methods(http.MethodPut).Path("/users/me/preferences").Handler(wrapHandler(wrapper.UpdatePreferences))
+
+	router.Methods(http.MethodGet).Path("/users/me/sessions").Handler(wrapHandler(wrapper.GetSessions))
+
+	router.Methods(http.MethodDelete).Path("/users/me/sessions/{session_id}").Handler(wrapHandler(wrapper.RevokeSession))
+
+	router.Methods(http.MethodPost).Path("/users/me/totp").Handler(wrapHandler(wrapper.EnrolTotp))
+
+	router.Methods(http.MethodPost).Path("/users/me/totp/confirm").Handler(wrapHandler(wrapper.ConfirmTotp))
 }
 
 // RegisterUnauthedHandlers registers any api handlers which do not have any authentication on them. Most services will not have any.
@@ -630,5 +2528,15 @@ func RegisterUnauthedHandlers(router *mux.Router, si ServerInterface, opts ...Se
 
 	router.Methods(http.MethodPost).Path("/login").Handler(wrapHandler(wrapper.Login))
 
+	router.Methods(http.MethodGet).Path("/login/oidc/{provider}").Handler(wrapHandler(wrapper.StartOidcLogin))
+
+	router.Methods(http.MethodGet).Path("/login/oidc/{provider}/callback").Handler(wrapHandler(wrapper.OidcCallback))
+
+	router.Methods(http.MethodPost).Path("/password/reset").Handler(wrapHandler(wrapper.RequestPasswordReset))
+
+	router.Methods(http.MethodPost).Path("/password/reset/confirm").Handler(wrapHandler(wrapper.ConfirmPasswordReset))
+
+	router.Methods(http.MethodPost).Path("/token/refresh").Handler(wrapHandler(wrapper.RefreshToken))
+
 	router.Methods(http.MethodPost).Path("/users").Handler(wrapHandler(wrapper.CreateUser))
 }
@@ -7,12 +7,94 @@ import (
 	"time"
 
 	externalRef0 "github.com/Jacobbrewer1/golf-stats-tracker/pkg/codegen/apis/common"
+	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
 const (
-	BasicAuthScopes = "basicAuth.Scopes"
+	BearerAuthScopes = "bearerAuth.Scopes"
 )
 
+// ApiKey defines the model for api_key.
+type ApiKey struct {
+	// CreatedAt When the API key was created
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+
+	// ExpiresAt When the API key expires
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+
+	// Id The API key id
+	Id *int64 `json:"id,omitempty"`
+
+	// Key The API key, only returned when the key is created
+	Key *string `json:"key,omitempty"`
+
+	// LastUsedAt When the API key was last used
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+
+	// Name The name of the API key
+	Name   *string        `json:"name,omitempty"`
+	Scopes *[]ApiKeyScope `json:"scopes,omitempty"`
+}
+
+// ApiKeyCreate defines the model for api_key_create.
+type ApiKeyCreate struct {
+	// ExpiresAt When the API key expires, the key does not expire if not set
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+
+	// Name The name of the API key, to tell it apart from the other keys of the user
+	Name   string        `json:"name"`
+	Scopes []ApiKeyScope `json:"scopes"`
+}
+
+// ApiKeyScope defines the model for api_key_scope.
+type ApiKeyScope = string
+
+// List of ApiKeyScope
+const (
+	ApiKeyScope_read_charts ApiKeyScope = "read:charts"
+	ApiKeyScope_read_rounds ApiKeyScope = "read:rounds"
+	ApiKeyScope_write_stats ApiKeyScope = "write:stats"
+)
+
+// ApiKeysResponse defines the model for api_keys_response.
+type ApiKeysResponse struct {
+	ApiKeys []ApiKey `json:"api_keys"`
+	Total   int64    `json:"total"`
+}
+
+// AuditEvent defines the model for audit_event.
+type AuditEvent struct {
+	// Action The action that was performed, such as round.delete
+	Action *string `json:"action,omitempty"`
+
+	// ActorId The user that performed the action, if known
+	ActorId *int64 `json:"actor_id,omitempty"`
+
+	// CreatedAt When the action happened
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+
+	// Details Details of the action, such as the changes made
+	Details *string `json:"details,omitempty"`
+
+	// Id The audit event id
+	Id *int64 `json:"id,omitempty"`
+
+	// IpAddress The IP address the action came from
+	IpAddress *string `json:"ip_address,omitempty"`
+
+	// TargetId The id of the resource the action changed
+	TargetId *int64 `json:"target_id,omitempty"`
+
+	// TargetType The type of resource the action changed
+	TargetType *string `json:"target_type,omitempty"`
+}
+
+// AuditEventsResponse defines the model for audit_events_response.
+type AuditEventsResponse struct {
+	Events []AuditEvent `json:"events"`
+	Total  int64        `json:"total"`
+}
+
 // AverageType defines the model for average_type.
 type AverageType = string
 
@@ -42,6 +124,27 @@ type ChartDataResponse struct {
 	Total int64            `json:"total"`
 }
 
+// CoachGrant defines the model for coach_grant.
+type CoachGrant struct {
+	Coach *User `json:"coach,omitempty"`
+
+	// CreatedAt When the access was granted
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	Player    *User      `json:"player,omitempty"`
+}
+
+// CoachGrantCreate defines the model for coach_grant_create.
+type CoachGrantCreate struct {
+	// Username The username of the coach
+	Username string `json:"username"`
+}
+
+// CoachGrantsResponse defines the model for coach_grants_response.
+type CoachGrantsResponse struct {
+	Grants []CoachGrant `json:"grants"`
+	Total  int64        `json:"total"`
+}
+
 // Course defines the model for course.
 type Course struct {
 	Details []CourseDetails `json:"details"`
@@ -51,8 +154,20 @@ type Course struct {
 
 // CourseDetails defines the model for course_details.
 type CourseDetails struct {
-	Holes            []Hole   `json:"holes"`
-	Id               int64    `json:"id"`
+	// DistanceBackNine The length of the back nine in the preferred unit of the user
+	DistanceBackNine *int64 `json:"distance_back_nine,omitempty"`
+
+	// DistanceFrontNine The length of the front nine in the preferred unit of the user
+	DistanceFrontNine *int64 `json:"distance_front_nine,omitempty"`
+
+	// DistanceTotal The length of the course in the preferred unit of the user
+	DistanceTotal *int64        `json:"distance_total,omitempty"`
+	DistanceUnit  *DistanceUnit `json:"distance_unit,omitempty"`
+	Holes         []Hole        `json:"holes"`
+	Id            int64         `json:"id"`
+
+	// IsDefault Whether the marker is the default marker of the user
+	IsDefault        *bool    `json:"is_default,omitempty"`
 	Marker           *string  `json:"marker,omitempty"`
 	MetersBackNine   *int64   `json:"meters_back_nine,omitempty"`
 	MetersFrontNine  *int64   `json:"meters_front_nine,omitempty"`
@@ -79,6 +194,31 @@ type CoursesResponse struct {
 	Total   int64    `json:"total"`
 }
 
+// CustomCourse defines the model for custom_course.
+type CustomCourse struct {
+	Details []CourseDetails `json:"details"`
+	Name    string          `json:"name"`
+}
+
+// DistanceUnit defines the model for distance_unit.
+type DistanceUnit = string
+
+// List of DistanceUnit
+const (
+	DistanceUnit_meters DistanceUnit = "meters"
+	DistanceUnit_yards  DistanceUnit = "yards"
+)
+
+// Gender defines the model for gender.
+type Gender = string
+
+// List of Gender
+const (
+	Gender_female Gender = "female"
+	Gender_male   Gender = "male"
+	Gender_other  Gender = "other"
+)
+
 // HitInRegulation defines the model for hit_in_regulation.
 type HitInRegulation = string
 
@@ -94,12 +234,15 @@ const (
 
 // Hole defines the model for hole.
 type Hole struct {
-	Id          *int64 `json:"id,omitempty"`
-	Meters      *int64 `json:"meters,omitempty"`
-	Number      *int64 `json:"number,omitempty"`
-	Par         *int64 `json:"par,omitempty"`
-	StrokeIndex *int64 `json:"stroke_index,omitempty"`
-	Yardage     *int64 `json:"yardage,omitempty"`
+	// Distance The length of the hole in the preferred unit of the user
+	Distance     *int64        `json:"distance,omitempty"`
+	DistanceUnit *DistanceUnit `json:"distance_unit,omitempty"`
+	Id           *int64        `json:"id,omitempty"`
+	Meters       *int64        `json:"meters,omitempty"`
+	Number       *int64        `json:"number,omitempty"`
+	Par          *int64        `json:"par,omitempty"`
+	StrokeIndex  *int64        `json:"stroke_index,omitempty"`
+	Yardage      *int64        `json:"yardage,omitempty"`
 }
 
 // HoleStats defines the model for hole_stats.
@@ -122,10 +265,139 @@ type HoleStats struct {
 
 // HolesResponse defines the model for holes_response.
 type HolesResponse struct {
-	Holes []Hole `json:"holes"`
-	Total int64  `json:"total"`
+	Holes []Hole                   `json:"holes"`
+	Next  *externalRef0.PageCursor `json:"next,omitempty"`
+	Total int64                    `json:"total"`
 }
 
+// IdentitiesResponse defines the model for identities_response.
+type IdentitiesResponse struct {
+	Identities []Identity `json:"identities"`
+	Total      int64      `json:"total"`
+}
+
+// Identity defines the model for identity.
+type Identity struct {
+	// CreatedAt When the identity was linked
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+
+	// Email The email address from the provider
+	Email *string `json:"email,omitempty"`
+
+	// Id The identity id
+	Id *int64 `json:"id,omitempty"`
+
+	// LastLoginAt When the identity was last used to log in
+	LastLoginAt *time.Time `json:"last_login_at,omitempty"`
+
+	// Provider The name of the provider
+	Provider *string `json:"provider,omitempty"`
+}
+
+// OidcAuthorization defines the model for oidc_authorization.
+type OidcAuthorization struct {
+	// AuthorizationUrl The URL to send the user to so that they authenticate with the provider
+	AuthorizationUrl string `json:"authorization_url"`
+}
+
+// PasswordChange defines the model for password_change.
+type PasswordChange struct {
+	// CurrentPassword The current password
+	CurrentPassword string `json:"current_password"`
+
+	// NewPassword The new password
+	NewPassword string `json:"new_password"`
+}
+
+// PasswordResetConfirm defines the model for password_reset_confirm.
+type PasswordResetConfirm struct {
+	// NewPassword The new password
+	NewPassword string `json:"new_password"`
+
+	// Token The password reset token
+	Token string `json:"token"`
+}
+
+// PasswordResetRequest defines the model for password_reset_request.
+type PasswordResetRequest struct {
+	// Username The username of the user to reset the password for
+	Username string `json:"username"`
+}
+
+// Preferences defines the model for preferences.
+type Preferences struct {
+	// DefaultMarker The tee marker the user usually plays from, such as Yellow
+	DefaultMarker *string       `json:"default_marker,omitempty"`
+	DistanceUnit  *DistanceUnit `json:"distance_unit,omitempty"`
+
+	// Timezone The IANA timezone times are shown in, such as Europe/London
+	Timezone *string `json:"timezone,omitempty"`
+}
+
+// Profile defines the model for profile.
+type Profile struct {
+	// DateOfBirth The date of birth of the user
+	DateOfBirth *openapi_types.Date `json:"date_of_birth,omitempty"`
+
+	// Email The email address of the user
+	Email  *string `json:"email,omitempty"`
+	Gender *Gender `json:"gender,omitempty"`
+
+	// HomeCourseId The course the user usually plays
+	HomeCourseId *int64 `json:"home_course_id,omitempty"`
+
+	// Id The user id
+	Id *int64 `json:"id,omitempty"`
+
+	// LastLogin When the user last logged in
+	LastLogin *time.Time `json:"last_login,omitempty"`
+
+	// Name The name of the user
+	Name *string `json:"name,omitempty"`
+	Role *Role   `json:"role,omitempty"`
+
+	// Username The username
+	Username *string `json:"username,omitempty"`
+}
+
+// ProfileUpdate defines the model for profile_update.
+type ProfileUpdate struct {
+	// DateOfBirth The date of birth of the user
+	DateOfBirth *openapi_types.Date `json:"date_of_birth,omitempty"`
+
+	// Email The email address of the user, or empty to remove it
+	Email  *string `json:"email,omitempty"`
+	Gender *Gender `json:"gender,omitempty"`
+
+	// HomeCourseId The course the user usually plays, or 0 to remove it
+	HomeCourseId *int64 `json:"home_course_id,omitempty"`
+
+	// Name The name of the user
+	Name *string `json:"name,omitempty"`
+}
+
+// RecoveryCodes defines the model for recovery_codes.
+type RecoveryCodes struct {
+	// RecoveryCodes The recovery codes, each of which can be used once instead of a totp code
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// RefreshTokenRequest defines the model for refresh_token_request.
+type RefreshTokenRequest struct {
+	// RefreshToken The refresh token
+	RefreshToken string `json:"refresh_token"`
+}
+
+// Role defines the model for role.
+type Role = string
+
+// List of Role
+const (
+	Role_admin  Role = "admin"
+	Role_coach  Role = "coach"
+	Role_player Role = "player"
+)
+
 // Round defines the model for round.
 type Round struct {
 	// CourseName The course name
@@ -143,9 +415,12 @@ type Round struct {
 
 // RoundCreate defines the model for round_create.
 type RoundCreate struct {
-	// CourseId The course id
+	// CourseId The golf data course id
 	CourseId *int64 `json:"course_id,omitempty"`
 
+	// CustomCourseId The custom course id, used instead of the golf data course id
+	CustomCourseId *int64 `json:"custom_course_id,omitempty"`
+
 	// Id The round id
 	Id *int64 `json:"id,omitempty"`
 
@@ -156,16 +431,120 @@ type RoundCreate struct {
 	TeeTime *time.Time `json:"tee_time,omitempty"`
 }
 
+// RoundStats defines the model for round_stats.
+type RoundStats struct {
+	// AvgFairwaysHit The percentage of fairways hit
+	AvgFairwaysHit *float64 `json:"avg_fairways_hit,omitempty"`
+
+	// AvgGreensHit The percentage of greens hit in regulation
+	AvgGreensHit *float64 `json:"avg_greens_hit,omitempty"`
+
+	// AvgPar3 The average score on par 3s
+	AvgPar3 *float64 `json:"avg_par_3,omitempty"`
+
+	// AvgPar4 The average score on par 4s
+	AvgPar4 *float64 `json:"avg_par_4,omitempty"`
+
+	// AvgPar5 The average score on par 5s
+	AvgPar5 *float64 `json:"avg_par_5,omitempty"`
+
+	// AvgPutts The average number of putts per hole
+	AvgPutts *float64 `json:"avg_putts,omitempty"`
+
+	// CourseName The course name
+	CourseName *string `json:"course_name,omitempty"`
+
+	// Penalties The number of penalties
+	Penalties *int64 `json:"penalties,omitempty"`
+
+	// RoundId The round id
+	RoundId *int64 `json:"round_id,omitempty"`
+
+	// TeeTime The tee time
+	TeeTime *time.Time `json:"tee_time,omitempty"`
+}
+
+// RoundStatsResponse defines the model for round_stats_response.
+type RoundStatsResponse struct {
+	Next  *externalRef0.PageCursor `json:"next,omitempty"`
+	Stats []RoundStats             `json:"stats"`
+	Total int64                    `json:"total"`
+}
+
 // RoundsResponse defines the model for rounds_response.
 type RoundsResponse struct {
-	Rounds []Round `json:"rounds"`
-	Total  int64   `json:"total"`
+	Next   *externalRef0.PageCursor `json:"next,omitempty"`
+	Rounds []Round                  `json:"rounds"`
+	Total  int64                    `json:"total"`
+}
+
+// Session defines the model for session.
+type Session struct {
+	// CreatedAt When the session was started
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+
+	// Current Whether this is the session of the request
+	Current *bool `json:"current,omitempty"`
+
+	// ExpiresAt When the session expires
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+
+	// Id The session id
+	Id *int64 `json:"id,omitempty"`
+
+	// LastUsedAt When the session was last refreshed
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+
+	// UserAgent The user agent that started the session
+	UserAgent *string `json:"user_agent,omitempty"`
+}
+
+// SessionsResponse defines the model for sessions_response.
+type SessionsResponse struct {
+	Sessions []Session `json:"sessions"`
+	Total    int64     `json:"total"`
 }
 
 // Token defines the model for token.
 type Token struct {
-	// Token The token
+	// ExpiresAt When the token expires
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+
+	// RefreshToken The token used to get a new access token when it expires
+	RefreshToken *string `json:"refresh_token,omitempty"`
+
+	// Token The signed access token
 	Token *string `json:"token,omitempty"`
+
+	// TokenType The type of the token, always Bearer
+	TokenType *string `json:"token_type,omitempty"`
+}
+
+// TotpConfirm defines the model for totp_confirm.
+type TotpConfirm struct {
+	// Code The code from the authenticator app
+	Code string `json:"code"`
+}
+
+// TotpEnrolment defines the model for totp_enrolment.
+type TotpEnrolment struct {
+	// OtpauthUri The otpauth URI to show as a QR code for authenticator apps
+	OtpauthUri string `json:"otpauth_uri"`
+
+	// Secret The TOTP secret, encoded as base32
+	Secret string `json:"secret"`
+}
+
+// TwoFactorChallenge defines the model for two_factor_challenge.
+type TwoFactorChallenge struct {
+	// Challenge Set when the user has two-factor authentication enabled. Send it to the login endpoint with a totp or recovery code to complete the login
+	Challenge *string `json:"challenge,omitempty"`
+
+	// ExpiresAt When the challenge expires
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+
+	// Message The reason the login failed
+	Message string `json:"message"`
 }
 
 // User defines the model for user.
@@ -178,43 +557,179 @@ type User struct {
 
 	// Password The password
 	Password *string `json:"password,omitempty"`
+	Role     *Role   `json:"role,omitempty"`
 
 	// Username The username
 	Username *string `json:"username,omitempty"`
 }
 
+// UserUpdate defines the model for user_update.
+type UserUpdate struct {
+	Role Role `json:"role"`
+}
+
+// UsersResponse defines the model for users_response.
+type UsersResponse struct {
+	Total int64  `json:"total"`
+	Users []User `json:"users"`
+}
+
+// PathApiKeyId defines the model for path_api_key_id.
+type PathApiKeyId = int64
+
+// PathCoachId defines the model for path_coach_id.
+type PathCoachId = int64
+
 // PathCourseId defines the model for path_course_id.
 type PathCourseId = int64
 
 // PathHoleId defines the model for path_hole_id.
 type PathHoleId = int64
 
+// PathOidcProvider defines the model for path_oidc_provider.
+type PathOidcProvider = string
+
 // PathRoundId defines the model for path_round_id.
 type PathRoundId = int64
 
+// PathSessionId defines the model for path_session_id.
+type PathSessionId = int64
+
+// PathUserId defines the model for path_user_id.
+type PathUserId = int64
+
+// QueryActorId defines the model for query_actor_id.
+type QueryActorId = int64
+
+// QueryAuditAction defines the model for query_audit_action.
+type QueryAuditAction = string
+
 // QueryAverageType defines the model for query_average_type.
 type QueryAverageType = AverageType
 
+// QueryLimit defines the model for query_limit.
+type QueryLimit = int64
+
 // QueryNameParam defines the model for query_name_param.
 type QueryNameParam = string
 
+// QuerySince defines the model for query_since.
+type QuerySince = time.Time
+
+// QueryTargetId defines the model for query_target_id.
+type QueryTargetId = int64
+
+// QueryTargetType defines the model for query_target_type.
+type QueryTargetType = string
+
+// QueryUntil defines the model for query_until.
+type QueryUntil = time.Time
+
+// QueryUserId defines the model for query_user_id.
+type QueryUserId = int64
+
+// GetAuditEventsParams defines parameters for GetAuditEvents.
+type GetAuditEventsParams struct {
+	// ActorId Only return the events performed by the user
+	ActorId *QueryActorId `form:"actor_id,omitempty" json:"actor_id,omitempty"`
+
+	// Action Only return the events with the action
+	Action *QueryAuditAction `form:"action,omitempty" json:"action,omitempty"`
+
+	// TargetType Only return the events that changed the type of resource
+	TargetType *QueryTargetType `form:"target_type,omitempty" json:"target_type,omitempty"`
+
+	// TargetId Only return the events that changed the resource
+	TargetId *QueryTargetId `form:"target_id,omitempty" json:"target_id,omitempty"`
+
+	// Since Only return the events that happened at or after the time
+	Since *QuerySince `form:"since,omitempty" json:"since,omitempty"`
+
+	// Until Only return the events that happened before the time
+	Until *QueryUntil `form:"until,omitempty" json:"until,omitempty"`
+
+	// Limit The maximum number of items to return
+	Limit *QueryLimit `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
 // LoginJSONBody defines parameters for Login.
 type LoginJSONBody struct {
+	// Challenge The challenge from a login with an OpenID Connect provider that needs a two-factor code, sent with the totp or recovery code instead of the username and password
+	Challenge *string `json:"challenge,omitempty"`
+
 	// Password The password
 	Password *string `json:"password,omitempty"`
 
+	// RecoveryCode A recovery code, which can be used once instead of the totp code
+	RecoveryCode *string `json:"recovery_code,omitempty"`
+
+	// TotpCode The code from the authenticator app, required when two-factor authentication is enabled
+	TotpCode *string `json:"totp_code,omitempty"`
+
 	// Username The username
 	Username *string `json:"username,omitempty"`
 }
 
+// OidcCallbackParams defines parameters for OidcCallback.
+type OidcCallbackParams struct {
+	State string  `form:"state" json:"state"`
+	Code  *string `form:"code,omitempty" json:"code,omitempty"`
+	Error *string `form:"error,omitempty" json:"error,omitempty"`
+}
+
+// GetRoundsParams defines parameters for GetRounds.
+type GetRoundsParams struct {
+	// UserId The user to get the data of, defaults to the user making the request
+	UserId *QueryUserId `form:"user_id,omitempty" json:"user_id,omitempty"`
+
+	// Limit Pagination details, maximum number of items on the page.
+	Limit *externalRef0.LimitParam `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// LastVal Pagination details, last value of the sort column on the previous page.
+	LastVal *externalRef0.LastValue `form:"last_val,omitempty" json:"last_val,omitempty"`
+
+	// LastId Pagination details, last value of the id column on the previous page.
+	LastId *externalRef0.LastId `form:"last_id,omitempty" json:"last_id,omitempty"`
+
+	// SortBy Pagination details, sort column, if empty uses the id column.
+	SortBy *externalRef0.SortBy `form:"sort_by,omitempty" json:"sort_by,omitempty"`
+
+	// SortDir Pagination details, sorting order.
+	SortDir *externalRef0.SortDirection `form:"sort_dir,omitempty" json:"sort_dir,omitempty"`
+}
+
 // GetNewRoundCoursesParams defines parameters for GetNewRoundCourses.
 type GetNewRoundCoursesParams struct {
 	// Name The name of the club
 	Name *QueryNameParam `form:"name,omitempty" json:"name,omitempty"`
 }
 
+// GetRoundStatsParams defines parameters for GetRoundStats.
+type GetRoundStatsParams struct {
+	// UserId The user to get the data of, defaults to the user making the request
+	UserId *QueryUserId `form:"user_id,omitempty" json:"user_id,omitempty"`
+
+	// Limit Pagination details, maximum number of items on the page.
+	Limit *externalRef0.LimitParam `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// LastVal Pagination details, last value of the sort column on the previous page.
+	LastVal *externalRef0.LastValue `form:"last_val,omitempty" json:"last_val,omitempty"`
+
+	// LastId Pagination details, last value of the id column on the previous page.
+	LastId *externalRef0.LastId `form:"last_id,omitempty" json:"last_id,omitempty"`
+
+	// SortBy Pagination details, sort column, if empty uses the id column.
+	SortBy *externalRef0.SortBy `form:"sort_by,omitempty" json:"sort_by,omitempty"`
+
+	// SortDir Pagination details, sorting order.
+	SortDir *externalRef0.SortDirection `form:"sort_dir,omitempty" json:"sort_dir,omitempty"`
+}
+
 // GetLineChartAveragesParams defines parameters for GetLineChartAverages.
 type GetLineChartAveragesParams struct {
+	// UserId The user to get the data of, defaults to the user making the request
+	UserId *QueryUserId `form:"user_id,omitempty" json:"user_id,omitempty"`
+
 	// AverageType The type of average
 	AverageType QueryAverageType `form:"average_type" json:"average_type"`
 
@@ -227,18 +742,75 @@ type GetLineChartAveragesParams struct {
 
 // GetPieChartAveragesParams defines parameters for GetPieChartAverages.
 type GetPieChartAveragesParams struct {
+	// UserId The user to get the data of, defaults to the user making the request
+	UserId *QueryUserId `form:"user_id,omitempty" json:"user_id,omitempty"`
+
 	// AverageType The type of average
 	AverageType QueryAverageType `form:"average_type" json:"average_type"`
 }
 
+// GetRoundHolesParams defines parameters for GetRoundHoles.
+type GetRoundHolesParams struct {
+	// Limit Pagination details, maximum number of items on the page.
+	Limit *externalRef0.LimitParam `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// LastVal Pagination details, last value of the sort column on the previous page.
+	LastVal *externalRef0.LastValue `form:"last_val,omitempty" json:"last_val,omitempty"`
+
+	// LastId Pagination details, last value of the id column on the previous page.
+	LastId *externalRef0.LastId `form:"last_id,omitempty" json:"last_id,omitempty"`
+
+	// SortBy Pagination details, sort column, if empty uses the id column.
+	SortBy *externalRef0.SortBy `form:"sort_by,omitempty" json:"sort_by,omitempty"`
+
+	// SortDir Pagination details, sorting order.
+	SortDir *externalRef0.SortDirection `form:"sort_dir,omitempty" json:"sort_dir,omitempty"`
+}
+
+// UpdateUserJSONRequestBody defines body for UpdateUser for application/json ContentType.
+type UpdateUserJSONRequestBody = UserUpdate
+
+// CreateCourseJSONRequestBody defines body for CreateCourse for application/json ContentType.
+type CreateCourseJSONRequestBody = CustomCourse
+
+// UpdateCourseJSONRequestBody defines body for UpdateCourse for application/json ContentType.
+type UpdateCourseJSONRequestBody = CustomCourse
+
 // LoginJSONRequestBody defines body for Login for application/json ContentType.
 type LoginJSONRequestBody LoginJSONBody
 
+// RequestPasswordResetJSONRequestBody defines body for RequestPasswordReset for application/json ContentType.
+type RequestPasswordResetJSONRequestBody = PasswordResetRequest
+
+// ConfirmPasswordResetJSONRequestBody defines body for ConfirmPasswordReset for application/json ContentType.
+type ConfirmPasswordResetJSONRequestBody = PasswordResetConfirm
+
 // CreateRoundJSONRequestBody defines body for CreateRound for application/json ContentType.
 type CreateRoundJSONRequestBody = RoundCreate
 
 // UpdateHoleStatsJSONRequestBody defines body for UpdateHoleStats for application/json ContentType.
 type UpdateHoleStatsJSONRequestBody = HoleStats
 
+// RefreshTokenJSONRequestBody defines body for RefreshToken for application/json ContentType.
+type RefreshTokenJSONRequestBody = RefreshTokenRequest
+
 // CreateUserJSONRequestBody defines body for CreateUser for application/json ContentType.
 type CreateUserJSONRequestBody = User
+
+// UpdateProfileJSONRequestBody defines body for UpdateProfile for application/json ContentType.
+type UpdateProfileJSONRequestBody = ProfileUpdate
+
+// CreateApiKeyJSONRequestBody defines body for CreateApiKey for application/json ContentType.
+type CreateApiKeyJSONRequestBody = ApiKeyCreate
+
+// GrantCoachJSONRequestBody defines body for GrantCoach for application/json ContentType.
+type GrantCoachJSONRequestBody = CoachGrantCreate
+
+// ChangePasswordJSONRequestBody defines body for ChangePassword for application/json ContentType.
+type ChangePasswordJSONRequestBody = PasswordChange
+
+// UpdatePreferencesJSONRequestBody defines body for UpdatePreferences for application/json ContentType.
+type UpdatePreferencesJSONRequestBody = Preferences
+
+// ConfirmTotpJSONRequestBody defines body for ConfirmTotp for application/json ContentType.
+type ConfirmTotpJSONRequestBody = TotpConfirm
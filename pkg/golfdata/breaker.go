@@ -0,0 +1,97 @@
+package golfdata
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultFailureThreshold is the default number of consecutive failures that open the circuit.
+	defaultFailureThreshold = 5
+
+	// defaultCooldown is the default time the circuit stays open before a trial request is allowed.
+	defaultCooldown = 30 * time.Second
+)
+
+// ErrCircuitOpen is returned when requests are not being made because the golf data service is failing.
+var ErrCircuitOpen = errors.New("golf data circuit breaker is open")
+
+type breakerState int
+
+const (
+	breakerClosed breakerState = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+// circuitBreaker stops requests being made to a failing service. After failureThreshold consecutive failures the
+// circuit opens and all requests are rejected until cooldown has passed, at which point a single trial request is
+// allowed through. A successful trial closes the circuit, a failed trial opens it again.
+type circuitBreaker struct {
+	mut sync.Mutex
+
+	// failureThreshold is the number of consecutive failures that open the circuit.
+	failureThreshold int
+
+	// cooldown is how long the circuit stays open before a trial request is allowed.
+	cooldown time.Duration
+
+	state    breakerState
+	failures int
+	openedAt time.Time
+
+	// now is the clock used by the breaker.
+	now func() time.Time
+}
+
+func newCircuitBreaker(failureThreshold int, cooldown time.Duration) *circuitBreaker {
+	return &circuitBreaker{
+		failureThreshold: failureThreshold,
+		cooldown:         cooldown,
+		now:              time.Now,
+	}
+}
+
+// Allow reports whether a request can be made.
+func (b *circuitBreaker) Allow() bool {
+	b.mut.Lock()
+	defer b.mut.Unlock()
+
+	switch b.state {
+	case breakerOpen:
+		if b.now().Sub(b.openedAt) < b.cooldown {
+			return false
+		}
+		b.state = breakerHalfOpen
+		return true
+	case breakerHalfOpen:
+		// Only the single trial request is allowed while half open.
+		return false
+	default:
+		return true
+	}
+}
+
+// Success records a successful request.
+func (b *circuitBreaker) Success() {
+	b.mut.Lock()
+	defer b.mut.Unlock()
+
+	b.state = breakerClosed
+	b.failures = 0
+	circuitState.Set(float64(breakerClosed))
+}
+
+// Failure records a failed request.
+func (b *circuitBreaker) Failure() {
+	b.mut.Lock()
+	defer b.mut.Unlock()
+
+	b.failures++
+	if b.state == breakerHalfOpen || b.failures >= b.failureThreshold {
+		b.state = breakerOpen
+		b.openedAt = b.now()
+		circuitState.Set(float64(breakerOpen))
+	}
+}
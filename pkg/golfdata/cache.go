@@ -0,0 +1,255 @@
+package golfdata
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"sync"
+	"time"
+
+	api "github.com/Jacobbrewer1/golf-stats-tracker/pkg/codegen/apis/rounder"
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/logging"
+)
+
+const (
+	// defaultCacheTTL is the default time a cached response is served without asking the golf data service.
+	defaultCacheTTL = 15 * time.Minute
+
+	// defaultCacheMaxEntries is the default number of responses held in memory.
+	defaultCacheMaxEntries = 1000
+
+	// defaultCacheMaxStale is the default time past the TTL that a cached response is served while the golf data
+	// service is failing.
+	defaultCacheMaxStale = 24 * time.Hour
+)
+
+// CacheEntry is a cached response from the golf data service.
+type CacheEntry struct {
+	// Key is the key of the cached response.
+	Key string
+
+	// Value is the JSON encoded response.
+	Value []byte
+
+	// FetchedAt is when the response was fetched from the golf data service.
+	FetchedAt time.Time
+}
+
+// Store persists cached responses so that they survive restarts.
+type Store interface {
+	// Get gets the cache entry for the key, returning nil if there is no entry.
+	Get(ctx context.Context, key string) (*CacheEntry, error)
+
+	// Set saves the cache entry.
+	Set(ctx context.Context, entry *CacheEntry) error
+}
+
+type cachedClient struct {
+	mut sync.Mutex
+
+	// next is the client used when a response is not cached.
+	next Client
+
+	// ttl is the time a cached response is served without asking the golf data service.
+	ttl time.Duration
+
+	// maxStale is the time past the ttl that a cached response is served while the golf data service is failing.
+	maxStale time.Duration
+
+	// maxEntries is the number of responses held in memory.
+	maxEntries int
+
+	// store optionally persists the cached responses.
+	store Store
+
+	// entries indexes the elements of lru by key.
+	entries map[string]*list.Element
+
+	// lru holds the cached responses, with the most recently used at the front.
+	lru *list.List
+
+	// now is the clock used by the cache.
+	now func() time.Time
+}
+
+// CacheOption is a function that configures the cache.
+type CacheOption func(c *cachedClient)
+
+// WithCacheTTL sets the time a cached response is served without asking the golf data service.
+func WithCacheTTL(ttl time.Duration) CacheOption {
+	return func(c *cachedClient) {
+		c.ttl = ttl
+	}
+}
+
+// WithCacheMaxStale sets the time past the TTL that a cached response is served while the golf data service is
+// failing.
+func WithCacheMaxStale(maxStale time.Duration) CacheOption {
+	return func(c *cachedClient) {
+		c.maxStale = maxStale
+	}
+}
+
+// WithCacheMaxEntries sets the number of responses held in memory.
+func WithCacheMaxEntries(maxEntries int) CacheOption {
+	return func(c *cachedClient) {
+		c.maxEntries = maxEntries
+	}
+}
+
+// WithCacheStore persists the cached responses to the store.
+func WithCacheStore(store Store) CacheOption {
+	return func(c *cachedClient) {
+		c.store = store
+	}
+}
+
+// NewCachedClient creates a client that caches the responses of the next client.
+func NewCachedClient(next Client, opts ...CacheOption) Client {
+	c := &cachedClient{
+		next:       next,
+		ttl:        defaultCacheTTL,
+		maxStale:   defaultCacheMaxStale,
+		maxEntries: defaultCacheMaxEntries,
+		entries:    make(map[string]*list.Element),
+		lru:        list.New(),
+		now:        time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+func (c *cachedClient) GetCourses(ctx context.Context, name string) (*api.CoursesResponse, error) {
+	return cached(ctx, c, "get_courses", "courses:"+name, func() (*api.CoursesResponse, error) {
+		return c.next.GetCourses(ctx, name)
+	})
+}
+
+func (c *cachedClient) GetCourse(ctx context.Context, courseId int) (*api.Course, error) {
+	return cached(ctx, c, "get_course", "course:"+strconv.Itoa(courseId), func() (*api.Course, error) {
+		return c.next.GetCourse(ctx, courseId)
+	})
+}
+
+// cached returns the cached response for the key if it is fresh, otherwise it fetches a new response. If fetching
+// fails and the cached response is within the max stale window, the stale response is returned instead.
+func cached[T any](ctx context.Context, c *cachedClient, operation string, key string, fetch func() (*T, error)) (*T, error) {
+	entry := c.lookup(ctx, key)
+	if entry != nil && c.now().Sub(entry.FetchedAt) < c.ttl {
+		v := new(T)
+		err := json.Unmarshal(entry.Value, v)
+		if err == nil {
+			cacheRequests.WithLabelValues(operation, cacheResultHit).Inc()
+			return v, nil
+		}
+
+		slog.Warn("Error decoding cached golf data response",
+			slog.String("key", key),
+			slog.String(logging.KeyError, err.Error()),
+		)
+	}
+
+	v, err := fetch()
+	if err != nil {
+		if entry != nil && !errors.Is(err, ErrNotFound) && c.now().Sub(entry.FetchedAt) < c.ttl+c.maxStale {
+			stale := new(T)
+			if decodeErr := json.Unmarshal(entry.Value, stale); decodeErr == nil {
+				slog.Warn("Serving stale golf data response",
+					slog.String("key", key),
+					slog.Time("fetched_at", entry.FetchedAt),
+					slog.String(logging.KeyError, err.Error()),
+				)
+				cacheRequests.WithLabelValues(operation, cacheResultStale).Inc()
+				return stale, nil
+			}
+		}
+
+		return nil, err
+	}
+
+	cacheRequests.WithLabelValues(operation, cacheResultMiss).Inc()
+
+	value, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode response: %w", err)
+	}
+
+	c.save(ctx, &CacheEntry{
+		Key:       key,
+		Value:     value,
+		FetchedAt: c.now(),
+	})
+
+	return v, nil
+}
+
+// lookup gets the cache entry for the key from memory, falling back to the store.
+func (c *cachedClient) lookup(ctx context.Context, key string) *CacheEntry {
+	c.mut.Lock()
+	if el, ok := c.entries[key]; ok {
+		c.lru.MoveToFront(el)
+		c.mut.Unlock()
+		return el.Value.(*CacheEntry)
+	}
+	c.mut.Unlock()
+
+	if c.store == nil {
+		return nil
+	}
+
+	entry, err := c.store.Get(ctx, key)
+	if err != nil {
+		slog.Error("Error getting golf data cache entry", slog.String("key", key), slog.String(logging.KeyError, err.Error()))
+		return nil
+	} else if entry == nil {
+		return nil
+	}
+
+	c.put(entry)
+	return entry
+}
+
+// save saves the cache entry to memory and the store.
+func (c *cachedClient) save(ctx context.Context, entry *CacheEntry) {
+	c.put(entry)
+
+	if c.store == nil {
+		return
+	}
+
+	err := c.store.Set(ctx, entry)
+	if err != nil {
+		slog.Error("Error saving golf data cache entry", slog.String("key", entry.Key), slog.String(logging.KeyError, err.Error()))
+	}
+}
+
+// put adds the cache entry to memory, evicting the least recently used entries when the cache is full.
+func (c *cachedClient) put(entry *CacheEntry) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	if el, ok := c.entries[entry.Key]; ok {
+		el.Value = entry
+		c.lru.MoveToFront(el)
+		return
+	}
+
+	c.entries[entry.Key] = c.lru.PushFront(entry)
+
+	for c.maxEntries > 0 && c.lru.Len() > c.maxEntries {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*CacheEntry).Key)
+		cacheEvictions.Inc()
+	}
+
+	cacheEntries.Set(float64(c.lru.Len()))
+}
@@ -0,0 +1,138 @@
+package golfdata
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	api "github.com/Jacobbrewer1/golf-stats-tracker/pkg/codegen/apis/rounder"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCache(t *testing.T, next Client, now *time.Time, opts ...CacheOption) *cachedClient {
+	t.Helper()
+
+	c := NewCachedClient(next, opts...).(*cachedClient)
+	c.now = func() time.Time { return *now }
+	return c
+}
+
+func TestCachedClient_GetCourse(t *testing.T) {
+	upstreamErr := &StatusError{StatusCode: 503}
+
+	tests := []struct {
+		name     string
+		advance  time.Duration
+		fetchErr error
+		wantErr  error
+		wantName string
+	}{
+		{
+			name:     "fresh entry is served from the cache",
+			advance:  time.Minute,
+			wantName: "Cached",
+		},
+		{
+			name:     "expired entry is refetched",
+			advance:  2 * time.Hour,
+			wantName: "Fetched",
+		},
+		{
+			name:     "stale entry is served when upstream fails",
+			advance:  90 * time.Minute,
+			fetchErr: upstreamErr,
+			wantName: "Cached",
+		},
+		{
+			name:     "entry past max stale is not served",
+			advance:  48 * time.Hour,
+			fetchErr: upstreamErr,
+			wantErr:  upstreamErr,
+		},
+		{
+			name:     "stale entry is not served when upstream has removed the course",
+			advance:  90 * time.Minute,
+			fetchErr: ErrNotFound,
+			wantErr:  ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Now()
+			next := NewMockClient(t)
+			c := newTestCache(t, next, &now, WithCacheTTL(time.Hour), WithCacheMaxStale(time.Hour))
+
+			next.On("GetCourse", mock.Anything, 1).Return(&api.Course{Id: 1, Name: "Cached"}, nil).Once()
+			_, err := c.GetCourse(context.Background(), 1)
+			require.NoError(t, err)
+
+			now = now.Add(tt.advance)
+			if tt.advance >= time.Hour {
+				if tt.fetchErr != nil {
+					next.On("GetCourse", mock.Anything, 1).Return(nil, tt.fetchErr).Once()
+				} else {
+					next.On("GetCourse", mock.Anything, 1).Return(&api.Course{Id: 1, Name: "Fetched"}, nil).Once()
+				}
+			}
+
+			course, err := c.GetCourse(context.Background(), 1)
+			if tt.wantErr != nil {
+				require.True(t, errors.Is(err, tt.wantErr), "GetCourse() error = %v, want %v", err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.wantName, course.Name)
+		})
+	}
+}
+
+func TestCachedClient_Eviction(t *testing.T) {
+	now := time.Now()
+	next := NewMockClient(t)
+	c := newTestCache(t, next, &now, WithCacheMaxEntries(2))
+
+	for _, id := range []int{1, 2, 3} {
+		next.On("GetCourse", mock.Anything, id).Return(&api.Course{Id: int64(id)}, nil).Once()
+		_, err := c.GetCourse(context.Background(), id)
+		require.NoError(t, err)
+	}
+
+	require.Equal(t, 2, c.lru.Len())
+	require.NotContains(t, c.entries, "course:1")
+
+	// The evicted course is fetched again.
+	next.On("GetCourse", mock.Anything, 1).Return(&api.Course{Id: 1}, nil).Once()
+	_, err := c.GetCourse(context.Background(), 1)
+	require.NoError(t, err)
+}
+
+func TestCachedClient_Store(t *testing.T) {
+	now := time.Now()
+	next := NewMockClient(t)
+	store := NewMockStore(t)
+	c := newTestCache(t, next, &now, WithCacheStore(store))
+
+	store.On("Get", mock.Anything, "course:1").Return(&CacheEntry{
+		Key:       "course:1",
+		Value:     []byte(`{"id": 1, "name": "Persisted", "details": []}`),
+		FetchedAt: now,
+	}, nil).Once()
+
+	course, err := c.GetCourse(context.Background(), 1)
+	require.NoError(t, err)
+	require.Equal(t, "Persisted", course.Name)
+
+	store.On("Get", mock.Anything, "course:2").Return(nil, nil).Once()
+	next.On("GetCourse", mock.Anything, 2).Return(&api.Course{Id: 2, Name: "Fetched"}, nil).Once()
+	store.On("Set", mock.Anything, mock.MatchedBy(func(e *CacheEntry) bool {
+		return e.Key == "course:2"
+	})).Return(nil).Once()
+
+	course, err = c.GetCourse(context.Background(), 2)
+	require.NoError(t, err)
+	require.Equal(t, "Fetched", course.Name)
+}
@@ -0,0 +1,252 @@
+package golfdata
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	api "github.com/Jacobbrewer1/golf-stats-tracker/pkg/codegen/apis/rounder"
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/logging"
+	"github.com/cenkalti/backoff/v4"
+)
+
+const (
+	// defaultTimeout is the default timeout for a single request to the golf data service.
+	defaultTimeout = 5 * time.Second
+
+	// defaultMaxRetries is the default number of times a failed request is retried.
+	defaultMaxRetries = 3
+
+	// defaultInitialBackoff is the default wait before the first retry.
+	defaultInitialBackoff = 100 * time.Millisecond
+
+	// defaultMaxBackoff is the default maximum wait between retries.
+	defaultMaxBackoff = 2 * time.Second
+)
+
+var (
+	// ErrNotFound is returned when the golf data service does not have the requested resource.
+	ErrNotFound = errors.New("not found")
+)
+
+// StatusError is returned when the golf data service responds with an unexpected status code.
+type StatusError struct {
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("unexpected status code: %d", e.StatusCode)
+}
+
+// Client is a client for the golf data service.
+type Client interface {
+	// GetCourses gets the courses, optionally filtered by name.
+	GetCourses(ctx context.Context, name string) (*api.CoursesResponse, error)
+
+	// GetCourse gets a course by its ID.
+	GetCourse(ctx context.Context, courseId int) (*api.Course, error)
+}
+
+type client struct {
+	// host is the base URL of the golf data service.
+	host string
+
+	// httpClient is the client used to make requests.
+	httpClient *http.Client
+
+	// maxRetries is the number of times a failed request is retried.
+	maxRetries uint64
+
+	// initialBackoff is the wait before the first retry.
+	initialBackoff time.Duration
+
+	// maxBackoff is the maximum wait between retries.
+	maxBackoff time.Duration
+
+	// breaker stops requests being made while the golf data service is failing.
+	breaker *circuitBreaker
+}
+
+// ClientOption is a function that configures the client.
+type ClientOption func(c *client)
+
+// WithTimeout sets the timeout for a single request.
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(c *client) {
+		c.httpClient.Timeout = timeout
+	}
+}
+
+// WithMaxRetries sets the number of times a failed request is retried.
+func WithMaxRetries(maxRetries uint64) ClientOption {
+	return func(c *client) {
+		c.maxRetries = maxRetries
+	}
+}
+
+// WithBackoff sets the initial and maximum wait between retries.
+func WithBackoff(initial, maximum time.Duration) ClientOption {
+	return func(c *client) {
+		c.initialBackoff = initial
+		c.maxBackoff = maximum
+	}
+}
+
+// WithCircuitBreaker sets the number of consecutive failures that open the circuit and how long it stays open for.
+func WithCircuitBreaker(failureThreshold int, cooldown time.Duration) ClientOption {
+	return func(c *client) {
+		c.breaker = newCircuitBreaker(failureThreshold, cooldown)
+	}
+}
+
+// WithHTTPClient sets the http client used to make requests.
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(c *client) {
+		c.httpClient = httpClient
+	}
+}
+
+// NewClient creates a new golf data client.
+func NewClient(host string, opts ...ClientOption) Client {
+	c := &client{
+		host: host,
+		httpClient: &http.Client{
+			Timeout: defaultTimeout,
+		},
+		maxRetries:     defaultMaxRetries,
+		initialBackoff: defaultInitialBackoff,
+		maxBackoff:     defaultMaxBackoff,
+		breaker:        newCircuitBreaker(defaultFailureThreshold, defaultCooldown),
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+func (c *client) GetCourses(ctx context.Context, name string) (*api.CoursesResponse, error) {
+	params := url.Values{}
+	if name != "" {
+		params.Add("name", name)
+	}
+
+	courses := new(api.CoursesResponse)
+	err := c.get(ctx, "get_courses", "/courses", params, courses)
+	if err != nil {
+		return nil, err
+	}
+
+	return courses, nil
+}
+
+func (c *client) GetCourse(ctx context.Context, courseId int) (*api.Course, error) {
+	course := new(api.Course)
+	err := c.get(ctx, "get_course", "/courses/"+strconv.Itoa(courseId), nil, course)
+	if err != nil {
+		return nil, err
+	}
+
+	return course, nil
+}
+
+// get makes a GET request to the golf data service and decodes the response into dest. Requests that fail with a
+// network error or a 5xx status code are retried with an exponential backoff.
+func (c *client) get(ctx context.Context, operation string, path string, params url.Values, dest any) error {
+	if !c.breaker.Allow() {
+		requestErrors.WithLabelValues(operation, reasonCircuitOpen).Inc()
+		return ErrCircuitOpen
+	}
+
+	b := backoff.NewExponentialBackOff()
+	b.InitialInterval = c.initialBackoff
+	b.MaxInterval = c.maxBackoff
+	b.MaxElapsedTime = 0
+
+	attempt := func() error {
+		err := c.do(ctx, operation, path, params, dest)
+		if err == nil {
+			return nil
+		}
+
+		statusErr := new(StatusError)
+		if errors.Is(err, ErrNotFound) || (errors.As(err, &statusErr) && statusErr.StatusCode < http.StatusInternalServerError) {
+			return backoff.Permanent(err)
+		}
+
+		return err
+	}
+
+	notify := func(err error, wait time.Duration) {
+		slog.Debug("Retrying golf data request",
+			slog.String("operation", operation),
+			slog.Duration("wait", wait),
+			slog.String(logging.KeyError, err.Error()),
+		)
+	}
+
+	err := backoff.RetryNotify(attempt, backoff.WithContext(backoff.WithMaxRetries(b, c.maxRetries), ctx), notify)
+
+	// Only failures of the golf data service count towards opening the circuit.
+	statusErr := new(StatusError)
+	if err == nil || errors.Is(err, ErrNotFound) || (errors.As(err, &statusErr) && statusErr.StatusCode < http.StatusInternalServerError) {
+		c.breaker.Success()
+	} else {
+		c.breaker.Failure()
+	}
+
+	return err
+}
+
+// do makes a single request to the golf data service.
+func (c *client) do(ctx context.Context, operation string, path string, params url.Values, dest any) error {
+	t := time.Now()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.host+path, nil)
+	if err != nil {
+		return backoff.Permanent(fmt.Errorf("failed to create request: %w", err))
+	}
+
+	if len(params) > 0 {
+		req.URL.RawQuery = params.Encode()
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		requestLatency.WithLabelValues(operation, statusError).Observe(time.Since(t).Seconds())
+		requestErrors.WithLabelValues(operation, reasonRequest).Inc()
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+
+	defer func() {
+		err := resp.Body.Close()
+		if err != nil {
+			slog.Error("failed to close response body", slog.String(logging.KeyError, err.Error()))
+		}
+	}()
+
+	requestLatency.WithLabelValues(operation, strconv.Itoa(resp.StatusCode)).Observe(time.Since(t).Seconds())
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return ErrNotFound
+	case resp.StatusCode != http.StatusOK:
+		requestErrors.WithLabelValues(operation, reasonStatus).Inc()
+		return &StatusError{StatusCode: resp.StatusCode}
+	}
+
+	err = json.NewDecoder(resp.Body).Decode(dest)
+	if err != nil {
+		requestErrors.WithLabelValues(operation, reasonDecode).Inc()
+		return backoff.Permanent(fmt.Errorf("failed to decode response: %w", err))
+	}
+
+	return nil
+}
@@ -0,0 +1,133 @@
+package golfdata
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_GetCourse(t *testing.T) {
+	tests := []struct {
+		name         string
+		statusCodes  []int
+		wantErr      error
+		wantStatus   int
+		wantRequests int32
+	}{
+		{
+			name:         "success",
+			statusCodes:  []int{http.StatusOK},
+			wantRequests: 1,
+		},
+		{
+			name:         "retries server errors",
+			statusCodes:  []int{http.StatusInternalServerError, http.StatusBadGateway, http.StatusOK},
+			wantRequests: 3,
+		},
+		{
+			name:         "gives up after max retries",
+			statusCodes:  []int{http.StatusServiceUnavailable},
+			wantStatus:   http.StatusServiceUnavailable,
+			wantRequests: 3,
+		},
+		{
+			name:         "does not retry not found",
+			statusCodes:  []int{http.StatusNotFound},
+			wantErr:      ErrNotFound,
+			wantRequests: 1,
+		},
+		{
+			name:         "does not retry client errors",
+			statusCodes:  []int{http.StatusBadRequest},
+			wantStatus:   http.StatusBadRequest,
+			wantRequests: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, "/courses/1", r.URL.Path)
+
+				i := int(requests.Add(1)) - 1
+				if i >= len(tt.statusCodes) {
+					i = len(tt.statusCodes) - 1
+				}
+
+				w.WriteHeader(tt.statusCodes[i])
+				_, _ = w.Write([]byte(`{"id": 1, "name": "Example Course", "details": []}`))
+			}))
+			defer srv.Close()
+
+			c := NewClient(srv.URL, WithMaxRetries(2), WithBackoff(time.Millisecond, time.Millisecond))
+
+			course, err := c.GetCourse(context.Background(), 1)
+			require.Equal(t, tt.wantRequests, requests.Load())
+
+			switch {
+			case tt.wantErr != nil:
+				require.ErrorIs(t, err, tt.wantErr)
+			case tt.wantStatus != 0:
+				statusErr := new(StatusError)
+				require.ErrorAs(t, err, &statusErr)
+				require.Equal(t, tt.wantStatus, statusErr.StatusCode)
+			default:
+				require.NoError(t, err)
+				require.Equal(t, "Example Course", course.Name)
+			}
+		})
+	}
+}
+
+func TestClient_CircuitBreaker(t *testing.T) {
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, WithMaxRetries(0), WithCircuitBreaker(2, time.Hour))
+
+	for range 2 {
+		_, err := c.GetCourse(context.Background(), 1)
+		require.Error(t, err)
+		require.NotErrorIs(t, err, ErrCircuitOpen)
+	}
+
+	_, err := c.GetCourse(context.Background(), 1)
+	require.ErrorIs(t, err, ErrCircuitOpen)
+	require.Equal(t, int32(2), requests.Load())
+}
+
+func TestCircuitBreaker_HalfOpen(t *testing.T) {
+	now := time.Now()
+	b := newCircuitBreaker(1, time.Minute)
+	b.now = func() time.Time { return now }
+
+	require.True(t, b.Allow())
+	b.Failure()
+	require.False(t, b.Allow())
+
+	// After the cooldown a single trial request is allowed.
+	now = now.Add(time.Minute)
+	require.True(t, b.Allow())
+	require.False(t, b.Allow())
+
+	// A failed trial opens the circuit again.
+	b.Failure()
+	require.False(t, b.Allow())
+
+	// A successful trial closes the circuit.
+	now = now.Add(time.Minute)
+	require.True(t, b.Allow())
+	b.Success()
+	require.True(t, b.Allow())
+	require.True(t, b.Allow())
+}
@@ -0,0 +1,142 @@
+package golfdata
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	api "github.com/Jacobbrewer1/golf-stats-tracker/pkg/codegen/apis/rounder"
+	"gopkg.in/yaml.v3"
+)
+
+type fileClient struct {
+	// courses is the loaded courses, ordered by ID.
+	courses []api.Course
+}
+
+// NewFileClient creates a client that serves the courses defined in the JSON and YAML files in the directory, so
+// that the golf data service is not needed. Each file holds either a single course or a list of courses.
+func NewFileClient(dir string) (Client, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read course directory: %w", err)
+	}
+
+	c := &fileClient{
+		courses: make([]api.Course, 0),
+	}
+
+	ids := make(map[int64]string)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		path := filepath.Join(dir, entry.Name())
+		courses, err := loadCourseFile(path)
+		if errors.Is(err, errUnsupportedFile) {
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to load %s: %w", path, err)
+		}
+
+		for _, course := range courses {
+			if course.Id == 0 {
+				return nil, fmt.Errorf("course %q in %s has no id", course.Name, path)
+			} else if other, ok := ids[course.Id]; ok {
+				return nil, fmt.Errorf("course id %d in %s is already defined in %s", course.Id, path, other)
+			}
+
+			if course.Details == nil {
+				course.Details = make([]api.CourseDetails, 0)
+			}
+
+			ids[course.Id] = path
+			c.courses = append(c.courses, course)
+		}
+	}
+
+	slices.SortFunc(c.courses, func(a, b api.Course) int {
+		return cmp.Compare(a.Id, b.Id)
+	})
+
+	return c, nil
+}
+
+func (c *fileClient) GetCourses(_ context.Context, name string) (*api.CoursesResponse, error) {
+	courses := make([]api.Course, 0)
+	for _, course := range c.courses {
+		if name != "" && !strings.Contains(strings.ToLower(course.Name), strings.ToLower(name)) {
+			continue
+		}
+
+		courses = append(courses, course)
+	}
+
+	return &api.CoursesResponse{
+		Courses: courses,
+		Total:   int64(len(courses)),
+	}, nil
+}
+
+func (c *fileClient) GetCourse(_ context.Context, courseId int) (*api.Course, error) {
+	i, found := slices.BinarySearchFunc(c.courses, int64(courseId), func(course api.Course, id int64) int {
+		return cmp.Compare(course.Id, id)
+	})
+	if !found {
+		return nil, ErrNotFound
+	}
+
+	course := c.courses[i]
+	return &course, nil
+}
+
+// errUnsupportedFile is returned when a file in the course directory is not a JSON or YAML file.
+var errUnsupportedFile = errors.New("unsupported file type")
+
+// loadCourseFile loads the courses from a JSON or YAML file.
+func loadCourseFile(path string) ([]api.Course, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+	case ".yaml", ".yml":
+		// The API models only have JSON tags, so the YAML is converted to JSON before decoding.
+		var v any
+		if err := yaml.Unmarshal(b, &v); err != nil {
+			return nil, fmt.Errorf("failed to decode yaml: %w", err)
+		}
+
+		b, err = json.Marshal(v)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert yaml: %w", err)
+		}
+	default:
+		return nil, errUnsupportedFile
+	}
+
+	if trimmed := strings.TrimSpace(string(b)); strings.HasPrefix(trimmed, "[") {
+		courses := make([]api.Course, 0)
+		if err := json.Unmarshal(b, &courses); err != nil {
+			return nil, fmt.Errorf("failed to decode courses: %w", err)
+		}
+
+		return courses, nil
+	}
+
+	course := new(api.Course)
+	if err := json.Unmarshal(b, course); err != nil {
+		return nil, fmt.Errorf("failed to decode course: %w", err)
+	}
+
+	return []api.Course{*course}, nil
+}
@@ -0,0 +1,136 @@
+package golfdata
+
+import (
+	"context"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+)
+
+func writeCourseFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+	}
+
+	return dir
+}
+
+func TestNewFileClient(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   map[string]string
+		wantIds []int64
+		wantErr bool
+	}{
+		{
+			name: "json and yaml files",
+			files: map[string]string{
+				"oak.json": `{"id": 2, "name": "Oak Park", "details": [{"id": 20, "marker": "White", "holes": []}]}`,
+				"pine.yaml": `
+- id: 1
+  name: Pine Valley
+  details:
+    - id: 10
+      marker: Blue
+      par_total: 72
+      holes:
+        - number: 1
+          par: 4
+          stroke_index: 7
+- id: 3
+  name: Pine Hills
+`,
+				"README.md": "not a course",
+			},
+			wantIds: []int64{1, 2, 3},
+		},
+		{
+			name: "duplicate ids",
+			files: map[string]string{
+				"a.json": `{"id": 1, "name": "A"}`,
+				"b.json": `{"id": 1, "name": "B"}`,
+			},
+			wantErr: true,
+		},
+		{
+			name: "missing id",
+			files: map[string]string{
+				"a.yml": `name: A`,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid json",
+			files: map[string]string{
+				"a.json": `{"id": 1,`,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewFileClient(writeCourseFiles(t, tt.files))
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+
+			courses, err := c.GetCourses(context.Background(), "")
+			require.NoError(t, err)
+			require.Equal(t, int64(len(tt.wantIds)), courses.Total)
+
+			ids := make([]int64, 0)
+			for _, course := range courses.Courses {
+				ids = append(ids, course.Id)
+			}
+			require.Equal(t, tt.wantIds, ids)
+		})
+	}
+}
+
+func TestFileClient_Handlers(t *testing.T) {
+	fc, err := NewFileClient(writeCourseFiles(t, map[string]string{
+		"courses.yaml": `
+- id: 1
+  name: Pine Valley
+  details:
+    - id: 10
+      marker: Blue
+      par_total: 72
+- id: 2
+  name: Oak Park
+`,
+	}))
+	require.NoError(t, err)
+
+	r := mux.NewRouter()
+	RegisterHandlers(r, fc)
+	srv := httptest.NewServer(r)
+	t.Cleanup(srv.Close)
+
+	c := NewClient(srv.URL, WithMaxRetries(0))
+
+	courses, err := c.GetCourses(context.Background(), "pine")
+	require.NoError(t, err)
+	require.Len(t, courses.Courses, 1)
+	require.Equal(t, "Pine Valley", courses.Courses[0].Name)
+
+	course, err := c.GetCourse(context.Background(), 1)
+	require.NoError(t, err)
+	require.Len(t, course.Details, 1)
+	require.Equal(t, "Blue", *course.Details[0].Marker)
+	require.Equal(t, int64(72), *course.Details[0].ParTotal)
+
+	_, err = c.GetCourse(context.Background(), 3)
+	require.ErrorIs(t, err, ErrNotFound)
+}
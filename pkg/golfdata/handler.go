@@ -0,0 +1,65 @@
+package golfdata
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/logging"
+	"github.com/Jacobbrewer1/uhttp"
+	"github.com/gorilla/mux"
+)
+
+// RegisterHandlers registers the golf data service routes on the router, serving the courses from the client. This
+// allows the courses from a file client to be served to other instances of the service.
+func RegisterHandlers(r *mux.Router, c Client) {
+	h := &handler{
+		c: c,
+	}
+
+	r.HandleFunc("/courses", h.getCourses).Methods(http.MethodGet)
+	r.HandleFunc("/courses/{course_id}", h.getCourse).Methods(http.MethodGet)
+}
+
+type handler struct {
+	// c is the client that the courses are served from.
+	c Client
+}
+
+func (h *handler) getCourses(w http.ResponseWriter, r *http.Request) {
+	courses, err := h.c.GetCourses(r.Context(), r.URL.Query().Get("name"))
+	if err != nil {
+		slog.Error("Error getting courses", slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "failed to get courses", err)
+		return
+	}
+
+	err = uhttp.Encode(w, http.StatusOK, courses)
+	if err != nil {
+		slog.Error("Error encoding courses", slog.String(logging.KeyError, err.Error()))
+	}
+}
+
+func (h *handler) getCourse(w http.ResponseWriter, r *http.Request) {
+	courseId, err := strconv.Atoi(mux.Vars(r)["course_id"])
+	if err != nil {
+		uhttp.SendErrorMessageWithStatus(w, http.StatusBadRequest, "invalid course id", err)
+		return
+	}
+
+	course, err := h.c.GetCourse(r.Context(), courseId)
+	if errors.Is(err, ErrNotFound) {
+		uhttp.SendMessageWithStatus(w, http.StatusNotFound, "course not found")
+		return
+	} else if err != nil {
+		slog.Error("Error getting course", slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "failed to get course", err)
+		return
+	}
+
+	err = uhttp.Encode(w, http.StatusOK, course)
+	if err != nil {
+		slog.Error("Error encoding course", slog.String(logging.KeyError, err.Error()))
+	}
+}
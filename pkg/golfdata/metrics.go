@@ -0,0 +1,73 @@
+package golfdata
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	// statusError is the status label used when no response was received.
+	statusError = "error"
+
+	reasonRequest     = "request"
+	reasonStatus      = "status"
+	reasonDecode      = "decode"
+	reasonCircuitOpen = "circuit_open"
+
+	cacheResultHit   = "hit"
+	cacheResultMiss  = "miss"
+	cacheResultStale = "stale"
+)
+
+var (
+	// requestLatency is the duration of requests to the golf data service.
+	requestLatency = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name: "golfdata_request_duration_seconds",
+			Help: "Duration of requests to the golf data service",
+		},
+		[]string{"operation", "status_code"},
+	)
+
+	// requestErrors is the number of failed requests to the golf data service.
+	requestErrors = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "golfdata_request_errors_total",
+			Help: "Total number of failed requests to the golf data service",
+		},
+		[]string{"operation", "reason"},
+	)
+
+	// circuitState is the state of the golf data circuit breaker, 0 is closed and 1 is open.
+	circuitState = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "golfdata_circuit_breaker_state",
+			Help: "State of the golf data circuit breaker, 0 is closed and 1 is open",
+		},
+	)
+
+	// cacheRequests is the number of golf data cache lookups by result.
+	cacheRequests = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "golfdata_cache_requests_total",
+			Help: "Total number of golf data cache lookups by result",
+		},
+		[]string{"operation", "result"},
+	)
+
+	// cacheEntries is the number of responses held in the golf data cache.
+	cacheEntries = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "golfdata_cache_entries",
+			Help: "Number of responses held in the golf data cache",
+		},
+	)
+
+	// cacheEvictions is the number of responses evicted from the golf data cache.
+	cacheEvictions = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "golfdata_cache_evictions_total",
+			Help: "Total number of responses evicted from the golf data cache",
+		},
+	)
+)
@@ -0,0 +1,48 @@
+package golfdata
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/models"
+)
+
+type databaseStore struct {
+	db models.DB
+}
+
+// NewDatabaseStore creates a cache store backed by the golf_data_cache table.
+func NewDatabaseStore(db models.DB) Store {
+	return &databaseStore{
+		db: db,
+	}
+}
+
+func (s *databaseStore) Get(ctx context.Context, key string) (*CacheEntry, error) {
+	m, err := models.GolfDataCacheByCacheKey(ctx, s.db, key)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, nil
+		default:
+			return nil, err
+		}
+	}
+
+	return &CacheEntry{
+		Key:       m.CacheKey,
+		Value:     []byte(m.Value),
+		FetchedAt: m.FetchedAt,
+	}, nil
+}
+
+func (s *databaseStore) Set(ctx context.Context, entry *CacheEntry) error {
+	m := &models.GolfDataCache{
+		CacheKey:  entry.Key,
+		Value:     string(entry.Value),
+		FetchedAt: entry.FetchedAt,
+	}
+
+	return m.InsertWithUpdate(ctx, s.db)
+}
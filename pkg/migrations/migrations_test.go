@@ -0,0 +1,125 @@
+package migrations
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    fstest.MapFS
+		versions []int
+		wantErr  bool
+	}{
+		{
+			name: "ordered by version",
+			files: fstest.MapFS{
+				"0010_add_index.up.sql":   {Data: []byte("create index a on b (c);")},
+				"0002_add_table.up.sql":   {Data: []byte("create table b (c int);")},
+				"0002_add_table.down.sql": {Data: []byte("drop table b;")},
+				"README.md":               {Data: []byte("not a migration")},
+			},
+			versions: []int{2, 10},
+		},
+		{
+			name: "missing up file",
+			files: fstest.MapFS{
+				"0001_add_table.down.sql": {Data: []byte("drop table b;")},
+			},
+			wantErr: true,
+		},
+		{
+			name: "duplicate version",
+			files: fstest.MapFS{
+				"0001_add_table.up.sql": {Data: []byte("create table b (c int);")},
+				"0001_add_index.up.sql": {Data: []byte("create index a on b (c);")},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid file name",
+			files: fstest.MapFS{
+				"add_table.sql": {Data: []byte("create table b (c int);")},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Load(tt.files)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			versions := make([]int, len(got))
+			for i, m := range got {
+				versions[i] = m.Version
+				require.Len(t, m.Checksum, 64)
+			}
+			require.Equal(t, tt.versions, versions)
+		})
+	}
+}
+
+func TestAll(t *testing.T) {
+	got, err := All()
+	require.NoError(t, err)
+	require.NotEmpty(t, got)
+
+	for _, m := range got {
+		_, err := splitStatements(m.Up)
+		require.NoError(t, err, m.String())
+
+		_, err = splitStatements(m.Down)
+		require.NoError(t, err, m.String())
+	}
+
+	// Reverting the baseline would drop every table, and the course catalogue cannot be split back into rounds.
+	require.Equal(t, "0001_baseline_schema", got[0].String())
+	require.Empty(t, got[0].Down)
+	require.Equal(t, "0002_course_catalogue", got[1].String())
+	require.Empty(t, got[1].Down)
+}
+
+func TestSplitStatements(t *testing.T) {
+	tests := []struct {
+		name    string
+		sql     string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "multiple statements",
+			sql:  "-- a comment\ncreate table a\n(\n    id int\n);\n\ncreate index b on a (id);\n",
+			want: []string{"create table a\n(\n    id int\n);", "create index b on a (id);"},
+		},
+		{
+			name: "empty",
+			sql:  "-- nothing to do\n",
+			want: []string{},
+		},
+		{
+			name:    "missing semicolon",
+			sql:     "create table a (id int)",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := splitStatements(tt.sql)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,354 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"time"
+)
+
+const (
+	// defaultLockTimeout is the default time to wait for another run of the migrations to finish.
+	defaultLockTimeout = 30 * time.Second
+
+	// lockName is the name of the database lock held while migrations run.
+	lockName = "rounder_schema_migration"
+
+	// createMigrationTable creates the table that records the applied migrations.
+	createMigrationTable = `
+		create table if not exists schema_migration
+		(
+			version    int          not null
+				primary key,
+			name       varchar(255) not null,
+			checksum   char(64)     not null,
+			applied_at datetime     not null
+		)
+	`
+)
+
+var (
+	// ErrLocked is returned when another run of the migrations holds the lock for longer than the lock timeout.
+	ErrLocked = errors.New("migrations are locked by another run")
+
+	// ErrChecksumMismatch is returned when an applied migration has changed since it was applied.
+	ErrChecksumMismatch = errors.New("applied migration has changed")
+
+	// ErrUnknownMigration is returned when the database has a migration applied that is not known, such as when it was
+	// migrated by a newer version of the application.
+	ErrUnknownMigration = errors.New("applied migration is not known")
+
+	// ErrIrreversible is returned when a migration that has no down file is reverted.
+	ErrIrreversible = errors.New("migration cannot be reverted")
+)
+
+// MigrationStatus is the state of a migration in the database.
+type MigrationStatus struct {
+	// Version is the version of the migration.
+	Version int
+
+	// Name is the name of the migration.
+	Name string
+
+	// AppliedAt is when the migration was applied, or nil if it has not been applied.
+	AppliedAt *time.Time
+
+	// Known is false if the migration was applied but is not one of the known migrations.
+	Known bool
+
+	// ChecksumMismatch is true if the migration changed after it was applied.
+	ChecksumMismatch bool
+}
+
+// appliedMigration is a row of the schema_migration table.
+type appliedMigration struct {
+	version   int
+	name      string
+	checksum  string
+	appliedAt time.Time
+}
+
+// Migrator applies and reverts migrations. Only one run can change the schema at a time, which is enforced with a
+// named database lock.
+type Migrator struct {
+	db         *sql.DB
+	migrations []*Migration
+
+	// lockTimeout is the time to wait for another run of the migrations to finish.
+	lockTimeout time.Duration
+
+	// now is the clock used to record when migrations are applied.
+	now func() time.Time
+}
+
+// MigratorOption is a function that configures the migrator.
+type MigratorOption func(m *Migrator)
+
+// WithLockTimeout sets the time to wait for another run of the migrations to finish.
+func WithLockTimeout(timeout time.Duration) MigratorOption {
+	return func(m *Migrator) {
+		m.lockTimeout = timeout
+	}
+}
+
+// NewMigrator creates a migrator of the given migrations, which must be ordered by version.
+func NewMigrator(db *sql.DB, migrations []*Migration, opts ...MigratorOption) *Migrator {
+	m := &Migrator{
+		db:          db,
+		migrations:  migrations,
+		lockTimeout: defaultLockTimeout,
+		now:         time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
+}
+
+// Up applies the migrations that have not been applied, in order of version. At most steps migrations are applied,
+// or all of them if steps is not positive. It returns the migrations that were applied.
+func (m *Migrator) Up(ctx context.Context, steps int) ([]*Migration, error) {
+	applied := make([]*Migration, 0)
+
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		done, err := m.verifiedApplied(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			if steps > 0 && len(applied) >= steps {
+				break
+			} else if _, ok := done[migration.Version]; ok {
+				continue
+			}
+
+			slog.Info("Applying migration", slog.String("migration", migration.String()))
+
+			if err := execStatements(ctx, conn, migration.Up); err != nil {
+				return fmt.Errorf("error applying migration %s: %w", migration, err)
+			}
+
+			_, err = conn.ExecContext(
+				ctx,
+				`INSERT INTO schema_migration (version, name, checksum, applied_at) VALUES (?, ?, ?, ?)`,
+				migration.Version, migration.Name, migration.Checksum, m.now().UTC(),
+			)
+			if err != nil {
+				return fmt.Errorf("error recording migration %s: %w", migration, err)
+			}
+
+			applied = append(applied, migration)
+		}
+
+		return nil
+	})
+
+	return applied, err
+}
+
+// Down reverts the most recently applied migrations, in reverse order of version. At most steps migrations are
+// reverted, or one if steps is not positive. It returns the migrations that were reverted.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]*Migration, error) {
+	if steps <= 0 {
+		steps = 1
+	}
+
+	reverted := make([]*Migration, 0)
+
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		done, err := m.verifiedApplied(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for i := len(m.migrations) - 1; i >= 0 && len(reverted) < steps; i-- {
+			migration := m.migrations[i]
+			if _, ok := done[migration.Version]; !ok {
+				continue
+			} else if migration.Down == "" {
+				return fmt.Errorf("%w: %s", ErrIrreversible, migration)
+			}
+
+			slog.Info("Reverting migration", slog.String("migration", migration.String()))
+
+			if err := execStatements(ctx, conn, migration.Down); err != nil {
+				return fmt.Errorf("error reverting migration %s: %w", migration, err)
+			}
+
+			_, err = conn.ExecContext(ctx, `DELETE FROM schema_migration WHERE version = ?`, migration.Version)
+			if err != nil {
+				return fmt.Errorf("error removing migration record %s: %w", migration, err)
+			}
+
+			reverted = append(reverted, migration)
+		}
+
+		return nil
+	})
+
+	return reverted, err
+}
+
+// Status gets the state of every known migration and of any applied migration that is not known, ordered by version.
+func (m *Migrator) Status(ctx context.Context) ([]*MigrationStatus, error) {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting connection: %w", err)
+	}
+	defer conn.Close()
+
+	done, err := m.applied(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]*MigrationStatus, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := &MigrationStatus{
+			Version: migration.Version,
+			Name:    migration.Name,
+			Known:   true,
+		}
+
+		if a, ok := done[migration.Version]; ok {
+			status.AppliedAt = &a.appliedAt
+			status.ChecksumMismatch = a.checksum != migration.Checksum
+			delete(done, migration.Version)
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	for _, a := range done {
+		statuses = append(statuses, &MigrationStatus{
+			Version:   a.version,
+			Name:      a.name,
+			AppliedAt: &a.appliedAt,
+		})
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Version < statuses[j].Version
+	})
+
+	return statuses, nil
+}
+
+// withLock runs the function on a single connection while holding the migration lock, creating the migration table
+// first if it does not exist.
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) (err error) {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("error getting connection: %w", err)
+	}
+	defer conn.Close()
+
+	// The lock belongs to the connection, so it is released even if the process dies and the connection is closed.
+	var locked sql.NullInt64
+	err = conn.QueryRowContext(ctx, `SELECT GET_LOCK(?, ?)`, lockName, int(m.lockTimeout.Seconds())).Scan(&locked)
+	if err != nil {
+		return fmt.Errorf("error acquiring migration lock: %w", err)
+	} else if !locked.Valid || locked.Int64 != 1 {
+		return ErrLocked
+	}
+
+	defer func() {
+		// Use a fresh context, so that the lock is released even if the migration was cancelled.
+		_, releaseErr := conn.ExecContext(context.Background(), `SELECT RELEASE_LOCK(?)`, lockName)
+		if releaseErr != nil && err == nil {
+			err = fmt.Errorf("error releasing migration lock: %w", releaseErr)
+		}
+	}()
+
+	if _, err := conn.ExecContext(ctx, createMigrationTable); err != nil {
+		return fmt.Errorf("error creating migration table: %w", err)
+	}
+
+	return fn(conn)
+}
+
+// verifiedApplied gets the applied migrations by version, returning an error if any of them are not known or have
+// changed since they were applied.
+func (m *Migrator) verifiedApplied(ctx context.Context, conn *sql.Conn) (map[int]*appliedMigration, error) {
+	done, err := m.applied(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	known := make(map[int]*Migration, len(m.migrations))
+	for _, migration := range m.migrations {
+		known[migration.Version] = migration
+	}
+
+	for version, a := range done {
+		migration, ok := known[version]
+		if !ok {
+			return nil, fmt.Errorf("%w: %04d_%s", ErrUnknownMigration, a.version, a.name)
+		} else if migration.Checksum != a.checksum {
+			return nil, fmt.Errorf("%w: %s", ErrChecksumMismatch, migration)
+		}
+	}
+
+	return done, nil
+}
+
+// applied gets the applied migrations by version. No migrations have been applied if the migration table does not
+// exist yet.
+func (m *Migrator) applied(ctx context.Context, conn *sql.Conn) (map[int]*appliedMigration, error) {
+	var exists int
+	err := conn.QueryRowContext(
+		ctx,
+		`SELECT COUNT(*) FROM information_schema.tables WHERE table_schema = DATABASE() AND table_name = 'schema_migration'`,
+	).Scan(&exists)
+	if err != nil {
+		return nil, fmt.Errorf("error checking migration table: %w", err)
+	}
+
+	done := make(map[int]*appliedMigration)
+	if exists == 0 {
+		return done, nil
+	}
+
+	rows, err := conn.QueryContext(ctx, `SELECT version, name, checksum, applied_at FROM schema_migration`)
+	if err != nil {
+		return nil, fmt.Errorf("error getting applied migrations: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		a := new(appliedMigration)
+		if err := rows.Scan(&a.version, &a.name, &a.checksum, &a.appliedAt); err != nil {
+			return nil, fmt.Errorf("error scanning applied migration: %w", err)
+		}
+		done[a.version] = a
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error getting applied migrations: %w", err)
+	}
+
+	return done, nil
+}
+
+// execStatements executes the statements of the query one at a time. MySQL commits schema changes implicitly, so a
+// migration that fails part way is not rolled back and must be written to be safe to run again.
+func execStatements(ctx context.Context, conn *sql.Conn, query string) error {
+	statements, err := splitStatements(query)
+	if err != nil {
+		return err
+	}
+
+	for _, statement := range statements {
+		if _, err := conn.ExecContext(ctx, statement); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,107 @@
+-- Moves the courses from one row per round into a catalogue that rounds share. A round now references the tee set that
+-- it was played on, and hole stats reference their round directly.
+--
+-- The courses of existing rounds were stored without their golf data ID, so they cannot be matched to the courses that
+-- are imported from the golf data service. Each of them becomes a custom course of the player of its round, with the
+-- ID of each tee set as its external ID.
+--
+-- The round_id column of course is only dropped at the end, so that the migration can be run again if it fails part
+-- way. There is no down file, as the courses of each round cannot be split out again once they are shared.
+
+alter table course
+    add column if not exists external_id int null after id,
+    add column if not exists user_id int null after external_id;
+
+alter table course_details
+    add column if not exists external_id int null after course_id,
+    add column if not exists version int null after external_id;
+
+alter table round
+    add column if not exists course_details_id int null after user_id;
+
+alter table hole_stats
+    add column if not exists round_id int null after id;
+
+update round r
+    inner join course c on c.round_id = r.id
+    inner join course_details cd on cd.course_id = c.id
+set r.course_details_id = cd.id
+where r.course_details_id is null;
+
+update hole_stats hs
+    inner join hole h on h.id = hs.hole_id
+    inner join course_details cd on cd.id = h.course_details_id
+    inner join course c on c.id = cd.course_id
+set hs.round_id = c.round_id
+where hs.round_id is null;
+
+update course c
+    inner join round r on r.id = c.round_id
+set c.user_id = r.user_id
+where c.external_id is null
+  and c.user_id is null;
+
+update course_details
+set external_id = id,
+    version     = 1
+where external_id is null;
+
+-- Rounds whose course failed to import have no tee set and could never be read, so they are removed along with their
+-- stats and any course that was imported without tee sets.
+delete from round_hit_stats
+where round_stats_id in (select rs.id
+                         from round_stats rs
+                                  inner join round r on r.id = rs.round_id
+                         where r.course_details_id is null);
+
+delete from round_stats
+where round_id in (select id from round where course_details_id is null);
+
+delete from course
+where round_id in (select id from round where course_details_id is null);
+
+delete from round
+where course_details_id is null;
+
+-- Only the latest stats of a hole are kept if a hole was given stats more than once in a round.
+delete from hole_stats
+where id in (select id
+             from (select older.id
+                   from hole_stats older
+                            inner join hole_stats newer
+                                       on newer.round_id = older.round_id
+                                           and newer.hole_id = older.hole_id
+                                           and newer.id > older.id) duplicate);
+
+alter table course_details
+    modify external_id int not null,
+    modify version int not null;
+
+alter table round
+    modify course_details_id int not null;
+
+alter table hole_stats
+    modify round_id int not null;
+
+alter table course
+    add unique index if not exists course_external_id_uindex (external_id),
+    add constraint course_user_id_fk
+        foreign key if not exists (user_id) references user (id);
+
+alter table course_details
+    add unique index if not exists course_details_course_id_external_id_version_uindex (course_id, external_id, version);
+
+alter table round
+    add constraint round_course_details_id_fk
+        foreign key if not exists (course_details_id) references course_details (id);
+
+alter table hole_stats
+    add unique index if not exists hole_stats_round_id_hole_id_uindex (round_id, hole_id),
+    add constraint hole_stats_round_id_fk
+        foreign key if not exists (round_id) references round (id);
+
+alter table course
+    drop foreign key if exists course_round_id_fk;
+
+alter table course
+    drop column if exists round_id;
@@ -0,0 +1 @@
+drop table if exists golf_data_cache;
@@ -0,0 +1,9 @@
+-- Adds the table that persists the golf data cache.
+
+create table if not exists golf_data_cache
+(
+    cache_key  varchar(255) not null
+        primary key,
+    value      mediumtext   not null,
+    fetched_at datetime     not null
+);
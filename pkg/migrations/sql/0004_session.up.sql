@@ -0,0 +1,18 @@
+-- Adds the sessions that refresh tokens belong to.
+
+create table if not exists session
+(
+    id                 int auto_increment
+        primary key,
+    user_id            int          not null,
+    refresh_token_hash char(64)     not null,
+    user_agent         varchar(255) null,
+    created_at         datetime     not null,
+    last_used_at       datetime     not null,
+    expires_at         datetime     not null,
+    revoked_at         datetime     null,
+    constraint session_refresh_token_hash_uindex
+        unique (refresh_token_hash),
+    constraint session_user_id_fk
+        foreign key (user_id) references user (id)
+);
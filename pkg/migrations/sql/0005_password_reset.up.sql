@@ -0,0 +1,16 @@
+-- Adds the tokens of password resets.
+
+create table if not exists password_reset
+(
+    id         int auto_increment
+        primary key,
+    user_id    int      not null,
+    token_hash char(64) not null,
+    created_at datetime not null,
+    expires_at datetime not null,
+    used_at    datetime null,
+    constraint password_reset_token_hash_uindex
+        unique (token_hash),
+    constraint password_reset_user_id_fk
+        foreign key (user_id) references user (id)
+);
@@ -0,0 +1,9 @@
+-- Adds the table that holds rate limit buckets when they are stored in the database.
+
+create table if not exists rate_limit_bucket
+(
+    bucket_key varchar(255) not null
+        primary key,
+    tokens     double       not null,
+    updated_at datetime(6)  not null
+);
@@ -0,0 +1,19 @@
+-- Adds scoped API keys.
+
+create table if not exists api_key
+(
+    id           int auto_increment
+        primary key,
+    user_id      int          not null,
+    name         varchar(100) not null,
+    key_hash     char(64)     not null,
+    scopes       varchar(255) not null,
+    created_at   datetime     not null,
+    last_used_at datetime     null,
+    expires_at   datetime     null,
+    revoked_at   datetime     null,
+    constraint api_key_key_hash_uindex
+        unique (key_hash),
+    constraint api_key_user_id_fk
+        foreign key (user_id) references user (id)
+);
@@ -0,0 +1,33 @@
+-- Adds the state of OIDC logins and the identities that are linked to users.
+
+create table if not exists oidc_login
+(
+    id            int auto_increment
+        primary key,
+    state_hash    char(64)     not null,
+    provider      varchar(50)  not null,
+    code_verifier varchar(128) not null,
+    nonce         varchar(64)  not null,
+    user_id       int          null,
+    created_at    datetime     not null,
+    expires_at    datetime     not null,
+    constraint oidc_login_state_hash_uindex
+        unique (state_hash)
+);
+
+create table if not exists user_identity
+(
+    id            int auto_increment
+        primary key,
+    user_id       int          not null,
+    provider      varchar(50)  not null,
+    issuer        varchar(255) not null,
+    subject       varchar(255) not null,
+    email         varchar(255) null,
+    created_at    datetime     not null,
+    last_login_at datetime     null,
+    constraint user_identity_issuer_subject_uindex
+        unique (issuer, subject),
+    constraint user_identity_user_id_fk
+        foreign key (user_id) references user (id)
+);
@@ -0,0 +1,4 @@
+drop table if exists coach_grant;
+
+alter table user
+    drop column if exists role;
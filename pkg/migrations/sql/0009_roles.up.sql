@@ -0,0 +1,18 @@
+-- Adds the roles of users and the coaches that players have granted access to their data. Existing users become
+-- players.
+
+alter table user
+    add column if not exists role varchar(20) default 'player' not null after last_login;
+
+create table if not exists coach_grant
+(
+    id         int auto_increment
+        primary key,
+    player_id  int      not null,
+    coach_id   int      not null,
+    created_at datetime not null,
+    constraint coach_grant_player_id_coach_id_uindex
+        unique (player_id, coach_id),
+    constraint coach_grant_player_id_fk
+        foreign key (player_id) references user (id)
+);
@@ -0,0 +1,20 @@
+-- Adds the audit log.
+
+create table if not exists audit_event
+(
+    id          int auto_increment
+        primary key,
+    actor_id    int          null,
+    action      varchar(50)  not null,
+    target_type varchar(50)  null,
+    target_id   int          null,
+    ip_address  varchar(45)  null,
+    details     text         null,
+    created_at  datetime     not null
+);
+
+create index if not exists audit_event_actor_id_index
+    on audit_event (actor_id);
+
+create index if not exists audit_event_created_at_index
+    on audit_event (created_at);
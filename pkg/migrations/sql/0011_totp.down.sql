@@ -0,0 +1,2 @@
+drop table if exists recovery_code;
+drop table if exists user_totp;
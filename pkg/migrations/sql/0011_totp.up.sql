@@ -0,0 +1,29 @@
+-- Adds TOTP two-factor authentication and its recovery codes.
+
+create table if not exists user_totp
+(
+    id             int auto_increment
+        primary key,
+    user_id        int          not null,
+    secret         varchar(255) not null,
+    created_at     datetime     not null,
+    confirmed_at   datetime     null,
+    last_used_step bigint       null,
+    constraint user_totp_user_id_uindex
+        unique (user_id),
+    constraint user_totp_user_id_fk
+        foreign key (user_id) references user (id)
+);
+
+create table if not exists recovery_code
+(
+    id        int auto_increment
+        primary key,
+    user_id   int      not null,
+    code_hash char(64) not null,
+    used_at   datetime null,
+    constraint recovery_code_code_hash_uindex
+        unique (code_hash),
+    constraint recovery_code_user_id_fk
+        foreign key (user_id) references user (id)
+);
@@ -0,0 +1,7 @@
+drop table if exists user_preference;
+
+alter table user
+    drop column if exists home_course_id,
+    drop column if exists date_of_birth,
+    drop column if exists gender,
+    drop column if exists email;
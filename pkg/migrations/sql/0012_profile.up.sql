@@ -0,0 +1,21 @@
+-- Adds the profile of users and their preferences.
+
+alter table user
+    add column if not exists email varchar(255) null after role,
+    add column if not exists gender varchar(20) null after email,
+    add column if not exists date_of_birth date null after gender,
+    add column if not exists home_course_id int null after date_of_birth;
+
+create table if not exists user_preference
+(
+    id             int auto_increment
+        primary key,
+    user_id        int                   not null,
+    distance_unit  varchar(10)  default 'yards' not null,
+    default_marker varchar(255)          null,
+    timezone       varchar(64)  default 'UTC' not null,
+    constraint user_preference_user_id_uindex
+        unique (user_id),
+    constraint user_preference_user_id_fk
+        foreign key (user_id) references user (id)
+);
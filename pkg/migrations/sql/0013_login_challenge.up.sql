@@ -0,0 +1,16 @@
+-- Adds the challenges of logins that still need a two-factor code after the first factor was checked.
+
+create table if not exists login_challenge
+(
+    id         int auto_increment
+        primary key,
+    user_id    int          not null,
+    token_hash char(64)     not null,
+    details    varchar(255) not null,
+    created_at datetime     not null,
+    expires_at datetime     not null,
+    constraint login_challenge_token_hash_uindex
+        unique (token_hash),
+    constraint login_challenge_user_id_fk
+        foreign key (user_id) references user (id)
+);
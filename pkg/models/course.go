@@ -4,32 +4,34 @@
 package models
 
 import (
-	"github.com/prometheus/client_golang/prometheus"
+	"context"
+
+	usql "github.com/Jacobbrewer1/golf-stats-tracker/pkg/utils/sql"
 )
 
 // Course represents a row from 'course'.
 type Course struct {
-	Id      int    `db:"id,autoinc,pk"`
-	RoundId int    `db:"round_id"`
-	Name    string `db:"name"`
+	Id         int            `db:"id,autoinc,pk"`
+	ExternalId usql.NullInt64 `db:"external_id"`
+	UserId     usql.NullInt64 `db:"user_id"`
+	Name       string         `db:"name"`
 }
 
 // CourseColumns is the sorted column names for the type Course
-var CourseColumns = []string{"Id", "Name", "RoundId"}
+var CourseColumns = []string{"ExternalId", "Id", "Name", "UserId"}
 
 // Insert inserts the Course to the database.
-func (m *Course) Insert(db DB) error {
-	t := prometheus.NewTimer(DatabaseLatency.WithLabelValues("insert_Course"))
-	defer t.ObserveDuration()
+func (m *Course) Insert(ctx context.Context, db DB) error {
+	ctx = WithQueryName(ctx, "insert_Course")
 
 	const sqlstr = "INSERT INTO course (" +
-		"`round_id`, `name`" +
+		"`external_id`, `user_id`, `name`" +
 		") VALUES (" +
-		"?, ?" +
+		"?, ?, ?" +
 		")"
 
-	DBLog(sqlstr, m.RoundId, m.Name)
-	res, err := db.Exec(sqlstr, m.RoundId, m.Name)
+	DBLog(sqlstr, m.ExternalId, m.UserId, m.Name)
+	res, err := db.ExecContext(ctx, sqlstr, m.ExternalId, m.UserId, m.Name)
 	if err != nil {
 		return err
 	}
@@ -43,28 +45,27 @@ func (m *Course) Insert(db DB) error {
 	return nil
 }
 
-func InsertManyCourses(db DB, ms ...*Course) error {
+func InsertManyCourses(ctx context.Context, db DB, ms ...*Course) error {
 	if len(ms) == 0 {
 		return nil
 	}
 
-	t := prometheus.NewTimer(DatabaseLatency.WithLabelValues("insert_many_Course"))
-	defer t.ObserveDuration()
+	ctx = WithQueryName(ctx, "insert_many_Course")
 
 	var sqlstr = "INSERT INTO course (" +
-		"`round_id`,`name`" +
+		"`external_id`,`user_id`,`name`" +
 		") VALUES"
 
 	var args []interface{}
 	for _, m := range ms {
 		sqlstr += " (" +
-			"?,?" +
+			"?,?,?" +
 			"),"
-		args = append(args, m.RoundId, m.Name)
+		args = append(args, m.ExternalId, m.UserId, m.Name)
 	}
 
 	DBLog(sqlstr, args...)
-	res, err := db.Exec(sqlstr, args...)
+	res, err := db.ExecContext(ctx, sqlstr, args...)
 	if err != nil {
 		return err
 	}
@@ -87,16 +88,15 @@ func (m *Course) IsPrimaryKeySet() bool {
 }
 
 // Update updates the Course in the database.
-func (m *Course) Update(db DB) error {
-	t := prometheus.NewTimer(DatabaseLatency.WithLabelValues("update_Course"))
-	defer t.ObserveDuration()
+func (m *Course) Update(ctx context.Context, db DB) error {
+	ctx = WithQueryName(ctx, "update_Course")
 
 	const sqlstr = "UPDATE course " +
-		"SET `round_id` = ?, `name` = ? " +
+		"SET `external_id` = ?, `user_id` = ?, `name` = ? " +
 		"WHERE `id` = ?"
 
-	DBLog(sqlstr, m.RoundId, m.Name, m.Id)
-	res, err := db.Exec(sqlstr, m.RoundId, m.Name, m.Id)
+	DBLog(sqlstr, m.ExternalId, m.UserId, m.Name, m.Id)
+	res, err := db.ExecContext(ctx, sqlstr, m.ExternalId, m.UserId, m.Name, m.Id)
 	if err != nil {
 		return err
 	}
@@ -113,19 +113,18 @@ func (m *Course) Update(db DB) error {
 
 // InsertWithUpdate inserts the Course to the database, and tries to update
 // on unique constraint violations.
-func (m *Course) InsertWithUpdate(db DB) error {
-	t := prometheus.NewTimer(DatabaseLatency.WithLabelValues("insert_update_Course"))
-	defer t.ObserveDuration()
+func (m *Course) InsertWithUpdate(ctx context.Context, db DB) error {
+	ctx = WithQueryName(ctx, "insert_update_Course")
 
 	const sqlstr = "INSERT INTO course (" +
-		"`round_id`, `name`" +
+		"`external_id`, `user_id`, `name`" +
 		") VALUES (" +
-		"?, ?" +
+		"?, ?, ?" +
 		") ON DUPLICATE KEY UPDATE " +
-		"`round_id` = VALUES(`round_id`), `name` = VALUES(`name`)"
+		"`external_id` = VALUES(`external_id`), `user_id` = VALUES(`user_id`), `name` = VALUES(`name`)"
 
-	DBLog(sqlstr, m.RoundId, m.Name)
-	res, err := db.Exec(sqlstr, m.RoundId, m.Name)
+	DBLog(sqlstr, m.ExternalId, m.UserId, m.Name)
+	res, err := db.ExecContext(ctx, sqlstr, m.ExternalId, m.UserId, m.Name)
 	if err != nil {
 		return err
 	}
@@ -140,31 +139,30 @@ func (m *Course) InsertWithUpdate(db DB) error {
 }
 
 // Save saves the Course to the database.
-func (m *Course) Save(db DB) error {
+func (m *Course) Save(ctx context.Context, db DB) error {
 	if m.IsPrimaryKeySet() {
-		return m.Update(db)
+		return m.Update(ctx, db)
 	}
-	return m.Insert(db)
+	return m.Insert(ctx, db)
 }
 
 // SaveOrUpdate saves the Course to the database, but tries to update
 // on unique constraint violations.
-func (m *Course) SaveOrUpdate(db DB) error {
+func (m *Course) SaveOrUpdate(ctx context.Context, db DB) error {
 	if m.IsPrimaryKeySet() {
-		return m.Update(db)
+		return m.Update(ctx, db)
 	}
-	return m.InsertWithUpdate(db)
+	return m.InsertWithUpdate(ctx, db)
 }
 
 // Delete deletes the Course from the database.
-func (m *Course) Delete(db DB) error {
-	t := prometheus.NewTimer(DatabaseLatency.WithLabelValues("delete_Course"))
-	defer t.ObserveDuration()
+func (m *Course) Delete(ctx context.Context, db DB) error {
+	ctx = WithQueryName(ctx, "delete_Course")
 
 	const sqlstr = "DELETE FROM course WHERE `id` = ?"
 
 	DBLog(sqlstr, m.Id)
-	_, err := db.Exec(sqlstr, m.Id)
+	_, err := db.ExecContext(ctx, sqlstr, m.Id)
 
 	return err
 }
@@ -172,17 +170,35 @@ func (m *Course) Delete(db DB) error {
 // CourseById retrieves a row from 'course' as a Course.
 //
 // Generated from primary key.
-func CourseById(db DB, id int) (*Course, error) {
-	t := prometheus.NewTimer(DatabaseLatency.WithLabelValues("insert_Course"))
-	defer t.ObserveDuration()
+func CourseById(ctx context.Context, db DB, id int) (*Course, error) {
+	ctx = WithQueryName(ctx, "insert_Course")
 
-	const sqlstr = "SELECT `id`, `round_id`, `name` " +
+	const sqlstr = "SELECT `id`, `external_id`, `user_id`, `name` " +
 		"FROM course " +
 		"WHERE `id` = ?"
 
 	DBLog(sqlstr, id)
 	var m Course
-	if err := db.Get(&m, sqlstr, id); err != nil {
+	if err := db.GetContext(ctx, &m, sqlstr, id); err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+// CourseByExternalId retrieves a row from 'course' as a *Course.
+//
+// Generated from index 'course_external_id_uindex' of type 'unique'.
+func CourseByExternalId(ctx context.Context, db DB, externalId usql.NullInt64) (*Course, error) {
+	ctx = WithQueryName(ctx, "insert_Course")
+
+	const sqlstr = "SELECT `id`, `external_id`, `user_id`, `name` " +
+		"FROM course " +
+		"WHERE `external_id` = ?"
+
+	DBLog(sqlstr, externalId)
+	var m Course
+	if err := db.GetContext(ctx, &m, sqlstr, externalId); err != nil {
 		return nil, err
 	}
 
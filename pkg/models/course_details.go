@@ -4,14 +4,17 @@
 package models
 
 import (
+	"context"
+
 	usql "github.com/Jacobbrewer1/golf-stats-tracker/pkg/utils/sql"
-	"github.com/prometheus/client_golang/prometheus"
 )
 
 // CourseDetails represents a row from 'course_details'.
 type CourseDetails struct {
 	Id              int             `db:"id,autoinc,pk"`
 	CourseId        int             `db:"course_id"`
+	ExternalId      int             `db:"external_id"`
+	Version         int             `db:"version"`
 	Marker          usql.NullString `db:"marker"`
 	Slope           int             `db:"slope"`
 	CourseRating    float64         `db:"course_rating"`
@@ -27,21 +30,20 @@ type CourseDetails struct {
 }
 
 // CourseDetailsColumns is the sorted column names for the type CourseDetails
-var CourseDetailsColumns = []string{"BackNineMeters", "BackNinePar", "BackNineYards", "CourseId", "CourseRating", "FrontNineMeters", "FrontNinePar", "FrontNineYards", "Id", "Marker", "Slope", "TotalMeters", "TotalPar", "TotalYards"}
+var CourseDetailsColumns = []string{"BackNineMeters", "BackNinePar", "BackNineYards", "CourseId", "CourseRating", "ExternalId", "FrontNineMeters", "FrontNinePar", "FrontNineYards", "Id", "Marker", "Slope", "TotalMeters", "TotalPar", "TotalYards", "Version"}
 
 // Insert inserts the CourseDetails to the database.
-func (m *CourseDetails) Insert(db DB) error {
-	t := prometheus.NewTimer(DatabaseLatency.WithLabelValues("insert_CourseDetails"))
-	defer t.ObserveDuration()
+func (m *CourseDetails) Insert(ctx context.Context, db DB) error {
+	ctx = WithQueryName(ctx, "insert_CourseDetails")
 
 	const sqlstr = "INSERT INTO course_details (" +
-		"`course_id`, `marker`, `slope`, `course_rating`, `front_nine_par`, `back_nine_par`, `total_par`, `front_nine_yards`, `back_nine_yards`, `total_yards`, `front_nine_meters`, `back_nine_meters`, `total_meters`" +
+		"`course_id`, `external_id`, `version`, `marker`, `slope`, `course_rating`, `front_nine_par`, `back_nine_par`, `total_par`, `front_nine_yards`, `back_nine_yards`, `total_yards`, `front_nine_meters`, `back_nine_meters`, `total_meters`" +
 		") VALUES (" +
-		"?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?" +
+		"?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?" +
 		")"
 
-	DBLog(sqlstr, m.CourseId, m.Marker, m.Slope, m.CourseRating, m.FrontNinePar, m.BackNinePar, m.TotalPar, m.FrontNineYards, m.BackNineYards, m.TotalYards, m.FrontNineMeters, m.BackNineMeters, m.TotalMeters)
-	res, err := db.Exec(sqlstr, m.CourseId, m.Marker, m.Slope, m.CourseRating, m.FrontNinePar, m.BackNinePar, m.TotalPar, m.FrontNineYards, m.BackNineYards, m.TotalYards, m.FrontNineMeters, m.BackNineMeters, m.TotalMeters)
+	DBLog(sqlstr, m.CourseId, m.ExternalId, m.Version, m.Marker, m.Slope, m.CourseRating, m.FrontNinePar, m.BackNinePar, m.TotalPar, m.FrontNineYards, m.BackNineYards, m.TotalYards, m.FrontNineMeters, m.BackNineMeters, m.TotalMeters)
+	res, err := db.ExecContext(ctx, sqlstr, m.CourseId, m.ExternalId, m.Version, m.Marker, m.Slope, m.CourseRating, m.FrontNinePar, m.BackNinePar, m.TotalPar, m.FrontNineYards, m.BackNineYards, m.TotalYards, m.FrontNineMeters, m.BackNineMeters, m.TotalMeters)
 	if err != nil {
 		return err
 	}
@@ -55,28 +57,27 @@ func (m *CourseDetails) Insert(db DB) error {
 	return nil
 }
 
-func InsertManyCourseDetailss(db DB, ms ...*CourseDetails) error {
+func InsertManyCourseDetailss(ctx context.Context, db DB, ms ...*CourseDetails) error {
 	if len(ms) == 0 {
 		return nil
 	}
 
-	t := prometheus.NewTimer(DatabaseLatency.WithLabelValues("insert_many_CourseDetails"))
-	defer t.ObserveDuration()
+	ctx = WithQueryName(ctx, "insert_many_CourseDetails")
 
 	var sqlstr = "INSERT INTO course_details (" +
-		"`course_id`,`marker`,`slope`,`course_rating`,`front_nine_par`,`back_nine_par`,`total_par`,`front_nine_yards`,`back_nine_yards`,`total_yards`,`front_nine_meters`,`back_nine_meters`,`total_meters`" +
+		"`course_id`,`external_id`,`version`,`marker`,`slope`,`course_rating`,`front_nine_par`,`back_nine_par`,`total_par`,`front_nine_yards`,`back_nine_yards`,`total_yards`,`front_nine_meters`,`back_nine_meters`,`total_meters`" +
 		") VALUES"
 
 	var args []interface{}
 	for _, m := range ms {
 		sqlstr += " (" +
-			"?,?,?,?,?,?,?,?,?,?,?,?,?" +
+			"?,?,?,?,?,?,?,?,?,?,?,?,?,?,?" +
 			"),"
-		args = append(args, m.CourseId, m.Marker, m.Slope, m.CourseRating, m.FrontNinePar, m.BackNinePar, m.TotalPar, m.FrontNineYards, m.BackNineYards, m.TotalYards, m.FrontNineMeters, m.BackNineMeters, m.TotalMeters)
+		args = append(args, m.CourseId, m.ExternalId, m.Version, m.Marker, m.Slope, m.CourseRating, m.FrontNinePar, m.BackNinePar, m.TotalPar, m.FrontNineYards, m.BackNineYards, m.TotalYards, m.FrontNineMeters, m.BackNineMeters, m.TotalMeters)
 	}
 
 	DBLog(sqlstr, args...)
-	res, err := db.Exec(sqlstr, args...)
+	res, err := db.ExecContext(ctx, sqlstr, args...)
 	if err != nil {
 		return err
 	}
@@ -99,16 +100,15 @@ func (m *CourseDetails) IsPrimaryKeySet() bool {
 }
 
 // Update updates the CourseDetails in the database.
-func (m *CourseDetails) Update(db DB) error {
-	t := prometheus.NewTimer(DatabaseLatency.WithLabelValues("update_CourseDetails"))
-	defer t.ObserveDuration()
+func (m *CourseDetails) Update(ctx context.Context, db DB) error {
+	ctx = WithQueryName(ctx, "update_CourseDetails")
 
 	const sqlstr = "UPDATE course_details " +
-		"SET `course_id` = ?, `marker` = ?, `slope` = ?, `course_rating` = ?, `front_nine_par` = ?, `back_nine_par` = ?, `total_par` = ?, `front_nine_yards` = ?, `back_nine_yards` = ?, `total_yards` = ?, `front_nine_meters` = ?, `back_nine_meters` = ?, `total_meters` = ? " +
+		"SET `course_id` = ?, `external_id` = ?, `version` = ?, `marker` = ?, `slope` = ?, `course_rating` = ?, `front_nine_par` = ?, `back_nine_par` = ?, `total_par` = ?, `front_nine_yards` = ?, `back_nine_yards` = ?, `total_yards` = ?, `front_nine_meters` = ?, `back_nine_meters` = ?, `total_meters` = ? " +
 		"WHERE `id` = ?"
 
-	DBLog(sqlstr, m.CourseId, m.Marker, m.Slope, m.CourseRating, m.FrontNinePar, m.BackNinePar, m.TotalPar, m.FrontNineYards, m.BackNineYards, m.TotalYards, m.FrontNineMeters, m.BackNineMeters, m.TotalMeters, m.Id)
-	res, err := db.Exec(sqlstr, m.CourseId, m.Marker, m.Slope, m.CourseRating, m.FrontNinePar, m.BackNinePar, m.TotalPar, m.FrontNineYards, m.BackNineYards, m.TotalYards, m.FrontNineMeters, m.BackNineMeters, m.TotalMeters, m.Id)
+	DBLog(sqlstr, m.CourseId, m.ExternalId, m.Version, m.Marker, m.Slope, m.CourseRating, m.FrontNinePar, m.BackNinePar, m.TotalPar, m.FrontNineYards, m.BackNineYards, m.TotalYards, m.FrontNineMeters, m.BackNineMeters, m.TotalMeters, m.Id)
+	res, err := db.ExecContext(ctx, sqlstr, m.CourseId, m.ExternalId, m.Version, m.Marker, m.Slope, m.CourseRating, m.FrontNinePar, m.BackNinePar, m.TotalPar, m.FrontNineYards, m.BackNineYards, m.TotalYards, m.FrontNineMeters, m.BackNineMeters, m.TotalMeters, m.Id)
 	if err != nil {
 		return err
 	}
@@ -125,19 +125,18 @@ func (m *CourseDetails) Update(db DB) error {
 
 // InsertWithUpdate inserts the CourseDetails to the database, and tries to update
 // on unique constraint violations.
-func (m *CourseDetails) InsertWithUpdate(db DB) error {
-	t := prometheus.NewTimer(DatabaseLatency.WithLabelValues("insert_update_CourseDetails"))
-	defer t.ObserveDuration()
+func (m *CourseDetails) InsertWithUpdate(ctx context.Context, db DB) error {
+	ctx = WithQueryName(ctx, "insert_update_CourseDetails")
 
 	const sqlstr = "INSERT INTO course_details (" +
-		"`course_id`, `marker`, `slope`, `course_rating`, `front_nine_par`, `back_nine_par`, `total_par`, `front_nine_yards`, `back_nine_yards`, `total_yards`, `front_nine_meters`, `back_nine_meters`, `total_meters`" +
+		"`course_id`, `external_id`, `version`, `marker`, `slope`, `course_rating`, `front_nine_par`, `back_nine_par`, `total_par`, `front_nine_yards`, `back_nine_yards`, `total_yards`, `front_nine_meters`, `back_nine_meters`, `total_meters`" +
 		") VALUES (" +
-		"?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?" +
+		"?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?" +
 		") ON DUPLICATE KEY UPDATE " +
-		"`course_id` = VALUES(`course_id`), `marker` = VALUES(`marker`), `slope` = VALUES(`slope`), `course_rating` = VALUES(`course_rating`), `front_nine_par` = VALUES(`front_nine_par`), `back_nine_par` = VALUES(`back_nine_par`), `total_par` = VALUES(`total_par`), `front_nine_yards` = VALUES(`front_nine_yards`), `back_nine_yards` = VALUES(`back_nine_yards`), `total_yards` = VALUES(`total_yards`), `front_nine_meters` = VALUES(`front_nine_meters`), `back_nine_meters` = VALUES(`back_nine_meters`), `total_meters` = VALUES(`total_meters`)"
+		"`course_id` = VALUES(`course_id`), `external_id` = VALUES(`external_id`), `version` = VALUES(`version`), `marker` = VALUES(`marker`), `slope` = VALUES(`slope`), `course_rating` = VALUES(`course_rating`), `front_nine_par` = VALUES(`front_nine_par`), `back_nine_par` = VALUES(`back_nine_par`), `total_par` = VALUES(`total_par`), `front_nine_yards` = VALUES(`front_nine_yards`), `back_nine_yards` = VALUES(`back_nine_yards`), `total_yards` = VALUES(`total_yards`), `front_nine_meters` = VALUES(`front_nine_meters`), `back_nine_meters` = VALUES(`back_nine_meters`), `total_meters` = VALUES(`total_meters`)"
 
-	DBLog(sqlstr, m.CourseId, m.Marker, m.Slope, m.CourseRating, m.FrontNinePar, m.BackNinePar, m.TotalPar, m.FrontNineYards, m.BackNineYards, m.TotalYards, m.FrontNineMeters, m.BackNineMeters, m.TotalMeters)
-	res, err := db.Exec(sqlstr, m.CourseId, m.Marker, m.Slope, m.CourseRating, m.FrontNinePar, m.BackNinePar, m.TotalPar, m.FrontNineYards, m.BackNineYards, m.TotalYards, m.FrontNineMeters, m.BackNineMeters, m.TotalMeters)
+	DBLog(sqlstr, m.CourseId, m.ExternalId, m.Version, m.Marker, m.Slope, m.CourseRating, m.FrontNinePar, m.BackNinePar, m.TotalPar, m.FrontNineYards, m.BackNineYards, m.TotalYards, m.FrontNineMeters, m.BackNineMeters, m.TotalMeters)
+	res, err := db.ExecContext(ctx, sqlstr, m.CourseId, m.ExternalId, m.Version, m.Marker, m.Slope, m.CourseRating, m.FrontNinePar, m.BackNinePar, m.TotalPar, m.FrontNineYards, m.BackNineYards, m.TotalYards, m.FrontNineMeters, m.BackNineMeters, m.TotalMeters)
 	if err != nil {
 		return err
 	}
@@ -152,31 +151,30 @@ func (m *CourseDetails) InsertWithUpdate(db DB) error {
 }
 
 // Save saves the CourseDetails to the database.
-func (m *CourseDetails) Save(db DB) error {
+func (m *CourseDetails) Save(ctx context.Context, db DB) error {
 	if m.IsPrimaryKeySet() {
-		return m.Update(db)
+		return m.Update(ctx, db)
 	}
-	return m.Insert(db)
+	return m.Insert(ctx, db)
 }
 
 // SaveOrUpdate saves the CourseDetails to the database, but tries to update
 // on unique constraint violations.
-func (m *CourseDetails) SaveOrUpdate(db DB) error {
+func (m *CourseDetails) SaveOrUpdate(ctx context.Context, db DB) error {
 	if m.IsPrimaryKeySet() {
-		return m.Update(db)
+		return m.Update(ctx, db)
 	}
-	return m.InsertWithUpdate(db)
+	return m.InsertWithUpdate(ctx, db)
 }
 
 // Delete deletes the CourseDetails from the database.
-func (m *CourseDetails) Delete(db DB) error {
-	t := prometheus.NewTimer(DatabaseLatency.WithLabelValues("delete_CourseDetails"))
-	defer t.ObserveDuration()
+func (m *CourseDetails) Delete(ctx context.Context, db DB) error {
+	ctx = WithQueryName(ctx, "delete_CourseDetails")
 
 	const sqlstr = "DELETE FROM course_details WHERE `id` = ?"
 
 	DBLog(sqlstr, m.Id)
-	_, err := db.Exec(sqlstr, m.Id)
+	_, err := db.ExecContext(ctx, sqlstr, m.Id)
 
 	return err
 }
@@ -184,17 +182,16 @@ func (m *CourseDetails) Delete(db DB) error {
 // CourseDetailsById retrieves a row from 'course_details' as a CourseDetails.
 //
 // Generated from primary key.
-func CourseDetailsById(db DB, id int) (*CourseDetails, error) {
-	t := prometheus.NewTimer(DatabaseLatency.WithLabelValues("insert_CourseDetails"))
-	defer t.ObserveDuration()
+func CourseDetailsById(ctx context.Context, db DB, id int) (*CourseDetails, error) {
+	ctx = WithQueryName(ctx, "insert_CourseDetails")
 
-	const sqlstr = "SELECT `id`, `course_id`, `marker`, `slope`, `course_rating`, `front_nine_par`, `back_nine_par`, `total_par`, `front_nine_yards`, `back_nine_yards`, `total_yards`, `front_nine_meters`, `back_nine_meters`, `total_meters` " +
+	const sqlstr = "SELECT `id`, `course_id`, `external_id`, `version`, `marker`, `slope`, `course_rating`, `front_nine_par`, `back_nine_par`, `total_par`, `front_nine_yards`, `back_nine_yards`, `total_yards`, `front_nine_meters`, `back_nine_meters`, `total_meters` " +
 		"FROM course_details " +
 		"WHERE `id` = ?"
 
 	DBLog(sqlstr, id)
 	var m CourseDetails
-	if err := db.Get(&m, sqlstr, id); err != nil {
+	if err := db.GetContext(ctx, &m, sqlstr, id); err != nil {
 		return nil, err
 	}
 
@@ -204,6 +201,25 @@ func CourseDetailsById(db DB, id int) (*CourseDetails, error) {
 // GetCourse Gets an instance of Course
 //
 // Generated from constraint course_details_course_id_fk
-func (m *CourseDetails) GetCourse(db DB) (*Course, error) {
-	return CourseById(db, m.CourseId)
+func (m *CourseDetails) GetCourse(ctx context.Context, db DB) (*Course, error) {
+	return CourseById(ctx, db, m.CourseId)
+}
+
+// CourseDetailsByCourseIdExternalIdVersion retrieves a row from 'course_details' as a *CourseDetails.
+//
+// Generated from index 'course_details_course_id_external_id_version_uindex' of type 'unique'.
+func CourseDetailsByCourseIdExternalIdVersion(ctx context.Context, db DB, courseId int, externalId int, version int) (*CourseDetails, error) {
+	ctx = WithQueryName(ctx, "insert_CourseDetails")
+
+	const sqlstr = "SELECT `id`, `course_id`, `external_id`, `version`, `marker`, `slope`, `course_rating`, `front_nine_par`, `back_nine_par`, `total_par`, `front_nine_yards`, `back_nine_yards`, `total_yards`, `front_nine_meters`, `back_nine_meters`, `total_meters` " +
+		"FROM course_details " +
+		"WHERE `course_id` = ? AND `external_id` = ? AND `version` = ?"
+
+	DBLog(sqlstr, courseId, externalId, version)
+	var m CourseDetails
+	if err := db.GetContext(ctx, &m, sqlstr, courseId, externalId, version); err != nil {
+		return nil, err
+	}
+
+	return &m, nil
 }
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"context"
 	"database/sql"
 
 	"github.com/jmoiron/sqlx"
@@ -15,20 +16,21 @@ type DBTransactioner interface {
 
 // DB is the common interface for database operations
 //
-// This should work with database/sql.DB and database/sql.Tx.
+// This should work with database/sql.DB and database/sql.Tx. Every operation takes a context, so that a query stops
+// when the request that made it is cancelled.
 type DB interface {
-	Exec(string, ...any) (sql.Result, error)
-	Query(string, ...any) (*sql.Rows, error)
-	QueryRow(string, ...any) *sql.Row
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
 	// Additional sqlx methods we like
-	Get(dest any, query string, args ...interface{}) error
-	Select(dest any, query string, args ...interface{}) error
+	GetContext(ctx context.Context, dest any, query string, args ...any) error
+	SelectContext(ctx context.Context, dest any, query string, args ...any) error
 }
 
 // Transactioner is the interface that a database connection that can start
 // a transaction should implement.
 type Transactioner interface {
-	Beginx() (*sqlx.Tx, error)
+	BeginTxx(ctx context.Context, opts *sql.TxOptions) (*sqlx.Tx, error)
 }
 
 // XODB is a compat alias to DB
@@ -1,33 +1,34 @@
+-- The full schema after every migration in pkg/migrations/sql has been applied. Databases are created and upgraded with
+-- "rounder migrate up" rather than from this file, so every change must also be added as a migration.
+
 create table user
 (
-    id         int auto_increment
+    id             int auto_increment
         primary key,
-    name       varchar(50)  not null,
-    username   varchar(100) not null,
-    password   text         not null,
-    last_login datetime     null,
+    name           varchar(50)  not null,
+    username       varchar(100) not null,
+    password       text         not null,
+    last_login     datetime     null,
+    role           varchar(20)  default 'player' not null,
+    email          varchar(255) null,
+    gender         varchar(20)  null,
+    date_of_birth  date         null,
+    home_course_id int          null,
     constraint user_username_uindex
         unique (username)
 );
 
-create table round
-(
-    id       int auto_increment
-        primary key,
-    user_id  int                                   not null,
-    tee_time timestamp default current_timestamp() not null on update current_timestamp(),
-    constraint round_user_id_fk
-        foreign key (user_id) references user (id)
-);
-
 create table course
 (
-    id       int auto_increment
+    id          int auto_increment
         primary key,
-    round_id int          not null,
-    name     varchar(255) not null,
-    constraint course_round_id_fk
-        foreign key (round_id) references round (id)
+    external_id int          null,
+    user_id     int          null,
+    name        varchar(255) not null,
+    constraint course_external_id_uindex
+        unique (external_id),
+    constraint course_user_id_fk
+        foreign key (user_id) references user (id)
 );
 
 create table course_details
@@ -35,6 +36,8 @@ create table course_details
     id                int auto_increment
         primary key,
     course_id         int           not null,
+    external_id       int           not null,
+    version           int           not null,
     marker            varchar(255)  null,
     slope             int           not null,
     course_rating     decimal(4, 1) not null,
@@ -47,6 +50,8 @@ create table course_details
     front_nine_meters int           not null,
     back_nine_meters  int           not null,
     total_meters      int           not null,
+    constraint course_details_course_id_external_id_version_uindex
+        unique (course_id, external_id, version),
     constraint course_details_course_id_fk
         foreign key (course_id) references course (id)
 );
@@ -65,10 +70,24 @@ create table hole
         foreign key (course_details_id) references course_details (id)
 );
 
+create table round
+(
+    id                int auto_increment
+        primary key,
+    user_id           int                                   not null,
+    course_details_id int                                   not null,
+    tee_time          timestamp default current_timestamp() not null on update current_timestamp(),
+    constraint round_user_id_fk
+        foreign key (user_id) references user (id),
+    constraint round_course_details_id_fk
+        foreign key (course_details_id) references course_details (id)
+);
+
 create table hole_stats
 (
     id           int auto_increment
         primary key,
+    round_id     int                                                              not null,
     hole_id      int                                                              not null,
     score        int                                                              not null,
     fairway_hit  enum ('HIT', 'LEFT', 'RIGHT', 'SHORT', 'LONG', 'NOT_APPLICABLE') not null,
@@ -76,6 +95,10 @@ create table hole_stats
     pin_location varchar(100)                                                     not null,
     putts        int                                                              not null,
     penalties    int                                                              not null,
+    constraint hole_stats_round_id_hole_id_uindex
+        unique (round_id, hole_id),
+    constraint hole_stats_round_id_fk
+        foreign key (round_id) references round (id),
     constraint hole_stats_hole_id_fk
         foreign key (hole_id) references hole (id)
 );
@@ -108,3 +131,189 @@ create table round_hit_stats
         foreign key (round_stats_id) references round_stats (id)
 );
 
+create table golf_data_cache
+(
+    cache_key  varchar(255) not null
+        primary key,
+    value      mediumtext   not null,
+    fetched_at datetime     not null
+);
+
+create table session
+(
+    id                 int auto_increment
+        primary key,
+    user_id            int          not null,
+    refresh_token_hash char(64)     not null,
+    user_agent         varchar(255) null,
+    created_at         datetime     not null,
+    last_used_at       datetime     not null,
+    expires_at         datetime     not null,
+    revoked_at         datetime     null,
+    constraint session_refresh_token_hash_uindex
+        unique (refresh_token_hash),
+    constraint session_user_id_fk
+        foreign key (user_id) references user (id)
+);
+
+create table password_reset
+(
+    id         int auto_increment
+        primary key,
+    user_id    int      not null,
+    token_hash char(64) not null,
+    created_at datetime not null,
+    expires_at datetime not null,
+    used_at    datetime null,
+    constraint password_reset_token_hash_uindex
+        unique (token_hash),
+    constraint password_reset_user_id_fk
+        foreign key (user_id) references user (id)
+);
+
+create table rate_limit_bucket
+(
+    bucket_key varchar(255) not null
+        primary key,
+    tokens     double       not null,
+    updated_at datetime(6)  not null
+);
+
+create table api_key
+(
+    id           int auto_increment
+        primary key,
+    user_id      int          not null,
+    name         varchar(100) not null,
+    key_hash     char(64)     not null,
+    scopes       varchar(255) not null,
+    created_at   datetime     not null,
+    last_used_at datetime     null,
+    expires_at   datetime     null,
+    revoked_at   datetime     null,
+    constraint api_key_key_hash_uindex
+        unique (key_hash),
+    constraint api_key_user_id_fk
+        foreign key (user_id) references user (id)
+);
+
+create table oidc_login
+(
+    id            int auto_increment
+        primary key,
+    state_hash    char(64)     not null,
+    provider      varchar(50)  not null,
+    code_verifier varchar(128) not null,
+    nonce         varchar(64)  not null,
+    user_id       int          null,
+    created_at    datetime     not null,
+    expires_at    datetime     not null,
+    constraint oidc_login_state_hash_uindex
+        unique (state_hash)
+);
+
+create table user_identity
+(
+    id            int auto_increment
+        primary key,
+    user_id       int          not null,
+    provider      varchar(50)  not null,
+    issuer        varchar(255) not null,
+    subject       varchar(255) not null,
+    email         varchar(255) null,
+    created_at    datetime     not null,
+    last_login_at datetime     null,
+    constraint user_identity_issuer_subject_uindex
+        unique (issuer, subject),
+    constraint user_identity_user_id_fk
+        foreign key (user_id) references user (id)
+);
+
+create table coach_grant
+(
+    id         int auto_increment
+        primary key,
+    player_id  int      not null,
+    coach_id   int      not null,
+    created_at datetime not null,
+    constraint coach_grant_player_id_coach_id_uindex
+        unique (player_id, coach_id),
+    constraint coach_grant_player_id_fk
+        foreign key (player_id) references user (id)
+);
+
+create table audit_event
+(
+    id          int auto_increment
+        primary key,
+    actor_id    int          null,
+    action      varchar(50)  not null,
+    target_type varchar(50)  null,
+    target_id   int          null,
+    ip_address  varchar(45)  null,
+    details     text         null,
+    created_at  datetime     not null
+);
+
+create index audit_event_actor_id_index
+    on audit_event (actor_id);
+
+create index audit_event_created_at_index
+    on audit_event (created_at);
+
+create table user_totp
+(
+    id             int auto_increment
+        primary key,
+    user_id        int          not null,
+    secret         varchar(255) not null,
+    created_at     datetime     not null,
+    confirmed_at   datetime     null,
+    last_used_step bigint       null,
+    constraint user_totp_user_id_uindex
+        unique (user_id),
+    constraint user_totp_user_id_fk
+        foreign key (user_id) references user (id)
+);
+
+create table recovery_code
+(
+    id        int auto_increment
+        primary key,
+    user_id   int      not null,
+    code_hash char(64) not null,
+    used_at   datetime null,
+    constraint recovery_code_code_hash_uindex
+        unique (code_hash),
+    constraint recovery_code_user_id_fk
+        foreign key (user_id) references user (id)
+);
+
+create table user_preference
+(
+    id             int auto_increment
+        primary key,
+    user_id        int                   not null,
+    distance_unit  varchar(10)  default 'yards' not null,
+    default_marker varchar(255)          null,
+    timezone       varchar(64)  default 'UTC' not null,
+    constraint user_preference_user_id_uindex
+        unique (user_id),
+    constraint user_preference_user_id_fk
+        foreign key (user_id) references user (id)
+);
+
+create table login_challenge
+(
+    id         int auto_increment
+        primary key,
+    user_id    int          not null,
+    token_hash char(64)     not null,
+    details    varchar(255) not null,
+    created_at datetime     not null,
+    expires_at datetime     not null,
+    constraint login_challenge_token_hash_uindex
+        unique (token_hash),
+    constraint login_challenge_user_id_fk
+        foreign key (user_id) references user (id)
+);
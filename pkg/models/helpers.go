@@ -1,6 +1,7 @@
 package models
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
 	"reflect"
@@ -8,12 +9,12 @@ import (
 
 // Saveable is the interface implemented by types which can save themselves to the database.
 type Saveable interface {
-	Save(db DB) error
+	Save(ctx context.Context, db DB) error
 }
 
 // PreSaveable is the interface implemented by types which run a pre save step.
 type PreSaveable interface {
-	PreSave(db DB) error
+	PreSave(ctx context.Context, db DB) error
 }
 
 // PostSaveable is the interface implemented by types which run a post save step.
@@ -28,7 +29,7 @@ type SetLogger interface {
 
 // Deletable is the interface implemented by types which can delete themselves from the database.
 type Deletable interface {
-	Delete(db DB) error
+	Delete(ctx context.Context, db DB) error
 }
 
 // PreDeletable is the interface implemented by types which run a pre delete step.
@@ -45,7 +46,7 @@ type PostDeletable interface {
 type TransactionFunc func(db DB) error
 
 type TransactionHandler interface {
-	Handle(TransactionFunc) error
+	Handle(context.Context, TransactionFunc) error
 }
 
 // DBTransactionHandler handles a transaction that will return any error.
@@ -53,14 +54,20 @@ type DBTransactionHandler struct {
 	db Transactioner
 }
 
-// Handle implements the TransactionHandler interface.
-func (th *DBTransactionHandler) Handle(f TransactionFunc) error {
-	tx, err := th.db.Beginx()
+// Handle implements the TransactionHandler interface. The transaction is rolled back if the context is done before it
+// is committed.
+func (th *DBTransactionHandler) Handle(ctx context.Context, f TransactionFunc) error {
+	tx, err := th.db.BeginTxx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("begin tx: %w", err)
 	}
 
-	if err := f(tx); err != nil {
+	var db DB = tx
+	if t, ok := th.db.(*TimeoutDB); ok {
+		db = t.withDB(tx)
+	}
+
+	if err := f(db); err != nil {
 		if err2 := tx.Rollback(); err2 != nil {
 			return fmt.Errorf("%s: %w", err, err2)
 		}
@@ -4,7 +4,7 @@
 package models
 
 import (
-	"github.com/prometheus/client_golang/prometheus"
+	"context"
 )
 
 // Hole represents a row from 'hole'.
@@ -22,9 +22,8 @@ type Hole struct {
 var HoleColumns = []string{"CourseDetailsId", "DistanceMeters", "DistanceYards", "Id", "Number", "Par", "Stroke"}
 
 // Insert inserts the Hole to the database.
-func (m *Hole) Insert(db DB) error {
-	t := prometheus.NewTimer(DatabaseLatency.WithLabelValues("insert_Hole"))
-	defer t.ObserveDuration()
+func (m *Hole) Insert(ctx context.Context, db DB) error {
+	ctx = WithQueryName(ctx, "insert_Hole")
 
 	const sqlstr = "INSERT INTO hole (" +
 		"`course_details_id`, `number`, `par`, `stroke`, `distance_yards`, `distance_meters`" +
@@ -33,7 +32,7 @@ func (m *Hole) Insert(db DB) error {
 		")"
 
 	DBLog(sqlstr, m.CourseDetailsId, m.Number, m.Par, m.Stroke, m.DistanceYards, m.DistanceMeters)
-	res, err := db.Exec(sqlstr, m.CourseDetailsId, m.Number, m.Par, m.Stroke, m.DistanceYards, m.DistanceMeters)
+	res, err := db.ExecContext(ctx, sqlstr, m.CourseDetailsId, m.Number, m.Par, m.Stroke, m.DistanceYards, m.DistanceMeters)
 	if err != nil {
 		return err
 	}
@@ -47,13 +46,12 @@ func (m *Hole) Insert(db DB) error {
 	return nil
 }
 
-func InsertManyHoles(db DB, ms ...*Hole) error {
+func InsertManyHoles(ctx context.Context, db DB, ms ...*Hole) error {
 	if len(ms) == 0 {
 		return nil
 	}
 
-	t := prometheus.NewTimer(DatabaseLatency.WithLabelValues("insert_many_Hole"))
-	defer t.ObserveDuration()
+	ctx = WithQueryName(ctx, "insert_many_Hole")
 
 	var sqlstr = "INSERT INTO hole (" +
 		"`course_details_id`,`number`,`par`,`stroke`,`distance_yards`,`distance_meters`" +
@@ -68,7 +66,7 @@ func InsertManyHoles(db DB, ms ...*Hole) error {
 	}
 
 	DBLog(sqlstr, args...)
-	res, err := db.Exec(sqlstr, args...)
+	res, err := db.ExecContext(ctx, sqlstr, args...)
 	if err != nil {
 		return err
 	}
@@ -91,16 +89,15 @@ func (m *Hole) IsPrimaryKeySet() bool {
 }
 
 // Update updates the Hole in the database.
-func (m *Hole) Update(db DB) error {
-	t := prometheus.NewTimer(DatabaseLatency.WithLabelValues("update_Hole"))
-	defer t.ObserveDuration()
+func (m *Hole) Update(ctx context.Context, db DB) error {
+	ctx = WithQueryName(ctx, "update_Hole")
 
 	const sqlstr = "UPDATE hole " +
 		"SET `course_details_id` = ?, `number` = ?, `par` = ?, `stroke` = ?, `distance_yards` = ?, `distance_meters` = ? " +
 		"WHERE `id` = ?"
 
 	DBLog(sqlstr, m.CourseDetailsId, m.Number, m.Par, m.Stroke, m.DistanceYards, m.DistanceMeters, m.Id)
-	res, err := db.Exec(sqlstr, m.CourseDetailsId, m.Number, m.Par, m.Stroke, m.DistanceYards, m.DistanceMeters, m.Id)
+	res, err := db.ExecContext(ctx, sqlstr, m.CourseDetailsId, m.Number, m.Par, m.Stroke, m.DistanceYards, m.DistanceMeters, m.Id)
 	if err != nil {
 		return err
 	}
@@ -117,9 +114,8 @@ func (m *Hole) Update(db DB) error {
 
 // InsertWithUpdate inserts the Hole to the database, and tries to update
 // on unique constraint violations.
-func (m *Hole) InsertWithUpdate(db DB) error {
-	t := prometheus.NewTimer(DatabaseLatency.WithLabelValues("insert_update_Hole"))
-	defer t.ObserveDuration()
+func (m *Hole) InsertWithUpdate(ctx context.Context, db DB) error {
+	ctx = WithQueryName(ctx, "insert_update_Hole")
 
 	const sqlstr = "INSERT INTO hole (" +
 		"`course_details_id`, `number`, `par`, `stroke`, `distance_yards`, `distance_meters`" +
@@ -129,7 +125,7 @@ func (m *Hole) InsertWithUpdate(db DB) error {
 		"`course_details_id` = VALUES(`course_details_id`), `number` = VALUES(`number`), `par` = VALUES(`par`), `stroke` = VALUES(`stroke`), `distance_yards` = VALUES(`distance_yards`), `distance_meters` = VALUES(`distance_meters`)"
 
 	DBLog(sqlstr, m.CourseDetailsId, m.Number, m.Par, m.Stroke, m.DistanceYards, m.DistanceMeters)
-	res, err := db.Exec(sqlstr, m.CourseDetailsId, m.Number, m.Par, m.Stroke, m.DistanceYards, m.DistanceMeters)
+	res, err := db.ExecContext(ctx, sqlstr, m.CourseDetailsId, m.Number, m.Par, m.Stroke, m.DistanceYards, m.DistanceMeters)
 	if err != nil {
 		return err
 	}
@@ -144,31 +140,30 @@ func (m *Hole) InsertWithUpdate(db DB) error {
 }
 
 // Save saves the Hole to the database.
-func (m *Hole) Save(db DB) error {
+func (m *Hole) Save(ctx context.Context, db DB) error {
 	if m.IsPrimaryKeySet() {
-		return m.Update(db)
+		return m.Update(ctx, db)
 	}
-	return m.Insert(db)
+	return m.Insert(ctx, db)
 }
 
 // SaveOrUpdate saves the Hole to the database, but tries to update
 // on unique constraint violations.
-func (m *Hole) SaveOrUpdate(db DB) error {
+func (m *Hole) SaveOrUpdate(ctx context.Context, db DB) error {
 	if m.IsPrimaryKeySet() {
-		return m.Update(db)
+		return m.Update(ctx, db)
 	}
-	return m.InsertWithUpdate(db)
+	return m.InsertWithUpdate(ctx, db)
 }
 
 // Delete deletes the Hole from the database.
-func (m *Hole) Delete(db DB) error {
-	t := prometheus.NewTimer(DatabaseLatency.WithLabelValues("delete_Hole"))
-	defer t.ObserveDuration()
+func (m *Hole) Delete(ctx context.Context, db DB) error {
+	ctx = WithQueryName(ctx, "delete_Hole")
 
 	const sqlstr = "DELETE FROM hole WHERE `id` = ?"
 
 	DBLog(sqlstr, m.Id)
-	_, err := db.Exec(sqlstr, m.Id)
+	_, err := db.ExecContext(ctx, sqlstr, m.Id)
 
 	return err
 }
@@ -176,9 +171,8 @@ func (m *Hole) Delete(db DB) error {
 // HoleById retrieves a row from 'hole' as a Hole.
 //
 // Generated from primary key.
-func HoleById(db DB, id int) (*Hole, error) {
-	t := prometheus.NewTimer(DatabaseLatency.WithLabelValues("insert_Hole"))
-	defer t.ObserveDuration()
+func HoleById(ctx context.Context, db DB, id int) (*Hole, error) {
+	ctx = WithQueryName(ctx, "insert_Hole")
 
 	const sqlstr = "SELECT `id`, `course_details_id`, `number`, `par`, `stroke`, `distance_yards`, `distance_meters` " +
 		"FROM hole " +
@@ -186,7 +180,7 @@ func HoleById(db DB, id int) (*Hole, error) {
 
 	DBLog(sqlstr, id)
 	var m Hole
-	if err := db.Get(&m, sqlstr, id); err != nil {
+	if err := db.GetContext(ctx, &m, sqlstr, id); err != nil {
 		return nil, err
 	}
 
@@ -196,6 +190,6 @@ func HoleById(db DB, id int) (*Hole, error) {
 // GetCourseDetails Gets an instance of CourseDetails
 //
 // Generated from constraint hole_course_details_id_fk
-func (m *Hole) GetCourseDetails(db DB) (*CourseDetails, error) {
-	return CourseDetailsById(db, m.CourseDetailsId)
+func (m *Hole) GetCourseDetails(ctx context.Context, db DB) (*CourseDetails, error) {
+	return CourseDetailsById(ctx, db, m.CourseDetailsId)
 }
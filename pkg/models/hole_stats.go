@@ -4,13 +4,15 @@
 package models
 
 import (
+	"context"
+
 	usql "github.com/Jacobbrewer1/golf-stats-tracker/pkg/utils/sql"
-	"github.com/prometheus/client_golang/prometheus"
 )
 
 // HoleStats represents a row from 'hole_stats'.
 type HoleStats struct {
 	Id          int       `db:"id,autoinc,pk"`
+	RoundId     int       `db:"round_id"`
 	HoleId      int       `db:"hole_id"`
 	Score       int       `db:"score"`
 	FairwayHit  usql.Enum `db:"fairway_hit"`
@@ -21,21 +23,20 @@ type HoleStats struct {
 }
 
 // HoleStatsColumns is the sorted column names for the type HoleStats
-var HoleStatsColumns = []string{"FairwayHit", "GreenHit", "HoleId", "Id", "Penalties", "PinLocation", "Putts", "Score"}
+var HoleStatsColumns = []string{"FairwayHit", "GreenHit", "HoleId", "Id", "Penalties", "PinLocation", "Putts", "RoundId", "Score"}
 
 // Insert inserts the HoleStats to the database.
-func (m *HoleStats) Insert(db DB) error {
-	t := prometheus.NewTimer(DatabaseLatency.WithLabelValues("insert_HoleStats"))
-	defer t.ObserveDuration()
+func (m *HoleStats) Insert(ctx context.Context, db DB) error {
+	ctx = WithQueryName(ctx, "insert_HoleStats")
 
 	const sqlstr = "INSERT INTO hole_stats (" +
-		"`hole_id`, `score`, `fairway_hit`, `green_hit`, `pin_location`, `putts`, `penalties`" +
+		"`round_id`, `hole_id`, `score`, `fairway_hit`, `green_hit`, `pin_location`, `putts`, `penalties`" +
 		") VALUES (" +
-		"?, ?, ?, ?, ?, ?, ?" +
+		"?, ?, ?, ?, ?, ?, ?, ?" +
 		")"
 
-	DBLog(sqlstr, m.HoleId, m.Score, m.FairwayHit, m.GreenHit, m.PinLocation, m.Putts, m.Penalties)
-	res, err := db.Exec(sqlstr, m.HoleId, m.Score, m.FairwayHit, m.GreenHit, m.PinLocation, m.Putts, m.Penalties)
+	DBLog(sqlstr, m.RoundId, m.HoleId, m.Score, m.FairwayHit, m.GreenHit, m.PinLocation, m.Putts, m.Penalties)
+	res, err := db.ExecContext(ctx, sqlstr, m.RoundId, m.HoleId, m.Score, m.FairwayHit, m.GreenHit, m.PinLocation, m.Putts, m.Penalties)
 	if err != nil {
 		return err
 	}
@@ -49,28 +50,27 @@ func (m *HoleStats) Insert(db DB) error {
 	return nil
 }
 
-func InsertManyHoleStatss(db DB, ms ...*HoleStats) error {
+func InsertManyHoleStatss(ctx context.Context, db DB, ms ...*HoleStats) error {
 	if len(ms) == 0 {
 		return nil
 	}
 
-	t := prometheus.NewTimer(DatabaseLatency.WithLabelValues("insert_many_HoleStats"))
-	defer t.ObserveDuration()
+	ctx = WithQueryName(ctx, "insert_many_HoleStats")
 
 	var sqlstr = "INSERT INTO hole_stats (" +
-		"`hole_id`,`score`,`fairway_hit`,`green_hit`,`pin_location`,`putts`,`penalties`" +
+		"`round_id`,`hole_id`,`score`,`fairway_hit`,`green_hit`,`pin_location`,`putts`,`penalties`" +
 		") VALUES"
 
 	var args []interface{}
 	for _, m := range ms {
 		sqlstr += " (" +
-			"?,?,?,?,?,?,?" +
+			"?,?,?,?,?,?,?,?" +
 			"),"
-		args = append(args, m.HoleId, m.Score, m.FairwayHit, m.GreenHit, m.PinLocation, m.Putts, m.Penalties)
+		args = append(args, m.RoundId, m.HoleId, m.Score, m.FairwayHit, m.GreenHit, m.PinLocation, m.Putts, m.Penalties)
 	}
 
 	DBLog(sqlstr, args...)
-	res, err := db.Exec(sqlstr, args...)
+	res, err := db.ExecContext(ctx, sqlstr, args...)
 	if err != nil {
 		return err
 	}
@@ -93,16 +93,15 @@ func (m *HoleStats) IsPrimaryKeySet() bool {
 }
 
 // Update updates the HoleStats in the database.
-func (m *HoleStats) Update(db DB) error {
-	t := prometheus.NewTimer(DatabaseLatency.WithLabelValues("update_HoleStats"))
-	defer t.ObserveDuration()
+func (m *HoleStats) Update(ctx context.Context, db DB) error {
+	ctx = WithQueryName(ctx, "update_HoleStats")
 
 	const sqlstr = "UPDATE hole_stats " +
-		"SET `hole_id` = ?, `score` = ?, `fairway_hit` = ?, `green_hit` = ?, `pin_location` = ?, `putts` = ?, `penalties` = ? " +
+		"SET `round_id` = ?, `hole_id` = ?, `score` = ?, `fairway_hit` = ?, `green_hit` = ?, `pin_location` = ?, `putts` = ?, `penalties` = ? " +
 		"WHERE `id` = ?"
 
-	DBLog(sqlstr, m.HoleId, m.Score, m.FairwayHit, m.GreenHit, m.PinLocation, m.Putts, m.Penalties, m.Id)
-	res, err := db.Exec(sqlstr, m.HoleId, m.Score, m.FairwayHit, m.GreenHit, m.PinLocation, m.Putts, m.Penalties, m.Id)
+	DBLog(sqlstr, m.RoundId, m.HoleId, m.Score, m.FairwayHit, m.GreenHit, m.PinLocation, m.Putts, m.Penalties, m.Id)
+	res, err := db.ExecContext(ctx, sqlstr, m.RoundId, m.HoleId, m.Score, m.FairwayHit, m.GreenHit, m.PinLocation, m.Putts, m.Penalties, m.Id)
 	if err != nil {
 		return err
 	}
@@ -119,19 +118,18 @@ func (m *HoleStats) Update(db DB) error {
 
 // InsertWithUpdate inserts the HoleStats to the database, and tries to update
 // on unique constraint violations.
-func (m *HoleStats) InsertWithUpdate(db DB) error {
-	t := prometheus.NewTimer(DatabaseLatency.WithLabelValues("insert_update_HoleStats"))
-	defer t.ObserveDuration()
+func (m *HoleStats) InsertWithUpdate(ctx context.Context, db DB) error {
+	ctx = WithQueryName(ctx, "insert_update_HoleStats")
 
 	const sqlstr = "INSERT INTO hole_stats (" +
-		"`hole_id`, `score`, `fairway_hit`, `green_hit`, `pin_location`, `putts`, `penalties`" +
+		"`round_id`, `hole_id`, `score`, `fairway_hit`, `green_hit`, `pin_location`, `putts`, `penalties`" +
 		") VALUES (" +
-		"?, ?, ?, ?, ?, ?, ?" +
+		"?, ?, ?, ?, ?, ?, ?, ?" +
 		") ON DUPLICATE KEY UPDATE " +
-		"`hole_id` = VALUES(`hole_id`), `score` = VALUES(`score`), `fairway_hit` = VALUES(`fairway_hit`), `green_hit` = VALUES(`green_hit`), `pin_location` = VALUES(`pin_location`), `putts` = VALUES(`putts`), `penalties` = VALUES(`penalties`)"
+		"`round_id` = VALUES(`round_id`), `hole_id` = VALUES(`hole_id`), `score` = VALUES(`score`), `fairway_hit` = VALUES(`fairway_hit`), `green_hit` = VALUES(`green_hit`), `pin_location` = VALUES(`pin_location`), `putts` = VALUES(`putts`), `penalties` = VALUES(`penalties`)"
 
-	DBLog(sqlstr, m.HoleId, m.Score, m.FairwayHit, m.GreenHit, m.PinLocation, m.Putts, m.Penalties)
-	res, err := db.Exec(sqlstr, m.HoleId, m.Score, m.FairwayHit, m.GreenHit, m.PinLocation, m.Putts, m.Penalties)
+	DBLog(sqlstr, m.RoundId, m.HoleId, m.Score, m.FairwayHit, m.GreenHit, m.PinLocation, m.Putts, m.Penalties)
+	res, err := db.ExecContext(ctx, sqlstr, m.RoundId, m.HoleId, m.Score, m.FairwayHit, m.GreenHit, m.PinLocation, m.Putts, m.Penalties)
 	if err != nil {
 		return err
 	}
@@ -146,31 +144,30 @@ func (m *HoleStats) InsertWithUpdate(db DB) error {
 }
 
 // Save saves the HoleStats to the database.
-func (m *HoleStats) Save(db DB) error {
+func (m *HoleStats) Save(ctx context.Context, db DB) error {
 	if m.IsPrimaryKeySet() {
-		return m.Update(db)
+		return m.Update(ctx, db)
 	}
-	return m.Insert(db)
+	return m.Insert(ctx, db)
 }
 
 // SaveOrUpdate saves the HoleStats to the database, but tries to update
 // on unique constraint violations.
-func (m *HoleStats) SaveOrUpdate(db DB) error {
+func (m *HoleStats) SaveOrUpdate(ctx context.Context, db DB) error {
 	if m.IsPrimaryKeySet() {
-		return m.Update(db)
+		return m.Update(ctx, db)
 	}
-	return m.InsertWithUpdate(db)
+	return m.InsertWithUpdate(ctx, db)
 }
 
 // Delete deletes the HoleStats from the database.
-func (m *HoleStats) Delete(db DB) error {
-	t := prometheus.NewTimer(DatabaseLatency.WithLabelValues("delete_HoleStats"))
-	defer t.ObserveDuration()
+func (m *HoleStats) Delete(ctx context.Context, db DB) error {
+	ctx = WithQueryName(ctx, "delete_HoleStats")
 
 	const sqlstr = "DELETE FROM hole_stats WHERE `id` = ?"
 
 	DBLog(sqlstr, m.Id)
-	_, err := db.Exec(sqlstr, m.Id)
+	_, err := db.ExecContext(ctx, sqlstr, m.Id)
 
 	return err
 }
@@ -178,28 +175,53 @@ func (m *HoleStats) Delete(db DB) error {
 // HoleStatsById retrieves a row from 'hole_stats' as a HoleStats.
 //
 // Generated from primary key.
-func HoleStatsById(db DB, id int) (*HoleStats, error) {
-	t := prometheus.NewTimer(DatabaseLatency.WithLabelValues("insert_HoleStats"))
-	defer t.ObserveDuration()
+func HoleStatsById(ctx context.Context, db DB, id int) (*HoleStats, error) {
+	ctx = WithQueryName(ctx, "insert_HoleStats")
 
-	const sqlstr = "SELECT `id`, `hole_id`, `score`, `fairway_hit`, `green_hit`, `pin_location`, `putts`, `penalties` " +
+	const sqlstr = "SELECT `id`, `round_id`, `hole_id`, `score`, `fairway_hit`, `green_hit`, `pin_location`, `putts`, `penalties` " +
 		"FROM hole_stats " +
 		"WHERE `id` = ?"
 
 	DBLog(sqlstr, id)
 	var m HoleStats
-	if err := db.Get(&m, sqlstr, id); err != nil {
+	if err := db.GetContext(ctx, &m, sqlstr, id); err != nil {
 		return nil, err
 	}
 
 	return &m, nil
 }
 
+// GetRound Gets an instance of Round
+//
+// Generated from constraint hole_stats_round_id_fk
+func (m *HoleStats) GetRound(ctx context.Context, db DB) (*Round, error) {
+	return RoundById(ctx, db, m.RoundId)
+}
+
 // GetHole Gets an instance of Hole
 //
 // Generated from constraint hole_stats_hole_id_fk
-func (m *HoleStats) GetHole(db DB) (*Hole, error) {
-	return HoleById(db, m.HoleId)
+func (m *HoleStats) GetHole(ctx context.Context, db DB) (*Hole, error) {
+	return HoleById(ctx, db, m.HoleId)
+}
+
+// HoleStatsByRoundIdHoleId retrieves a row from 'hole_stats' as a *HoleStats.
+//
+// Generated from index 'hole_stats_round_id_hole_id_uindex' of type 'unique'.
+func HoleStatsByRoundIdHoleId(ctx context.Context, db DB, roundId int, holeId int) (*HoleStats, error) {
+	ctx = WithQueryName(ctx, "insert_HoleStats")
+
+	const sqlstr = "SELECT `id`, `round_id`, `hole_id`, `score`, `fairway_hit`, `green_hit`, `pin_location`, `putts`, `penalties` " +
+		"FROM hole_stats " +
+		"WHERE `round_id` = ? AND `hole_id` = ?"
+
+	DBLog(sqlstr, roundId, holeId)
+	var m HoleStats
+	if err := db.GetContext(ctx, &m, sqlstr, roundId, holeId); err != nil {
+		return nil, err
+	}
+
+	return &m, nil
 }
 
 // Valid values for the 'FairwayHit' enum column
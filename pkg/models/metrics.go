@@ -1,15 +1,79 @@
 package models
 
 import (
+	"context"
+	"errors"
+	"time"
+
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 )
 
-// DatabaseLatency is the duration of database queries.
+const (
+	// QueryStatusOK is the status of a query that ran without its context being done.
+	QueryStatusOK = "ok"
+
+	// QueryStatusCancelled is the status of a query whose context was cancelled, such as by the client disconnecting.
+	QueryStatusCancelled = "cancelled"
+
+	// QueryStatusTimeout is the status of a query whose context passed its deadline.
+	QueryStatusTimeout = "timeout"
+)
+
+// DatabaseLatency is the duration of database queries, by whether their context was done before they returned. The
+// queries are observed by TimeoutDB, under the name given with WithQueryName.
 var DatabaseLatency = promauto.NewHistogramVec(
 	prometheus.HistogramOpts{
 		Name: "database_latency",
 		Help: "Duration of database queries",
 	},
-	[]string{"query"},
+	[]string{"query", "status"},
 )
+
+type queryNameKey struct{}
+
+// WithQueryName returns a context that names the queries run with it in DatabaseLatency.
+func WithQueryName(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, queryNameKey{}, name)
+}
+
+// queryName gets the name of a query from its context, or the fallback if it has none.
+func queryName(ctx context.Context, fallback string) string {
+	if name, ok := ctx.Value(queryNameKey{}).(string); ok {
+		return name
+	}
+	return fallback
+}
+
+// queryTimer observes the duration of a query in DatabaseLatency.
+type queryTimer struct {
+	ctx   context.Context
+	query string
+	start time.Time
+}
+
+func newQueryTimer(ctx context.Context, query string) *queryTimer {
+	return &queryTimer{
+		ctx:   ctx,
+		query: query,
+		start: time.Now(),
+	}
+}
+
+// ObserveDuration records the time since the timer was created, along with the status of the context of the query. It
+// must be called before the context of the query is cancelled.
+func (t *queryTimer) ObserveDuration() {
+	DatabaseLatency.WithLabelValues(t.query, QueryStatus(t.ctx)).Observe(time.Since(t.start).Seconds())
+}
+
+// QueryStatus gets the status of a query from its context.
+func QueryStatus(ctx context.Context) string {
+	switch err := ctx.Err(); {
+	case err == nil:
+		return QueryStatusOK
+	case errors.Is(err, context.DeadlineExceeded):
+		return QueryStatusTimeout
+	default:
+		return QueryStatusCancelled
+	}
+}
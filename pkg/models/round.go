@@ -4,34 +4,33 @@
 package models
 
 import (
+	"context"
 	"time"
-
-	"github.com/prometheus/client_golang/prometheus"
 )
 
 // Round represents a row from 'round'.
 type Round struct {
-	Id      int       `db:"id,autoinc,pk"`
-	UserId  int       `db:"user_id"`
-	TeeTime time.Time `db:"tee_time"`
+	Id              int       `db:"id,autoinc,pk"`
+	UserId          int       `db:"user_id"`
+	CourseDetailsId int       `db:"course_details_id"`
+	TeeTime         time.Time `db:"tee_time"`
 }
 
 // RoundColumns is the sorted column names for the type Round
-var RoundColumns = []string{"Id", "TeeTime", "UserId"}
+var RoundColumns = []string{"CourseDetailsId", "Id", "TeeTime", "UserId"}
 
 // Insert inserts the Round to the database.
-func (m *Round) Insert(db DB) error {
-	t := prometheus.NewTimer(DatabaseLatency.WithLabelValues("insert_Round"))
-	defer t.ObserveDuration()
+func (m *Round) Insert(ctx context.Context, db DB) error {
+	ctx = WithQueryName(ctx, "insert_Round")
 
 	const sqlstr = "INSERT INTO round (" +
-		"`user_id`, `tee_time`" +
+		"`user_id`, `course_details_id`, `tee_time`" +
 		") VALUES (" +
-		"?, ?" +
+		"?, ?, ?" +
 		")"
 
-	DBLog(sqlstr, m.UserId, m.TeeTime)
-	res, err := db.Exec(sqlstr, m.UserId, m.TeeTime)
+	DBLog(sqlstr, m.UserId, m.CourseDetailsId, m.TeeTime)
+	res, err := db.ExecContext(ctx, sqlstr, m.UserId, m.CourseDetailsId, m.TeeTime)
 	if err != nil {
 		return err
 	}
@@ -45,28 +44,27 @@ func (m *Round) Insert(db DB) error {
 	return nil
 }
 
-func InsertManyRounds(db DB, ms ...*Round) error {
+func InsertManyRounds(ctx context.Context, db DB, ms ...*Round) error {
 	if len(ms) == 0 {
 		return nil
 	}
 
-	t := prometheus.NewTimer(DatabaseLatency.WithLabelValues("insert_many_Round"))
-	defer t.ObserveDuration()
+	ctx = WithQueryName(ctx, "insert_many_Round")
 
 	var sqlstr = "INSERT INTO round (" +
-		"`user_id`,`tee_time`" +
+		"`user_id`,`course_details_id`,`tee_time`" +
 		") VALUES"
 
 	var args []interface{}
 	for _, m := range ms {
 		sqlstr += " (" +
-			"?,?" +
+			"?,?,?" +
 			"),"
-		args = append(args, m.UserId, m.TeeTime)
+		args = append(args, m.UserId, m.CourseDetailsId, m.TeeTime)
 	}
 
 	DBLog(sqlstr, args...)
-	res, err := db.Exec(sqlstr, args...)
+	res, err := db.ExecContext(ctx, sqlstr, args...)
 	if err != nil {
 		return err
 	}
@@ -89,16 +87,15 @@ func (m *Round) IsPrimaryKeySet() bool {
 }
 
 // Update updates the Round in the database.
-func (m *Round) Update(db DB) error {
-	t := prometheus.NewTimer(DatabaseLatency.WithLabelValues("update_Round"))
-	defer t.ObserveDuration()
+func (m *Round) Update(ctx context.Context, db DB) error {
+	ctx = WithQueryName(ctx, "update_Round")
 
 	const sqlstr = "UPDATE round " +
-		"SET `user_id` = ?, `tee_time` = ? " +
+		"SET `user_id` = ?, `course_details_id` = ?, `tee_time` = ? " +
 		"WHERE `id` = ?"
 
-	DBLog(sqlstr, m.UserId, m.TeeTime, m.Id)
-	res, err := db.Exec(sqlstr, m.UserId, m.TeeTime, m.Id)
+	DBLog(sqlstr, m.UserId, m.CourseDetailsId, m.TeeTime, m.Id)
+	res, err := db.ExecContext(ctx, sqlstr, m.UserId, m.CourseDetailsId, m.TeeTime, m.Id)
 	if err != nil {
 		return err
 	}
@@ -115,19 +112,18 @@ func (m *Round) Update(db DB) error {
 
 // InsertWithUpdate inserts the Round to the database, and tries to update
 // on unique constraint violations.
-func (m *Round) InsertWithUpdate(db DB) error {
-	t := prometheus.NewTimer(DatabaseLatency.WithLabelValues("insert_update_Round"))
-	defer t.ObserveDuration()
+func (m *Round) InsertWithUpdate(ctx context.Context, db DB) error {
+	ctx = WithQueryName(ctx, "insert_update_Round")
 
 	const sqlstr = "INSERT INTO round (" +
-		"`user_id`, `tee_time`" +
+		"`user_id`, `course_details_id`, `tee_time`" +
 		") VALUES (" +
-		"?, ?" +
+		"?, ?, ?" +
 		") ON DUPLICATE KEY UPDATE " +
-		"`user_id` = VALUES(`user_id`), `tee_time` = VALUES(`tee_time`)"
+		"`user_id` = VALUES(`user_id`), `course_details_id` = VALUES(`course_details_id`), `tee_time` = VALUES(`tee_time`)"
 
-	DBLog(sqlstr, m.UserId, m.TeeTime)
-	res, err := db.Exec(sqlstr, m.UserId, m.TeeTime)
+	DBLog(sqlstr, m.UserId, m.CourseDetailsId, m.TeeTime)
+	res, err := db.ExecContext(ctx, sqlstr, m.UserId, m.CourseDetailsId, m.TeeTime)
 	if err != nil {
 		return err
 	}
@@ -142,31 +138,30 @@ func (m *Round) InsertWithUpdate(db DB) error {
 }
 
 // Save saves the Round to the database.
-func (m *Round) Save(db DB) error {
+func (m *Round) Save(ctx context.Context, db DB) error {
 	if m.IsPrimaryKeySet() {
-		return m.Update(db)
+		return m.Update(ctx, db)
 	}
-	return m.Insert(db)
+	return m.Insert(ctx, db)
 }
 
 // SaveOrUpdate saves the Round to the database, but tries to update
 // on unique constraint violations.
-func (m *Round) SaveOrUpdate(db DB) error {
+func (m *Round) SaveOrUpdate(ctx context.Context, db DB) error {
 	if m.IsPrimaryKeySet() {
-		return m.Update(db)
+		return m.Update(ctx, db)
 	}
-	return m.InsertWithUpdate(db)
+	return m.InsertWithUpdate(ctx, db)
 }
 
 // Delete deletes the Round from the database.
-func (m *Round) Delete(db DB) error {
-	t := prometheus.NewTimer(DatabaseLatency.WithLabelValues("delete_Round"))
-	defer t.ObserveDuration()
+func (m *Round) Delete(ctx context.Context, db DB) error {
+	ctx = WithQueryName(ctx, "delete_Round")
 
 	const sqlstr = "DELETE FROM round WHERE `id` = ?"
 
 	DBLog(sqlstr, m.Id)
-	_, err := db.Exec(sqlstr, m.Id)
+	_, err := db.ExecContext(ctx, sqlstr, m.Id)
 
 	return err
 }
@@ -174,17 +169,16 @@ func (m *Round) Delete(db DB) error {
 // RoundById retrieves a row from 'round' as a Round.
 //
 // Generated from primary key.
-func RoundById(db DB, id int) (*Round, error) {
-	t := prometheus.NewTimer(DatabaseLatency.WithLabelValues("insert_Round"))
-	defer t.ObserveDuration()
+func RoundById(ctx context.Context, db DB, id int) (*Round, error) {
+	ctx = WithQueryName(ctx, "insert_Round")
 
-	const sqlstr = "SELECT `id`, `user_id`, `tee_time` " +
+	const sqlstr = "SELECT `id`, `user_id`, `course_details_id`, `tee_time` " +
 		"FROM round " +
 		"WHERE `id` = ?"
 
 	DBLog(sqlstr, id)
 	var m Round
-	if err := db.Get(&m, sqlstr, id); err != nil {
+	if err := db.GetContext(ctx, &m, sqlstr, id); err != nil {
 		return nil, err
 	}
 
@@ -194,6 +188,13 @@ func RoundById(db DB, id int) (*Round, error) {
 // GetUser Gets an instance of User
 //
 // Generated from constraint round_user_id_fk
-func (m *Round) GetUser(db DB) (*User, error) {
-	return UserById(db, m.UserId)
+func (m *Round) GetUser(ctx context.Context, db DB) (*User, error) {
+	return UserById(ctx, db, m.UserId)
+}
+
+// GetCourseDetails Gets an instance of CourseDetails
+//
+// Generated from constraint round_course_details_id_fk
+func (m *Round) GetCourseDetails(ctx context.Context, db DB) (*CourseDetails, error) {
+	return CourseDetailsById(ctx, db, m.CourseDetailsId)
 }
@@ -4,8 +4,9 @@
 package models
 
 import (
+	"context"
+
 	usql "github.com/Jacobbrewer1/golf-stats-tracker/pkg/utils/sql"
-	"github.com/prometheus/client_golang/prometheus"
 )
 
 // RoundHitStats represents a row from 'round_hit_stats'.
@@ -21,9 +22,8 @@ type RoundHitStats struct {
 var RoundHitStatsColumns = []string{"Count", "Id", "Miss", "RoundStatsId", "Type"}
 
 // Insert inserts the RoundHitStats to the database.
-func (m *RoundHitStats) Insert(db DB) error {
-	t := prometheus.NewTimer(DatabaseLatency.WithLabelValues("insert_RoundHitStats"))
-	defer t.ObserveDuration()
+func (m *RoundHitStats) Insert(ctx context.Context, db DB) error {
+	ctx = WithQueryName(ctx, "insert_RoundHitStats")
 
 	const sqlstr = "INSERT INTO round_hit_stats (" +
 		"`round_stats_id`, `type`, `miss`, `count`" +
@@ -32,7 +32,7 @@ func (m *RoundHitStats) Insert(db DB) error {
 		")"
 
 	DBLog(sqlstr, m.RoundStatsId, m.Type, m.Miss, m.Count)
-	res, err := db.Exec(sqlstr, m.RoundStatsId, m.Type, m.Miss, m.Count)
+	res, err := db.ExecContext(ctx, sqlstr, m.RoundStatsId, m.Type, m.Miss, m.Count)
 	if err != nil {
 		return err
 	}
@@ -46,13 +46,12 @@ func (m *RoundHitStats) Insert(db DB) error {
 	return nil
 }
 
-func InsertManyRoundHitStatss(db DB, ms ...*RoundHitStats) error {
+func InsertManyRoundHitStatss(ctx context.Context, db DB, ms ...*RoundHitStats) error {
 	if len(ms) == 0 {
 		return nil
 	}
 
-	t := prometheus.NewTimer(DatabaseLatency.WithLabelValues("insert_many_RoundHitStats"))
-	defer t.ObserveDuration()
+	ctx = WithQueryName(ctx, "insert_many_RoundHitStats")
 
 	var sqlstr = "INSERT INTO round_hit_stats (" +
 		"`round_stats_id`,`type`,`miss`,`count`" +
@@ -67,7 +66,7 @@ func InsertManyRoundHitStatss(db DB, ms ...*RoundHitStats) error {
 	}
 
 	DBLog(sqlstr, args...)
-	res, err := db.Exec(sqlstr, args...)
+	res, err := db.ExecContext(ctx, sqlstr, args...)
 	if err != nil {
 		return err
 	}
@@ -90,16 +89,15 @@ func (m *RoundHitStats) IsPrimaryKeySet() bool {
 }
 
 // Update updates the RoundHitStats in the database.
-func (m *RoundHitStats) Update(db DB) error {
-	t := prometheus.NewTimer(DatabaseLatency.WithLabelValues("update_RoundHitStats"))
-	defer t.ObserveDuration()
+func (m *RoundHitStats) Update(ctx context.Context, db DB) error {
+	ctx = WithQueryName(ctx, "update_RoundHitStats")
 
 	const sqlstr = "UPDATE round_hit_stats " +
 		"SET `round_stats_id` = ?, `type` = ?, `miss` = ?, `count` = ? " +
 		"WHERE `id` = ?"
 
 	DBLog(sqlstr, m.RoundStatsId, m.Type, m.Miss, m.Count, m.Id)
-	res, err := db.Exec(sqlstr, m.RoundStatsId, m.Type, m.Miss, m.Count, m.Id)
+	res, err := db.ExecContext(ctx, sqlstr, m.RoundStatsId, m.Type, m.Miss, m.Count, m.Id)
 	if err != nil {
 		return err
 	}
@@ -116,9 +114,8 @@ func (m *RoundHitStats) Update(db DB) error {
 
 // InsertWithUpdate inserts the RoundHitStats to the database, and tries to update
 // on unique constraint violations.
-func (m *RoundHitStats) InsertWithUpdate(db DB) error {
-	t := prometheus.NewTimer(DatabaseLatency.WithLabelValues("insert_update_RoundHitStats"))
-	defer t.ObserveDuration()
+func (m *RoundHitStats) InsertWithUpdate(ctx context.Context, db DB) error {
+	ctx = WithQueryName(ctx, "insert_update_RoundHitStats")
 
 	const sqlstr = "INSERT INTO round_hit_stats (" +
 		"`round_stats_id`, `type`, `miss`, `count`" +
@@ -128,7 +125,7 @@ func (m *RoundHitStats) InsertWithUpdate(db DB) error {
 		"`round_stats_id` = VALUES(`round_stats_id`), `type` = VALUES(`type`), `miss` = VALUES(`miss`), `count` = VALUES(`count`)"
 
 	DBLog(sqlstr, m.RoundStatsId, m.Type, m.Miss, m.Count)
-	res, err := db.Exec(sqlstr, m.RoundStatsId, m.Type, m.Miss, m.Count)
+	res, err := db.ExecContext(ctx, sqlstr, m.RoundStatsId, m.Type, m.Miss, m.Count)
 	if err != nil {
 		return err
 	}
@@ -143,31 +140,30 @@ func (m *RoundHitStats) InsertWithUpdate(db DB) error {
 }
 
 // Save saves the RoundHitStats to the database.
-func (m *RoundHitStats) Save(db DB) error {
+func (m *RoundHitStats) Save(ctx context.Context, db DB) error {
 	if m.IsPrimaryKeySet() {
-		return m.Update(db)
+		return m.Update(ctx, db)
 	}
-	return m.Insert(db)
+	return m.Insert(ctx, db)
 }
 
 // SaveOrUpdate saves the RoundHitStats to the database, but tries to update
 // on unique constraint violations.
-func (m *RoundHitStats) SaveOrUpdate(db DB) error {
+func (m *RoundHitStats) SaveOrUpdate(ctx context.Context, db DB) error {
 	if m.IsPrimaryKeySet() {
-		return m.Update(db)
+		return m.Update(ctx, db)
 	}
-	return m.InsertWithUpdate(db)
+	return m.InsertWithUpdate(ctx, db)
 }
 
 // Delete deletes the RoundHitStats from the database.
-func (m *RoundHitStats) Delete(db DB) error {
-	t := prometheus.NewTimer(DatabaseLatency.WithLabelValues("delete_RoundHitStats"))
-	defer t.ObserveDuration()
+func (m *RoundHitStats) Delete(ctx context.Context, db DB) error {
+	ctx = WithQueryName(ctx, "delete_RoundHitStats")
 
 	const sqlstr = "DELETE FROM round_hit_stats WHERE `id` = ?"
 
 	DBLog(sqlstr, m.Id)
-	_, err := db.Exec(sqlstr, m.Id)
+	_, err := db.ExecContext(ctx, sqlstr, m.Id)
 
 	return err
 }
@@ -175,9 +171,8 @@ func (m *RoundHitStats) Delete(db DB) error {
 // RoundHitStatsById retrieves a row from 'round_hit_stats' as a RoundHitStats.
 //
 // Generated from primary key.
-func RoundHitStatsById(db DB, id int) (*RoundHitStats, error) {
-	t := prometheus.NewTimer(DatabaseLatency.WithLabelValues("insert_RoundHitStats"))
-	defer t.ObserveDuration()
+func RoundHitStatsById(ctx context.Context, db DB, id int) (*RoundHitStats, error) {
+	ctx = WithQueryName(ctx, "insert_RoundHitStats")
 
 	const sqlstr = "SELECT `id`, `round_stats_id`, `type`, `miss`, `count` " +
 		"FROM round_hit_stats " +
@@ -185,7 +180,7 @@ func RoundHitStatsById(db DB, id int) (*RoundHitStats, error) {
 
 	DBLog(sqlstr, id)
 	var m RoundHitStats
-	if err := db.Get(&m, sqlstr, id); err != nil {
+	if err := db.GetContext(ctx, &m, sqlstr, id); err != nil {
 		return nil, err
 	}
 
@@ -195,8 +190,8 @@ func RoundHitStatsById(db DB, id int) (*RoundHitStats, error) {
 // GetRoundStats Gets an instance of RoundStats
 //
 // Generated from constraint round_hit_stats_round_stats_id_fk
-func (m *RoundHitStats) GetRoundStats(db DB) (*RoundStats, error) {
-	return RoundStatsById(db, m.RoundStatsId)
+func (m *RoundHitStats) GetRoundStats(ctx context.Context, db DB) (*RoundStats, error) {
+	return RoundStatsById(ctx, db, m.RoundStatsId)
 }
 
 // Valid values for the 'Type' enum column
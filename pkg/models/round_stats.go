@@ -4,7 +4,7 @@
 package models
 
 import (
-	"github.com/prometheus/client_golang/prometheus"
+	"context"
 )
 
 // RoundStats represents a row from 'round_stats'.
@@ -24,9 +24,8 @@ type RoundStats struct {
 var RoundStatsColumns = []string{"AvgFairwaysHit", "AvgGreensHit", "AvgPar3", "AvgPar4", "AvgPar5", "AvgPutts", "Id", "Penalties", "RoundId"}
 
 // Insert inserts the RoundStats to the database.
-func (m *RoundStats) Insert(db DB) error {
-	t := prometheus.NewTimer(DatabaseLatency.WithLabelValues("insert_RoundStats"))
-	defer t.ObserveDuration()
+func (m *RoundStats) Insert(ctx context.Context, db DB) error {
+	ctx = WithQueryName(ctx, "insert_RoundStats")
 
 	const sqlstr = "INSERT INTO round_stats (" +
 		"`round_id`, `avg_fairways_hit`, `avg_greens_hit`, `avg_putts`, `penalties`, `avg_par_3`, `avg_par_4`, `avg_par_5`" +
@@ -35,7 +34,7 @@ func (m *RoundStats) Insert(db DB) error {
 		")"
 
 	DBLog(sqlstr, m.RoundId, m.AvgFairwaysHit, m.AvgGreensHit, m.AvgPutts, m.Penalties, m.AvgPar3, m.AvgPar4, m.AvgPar5)
-	res, err := db.Exec(sqlstr, m.RoundId, m.AvgFairwaysHit, m.AvgGreensHit, m.AvgPutts, m.Penalties, m.AvgPar3, m.AvgPar4, m.AvgPar5)
+	res, err := db.ExecContext(ctx, sqlstr, m.RoundId, m.AvgFairwaysHit, m.AvgGreensHit, m.AvgPutts, m.Penalties, m.AvgPar3, m.AvgPar4, m.AvgPar5)
 	if err != nil {
 		return err
 	}
@@ -49,13 +48,12 @@ func (m *RoundStats) Insert(db DB) error {
 	return nil
 }
 
-func InsertManyRoundStatss(db DB, ms ...*RoundStats) error {
+func InsertManyRoundStatss(ctx context.Context, db DB, ms ...*RoundStats) error {
 	if len(ms) == 0 {
 		return nil
 	}
 
-	t := prometheus.NewTimer(DatabaseLatency.WithLabelValues("insert_many_RoundStats"))
-	defer t.ObserveDuration()
+	ctx = WithQueryName(ctx, "insert_many_RoundStats")
 
 	var sqlstr = "INSERT INTO round_stats (" +
 		"`round_id`,`avg_fairways_hit`,`avg_greens_hit`,`avg_putts`,`penalties`,`avg_par_3`,`avg_par_4`,`avg_par_5`" +
@@ -70,7 +68,7 @@ func InsertManyRoundStatss(db DB, ms ...*RoundStats) error {
 	}
 
 	DBLog(sqlstr, args...)
-	res, err := db.Exec(sqlstr, args...)
+	res, err := db.ExecContext(ctx, sqlstr, args...)
 	if err != nil {
 		return err
 	}
@@ -93,16 +91,15 @@ func (m *RoundStats) IsPrimaryKeySet() bool {
 }
 
 // Update updates the RoundStats in the database.
-func (m *RoundStats) Update(db DB) error {
-	t := prometheus.NewTimer(DatabaseLatency.WithLabelValues("update_RoundStats"))
-	defer t.ObserveDuration()
+func (m *RoundStats) Update(ctx context.Context, db DB) error {
+	ctx = WithQueryName(ctx, "update_RoundStats")
 
 	const sqlstr = "UPDATE round_stats " +
 		"SET `round_id` = ?, `avg_fairways_hit` = ?, `avg_greens_hit` = ?, `avg_putts` = ?, `penalties` = ?, `avg_par_3` = ?, `avg_par_4` = ?, `avg_par_5` = ? " +
 		"WHERE `id` = ?"
 
 	DBLog(sqlstr, m.RoundId, m.AvgFairwaysHit, m.AvgGreensHit, m.AvgPutts, m.Penalties, m.AvgPar3, m.AvgPar4, m.AvgPar5, m.Id)
-	res, err := db.Exec(sqlstr, m.RoundId, m.AvgFairwaysHit, m.AvgGreensHit, m.AvgPutts, m.Penalties, m.AvgPar3, m.AvgPar4, m.AvgPar5, m.Id)
+	res, err := db.ExecContext(ctx, sqlstr, m.RoundId, m.AvgFairwaysHit, m.AvgGreensHit, m.AvgPutts, m.Penalties, m.AvgPar3, m.AvgPar4, m.AvgPar5, m.Id)
 	if err != nil {
 		return err
 	}
@@ -119,9 +116,8 @@ func (m *RoundStats) Update(db DB) error {
 
 // InsertWithUpdate inserts the RoundStats to the database, and tries to update
 // on unique constraint violations.
-func (m *RoundStats) InsertWithUpdate(db DB) error {
-	t := prometheus.NewTimer(DatabaseLatency.WithLabelValues("insert_update_RoundStats"))
-	defer t.ObserveDuration()
+func (m *RoundStats) InsertWithUpdate(ctx context.Context, db DB) error {
+	ctx = WithQueryName(ctx, "insert_update_RoundStats")
 
 	const sqlstr = "INSERT INTO round_stats (" +
 		"`round_id`, `avg_fairways_hit`, `avg_greens_hit`, `avg_putts`, `penalties`, `avg_par_3`, `avg_par_4`, `avg_par_5`" +
@@ -131,7 +127,7 @@ func (m *RoundStats) InsertWithUpdate(db DB) error {
 		"`round_id` = VALUES(`round_id`), `avg_fairways_hit` = VALUES(`avg_fairways_hit`), `avg_greens_hit` = VALUES(`avg_greens_hit`), `avg_putts` = VALUES(`avg_putts`), `penalties` = VALUES(`penalties`), `avg_par_3` = VALUES(`avg_par_3`), `avg_par_4` = VALUES(`avg_par_4`), `avg_par_5` = VALUES(`avg_par_5`)"
 
 	DBLog(sqlstr, m.RoundId, m.AvgFairwaysHit, m.AvgGreensHit, m.AvgPutts, m.Penalties, m.AvgPar3, m.AvgPar4, m.AvgPar5)
-	res, err := db.Exec(sqlstr, m.RoundId, m.AvgFairwaysHit, m.AvgGreensHit, m.AvgPutts, m.Penalties, m.AvgPar3, m.AvgPar4, m.AvgPar5)
+	res, err := db.ExecContext(ctx, sqlstr, m.RoundId, m.AvgFairwaysHit, m.AvgGreensHit, m.AvgPutts, m.Penalties, m.AvgPar3, m.AvgPar4, m.AvgPar5)
 	if err != nil {
 		return err
 	}
@@ -146,31 +142,30 @@ func (m *RoundStats) InsertWithUpdate(db DB) error {
 }
 
 // Save saves the RoundStats to the database.
-func (m *RoundStats) Save(db DB) error {
+func (m *RoundStats) Save(ctx context.Context, db DB) error {
 	if m.IsPrimaryKeySet() {
-		return m.Update(db)
+		return m.Update(ctx, db)
 	}
-	return m.Insert(db)
+	return m.Insert(ctx, db)
 }
 
 // SaveOrUpdate saves the RoundStats to the database, but tries to update
 // on unique constraint violations.
-func (m *RoundStats) SaveOrUpdate(db DB) error {
+func (m *RoundStats) SaveOrUpdate(ctx context.Context, db DB) error {
 	if m.IsPrimaryKeySet() {
-		return m.Update(db)
+		return m.Update(ctx, db)
 	}
-	return m.InsertWithUpdate(db)
+	return m.InsertWithUpdate(ctx, db)
 }
 
 // Delete deletes the RoundStats from the database.
-func (m *RoundStats) Delete(db DB) error {
-	t := prometheus.NewTimer(DatabaseLatency.WithLabelValues("delete_RoundStats"))
-	defer t.ObserveDuration()
+func (m *RoundStats) Delete(ctx context.Context, db DB) error {
+	ctx = WithQueryName(ctx, "delete_RoundStats")
 
 	const sqlstr = "DELETE FROM round_stats WHERE `id` = ?"
 
 	DBLog(sqlstr, m.Id)
-	_, err := db.Exec(sqlstr, m.Id)
+	_, err := db.ExecContext(ctx, sqlstr, m.Id)
 
 	return err
 }
@@ -178,9 +173,8 @@ func (m *RoundStats) Delete(db DB) error {
 // RoundStatsById retrieves a row from 'round_stats' as a RoundStats.
 //
 // Generated from primary key.
-func RoundStatsById(db DB, id int) (*RoundStats, error) {
-	t := prometheus.NewTimer(DatabaseLatency.WithLabelValues("insert_RoundStats"))
-	defer t.ObserveDuration()
+func RoundStatsById(ctx context.Context, db DB, id int) (*RoundStats, error) {
+	ctx = WithQueryName(ctx, "insert_RoundStats")
 
 	const sqlstr = "SELECT `id`, `round_id`, `avg_fairways_hit`, `avg_greens_hit`, `avg_putts`, `penalties`, `avg_par_3`, `avg_par_4`, `avg_par_5` " +
 		"FROM round_stats " +
@@ -188,7 +182,7 @@ func RoundStatsById(db DB, id int) (*RoundStats, error) {
 
 	DBLog(sqlstr, id)
 	var m RoundStats
-	if err := db.Get(&m, sqlstr, id); err != nil {
+	if err := db.GetContext(ctx, &m, sqlstr, id); err != nil {
 		return nil, err
 	}
 
@@ -198,6 +192,6 @@ func RoundStatsById(db DB, id int) (*RoundStats, error) {
 // GetRound Gets an instance of Round
 //
 // Generated from constraint round_stats_round_id_fk
-func (m *RoundStats) GetRound(db DB) (*Round, error) {
-	return RoundById(db, m.RoundId)
+func (m *RoundStats) GetRound(ctx context.Context, db DB) (*Round, error) {
+	return RoundById(ctx, db, m.RoundId)
 }
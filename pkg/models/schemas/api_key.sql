@@ -0,0 +1,16 @@
+create table api_key
+(
+    id           int          not null auto_increment,
+    user_id      int          not null,
+    name         varchar(100) not null,
+    key_hash     char(64)     not null,
+    scopes       varchar(255) not null,
+    created_at   datetime     not null,
+    last_used_at datetime     null,
+    expires_at   datetime     null,
+    revoked_at   datetime     null,
+    primary key (id),
+    unique key api_key_key_hash_uindex (key_hash),
+    constraint api_key_user_id_fk
+        foreign key (user_id) references user (id)
+);
@@ -0,0 +1,12 @@
+create table audit_event
+(
+    id          int          not null auto_increment,
+    actor_id    int          null,
+    action      varchar(50)  not null,
+    target_type varchar(50)  null,
+    target_id   int          null,
+    ip_address  varchar(45)  null,
+    details     text         null,
+    created_at  datetime     not null,
+    primary key (id)
+);
@@ -0,0 +1,11 @@
+create table coach_grant
+(
+    id         int      not null auto_increment,
+    player_id  int      not null,
+    coach_id   int      not null,
+    created_at datetime not null,
+    primary key (id),
+    unique key coach_grant_player_id_coach_id_uindex (player_id, coach_id),
+    constraint coach_grant_player_id_fk
+        foreign key (player_id) references user (id)
+);
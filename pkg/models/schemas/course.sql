@@ -1,7 +1,10 @@
 create table course
 (
-    id       int          not null auto_increment,
-    round_id int          not null,
-    name     varchar(255) not null,
-    primary key (id)
+    id          int          not null auto_increment,
+    external_id int null,
+    user_id     int null,
+    name        varchar(255) not null,
+    primary key (id),
+    constraint course_external_id_uindex
+        unique (external_id)
 );
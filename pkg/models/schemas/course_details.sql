@@ -2,6 +2,8 @@ create table course_details
 (
     id                int           not null auto_increment,
     course_id         int           not null,
+    external_id       int           not null,
+    version           int           not null,
     marker            varchar(255) null,
     slope             int           not null,
     course_rating     decimal(4, 1) not null,
@@ -15,6 +17,8 @@ create table course_details
     back_nine_meters  int           not null,
     total_meters      int           not null,
     primary key (id),
+    constraint course_details_course_id_external_id_version_uindex
+        unique (course_id, external_id, version),
     constraint course_details_course_id_fk
         foreign key (course_id) references course (id)
 );
@@ -0,0 +1,7 @@
+create table golf_data_cache
+(
+    cache_key  varchar(255) not null,
+    value      mediumtext   not null,
+    fetched_at datetime     not null,
+    primary key (cache_key)
+);
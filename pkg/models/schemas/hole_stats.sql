@@ -1,6 +1,7 @@
 create table hole_stats
 (
     id           int          not null auto_increment,
+    round_id     int          not null,
     hole_id      int          not null,
     score        int          not null,
     fairway_hit  enum ('HIT', 'LEFT', 'RIGHT', 'SHORT', 'LONG', 'NOT_APPLICABLE') not null,
@@ -9,6 +10,10 @@ create table hole_stats
     putts        int          not null,
     penalties    int          not null,
     primary key (id),
+    constraint hole_stats_round_id_hole_id_uindex
+        unique (round_id, hole_id),
+    constraint hole_stats_round_id_fk
+        foreign key (round_id) references round (id),
     constraint hole_stats_hole_id_fk
         foreign key (hole_id) references hole (id)
 );
\ No newline at end of file
@@ -0,0 +1,13 @@
+create table login_challenge
+(
+    id         int          not null auto_increment,
+    user_id    int          not null,
+    token_hash char(64)     not null,
+    details    varchar(255) not null,
+    created_at datetime     not null,
+    expires_at datetime     not null,
+    primary key (id),
+    unique key login_challenge_token_hash_uindex (token_hash),
+    constraint login_challenge_user_id_fk
+        foreign key (user_id) references user (id)
+);
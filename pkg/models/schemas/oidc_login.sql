@@ -0,0 +1,13 @@
+create table oidc_login
+(
+    id            int          not null auto_increment,
+    state_hash    char(64)     not null,
+    provider      varchar(50)  not null,
+    code_verifier varchar(128) not null,
+    nonce         varchar(64)  not null,
+    user_id       int          null,
+    created_at    datetime     not null,
+    expires_at    datetime     not null,
+    primary key (id),
+    unique key oidc_login_state_hash_uindex (state_hash)
+);
@@ -0,0 +1,13 @@
+create table password_reset
+(
+    id         int      not null auto_increment,
+    user_id    int      not null,
+    token_hash char(64) not null,
+    created_at datetime not null,
+    expires_at datetime not null,
+    used_at    datetime null,
+    primary key (id),
+    unique key password_reset_token_hash_uindex (token_hash),
+    constraint password_reset_user_id_fk
+        foreign key (user_id) references user (id)
+);
@@ -0,0 +1,7 @@
+create table rate_limit_bucket
+(
+    bucket_key varchar(255) not null,
+    tokens     double       not null,
+    updated_at datetime(6)  not null,
+    primary key (bucket_key)
+);
@@ -0,0 +1,11 @@
+create table recovery_code
+(
+    id        int      not null auto_increment,
+    user_id   int      not null,
+    code_hash char(64) not null,
+    used_at   datetime null,
+    primary key (id),
+    unique key recovery_code_code_hash_uindex (code_hash),
+    constraint recovery_code_user_id_fk
+        foreign key (user_id) references user (id)
+);
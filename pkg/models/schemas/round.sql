@@ -1,9 +1,12 @@
 create table round
 (
-    id       int       not null auto_increment,
-    user_id  int       not null,
-    tee_time timestamp not null,
+    id                int       not null auto_increment,
+    user_id           int       not null,
+    course_details_id int       not null,
+    tee_time          timestamp not null,
     primary key (id),
     constraint round_user_id_fk
-        foreign key (user_id) references user (id)
-);
\ No newline at end of file
+        foreign key (user_id) references user (id),
+    constraint round_course_details_id_fk
+        foreign key (course_details_id) references course_details (id)
+);
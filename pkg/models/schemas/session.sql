@@ -0,0 +1,15 @@
+create table session
+(
+    id                 int          not null auto_increment,
+    user_id            int          not null,
+    refresh_token_hash char(64)     not null,
+    user_agent         varchar(255) null,
+    created_at         datetime     not null,
+    last_used_at       datetime     not null,
+    expires_at         datetime     not null,
+    revoked_at         datetime     null,
+    primary key (id),
+    unique key session_refresh_token_hash_uindex (refresh_token_hash),
+    constraint session_user_id_fk
+        foreign key (user_id) references user (id)
+);
@@ -1,9 +1,14 @@
 create table user
 (
-    id         int          not null auto_increment,
-    name       varchar(50)  not null,
-    username   varchar(100) not null,
-    password   text         not null,
-    last_login datetime null,
+    id             int          not null auto_increment,
+    name           varchar(50)  not null,
+    username       varchar(100) not null,
+    password       text         not null,
+    last_login     datetime null,
+    role           varchar(20)  not null default 'player',
+    email          varchar(255) null,
+    gender         varchar(20)  null,
+    date_of_birth  date         null,
+    home_course_id int          null,
     primary key (id)
-);
\ No newline at end of file
+);
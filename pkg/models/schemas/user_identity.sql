@@ -0,0 +1,15 @@
+create table user_identity
+(
+    id            int          not null auto_increment,
+    user_id       int          not null,
+    provider      varchar(50)  not null,
+    issuer        varchar(255) not null,
+    subject       varchar(255) not null,
+    email         varchar(255) null,
+    created_at    datetime     not null,
+    last_login_at datetime     null,
+    primary key (id),
+    unique key user_identity_issuer_subject_uindex (issuer, subject),
+    constraint user_identity_user_id_fk
+        foreign key (user_id) references user (id)
+);
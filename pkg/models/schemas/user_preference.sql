@@ -0,0 +1,12 @@
+create table user_preference
+(
+    id             int          not null auto_increment,
+    user_id        int          not null,
+    distance_unit  varchar(10)  not null default 'yards',
+    default_marker varchar(255) null,
+    timezone       varchar(64)  not null default 'UTC',
+    primary key (id),
+    unique key user_preference_user_id_uindex (user_id),
+    constraint user_preference_user_id_fk
+        foreign key (user_id) references user (id)
+);
@@ -0,0 +1,13 @@
+create table user_totp
+(
+    id             int          not null auto_increment,
+    user_id        int          not null,
+    secret         varchar(255) not null,
+    created_at     datetime     not null,
+    confirmed_at   datetime     null,
+    last_used_step bigint       null,
+    primary key (id),
+    unique key user_totp_user_id_uindex (user_id),
+    constraint user_totp_user_id_fk
+        foreign key (user_id) references user (id)
+);
@@ -0,0 +1,97 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// TimeoutDB is a database connection that gives each query a deadline, on top of any deadline that its context
+// already has. Transactions get the same deadline for all their queries together, and each of their queries started
+// with a DBTransactionHandler also gets its own. The duration of each query run with ExecContext, GetContext or
+// SelectContext is observed in DatabaseLatency, with the status of its deadline.
+type TimeoutDB struct {
+	db      DB
+	tx      Transactioner
+	timeout time.Duration
+}
+
+// NewTimeoutDB returns a connection that gives each query the timeout as its deadline. A timeout that is not positive
+// leaves the queries to the deadline of their context.
+func NewTimeoutDB(db DBTransactioner, timeout time.Duration) *TimeoutDB {
+	return &TimeoutDB{
+		db:      db,
+		tx:      db,
+		timeout: timeout,
+	}
+}
+
+// withDB returns a connection that runs its queries on the given connection with the same timeout.
+func (t *TimeoutDB) withDB(db DB) *TimeoutDB {
+	return &TimeoutDB{
+		db:      db,
+		tx:      t.tx,
+		timeout: t.timeout,
+	}
+}
+
+func (t *TimeoutDB) queryContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if t.timeout <= 0 {
+		return ctx, func() {}
+	}
+
+	return context.WithTimeout(ctx, t.timeout)
+}
+
+// detachedContext returns the context with the deadline for a query or transaction that is still used after the
+// method that started it returns. The rows and transactions cannot report when they are closed, so the context is
+// released when its deadline passes rather than when they are closed. Rows or a transaction still open by then are
+// closed or rolled back.
+func (t *TimeoutDB) detachedContext(ctx context.Context) context.Context {
+	if t.timeout <= 0 {
+		return ctx
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	context.AfterFunc(ctx, cancel)
+	return ctx
+}
+
+func (t *TimeoutDB) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	ctx, cancel := t.queryContext(ctx)
+	defer cancel()
+	defer newQueryTimer(ctx, queryName(ctx, "exec")).ObserveDuration()
+
+	return t.db.ExecContext(ctx, query, args...)
+}
+
+func (t *TimeoutDB) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return t.db.QueryContext(t.detachedContext(ctx), query, args...)
+}
+
+func (t *TimeoutDB) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	return t.db.QueryRowContext(t.detachedContext(ctx), query, args...)
+}
+
+func (t *TimeoutDB) GetContext(ctx context.Context, dest any, query string, args ...any) error {
+	ctx, cancel := t.queryContext(ctx)
+	defer cancel()
+	defer newQueryTimer(ctx, queryName(ctx, "get")).ObserveDuration()
+
+	return t.db.GetContext(ctx, dest, query, args...)
+}
+
+func (t *TimeoutDB) SelectContext(ctx context.Context, dest any, query string, args ...any) error {
+	ctx, cancel := t.queryContext(ctx)
+	defer cancel()
+	defer newQueryTimer(ctx, queryName(ctx, "select")).ObserveDuration()
+
+	return t.db.SelectContext(ctx, dest, query, args...)
+}
+
+// BeginTxx starts a transaction that has the timeout as the deadline for all of its queries together.
+func (t *TimeoutDB) BeginTxx(ctx context.Context, opts *sql.TxOptions) (*sqlx.Tx, error) {
+	return t.tx.BeginTxx(t.detachedContext(ctx), opts)
+}
@@ -4,35 +4,40 @@
 package models
 
 import (
+	"context"
+
 	usql "github.com/Jacobbrewer1/golf-stats-tracker/pkg/utils/sql"
-	"github.com/prometheus/client_golang/prometheus"
 )
 
 // User represents a row from 'user'.
 type User struct {
-	Id        int           `db:"id,autoinc,pk"`
-	Name      string        `db:"name"`
-	Username  string        `db:"username"`
-	Password  string        `db:"password"`
-	LastLogin usql.NullTime `db:"last_login"`
+	Id           int             `db:"id,autoinc,pk"`
+	Name         string          `db:"name"`
+	Username     string          `db:"username"`
+	Password     string          `db:"password"`
+	LastLogin    usql.NullTime   `db:"last_login"`
+	Role         string          `db:"role,default"`
+	Email        usql.NullString `db:"email"`
+	Gender       usql.NullString `db:"gender"`
+	DateOfBirth  usql.NullTime   `db:"date_of_birth"`
+	HomeCourseId usql.NullInt64  `db:"home_course_id"`
 }
 
 // UserColumns is the sorted column names for the type User
-var UserColumns = []string{"Id", "LastLogin", "Name", "Password", "Username"}
+var UserColumns = []string{"DateOfBirth", "Email", "Gender", "HomeCourseId", "Id", "LastLogin", "Name", "Password", "Role", "Username"}
 
 // Insert inserts the User to the database.
-func (m *User) Insert(db DB) error {
-	t := prometheus.NewTimer(DatabaseLatency.WithLabelValues("insert_User"))
-	defer t.ObserveDuration()
+func (m *User) Insert(ctx context.Context, db DB) error {
+	ctx = WithQueryName(ctx, "insert_User")
 
 	const sqlstr = "INSERT INTO user (" +
-		"`name`, `username`, `password`, `last_login`" +
+		"`name`, `username`, `password`, `last_login`, `role`, `email`, `gender`, `date_of_birth`, `home_course_id`" +
 		") VALUES (" +
-		"?, ?, ?, ?" +
+		"?, ?, ?, ?, ?, ?, ?, ?, ?" +
 		")"
 
-	DBLog(sqlstr, m.Name, m.Username, m.Password, m.LastLogin)
-	res, err := db.Exec(sqlstr, m.Name, m.Username, m.Password, m.LastLogin)
+	DBLog(sqlstr, m.Name, m.Username, m.Password, m.LastLogin, m.Role, m.Email, m.Gender, m.DateOfBirth, m.HomeCourseId)
+	res, err := db.ExecContext(ctx, sqlstr, m.Name, m.Username, m.Password, m.LastLogin, m.Role, m.Email, m.Gender, m.DateOfBirth, m.HomeCourseId)
 	if err != nil {
 		return err
 	}
@@ -46,28 +51,27 @@ func (m *User) Insert(db DB) error {
 	return nil
 }
 
-func InsertManyUsers(db DB, ms ...*User) error {
+func InsertManyUsers(ctx context.Context, db DB, ms ...*User) error {
 	if len(ms) == 0 {
 		return nil
 	}
 
-	t := prometheus.NewTimer(DatabaseLatency.WithLabelValues("insert_many_User"))
-	defer t.ObserveDuration()
+	ctx = WithQueryName(ctx, "insert_many_User")
 
 	var sqlstr = "INSERT INTO user (" +
-		"`name`,`username`,`password`,`last_login`" +
+		"`name`,`username`,`password`,`last_login`,`role`,`email`,`gender`,`date_of_birth`,`home_course_id`" +
 		") VALUES"
 
 	var args []interface{}
 	for _, m := range ms {
 		sqlstr += " (" +
-			"?,?,?,?" +
+			"?,?,?,?,?,?,?,?,?" +
 			"),"
-		args = append(args, m.Name, m.Username, m.Password, m.LastLogin)
+		args = append(args, m.Name, m.Username, m.Password, m.LastLogin, m.Role, m.Email, m.Gender, m.DateOfBirth, m.HomeCourseId)
 	}
 
 	DBLog(sqlstr, args...)
-	res, err := db.Exec(sqlstr, args...)
+	res, err := db.ExecContext(ctx, sqlstr, args...)
 	if err != nil {
 		return err
 	}
@@ -90,16 +94,15 @@ func (m *User) IsPrimaryKeySet() bool {
 }
 
 // Update updates the User in the database.
-func (m *User) Update(db DB) error {
-	t := prometheus.NewTimer(DatabaseLatency.WithLabelValues("update_User"))
-	defer t.ObserveDuration()
+func (m *User) Update(ctx context.Context, db DB) error {
+	ctx = WithQueryName(ctx, "update_User")
 
 	const sqlstr = "UPDATE user " +
-		"SET `name` = ?, `username` = ?, `password` = ?, `last_login` = ? " +
+		"SET `name` = ?, `username` = ?, `password` = ?, `last_login` = ?, `role` = ?, `email` = ?, `gender` = ?, `date_of_birth` = ?, `home_course_id` = ? " +
 		"WHERE `id` = ?"
 
-	DBLog(sqlstr, m.Name, m.Username, m.Password, m.LastLogin, m.Id)
-	res, err := db.Exec(sqlstr, m.Name, m.Username, m.Password, m.LastLogin, m.Id)
+	DBLog(sqlstr, m.Name, m.Username, m.Password, m.LastLogin, m.Role, m.Email, m.Gender, m.DateOfBirth, m.HomeCourseId, m.Id)
+	res, err := db.ExecContext(ctx, sqlstr, m.Name, m.Username, m.Password, m.LastLogin, m.Role, m.Email, m.Gender, m.DateOfBirth, m.HomeCourseId, m.Id)
 	if err != nil {
 		return err
 	}
@@ -116,19 +119,18 @@ func (m *User) Update(db DB) error {
 
 // InsertWithUpdate inserts the User to the database, and tries to update
 // on unique constraint violations.
-func (m *User) InsertWithUpdate(db DB) error {
-	t := prometheus.NewTimer(DatabaseLatency.WithLabelValues("insert_update_User"))
-	defer t.ObserveDuration()
+func (m *User) InsertWithUpdate(ctx context.Context, db DB) error {
+	ctx = WithQueryName(ctx, "insert_update_User")
 
 	const sqlstr = "INSERT INTO user (" +
-		"`name`, `username`, `password`, `last_login`" +
+		"`name`, `username`, `password`, `last_login`, `role`, `email`, `gender`, `date_of_birth`, `home_course_id`" +
 		") VALUES (" +
-		"?, ?, ?, ?" +
+		"?, ?, ?, ?, ?, ?, ?, ?, ?" +
 		") ON DUPLICATE KEY UPDATE " +
-		"`name` = VALUES(`name`), `username` = VALUES(`username`), `password` = VALUES(`password`), `last_login` = VALUES(`last_login`)"
+		"`name` = VALUES(`name`), `username` = VALUES(`username`), `password` = VALUES(`password`), `last_login` = VALUES(`last_login`), `role` = VALUES(`role`), `email` = VALUES(`email`), `gender` = VALUES(`gender`), `date_of_birth` = VALUES(`date_of_birth`), `home_course_id` = VALUES(`home_course_id`)"
 
-	DBLog(sqlstr, m.Name, m.Username, m.Password, m.LastLogin)
-	res, err := db.Exec(sqlstr, m.Name, m.Username, m.Password, m.LastLogin)
+	DBLog(sqlstr, m.Name, m.Username, m.Password, m.LastLogin, m.Role, m.Email, m.Gender, m.DateOfBirth, m.HomeCourseId)
+	res, err := db.ExecContext(ctx, sqlstr, m.Name, m.Username, m.Password, m.LastLogin, m.Role, m.Email, m.Gender, m.DateOfBirth, m.HomeCourseId)
 	if err != nil {
 		return err
 	}
@@ -143,31 +145,30 @@ func (m *User) InsertWithUpdate(db DB) error {
 }
 
 // Save saves the User to the database.
-func (m *User) Save(db DB) error {
+func (m *User) Save(ctx context.Context, db DB) error {
 	if m.IsPrimaryKeySet() {
-		return m.Update(db)
+		return m.Update(ctx, db)
 	}
-	return m.Insert(db)
+	return m.Insert(ctx, db)
 }
 
 // SaveOrUpdate saves the User to the database, but tries to update
 // on unique constraint violations.
-func (m *User) SaveOrUpdate(db DB) error {
+func (m *User) SaveOrUpdate(ctx context.Context, db DB) error {
 	if m.IsPrimaryKeySet() {
-		return m.Update(db)
+		return m.Update(ctx, db)
 	}
-	return m.InsertWithUpdate(db)
+	return m.InsertWithUpdate(ctx, db)
 }
 
 // Delete deletes the User from the database.
-func (m *User) Delete(db DB) error {
-	t := prometheus.NewTimer(DatabaseLatency.WithLabelValues("delete_User"))
-	defer t.ObserveDuration()
+func (m *User) Delete(ctx context.Context, db DB) error {
+	ctx = WithQueryName(ctx, "delete_User")
 
 	const sqlstr = "DELETE FROM user WHERE `id` = ?"
 
 	DBLog(sqlstr, m.Id)
-	_, err := db.Exec(sqlstr, m.Id)
+	_, err := db.ExecContext(ctx, sqlstr, m.Id)
 
 	return err
 }
@@ -175,17 +176,16 @@ func (m *User) Delete(db DB) error {
 // UserById retrieves a row from 'user' as a User.
 //
 // Generated from primary key.
-func UserById(db DB, id int) (*User, error) {
-	t := prometheus.NewTimer(DatabaseLatency.WithLabelValues("insert_User"))
-	defer t.ObserveDuration()
+func UserById(ctx context.Context, db DB, id int) (*User, error) {
+	ctx = WithQueryName(ctx, "insert_User")
 
-	const sqlstr = "SELECT `id`, `name`, `username`, `password`, `last_login` " +
+	const sqlstr = "SELECT `id`, `name`, `username`, `password`, `last_login`, `role`, `email`, `gender`, `date_of_birth`, `home_course_id` " +
 		"FROM user " +
 		"WHERE `id` = ?"
 
 	DBLog(sqlstr, id)
 	var m User
-	if err := db.Get(&m, sqlstr, id); err != nil {
+	if err := db.GetContext(ctx, &m, sqlstr, id); err != nil {
 		return nil, err
 	}
 
@@ -0,0 +1,92 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// Notification is a message sent to a user.
+type Notification struct {
+	// To is the username of the user the notification is for.
+	To string
+
+	// Subject is the subject of the notification.
+	Subject string
+
+	// Body is the body of the notification.
+	Body string
+}
+
+// Notifier delivers notifications to users.
+type Notifier interface {
+	// Notify delivers the notification.
+	Notify(ctx context.Context, n *Notification) error
+}
+
+type writerNotifier struct {
+	mut sync.Mutex
+
+	// w is the writer that the notifications are written to.
+	w io.Writer
+}
+
+// NewWriterNotifier creates a notifier that writes the notifications to the writer. This is intended for local use,
+// where the notifications are read from stdout.
+func NewWriterNotifier(w io.Writer) Notifier {
+	return &writerNotifier{
+		w: w,
+	}
+}
+
+func (n *writerNotifier) Notify(_ context.Context, notification *Notification) error {
+	n.mut.Lock()
+	defer n.mut.Unlock()
+
+	return writeNotification(n.w, notification)
+}
+
+type fileNotifier struct {
+	mut sync.Mutex
+
+	// path is the file that the notifications are appended to.
+	path string
+}
+
+// NewFileNotifier creates a notifier that appends the notifications to the file at the path.
+func NewFileNotifier(path string) Notifier {
+	return &fileNotifier{
+		path: path,
+	}
+}
+
+func (n *fileNotifier) Notify(_ context.Context, notification *Notification) (err error) {
+	n.mut.Lock()
+	defer n.mut.Unlock()
+
+	f, err := os.OpenFile(n.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open notification file: %w", err)
+	}
+
+	defer func() {
+		if closeErr := f.Close(); closeErr != nil && err == nil {
+			err = fmt.Errorf("failed to close notification file: %w", closeErr)
+		}
+	}()
+
+	return writeNotification(f, notification)
+}
+
+// writeNotification writes the notification to the writer in a human-readable format.
+func writeNotification(w io.Writer, n *Notification) error {
+	_, err := fmt.Fprintf(w, "---\nDate: %s\nTo: %s\nSubject: %s\n\n%s\n", time.Now().UTC().Format(time.RFC1123Z), n.To, n.Subject, n.Body)
+	if err != nil {
+		return fmt.Errorf("failed to write notification: %w", err)
+	}
+
+	return nil
+}
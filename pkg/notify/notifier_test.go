@@ -0,0 +1,33 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotifiers(t *testing.T) {
+	n := &Notification{
+		To:      "user@example.com",
+		Subject: "Reset your password",
+		Body:    "token",
+	}
+
+	buf := new(bytes.Buffer)
+	require.NoError(t, NewWriterNotifier(buf).Notify(context.Background(), n))
+	require.Contains(t, buf.String(), "To: user@example.com\nSubject: Reset your password\n\ntoken\n")
+
+	path := filepath.Join(t.TempDir(), "notifications.txt")
+	fn := NewFileNotifier(path)
+	require.NoError(t, fn.Notify(context.Background(), n))
+	require.NoError(t, fn.Notify(context.Background(), n))
+
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, 2, strings.Count(string(b), "Subject: Reset your password"))
+}
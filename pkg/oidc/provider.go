@@ -0,0 +1,415 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+)
+
+const (
+	// defaultTimeout is the default timeout for a single request to the issuer.
+	defaultTimeout = 10 * time.Second
+
+	// jwksRefreshInterval is the minimum time between fetches of the issuer's keys, so that tokens signed with unknown
+	// keys cannot be used to make the provider hammer the issuer.
+	jwksRefreshInterval = time.Minute
+
+	// clockSkew is the leeway given when validating the expiry of an ID token.
+	clockSkew = time.Minute
+
+	// maxResponseSize is the maximum size of a response from the issuer.
+	maxResponseSize = 1 << 20
+)
+
+var (
+	// ErrInvalidIDToken is returned when the ID token from the issuer is malformed, has an invalid signature, has
+	// expired or was not issued for this login.
+	ErrInvalidIDToken = errors.New("invalid id token")
+
+	// signatureAlgorithms are the algorithms that ID tokens can be signed with.
+	signatureAlgorithms = []jose.SignatureAlgorithm{
+		jose.RS256, jose.RS384, jose.RS512,
+		jose.ES256, jose.ES384, jose.ES512,
+		jose.PS256, jose.PS384, jose.PS512,
+	}
+)
+
+// Config is the configuration of a client registered with an OpenID Connect issuer.
+type Config struct {
+	// Issuer is the URL of the issuer, the discovery document is read from below it.
+	Issuer string
+
+	// ClientId is the ID of the client registered with the issuer.
+	ClientId string
+
+	// ClientSecret is the secret of the client, empty for public clients.
+	ClientSecret string
+
+	// RedirectURL is the URL the issuer redirects to after the user has authenticated.
+	RedirectURL string
+
+	// Scopes are the scopes requested in addition to openid.
+	Scopes []string
+}
+
+// Identity is the verified identity of a user from the issuer.
+type Identity struct {
+	// Issuer is the issuer of the identity.
+	Issuer string
+
+	// Subject is the ID of the user at the issuer.
+	Subject string
+
+	// Email is the email address of the user, if the issuer shared it.
+	Email string
+
+	// EmailVerified is whether the issuer has verified the email address.
+	EmailVerified bool
+
+	// Name is the full name of the user, if the issuer shared it.
+	Name string
+
+	// PreferredUsername is the username the user prefers, if the issuer shared it.
+	PreferredUsername string
+}
+
+// Provider runs the authorization code flow with PKCE against an OpenID Connect issuer.
+type Provider interface {
+	// AuthCodeURL returns the URL to send the user to so that they authenticate with the issuer. The code challenge
+	// is the S256 challenge of the code verifier that will be passed to Exchange.
+	AuthCodeURL(ctx context.Context, state, nonce, codeChallenge string) (string, error)
+
+	// Exchange exchanges the authorization code for tokens and returns the identity from the verified ID token.
+	Exchange(ctx context.Context, code, codeVerifier, nonce string) (*Identity, error)
+}
+
+// discovery is the part of the issuer's discovery document that is used.
+type discovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JwksURI               string `json:"jwks_uri"`
+}
+
+// tokenResponse is the response from the token endpoint.
+type tokenResponse struct {
+	IdToken          string `json:"id_token"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// idTokenClaims are the claims of an ID token that are not registered JWT claims.
+type idTokenClaims struct {
+	Nonce             string `json:"nonce"`
+	Email             string `json:"email"`
+	EmailVerified     bool   `json:"email_verified"`
+	Name              string `json:"name"`
+	PreferredUsername string `json:"preferred_username"`
+}
+
+type provider struct {
+	mut sync.Mutex
+
+	cfg Config
+
+	// httpClient is the client used to make requests to the issuer.
+	httpClient *http.Client
+
+	// discovery is the issuer's discovery document, nil until it has been fetched.
+	discovery *discovery
+
+	// keys are the issuer's signing keys.
+	keys *jose.JSONWebKeySet
+
+	// keysFetched is when the keys were last fetched.
+	keysFetched time.Time
+
+	// now is the clock used when verifying ID tokens.
+	now func() time.Time
+}
+
+// ProviderOption is a function that configures the provider.
+type ProviderOption func(p *provider)
+
+// WithHTTPClient sets the http client used to make requests to the issuer.
+func WithHTTPClient(httpClient *http.Client) ProviderOption {
+	return func(p *provider) {
+		p.httpClient = httpClient
+	}
+}
+
+// NewProvider creates a provider for the issuer. The issuer's discovery document is fetched when it is first needed,
+// so that the issuer does not have to be available when the service starts.
+func NewProvider(cfg Config, opts ...ProviderOption) (Provider, error) {
+	switch {
+	case cfg.Issuer == "":
+		return nil, errors.New("issuer is required")
+	case cfg.ClientId == "":
+		return nil, errors.New("client id is required")
+	case cfg.RedirectURL == "":
+		return nil, errors.New("redirect url is required")
+	}
+
+	p := &provider{
+		cfg: cfg,
+		httpClient: &http.Client{
+			Timeout: defaultTimeout,
+		},
+		now: time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p, nil
+}
+
+func (p *provider) AuthCodeURL(ctx context.Context, state, nonce, codeChallenge string) (string, error) {
+	d, err := p.getDiscovery(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	u, err := url.Parse(d.AuthorizationEndpoint)
+	if err != nil {
+		return "", fmt.Errorf("invalid authorization endpoint: %w", err)
+	}
+
+	q := u.Query()
+	q.Set("response_type", "code")
+	q.Set("client_id", p.cfg.ClientId)
+	q.Set("redirect_uri", p.cfg.RedirectURL)
+	q.Set("scope", strings.Join(p.scopes(), " "))
+	q.Set("state", state)
+	q.Set("nonce", nonce)
+	q.Set("code_challenge", codeChallenge)
+	q.Set("code_challenge_method", CodeChallengeMethod)
+	u.RawQuery = q.Encode()
+
+	return u.String(), nil
+}
+
+func (p *provider) Exchange(ctx context.Context, code, codeVerifier, nonce string) (*Identity, error) {
+	d, err := p.getDiscovery(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.cfg.RedirectURL)
+	form.Set("client_id", p.cfg.ClientId)
+	form.Set("code_verifier", codeVerifier)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create token request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if p.cfg.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(p.cfg.ClientId), url.QueryEscape(p.cfg.ClientSecret))
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	token := new(tokenResponse)
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(token); err != nil {
+		return nil, fmt.Errorf("failed to decode token response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("token request failed with status %d: %s %s", resp.StatusCode, token.Error, token.ErrorDescription)
+	} else if token.IdToken == "" {
+		return nil, fmt.Errorf("%w: token response has no id token", ErrInvalidIDToken)
+	}
+
+	return p.verify(ctx, d, token.IdToken, nonce)
+}
+
+// verify verifies the ID token and returns the identity from it.
+func (p *provider) verify(ctx context.Context, d *discovery, idToken, nonce string) (*Identity, error) {
+	parsed, err := jwt.ParseSigned(idToken, signatureAlgorithms)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidIDToken, err)
+	} else if len(parsed.Headers) != 1 {
+		return nil, fmt.Errorf("%w: expected one signature", ErrInvalidIDToken)
+	}
+
+	key, err := p.getKey(ctx, d, parsed.Headers[0].KeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	claims := new(jwt.Claims)
+	private := new(idTokenClaims)
+	if err := parsed.Claims(key, claims, private); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidIDToken, err)
+	}
+
+	err = claims.ValidateWithLeeway(jwt.Expected{
+		Issuer:      d.Issuer,
+		AnyAudience: jwt.Audience{p.cfg.ClientId},
+		Time:        p.now(),
+	}, clockSkew)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidIDToken, err)
+	}
+
+	switch {
+	case claims.Expiry == nil:
+		return nil, fmt.Errorf("%w: missing expiry", ErrInvalidIDToken)
+	case claims.Subject == "":
+		return nil, fmt.Errorf("%w: missing subject", ErrInvalidIDToken)
+	case private.Nonce != nonce:
+		return nil, fmt.Errorf("%w: nonce does not match", ErrInvalidIDToken)
+	}
+
+	return &Identity{
+		Issuer:            claims.Issuer,
+		Subject:           claims.Subject,
+		Email:             private.Email,
+		EmailVerified:     private.EmailVerified,
+		Name:              private.Name,
+		PreferredUsername: private.PreferredUsername,
+	}, nil
+}
+
+// getKey returns the issuer's signing key with the key ID, fetching the keys again if it is not known as the issuer
+// may have rotated its keys.
+func (p *provider) getKey(ctx context.Context, d *discovery, keyId string) (*jose.JSONWebKey, error) {
+	p.mut.Lock()
+	defer p.mut.Unlock()
+
+	if p.keys != nil {
+		if key := findKey(p.keys, keyId); key != nil {
+			return key, nil
+		}
+	}
+
+	if p.keys != nil && p.now().Sub(p.keysFetched) < jwksRefreshInterval {
+		return nil, fmt.Errorf("%w: unknown key %q", ErrInvalidIDToken, keyId)
+	}
+
+	keys := new(jose.JSONWebKeySet)
+	if err := p.getJSON(ctx, d.JwksURI, keys); err != nil {
+		return nil, fmt.Errorf("failed to get issuer keys: %w", err)
+	}
+
+	p.keys = keys
+	p.keysFetched = p.now()
+
+	if key := findKey(p.keys, keyId); key != nil {
+		return key, nil
+	}
+
+	return nil, fmt.Errorf("%w: unknown key %q", ErrInvalidIDToken, keyId)
+}
+
+// getDiscovery returns the issuer's discovery document, fetching it if it has not been fetched yet.
+func (p *provider) getDiscovery(ctx context.Context) (*discovery, error) {
+	p.mut.Lock()
+	defer p.mut.Unlock()
+
+	if p.discovery != nil {
+		return p.discovery, nil
+	}
+
+	d := new(discovery)
+	err := p.getJSON(ctx, strings.TrimSuffix(p.cfg.Issuer, "/")+"/.well-known/openid-configuration", d)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get discovery document: %w", err)
+	}
+
+	switch {
+	case d.Issuer != p.cfg.Issuer:
+		return nil, fmt.Errorf("discovery document is for issuer %q, expected %q", d.Issuer, p.cfg.Issuer)
+	case d.AuthorizationEndpoint == "" || d.TokenEndpoint == "" || d.JwksURI == "":
+		return nil, errors.New("discovery document is missing endpoints")
+	}
+
+	p.discovery = d
+	return d, nil
+}
+
+// getJSON gets the JSON document at the URL.
+func (p *provider) getJSON(ctx context.Context, u string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return nil
+}
+
+// scopes returns the scopes to request, always including openid.
+func (p *provider) scopes() []string {
+	scopes := []string{"openid"}
+	for _, scope := range p.cfg.Scopes {
+		if !slices.Contains(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	return scopes
+}
+
+// findKey returns the signing key with the key ID. If the token did not name a key and the issuer has a single
+// signing key, that key is returned.
+func findKey(keys *jose.JSONWebKeySet, keyId string) *jose.JSONWebKey {
+	if keyId == "" {
+		signing := make([]jose.JSONWebKey, 0, len(keys.Keys))
+		for _, key := range keys.Keys {
+			if key.Use == "" || key.Use == "sig" {
+				signing = append(signing, key)
+			}
+		}
+
+		if len(signing) == 1 {
+			return &signing[0]
+		}
+
+		return nil
+	}
+
+	found := keys.Key(keyId)
+	if len(found) == 0 {
+		return nil
+	}
+
+	return &found[0]
+}
@@ -0,0 +1,254 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+	"github.com/stretchr/testify/require"
+)
+
+// mockIssuer is a local OpenID Connect issuer that issues an ID token for a single authorization code.
+type mockIssuer struct {
+	t      *testing.T
+	server *httptest.Server
+	key    *rsa.PrivateKey
+
+	// code is the authorization code that the token endpoint accepts.
+	code string
+
+	// challenge is the PKCE code challenge that the code was issued for.
+	challenge string
+
+	// claims are the claims of the ID token issued for the code.
+	claims jwt.Claims
+
+	// private are the private claims of the ID token issued for the code.
+	private idTokenClaims
+}
+
+func newMockIssuer(t *testing.T) *mockIssuer {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	m := &mockIssuer{
+		t:    t,
+		key:  key,
+		code: "code",
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		m.writeJSON(w, discovery{
+			Issuer:                m.server.URL,
+			AuthorizationEndpoint: m.server.URL + "/authorize",
+			TokenEndpoint:         m.server.URL + "/token",
+			JwksURI:               m.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		m.writeJSON(w, jose.JSONWebKeySet{Keys: []jose.JSONWebKey{
+			{Key: &m.key.PublicKey, KeyID: "key-1", Algorithm: string(jose.RS256), Use: "sig"},
+		}})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+
+		if r.PostForm.Get("code") != m.code || CodeChallenge(r.PostForm.Get("code_verifier")) != m.challenge {
+			w.WriteHeader(http.StatusBadRequest)
+			m.writeJSON(w, tokenResponse{Error: "invalid_grant"})
+			return
+		}
+
+		m.writeJSON(w, tokenResponse{IdToken: m.sign(m.claims, m.private)})
+	})
+
+	m.server = httptest.NewServer(mux)
+	t.Cleanup(m.server.Close)
+
+	return m
+}
+
+func (m *mockIssuer) writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	require.NoError(m.t, json.NewEncoder(w).Encode(v))
+}
+
+func (m *mockIssuer) sign(claims jwt.Claims, private idTokenClaims) string {
+	signer, err := jose.NewSigner(
+		jose.SigningKey{Algorithm: jose.RS256, Key: m.key},
+		(&jose.SignerOptions{}).WithType("JWT").WithHeader("kid", "key-1"),
+	)
+	require.NoError(m.t, err)
+
+	token, err := jwt.Signed(signer).Claims(claims).Claims(private).Serialize()
+	require.NoError(m.t, err)
+
+	return token
+}
+
+func TestProvider(t *testing.T) {
+	issuer := newMockIssuer(t)
+
+	p, err := NewProvider(Config{
+		Issuer:      issuer.server.URL,
+		ClientId:    "rounder",
+		RedirectURL: "http://localhost/callback",
+		Scopes:      []string{"email", "openid"},
+	})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	verifier, err := NewCodeVerifier()
+	require.NoError(t, err)
+	issuer.challenge = CodeChallenge(verifier)
+
+	authURL, err := p.AuthCodeURL(ctx, "state", "nonce", issuer.challenge)
+	require.NoError(t, err)
+
+	u, err := url.Parse(authURL)
+	require.NoError(t, err)
+	require.Equal(t, issuer.server.URL+"/authorize", u.Scheme+"://"+u.Host+u.Path)
+	require.Equal(t, "openid email", u.Query().Get("scope"))
+	require.Equal(t, issuer.challenge, u.Query().Get("code_challenge"))
+	require.Equal(t, CodeChallengeMethod, u.Query().Get("code_challenge_method"))
+	require.Equal(t, "state", u.Query().Get("state"))
+
+	now := time.Now()
+	validClaims := jwt.Claims{
+		Issuer:   issuer.server.URL,
+		Subject:  "user-1",
+		Audience: jwt.Audience{"rounder"},
+		IssuedAt: jwt.NewNumericDate(now),
+		Expiry:   jwt.NewNumericDate(now.Add(time.Minute)),
+	}
+
+	tests := []struct {
+		name     string
+		claims   func(c jwt.Claims) jwt.Claims
+		nonce    string
+		verifier string
+		want     *Identity
+		wantErr  bool
+	}{
+		{
+			name:     "valid",
+			claims:   func(c jwt.Claims) jwt.Claims { return c },
+			nonce:    "nonce",
+			verifier: verifier,
+			want: &Identity{
+				Issuer:        issuer.server.URL,
+				Subject:       "user-1",
+				Email:         "player@example.com",
+				EmailVerified: true,
+				Name:          "Player One",
+			},
+		},
+		{
+			name:     "wrong nonce",
+			claims:   func(c jwt.Claims) jwt.Claims { return c },
+			nonce:    "other",
+			verifier: verifier,
+			wantErr:  true,
+		},
+		{
+			name:     "wrong code verifier",
+			claims:   func(c jwt.Claims) jwt.Claims { return c },
+			nonce:    "nonce",
+			verifier: "other",
+			wantErr:  true,
+		},
+		{
+			name:     "wrong audience",
+			claims:   func(c jwt.Claims) jwt.Claims { c.Audience = jwt.Audience{"other"}; return c },
+			nonce:    "nonce",
+			verifier: verifier,
+			wantErr:  true,
+		},
+		{
+			name:     "wrong issuer",
+			claims:   func(c jwt.Claims) jwt.Claims { c.Issuer = "https://evil.example.com"; return c },
+			nonce:    "nonce",
+			verifier: verifier,
+			wantErr:  true,
+		},
+		{
+			name: "expired",
+			claims: func(c jwt.Claims) jwt.Claims {
+				c.Expiry = jwt.NewNumericDate(now.Add(-time.Hour))
+				return c
+			},
+			nonce:    "nonce",
+			verifier: verifier,
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issuer.claims = tt.claims(validClaims)
+			issuer.private = idTokenClaims{
+				Nonce:         "nonce",
+				Email:         "player@example.com",
+				EmailVerified: true,
+				Name:          "Player One",
+			}
+
+			got, err := p.Exchange(ctx, issuer.code, tt.verifier, tt.nonce)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestProviderRejectsForeignKey(t *testing.T) {
+	issuer := newMockIssuer(t)
+
+	p, err := NewProvider(Config{
+		Issuer:      issuer.server.URL,
+		ClientId:    "rounder",
+		RedirectURL: "http://localhost/callback",
+	})
+	require.NoError(t, err)
+
+	// Sign the token with a key the issuer does not publish.
+	other, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	issuer.key, other = other, issuer.key
+
+	now := time.Now()
+	token := issuer.sign(jwt.Claims{
+		Issuer:   issuer.server.URL,
+		Subject:  "user-1",
+		Audience: jwt.Audience{"rounder"},
+		Expiry:   jwt.NewNumericDate(now.Add(time.Minute)),
+	}, idTokenClaims{Nonce: "nonce"})
+
+	// Publish the original key again.
+	issuer.key = other
+
+	d, err := p.(*provider).getDiscovery(context.Background())
+	require.NoError(t, err)
+
+	_, err = p.(*provider).verify(context.Background(), d, token, "nonce")
+	require.ErrorIs(t, err, ErrInvalidIDToken)
+}
+
+func TestCodeChallenge(t *testing.T) {
+	// Example from RFC 7636 appendix B.
+	require.Equal(t, "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM", CodeChallenge("dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"))
+}
@@ -0,0 +1,3 @@
+package policy
+
+//go:generate go run -mod=mod github.com/vektra/mockery/v2 --inpackage --all --recursive
@@ -0,0 +1,122 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/auth"
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/models"
+	repo "github.com/Jacobbrewer1/golf-stats-tracker/pkg/repositories/rounder"
+)
+
+var (
+	// ErrForbidden is returned when the user is not allowed to perform the action.
+	ErrForbidden = errors.New("forbidden")
+)
+
+// Action is what a user wants to do with a resource.
+type Action string
+
+const (
+	// ActionRead is reading a resource.
+	ActionRead Action = "read"
+
+	// ActionWrite is creating, updating or deleting a resource.
+	ActionWrite Action = "write"
+)
+
+// Policy decides what users are allowed to do. Users can do anything with their own data. Coaches can read the data
+// of the players that have granted them access, and admins can read the data of all users and manage users.
+type Policy interface {
+	// AuthorizeUserData returns ErrForbidden if the user cannot perform the action on the data owned by the owner.
+	AuthorizeUserData(ctx context.Context, userId int, ownerId int, action Action) error
+
+	// AuthorizeRound returns ErrForbidden if the user cannot perform the action on the round.
+	AuthorizeRound(ctx context.Context, userId int, round *models.Round, action Action) error
+
+	// AuthorizeRole returns ErrForbidden if the user does not have the role.
+	AuthorizeRole(ctx context.Context, userId int, role auth.Role) error
+}
+
+type policy struct {
+	r repo.Repository
+}
+
+// NewPolicy creates a policy that reads the roles of users and the grants of players from the repository.
+func NewPolicy(r repo.Repository) Policy {
+	return &policy{
+		r: r,
+	}
+}
+
+func (p *policy) AuthorizeUserData(ctx context.Context, userId int, ownerId int, action Action) error {
+	if userId <= 0 {
+		return ErrForbidden
+	} else if userId == ownerId {
+		return nil
+	} else if action != ActionRead {
+		return ErrForbidden
+	}
+
+	role, err := p.role(ctx, userId)
+	if err != nil {
+		return err
+	}
+
+	switch role {
+	case auth.RoleAdmin:
+		return nil
+	case auth.RoleCoach:
+		_, err := p.r.GetCoachGrant(ctx, ownerId, userId)
+		switch {
+		case err == nil:
+			return nil
+		case errors.Is(err, repo.ErrCoachGrantNotFound):
+			return ErrForbidden
+		default:
+			return fmt.Errorf("error getting coach grant: %w", err)
+		}
+	default:
+		return ErrForbidden
+	}
+}
+
+func (p *policy) AuthorizeRound(ctx context.Context, userId int, round *models.Round, action Action) error {
+	return p.AuthorizeUserData(ctx, userId, round.UserId, action)
+}
+
+func (p *policy) AuthorizeRole(ctx context.Context, userId int, role auth.Role) error {
+	if userId <= 0 {
+		return ErrForbidden
+	}
+
+	userRole, err := p.role(ctx, userId)
+	if err != nil {
+		return err
+	} else if userRole != role {
+		return ErrForbidden
+	}
+
+	return nil
+}
+
+// role gets the role of the user.
+func (p *policy) role(ctx context.Context, userId int) (auth.Role, error) {
+	user, err := p.r.GetUserById(ctx, userId)
+	if err != nil {
+		switch {
+		case errors.Is(err, repo.ErrUserNotFound):
+			return "", ErrForbidden
+		default:
+			return "", fmt.Errorf("error getting user: %w", err)
+		}
+	}
+
+	// Users created before roles were added are players.
+	if user.Role == "" {
+		return auth.RolePlayer, nil
+	}
+
+	return auth.ParseRole(user.Role)
+}
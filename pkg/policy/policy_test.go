@@ -0,0 +1,103 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/auth"
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/models"
+	repo "github.com/Jacobbrewer1/golf-stats-tracker/pkg/repositories/rounder"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthorizeUserData(t *testing.T) {
+	const (
+		playerId = 1
+		coachId  = 2
+		adminId  = 3
+		otherId  = 4
+	)
+
+	users := map[int]*models.User{
+		playerId: {Id: playerId, Role: string(auth.RolePlayer)},
+		coachId:  {Id: coachId, Role: string(auth.RoleCoach)},
+		adminId:  {Id: adminId, Role: string(auth.RoleAdmin)},
+		otherId:  {Id: otherId, Role: string(auth.RoleCoach)},
+	}
+
+	tests := []struct {
+		name    string
+		userId  int
+		ownerId int
+		action  Action
+		wantErr error
+	}{
+		{name: "owner can read", userId: playerId, ownerId: playerId, action: ActionRead},
+		{name: "owner can write", userId: playerId, ownerId: playerId, action: ActionWrite},
+		{name: "no user", userId: -1, ownerId: playerId, action: ActionRead, wantErr: ErrForbidden},
+		{name: "player cannot read others", userId: playerId, ownerId: otherId, action: ActionRead, wantErr: ErrForbidden},
+		{name: "granted coach can read", userId: coachId, ownerId: playerId, action: ActionRead},
+		{name: "granted coach cannot write", userId: coachId, ownerId: playerId, action: ActionWrite, wantErr: ErrForbidden},
+		{name: "coach without grant cannot read", userId: otherId, ownerId: playerId, action: ActionRead, wantErr: ErrForbidden},
+		{name: "admin can read", userId: adminId, ownerId: playerId, action: ActionRead},
+		{name: "admin cannot write", userId: adminId, ownerId: playerId, action: ActionWrite, wantErr: ErrForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := repo.NewMockRepository(t)
+			r.On("GetUserById", mock.Anything, tt.userId).Return(users[tt.userId], nil).Maybe()
+			r.On("GetCoachGrant", mock.Anything, playerId, coachId).Return(&models.CoachGrant{PlayerId: playerId, CoachId: coachId}, nil).Maybe()
+			r.On("GetCoachGrant", mock.Anything, playerId, otherId).Return(nil, repo.ErrCoachGrantNotFound).Maybe()
+
+			err := NewPolicy(r).AuthorizeUserData(context.Background(), tt.userId, tt.ownerId, tt.action)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestAuthorizeRole(t *testing.T) {
+	tests := []struct {
+		name    string
+		user    *models.User
+		userErr error
+		role    auth.Role
+		wantErr error
+	}{
+		{name: "has role", user: &models.User{Id: 1, Role: string(auth.RoleAdmin)}, role: auth.RoleAdmin},
+		{name: "does not have role", user: &models.User{Id: 1, Role: string(auth.RoleCoach)}, role: auth.RoleAdmin, wantErr: ErrForbidden},
+		{name: "empty role is player", user: &models.User{Id: 1}, role: auth.RolePlayer},
+		{name: "user not found", userErr: repo.ErrUserNotFound, role: auth.RoleAdmin, wantErr: ErrForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := repo.NewMockRepository(t)
+			r.On("GetUserById", mock.Anything, 1).Return(tt.user, tt.userErr)
+
+			err := NewPolicy(r).AuthorizeRole(context.Background(), 1, tt.role)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+
+	t.Run("repository error", func(t *testing.T) {
+		r := repo.NewMockRepository(t)
+		r.On("GetUserById", mock.Anything, 1).Return(nil, errors.New("connection refused"))
+
+		err := NewPolicy(r).AuthorizeRole(context.Background(), 1, auth.RoleAdmin)
+		require.Error(t, err)
+		require.NotErrorIs(t, err, ErrForbidden)
+	})
+}
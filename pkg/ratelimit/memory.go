@@ -0,0 +1,83 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// memorySweepInterval is how often full buckets are removed from the memory store.
+const memorySweepInterval = time.Minute
+
+type bucket struct {
+	// tokens is the number of tokens in the bucket when it was last updated.
+	tokens float64
+
+	// updated is when the bucket was last updated.
+	updated time.Time
+
+	// full is when the bucket will be full again.
+	full time.Time
+}
+
+type memoryStore struct {
+	mut sync.Mutex
+
+	// buckets holds the bucket for each key.
+	buckets map[string]*bucket
+
+	// lastSweep is when full buckets were last removed.
+	lastSweep time.Time
+
+	// now is the clock used by the store.
+	now func() time.Time
+}
+
+// NewMemoryStore creates a store that holds the buckets in memory. The limits are not shared between instances of
+// the service.
+func NewMemoryStore() Store {
+	return &memoryStore{
+		buckets:   make(map[string]*bucket),
+		lastSweep: time.Now(),
+		now:       time.Now,
+	}
+}
+
+func (s *memoryStore) Take(_ context.Context, key string, limit Limit) (*Result, error) {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	now := s.now()
+	s.sweep(now)
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{
+			tokens:  float64(limit.Burst),
+			updated: now,
+		}
+		s.buckets[key] = b
+	}
+
+	tokens, res := take(b.tokens, b.updated, now, limit)
+	b.tokens = tokens
+	b.updated = now
+	b.full = now.Add(res.ResetAfter)
+
+	return res, nil
+}
+
+// sweep removes the buckets that are full, as they are the same as a new bucket.
+func (s *memoryStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < memorySweepInterval {
+		return
+	}
+
+	for key, b := range s.buckets {
+		if !now.Before(b.full) {
+			delete(s.buckets, key)
+		}
+	}
+
+	s.lastSweep = now
+}
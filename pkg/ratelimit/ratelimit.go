@@ -0,0 +1,76 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// Limit is the rate that requests are allowed at.
+type Limit struct {
+	// Rate is the number of requests allowed per second.
+	Rate float64
+
+	// Burst is the maximum number of requests allowed at once.
+	Burst int
+}
+
+// Result is the outcome of taking a token from a bucket.
+type Result struct {
+	// Allowed is whether the request is allowed.
+	Allowed bool
+
+	// Limit is the burst of the bucket.
+	Limit int
+
+	// Remaining is the number of whole tokens left in the bucket.
+	Remaining int
+
+	// RetryAfter is how long to wait before a token is available, zero if the request was allowed.
+	RetryAfter time.Duration
+
+	// ResetAfter is how long until the bucket is full again.
+	ResetAfter time.Duration
+}
+
+// Store holds the token buckets.
+type Store interface {
+	// Take takes a token from the bucket for the key, creating a full bucket if there is not one.
+	Take(ctx context.Context, key string, limit Limit) (*Result, error)
+}
+
+// take takes a token from a bucket that had the given number of tokens when it was last updated, returning the
+// number of tokens left in the bucket and the result.
+func take(tokens float64, updated time.Time, now time.Time, limit Limit) (float64, *Result) {
+	burst := float64(limit.Burst)
+
+	// Refill the bucket for the time since it was last updated.
+	if elapsed := now.Sub(updated).Seconds(); elapsed > 0 {
+		tokens = math.Min(burst, tokens+elapsed*limit.Rate)
+	}
+
+	res := &Result{
+		Limit: limit.Burst,
+	}
+
+	if tokens >= 1 {
+		tokens--
+		res.Allowed = true
+	} else {
+		res.RetryAfter = rateDuration(1-tokens, limit.Rate)
+	}
+
+	res.Remaining = int(math.Floor(tokens))
+	res.ResetAfter = rateDuration(burst-tokens, limit.Rate)
+
+	return tokens, res
+}
+
+// rateDuration returns how long it takes to refill the number of tokens at the rate.
+func rateDuration(tokens float64, rate float64) time.Duration {
+	if rate <= 0 {
+		return 0
+	}
+
+	return time.Duration(tokens / rate * float64(time.Second))
+}
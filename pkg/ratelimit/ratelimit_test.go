@@ -0,0 +1,97 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTake(t *testing.T) {
+	now := time.Now()
+	limit := Limit{Rate: 2, Burst: 4}
+
+	tests := []struct {
+		name       string
+		tokens     float64
+		updated    time.Time
+		wantTokens float64
+		want       *Result
+	}{
+		{
+			name:       "full bucket",
+			tokens:     4,
+			updated:    now,
+			wantTokens: 3,
+			want:       &Result{Allowed: true, Limit: 4, Remaining: 3, ResetAfter: 500 * time.Millisecond},
+		},
+		{
+			name:       "empty bucket",
+			tokens:     0,
+			updated:    now,
+			wantTokens: 0,
+			want:       &Result{Allowed: false, Limit: 4, Remaining: 0, RetryAfter: 500 * time.Millisecond, ResetAfter: 2 * time.Second},
+		},
+		{
+			name:       "refilled since last update",
+			tokens:     0,
+			updated:    now.Add(-time.Second),
+			wantTokens: 1,
+			want:       &Result{Allowed: true, Limit: 4, Remaining: 1, ResetAfter: 1500 * time.Millisecond},
+		},
+		{
+			name:       "refill is capped at the burst",
+			tokens:     0,
+			updated:    now.Add(-time.Hour),
+			wantTokens: 3,
+			want:       &Result{Allowed: true, Limit: 4, Remaining: 3, ResetAfter: 500 * time.Millisecond},
+		},
+		{
+			name:       "partial token",
+			tokens:     0.5,
+			updated:    now,
+			wantTokens: 0.5,
+			want:       &Result{Allowed: false, Limit: 4, Remaining: 0, RetryAfter: 250 * time.Millisecond, ResetAfter: 1750 * time.Millisecond},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokens, res := take(tt.tokens, tt.updated, now, limit)
+			require.InDelta(t, tt.wantTokens, tokens, 0.0001)
+			require.Equal(t, tt.want, res)
+		})
+	}
+}
+
+func TestMemoryStore(t *testing.T) {
+	now := time.Now()
+	s := NewMemoryStore().(*memoryStore)
+	s.now = func() time.Time { return now }
+
+	limit := Limit{Rate: 1, Burst: 2}
+
+	for i, wantAllowed := range []bool{true, true, false} {
+		res, err := s.Take(context.Background(), "a", limit)
+		require.NoError(t, err)
+		require.Equal(t, wantAllowed, res.Allowed, "request %d", i)
+	}
+
+	// Other keys have their own bucket.
+	res, err := s.Take(context.Background(), "b", limit)
+	require.NoError(t, err)
+	require.True(t, res.Allowed)
+
+	now = now.Add(time.Second)
+	res, err = s.Take(context.Background(), "a", limit)
+	require.NoError(t, err)
+	require.True(t, res.Allowed)
+	require.Equal(t, 0, res.Remaining)
+
+	// Full buckets are removed by the sweep.
+	now = now.Add(time.Hour)
+	_, err = s.Take(context.Background(), "c", limit)
+	require.NoError(t, err)
+	require.Len(t, s.buckets, 1)
+}
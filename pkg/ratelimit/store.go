@@ -0,0 +1,95 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/logging"
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/models"
+)
+
+const (
+	// databaseSweepInterval is how often idle buckets are removed from the database.
+	databaseSweepInterval = time.Hour
+
+	// databaseBucketIdle is how long a bucket is kept in the database after it was last used.
+	databaseBucketIdle = 24 * time.Hour
+)
+
+type databaseStore struct {
+	mut sync.Mutex
+
+	db models.DBTransactioner
+
+	// lastSweep is when idle buckets were last removed.
+	lastSweep time.Time
+}
+
+// NewDatabaseStore creates a store backed by the rate_limit_bucket table, so that the limits are shared between
+// instances of the service.
+func NewDatabaseStore(db models.DBTransactioner) Store {
+	return &databaseStore{
+		db:        db,
+		lastSweep: time.Now(),
+	}
+}
+
+func (s *databaseStore) Take(ctx context.Context, key string, limit Limit) (*Result, error) {
+	s.sweep(ctx)
+
+	res := new(Result)
+	err := models.NewDBTransactionHandler(s.db).Handle(ctx, func(db models.DB) error {
+		now := time.Now().UTC()
+
+		// Create the bucket full if it is missing before locking it. Locking a missing row takes a gap lock, which
+		// deadlocks two instances that both then insert the row for a new key.
+		_, err := db.ExecContext(ctx, `INSERT INTO rate_limit_bucket (bucket_key, tokens, updated_at) VALUES (?, ?, ?) ON DUPLICATE KEY UPDATE bucket_key = bucket_key`, key, float64(limit.Burst), now)
+		if err != nil {
+			return fmt.Errorf("failed to create bucket: %w", err)
+		}
+
+		// Lock the bucket so that concurrent requests from other instances take tokens one at a time.
+		b := new(models.RateLimitBucket)
+		err = db.GetContext(ctx, b, `SELECT bucket_key, tokens, updated_at FROM rate_limit_bucket WHERE bucket_key = ? FOR UPDATE`, key)
+		if err != nil {
+			return fmt.Errorf("failed to get bucket: %w", err)
+		}
+
+		var tokens float64
+		tokens, res = take(b.Tokens, b.UpdatedAt, now, limit)
+
+		b.Tokens = tokens
+		b.UpdatedAt = now
+
+		err = b.Update(ctx, db)
+		if err != nil {
+			return fmt.Errorf("failed to save bucket: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// sweep removes the buckets that have not been used for a day, as they will have refilled.
+func (s *databaseStore) sweep(ctx context.Context) {
+	s.mut.Lock()
+	if time.Since(s.lastSweep) < databaseSweepInterval {
+		s.mut.Unlock()
+		return
+	}
+	s.lastSweep = time.Now()
+	s.mut.Unlock()
+
+	_, err := s.db.ExecContext(ctx, `DELETE FROM rate_limit_bucket WHERE updated_at < ?`, time.Now().UTC().Add(-databaseBucketIdle))
+	if err != nil {
+		slog.Error("Error removing idle rate limit buckets", slog.String(logging.KeyError, err.Error()))
+	}
+}
@@ -0,0 +1,90 @@
+package rounder
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/models"
+)
+
+var (
+	// ErrApiKeyNotFound is returned when the API key is not found.
+	ErrApiKeyNotFound = errors.New("api key not found")
+)
+
+func (r *repository) CreateApiKey(ctx context.Context, apiKey *models.ApiKey) error {
+	apiKey.Id = 0
+	return apiKey.Insert(ctx, r.db)
+}
+
+func (r *repository) UpdateApiKeyLastUsed(ctx context.Context, id int, usedAt time.Time) error {
+	// Only the last used time is written, so that a concurrent revoke of the key is not undone.
+	_, err := r.db.ExecContext(ctx, `UPDATE api_key SET last_used_at = ? WHERE id = ? AND revoked_at IS NULL`, usedAt, id)
+	if err != nil {
+		return fmt.Errorf("failed to update api key last used: %w", err)
+	}
+
+	return nil
+}
+
+func (r *repository) RevokeApiKey(ctx context.Context, id int) error {
+	_, err := r.db.ExecContext(ctx, `UPDATE api_key SET revoked_at = ? WHERE id = ? AND revoked_at IS NULL`, time.Now().UTC(), id)
+	if err != nil {
+		return fmt.Errorf("failed to revoke api key: %w", err)
+	}
+
+	return nil
+}
+
+func (r *repository) GetApiKeyById(ctx context.Context, id int) (*models.ApiKey, error) {
+	apiKey, err := models.ApiKeyById(ctx, r.db, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrApiKeyNotFound
+		default:
+			return nil, fmt.Errorf("failed to get api key by ID: %w", err)
+		}
+	}
+
+	return apiKey, nil
+}
+
+func (r *repository) GetApiKeyByKeyHash(ctx context.Context, hash string) (*models.ApiKey, error) {
+	apiKey, err := models.ApiKeyByKeyHash(ctx, r.db, hash)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrApiKeyNotFound
+		default:
+			return nil, fmt.Errorf("failed to get api key by key: %w", err)
+		}
+	}
+
+	return apiKey, nil
+}
+
+func (r *repository) GetActiveApiKeysByUserId(ctx context.Context, userId int) (*PaginationResponse[models.ApiKey], error) {
+	sqlStmt := `
+		SELECT ` + columns(models.ApiKey{}, "api_key", "") + `
+		FROM api_key
+		WHERE user_id = ?
+		  AND revoked_at IS NULL
+		  AND (expires_at IS NULL OR expires_at > ?)
+		ORDER BY created_at DESC
+	`
+
+	apiKeys := make([]*models.ApiKey, 0)
+	err := r.db.SelectContext(ctx, &apiKeys, sqlStmt, userId, time.Now().UTC())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get api keys: %w", err)
+	}
+
+	return &PaginationResponse[models.ApiKey]{
+		Items: apiKeys,
+		Total: int64(len(apiKeys)),
+	}, nil
+}
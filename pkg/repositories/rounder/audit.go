@@ -0,0 +1,81 @@
+package rounder
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/models"
+)
+
+// defaultAuditEventLimit is the number of audit events returned when no limit is given.
+const defaultAuditEventLimit = 100
+
+func (r *repository) CreateAuditEvent(ctx context.Context, event *models.AuditEvent) error {
+	event.Id = 0
+	return event.Insert(ctx, r.db)
+}
+
+func (r *repository) GetAuditEvents(ctx context.Context, filter *AuditEventFilter) (*PaginationResponse[models.AuditEvent], error) {
+	where := make([]string, 0)
+	args := make([]any, 0)
+
+	if filter.ActorId != nil {
+		where = append(where, "actor_id = ?")
+		args = append(args, *filter.ActorId)
+	}
+
+	if filter.Action != "" {
+		where = append(where, "action = ?")
+		args = append(args, filter.Action)
+	}
+
+	if filter.TargetType != "" {
+		where = append(where, "target_type = ?")
+		args = append(args, filter.TargetType)
+	}
+
+	if filter.TargetId != nil {
+		where = append(where, "target_id = ?")
+		args = append(args, *filter.TargetId)
+	}
+
+	if filter.Since != nil {
+		where = append(where, "created_at >= ?")
+		args = append(args, *filter.Since)
+	}
+
+	if filter.Until != nil {
+		where = append(where, "created_at < ?")
+		args = append(args, *filter.Until)
+	}
+
+	whereClause := ""
+	if len(where) > 0 {
+		whereClause = "WHERE " + strings.Join(where, " AND ")
+	}
+
+	var total int64
+	err := r.db.GetContext(ctx, &total, `SELECT COUNT(*) FROM audit_event `+whereClause, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error counting audit events: %w", err)
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultAuditEventLimit
+	}
+
+	sqlStmt := `SELECT ` + columns(models.AuditEvent{}, "audit_event", "") + ` FROM audit_event ` + whereClause + ` ORDER BY created_at DESC, id DESC LIMIT ?`
+
+	events := make([]*models.AuditEvent, 0)
+	err = r.db.SelectContext(ctx, &events, sqlStmt, append(args, limit)...)
+	if err != nil {
+		return nil, fmt.Errorf("error getting audit events: %w", err)
+	}
+
+	return &PaginationResponse[models.AuditEvent]{
+		Items: events,
+		Total: total,
+	}, nil
+}
@@ -0,0 +1,72 @@
+package rounder
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/models"
+)
+
+var (
+	// ErrCoachGrantNotFound is returned when the coach grant is not found.
+	ErrCoachGrantNotFound = errors.New("coach grant not found")
+)
+
+func (r *repository) CreateCoachGrant(ctx context.Context, grant *models.CoachGrant) error {
+	grant.Id = 0
+	return grant.Insert(ctx, r.db)
+}
+
+func (r *repository) GetCoachGrant(ctx context.Context, playerId int, coachId int) (*models.CoachGrant, error) {
+	grant, err := models.CoachGrantByPlayerIdCoachId(ctx, r.db, playerId, coachId)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrCoachGrantNotFound
+		default:
+			return nil, fmt.Errorf("error getting coach grant: %w", err)
+		}
+	}
+
+	return grant, nil
+}
+
+func (r *repository) DeleteCoachGrant(ctx context.Context, playerId int, coachId int) error {
+	res, err := r.db.ExecContext(ctx, `DELETE FROM coach_grant WHERE player_id = ? AND coach_id = ?`, playerId, coachId)
+	if err != nil {
+		return fmt.Errorf("error deleting coach grant: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error getting affected rows: %w", err)
+	} else if affected == 0 {
+		return ErrCoachGrantNotFound
+	}
+
+	return nil
+}
+
+func (r *repository) GetCoachGrantsByPlayerId(ctx context.Context, playerId int) (*PaginationResponse[models.CoachGrant], error) {
+	return r.getCoachGrants(ctx, `SELECT `+columns(models.CoachGrant{}, "coach_grant", "")+` FROM coach_grant WHERE player_id = ? ORDER BY created_at`, playerId)
+}
+
+func (r *repository) GetCoachGrantsByCoachId(ctx context.Context, coachId int) (*PaginationResponse[models.CoachGrant], error) {
+	return r.getCoachGrants(ctx, `SELECT `+columns(models.CoachGrant{}, "coach_grant", "")+` FROM coach_grant WHERE coach_id = ? ORDER BY created_at`, coachId)
+}
+
+// getCoachGrants gets the coach grants selected by the query.
+func (r *repository) getCoachGrants(ctx context.Context, sqlStmt string, args ...any) (*PaginationResponse[models.CoachGrant], error) {
+	grants := make([]*models.CoachGrant, 0)
+	err := r.db.SelectContext(ctx, &grants, sqlStmt, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error getting coach grants: %w", err)
+	}
+
+	return &PaginationResponse[models.CoachGrant]{
+		Items: grants,
+		Total: int64(len(grants)),
+	}, nil
+}
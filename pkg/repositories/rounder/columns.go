@@ -0,0 +1,34 @@
+package rounder
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// columns returns the select list of the columns of a model, qualified with the table alias. If a prefix is given,
+// each column is named prefix.column, so that sqlx scans it into the field tagged with the prefix. This lets a single
+// join load a model and the models that it references, instead of loading each of them by ID.
+func columns(model any, alias, prefix string) string {
+	t := reflect.TypeOf(model)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	cols := make([]string, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("db"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+
+		col := fmt.Sprintf("%s.`%s`", alias, name)
+		if prefix != "" {
+			col += fmt.Sprintf(" AS `%s.%s`", prefix, name)
+		}
+
+		cols = append(cols, col)
+	}
+
+	return strings.Join(cols, ", ")
+}
@@ -1,13 +1,296 @@
 package rounder
 
-import "github.com/Jacobbrewer1/golf-stats-tracker/pkg/models"
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
 
-func (r *repository) CreateCourse(course *models.Course) error {
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/models"
+	usql "github.com/Jacobbrewer1/golf-stats-tracker/pkg/utils/sql"
+)
+
+var (
+	ErrCourseNotFound        = errors.New("course not found")
+	ErrCourseDetailsNotFound = errors.New("course details not found")
+)
+
+func (r *repository) CreateCourse(ctx context.Context, course *models.Course) error {
 	course.Id = 0
-	return course.Insert(r.db)
+	return course.Insert(ctx, r.db)
+}
+
+func (r *repository) UpdateCourse(ctx context.Context, course *models.Course) error {
+	err := course.Update(ctx, r.db)
+	if err != nil && !errors.Is(err, models.ErrNoAffectedRows) {
+		return fmt.Errorf("failed to update course: %w", err)
+	}
+
+	return nil
+}
+
+func (r *repository) CreateCustomCourse(ctx context.Context, course *models.Course, teeSets []*TeeSet) error {
+	return models.NewDBTransactionHandler(r.db).Handle(ctx, func(db models.DB) error {
+		course.Id = 0
+		err := course.Insert(ctx, db)
+		if err != nil {
+			return fmt.Errorf("failed to create course: %w", err)
+		}
+
+		return createTeeSets(ctx, db, course.Id, teeSets)
+	})
+}
+
+func (r *repository) UpdateCustomCourse(ctx context.Context, course *models.Course, teeSets []*TeeSet) error {
+	return models.NewDBTransactionHandler(r.db).Handle(ctx, func(db models.DB) error {
+		err := course.Update(ctx, db)
+		if err != nil && !errors.Is(err, models.ErrNoAffectedRows) {
+			return fmt.Errorf("failed to update course: %w", err)
+		}
+
+		return createTeeSets(ctx, db, course.Id, teeSets)
+	})
+}
+
+// createTeeSets creates the tee sets of a course and their holes.
+func createTeeSets(ctx context.Context, db models.DB, courseId int, teeSets []*TeeSet) error {
+	for _, ts := range teeSets {
+		ts.Details.Id = 0
+		ts.Details.CourseId = courseId
+		err := ts.Details.Insert(ctx, db)
+		if err != nil {
+			return fmt.Errorf("failed to create course details: %w", err)
+		}
+
+		for _, h := range ts.Holes {
+			h.Id = 0
+			h.CourseDetailsId = ts.Details.Id
+			err = h.Insert(ctx, db)
+			if err != nil {
+				return fmt.Errorf("failed to create hole: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (r *repository) GetCourseById(ctx context.Context, id int) (*models.Course, error) {
+	course, err := models.CourseById(ctx, r.db, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrCourseNotFound
+		default:
+			return nil, fmt.Errorf("failed to get course by ID: %w", err)
+		}
+	}
+
+	return course, nil
+}
+
+func (r *repository) GetCoursesByUserId(ctx context.Context, userId int) (*PaginationResponse[models.Course], error) {
+	sqlStmt := `SELECT ` + columns(models.Course{}, "c", "") + ` FROM course c WHERE c.user_id = ? ORDER BY c.name`
+
+	courses := make([]*models.Course, 0)
+	err := r.db.SelectContext(ctx, &courses, sqlStmt, userId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get courses: %w", err)
+	}
+
+	return &PaginationResponse[models.Course]{
+		Items: courses,
+		Total: int64(len(courses)),
+	}, nil
+}
+
+func (r *repository) DeleteCourse(ctx context.Context, courseId int) error {
+	return models.NewDBTransactionHandler(r.db).Handle(ctx, func(db models.DB) error {
+		sqlStmt := `
+		DELETE FROM hole
+		WHERE course_details_id IN (SELECT id FROM course_details WHERE course_id = ?)
+		`
+
+		_, err := db.ExecContext(ctx, sqlStmt, courseId)
+		if err != nil {
+			return fmt.Errorf("failed to delete holes: %w", err)
+		}
+
+		_, err = db.ExecContext(ctx, `DELETE FROM course_details WHERE course_id = ?`, courseId)
+		if err != nil {
+			return fmt.Errorf("failed to delete course details: %w", err)
+		}
+
+		err = (&models.Course{Id: courseId}).Delete(ctx, db)
+		if err != nil {
+			return fmt.Errorf("failed to delete course: %w", err)
+		}
+
+		return nil
+	})
+}
+
+func (r *repository) CountRoundsByCourseId(ctx context.Context, courseId int) (int, error) {
+	sqlStmt := `
+	SELECT COUNT(*)
+	FROM round r
+		INNER JOIN course_details cd ON r.course_details_id = cd.id
+	WHERE cd.course_id = ?
+	`
+
+	var count int
+	err := r.db.GetContext(ctx, &count, sqlStmt, courseId)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count rounds: %w", err)
+	}
+
+	return count, nil
+}
+
+func (r *repository) GetCourseByExternalId(ctx context.Context, externalId int) (*models.Course, error) {
+	course, err := models.CourseByExternalId(ctx, r.db, *usql.NewNullInt64(int64(externalId)))
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrCourseNotFound
+		default:
+			return nil, fmt.Errorf("failed to get course by external ID: %w", err)
+		}
+	}
+
+	return course, nil
 }
 
-func (r *repository) CreateCourseDetails(courseDetails *models.CourseDetails) error {
+func (r *repository) CreateCourseDetails(ctx context.Context, courseDetails *models.CourseDetails) error {
 	courseDetails.Id = 0
-	return courseDetails.Insert(r.db)
+	return courseDetails.Insert(ctx, r.db)
+}
+
+func (r *repository) GetLatestCourseDetails(ctx context.Context, courseId int, externalId int) (*models.CourseDetails, error) {
+	return latestCourseDetails(ctx, r.db, courseId, externalId)
+}
+
+func latestCourseDetails(ctx context.Context, db models.DB, courseId int, externalId int) (*models.CourseDetails, error) {
+	sqlStmt := `
+	SELECT id
+	FROM course_details
+	WHERE course_id = ?
+		AND external_id = ?
+	ORDER BY version DESC
+	LIMIT 1
+	`
+
+	var id int
+	err := db.GetContext(ctx, &id, sqlStmt, courseId, externalId)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrCourseDetailsNotFound
+		default:
+			return nil, fmt.Errorf("failed to get course details ID: %w", err)
+		}
+	}
+
+	return models.CourseDetailsById(ctx, db, id)
+}
+
+func (r *repository) ImportTeeSet(ctx context.Context, course *models.Course, teeSet *TeeSet) (int, error) {
+	var detailsId int
+	err := models.NewDBTransactionHandler(r.db).Handle(ctx, func(db models.DB) error {
+		// The upsert locks the course, so concurrent imports of the same course wait for each other rather than
+		// failing on the unique external ID or creating the same version of the tee set twice.
+		sqlStmt := `
+		INSERT INTO course (external_id, name)
+		VALUES (?, ?)
+		ON DUPLICATE KEY UPDATE name = VALUES(name)
+		`
+
+		_, err := db.ExecContext(ctx, sqlStmt, course.ExternalId, course.Name)
+		if err != nil {
+			return fmt.Errorf("failed to save course: %w", err)
+		}
+
+		c, err := models.CourseByExternalId(ctx, db, course.ExternalId)
+		if err != nil {
+			return fmt.Errorf("failed to get course by external ID: %w", err)
+		}
+		*course = *c
+
+		teeSet.Details.CourseId = course.Id
+		teeSet.Details.Version = 1
+
+		latest, err := latestCourseDetails(ctx, db, course.Id, teeSet.Details.ExternalId)
+		switch {
+		case errors.Is(err, ErrCourseDetailsNotFound):
+		case err != nil:
+			return err
+		default:
+			holes := make([]*models.Hole, 0)
+			err = db.SelectContext(ctx, &holes, `SELECT `+columns(models.Hole{}, "h", "")+` FROM hole h WHERE h.course_details_id = ?`, latest.Id)
+			if err != nil {
+				return fmt.Errorf("failed to get holes: %w", err)
+			}
+
+			if teeSet.Equal(&TeeSet{Details: latest, Holes: holes}) {
+				detailsId = latest.Id
+				return nil
+			}
+
+			teeSet.Details.Version = latest.Version + 1
+		}
+
+		err = createTeeSets(ctx, db, course.Id, []*TeeSet{teeSet})
+		if err != nil {
+			return err
+		}
+
+		detailsId = teeSet.Details.Id
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return detailsId, nil
+}
+
+func (r *repository) GetCourseTeeSets(ctx context.Context, courseId int) (*PaginationResponse[models.CourseDetails], error) {
+	sqlStmt := `
+	SELECT ` + columns(models.CourseDetails{}, "cd", "") + `
+	FROM course_details cd
+	WHERE cd.course_id = ?
+		AND cd.version = (
+			SELECT MAX(v.version)
+			FROM course_details v
+			WHERE v.course_id = cd.course_id
+				AND v.external_id = cd.external_id
+		)
+	ORDER BY cd.external_id
+	`
+
+	details := make([]*models.CourseDetails, 0)
+	err := r.db.SelectContext(ctx, &details, sqlStmt, courseId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get course details: %w", err)
+	}
+
+	return &PaginationResponse[models.CourseDetails]{
+		Items: details,
+		Total: int64(len(details)),
+	}, nil
+}
+
+func (r *repository) GetCourseDetailsHoles(ctx context.Context, courseDetailsId int) (*PaginationResponse[models.Hole], error) {
+	sqlStmt := `SELECT ` + columns(models.Hole{}, "h", "") + ` FROM hole h WHERE h.course_details_id = ? ORDER BY h.number`
+
+	holes := make([]*models.Hole, 0)
+	err := r.db.SelectContext(ctx, &holes, sqlStmt, courseDetailsId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get holes: %w", err)
+	}
+
+	return &PaginationResponse[models.Hole]{
+		Items: holes,
+		Total: int64(len(holes)),
+	}, nil
 }
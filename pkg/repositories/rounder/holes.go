@@ -1,6 +1,7 @@
 package rounder
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
@@ -9,73 +10,68 @@ import (
 )
 
 var (
-	ErrNoHolesFound = errors.New("no holes found")
 	ErrNoStatsFound = errors.New("no stats found")
 )
 
-func (r *repository) CreateHole(hole *models.Hole) error {
+func (r *repository) CreateHole(ctx context.Context, hole *models.Hole) error {
 	hole.Id = 0
-	return hole.Insert(r.db)
+	return hole.Insert(ctx, r.db)
 }
 
-func (r *repository) GetRoundHoles(roundId int) (*PaginationResponse[models.Hole], error) {
-	sqlStmt := `
-	SELECT h.id
+// holeSorts is the columns that the holes of a round can be sorted by.
+var holeSorts = map[string]sortColumn[models.Hole]{
+	"id":              {column: "h.id", kind: sortKindInt, value: func(h *models.Hole) any { return h.Id }},
+	"number":          {column: "h.number", kind: sortKindInt, value: func(h *models.Hole) any { return h.Number }},
+	"par":             {column: "h.par", kind: sortKindInt, value: func(h *models.Hole) any { return h.Par }},
+	"stroke":          {column: "h.stroke", kind: sortKindInt, value: func(h *models.Hole) any { return h.Stroke }},
+	"distance_yards":  {column: "h.distance_yards", kind: sortKindInt, value: func(h *models.Hole) any { return h.DistanceYards }},
+	"distance_meters": {column: "h.distance_meters", kind: sortKindInt, value: func(h *models.Hole) any { return h.DistanceMeters }},
+}
+
+func (r *repository) GetRoundHoles(ctx context.Context, roundId int, details *PaginationDetails) (*PaginationResponse[models.Hole], error) {
+	from := `
 	FROM hole h
-		INNER JOIN course_details cd ON h.course_details_id = cd.id
-		INNER JOIN course c ON cd.course_id = c.id
-	WHERE c.round_id = ?
+		INNER JOIN round r ON h.course_details_id = r.course_details_id
 	`
 
-	holeIds := make([]int, 0)
-	err := r.db.Select(&holeIds, sqlStmt, roundId)
+	holes, err := paginate(ctx, r.db, &paginatedQuery[models.Hole]{
+		name:        "round_holes",
+		selectStmt:  `SELECT ` + columns(models.Hole{}, "h", "") + from,
+		countStmt:   `SELECT COUNT(*)` + from,
+		where:       []string{"r.id = ?"},
+		args:        []any{roundId},
+		idColumn:    "h.id",
+		id:          func(h *models.Hole) int { return h.Id },
+		sorts:       holeSorts,
+		defaultSort: "number",
+	}, details)
 	if err != nil {
-		switch {
-		case errors.Is(err, sql.ErrNoRows):
-			return nil, ErrNoHolesFound
-		default:
-			return nil, fmt.Errorf("failed to get hole IDs: %w", err)
-		}
+		return nil, fmt.Errorf("failed to get holes: %w", err)
 	}
 
-	holes := make([]*models.Hole, 0, len(holeIds))
-	for _, id := range holeIds {
-		h, err := models.HoleById(r.db, id)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get hole by ID: %w", err)
-		}
-		holes = append(holes, h)
-	}
-
-	return &PaginationResponse[models.Hole]{
-		Items: holes,
-		Total: int64(len(holes)),
-	}, nil
+	return holes, nil
 }
 
-func (r *repository) GetHoleStatsByHoleId(holeId int) (*models.HoleStats, error) {
-	sqlStmt := `SELECT id FROM hole_stats WHERE hole_id = ?`
-
-	var id int
-	err := r.db.Get(&id, sqlStmt, holeId)
+func (r *repository) GetHoleStatsByRoundAndHoleId(ctx context.Context, roundId int, holeId int) (*models.HoleStats, error) {
+	stats, err := models.HoleStatsByRoundIdHoleId(ctx, r.db, roundId, holeId)
 	if err != nil {
 		switch {
 		case errors.Is(err, sql.ErrNoRows):
 			return nil, ErrNoStatsFound
 		default:
-			return nil, fmt.Errorf("failed to get hole stats by hole ID: %w", err)
+			return nil, fmt.Errorf("failed to get hole stats by round and hole ID: %w", err)
 		}
 	}
 
-	return models.HoleStatsById(r.db, id)
+	return stats, nil
 }
 
-func (r *repository) GetHoleById(id int) (*models.Hole, error) {
-	return models.HoleById(r.db, id)
+func (r *repository) GetHoleById(ctx context.Context, id int) (*models.Hole, error) {
+	return models.HoleById(ctx, r.db, id)
 }
 
-func (r *repository) SaveHoleStats(holeStats *models.HoleStats) error {
-	err := holeStats.SaveOrUpdate(r.db)
+func (r *repository) SaveHoleStats(ctx context.Context, holeStats *models.HoleStats) error {
+	err := holeStats.SaveOrUpdate(ctx, r.db)
 	if err != nil {
 		switch {
 		case errors.Is(err, models.ErrNoAffectedRows):
@@ -88,73 +84,48 @@ func (r *repository) SaveHoleStats(holeStats *models.HoleStats) error {
 	return nil
 }
 
-func (r *repository) GetAllStatsForPar(userId int, par int64) (*PaginationResponse[HoleWithStats], error) {
-	sqlStmt := `
-	SELECT
-	    r.id AS round_id,
-		s.id AS stats_id
+// holeWithStatsQuery selects the stats of holes along with the hole and the round that they were recorded in.
+func holeWithStatsQuery() string {
+	return `
+	SELECT ` + columns(models.Round{}, "r", "round") + `,
+		` + columns(models.Hole{}, "h", "hole") + `,
+		` + columns(models.HoleStats{}, "s", "stats") + `
 	FROM hole h
 		INNER JOIN hole_stats s ON h.id = s.hole_id
-		INNER JOIN course_details cd ON h.course_details_id = cd.id
-		INNER JOIN course c ON cd.course_id = c.id
-		INNER JOIN round r ON c.round_id = r.id
+		INNER JOIN round r ON s.round_id = r.id
+	`
+}
+
+func (r *repository) GetAllStatsForPar(ctx context.Context, userId int, par int64) (*PaginationResponse[HoleWithStats], error) {
+	sqlStmt := holeWithStatsQuery() + `
 	WHERE r.user_id = ?
 		AND h.par = ?
+	ORDER BY r.id, h.number
 	`
 
-	type idStruct struct {
-		RoundId int `db:"round_id"`
-		StatsId int `db:"stats_id"`
-	}
-
-	ids := make([]idStruct, 0)
-	err := r.db.Select(&ids, sqlStmt, userId, par)
+	holeStats := make([]*HoleWithStats, 0)
+	err := r.db.SelectContext(models.WithQueryName(ctx, "select_hole_stats_by_par"), &holeStats, sqlStmt, userId, par)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get hole stats: %w", err)
 	}
 
-	holeStats := make([]*HoleWithStats, 0, len(ids))
-	for _, id := range ids {
-		round, err := models.RoundById(r.db, id.RoundId)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get round by ID: %w", err)
-		}
-
-		holeStat, err := models.HoleStatsById(r.db, id.StatsId)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get hole stats by ID: %w", err)
-		}
-
-		hole, err := holeStat.GetHole(r.db)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get hole by ID: %w", err)
-		}
-
-		holeStats = append(holeStats, &HoleWithStats{
-			Round: round,
-			Hole:  hole,
-			Stats: holeStat,
-		})
-	}
-
 	return &PaginationResponse[HoleWithStats]{
 		Items: holeStats,
 		Total: int64(len(holeStats)),
 	}, nil
 }
 
-func (r *repository) CountHolesByRoundAndPar(roundId int, par int64) (int, error) {
+func (r *repository) CountHolesByRoundAndPar(ctx context.Context, roundId int, par int64) (int, error) {
 	sqlStmt := `
 	SELECT COUNT(*)
 	FROM hole h
-		INNER JOIN course_details cd ON h.course_details_id = cd.id
-		INNER JOIN course c ON cd.course_id = c.id
-	WHERE c.round_id = ?
+		INNER JOIN round r ON h.course_details_id = r.course_details_id
+	WHERE r.id = ?
 		AND h.par = ?
 	`
 
 	var count int
-	err := r.db.Get(&count, sqlStmt, roundId, par)
+	err := r.db.GetContext(ctx, &count, sqlStmt, roundId, par)
 	if err != nil {
 		return 0, fmt.Errorf("failed to count holes: %w", err)
 	}
@@ -162,21 +133,15 @@ func (r *repository) CountHolesByRoundAndPar(roundId int, par int64) (int, error
 	return count, nil
 }
 
-func (r *repository) GetStatsByRoundId(userId int, roundId int) (*PaginationResponse[HoleWithStats], error) {
-	sqlStmt := `
-	SELECT
-		s.id AS stats_id
-	FROM hole h
-		INNER JOIN hole_stats s ON h.id = s.hole_id
-		INNER JOIN course_details cd ON h.course_details_id = cd.id
-		INNER JOIN course c ON cd.course_id = c.id
-		INNER JOIN round r ON c.round_id = r.id
+func (r *repository) GetStatsByRoundId(ctx context.Context, userId int, roundId int) (*PaginationResponse[HoleWithStats], error) {
+	sqlStmt := holeWithStatsQuery() + `
 	WHERE r.user_id = ?
 		AND r.id = ?
+	ORDER BY h.number
 	`
 
-	ids := make([]int, 0)
-	err := r.db.Select(&ids, sqlStmt, userId, roundId)
+	holeStats := make([]*HoleWithStats, 0)
+	err := r.db.SelectContext(models.WithQueryName(ctx, "select_hole_stats_by_round"), &holeStats, sqlStmt, userId, roundId)
 	if err != nil {
 		switch {
 		case errors.Is(err, sql.ErrNoRows):
@@ -186,47 +151,22 @@ func (r *repository) GetStatsByRoundId(userId int, roundId int) (*PaginationResp
 		}
 	}
 
-	round, err := models.RoundById(r.db, roundId)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get round by ID: %w", err)
-	}
-
-	holeStats := make([]*HoleWithStats, 0, len(ids))
-	for _, id := range ids {
-		holeStat, err := models.HoleStatsById(r.db, id)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get hole stats by ID: %w", err)
-		}
-
-		hole, err := holeStat.GetHole(r.db)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get hole by ID: %w", err)
-		}
-
-		holeStats = append(holeStats, &HoleWithStats{
-			Round: round,
-			Hole:  hole,
-			Stats: holeStat,
-		})
-	}
-
 	return &PaginationResponse[HoleWithStats]{
 		Items: holeStats,
 		Total: int64(len(holeStats)),
 	}, nil
 }
 
-func (r *repository) CountHolesByRoundId(roundId int) (int, error) {
+func (r *repository) CountHolesByRoundId(ctx context.Context, roundId int) (int, error) {
 	sqlStmt := `
 	SELECT COUNT(*)
 	FROM hole h
-		INNER JOIN course_details cd ON h.course_details_id = cd.id
-		INNER JOIN course c ON cd.course_id = c.id
-	WHERE c.round_id = ?
+		INNER JOIN round r ON h.course_details_id = r.course_details_id
+	WHERE r.id = ?
 	`
 
 	var count int
-	err := r.db.Get(&count, sqlStmt, roundId)
+	err := r.db.GetContext(ctx, &count, sqlStmt, roundId)
 	if err != nil {
 		return 0, fmt.Errorf("failed to count holes: %w", err)
 	}
@@ -234,61 +174,42 @@ func (r *repository) CountHolesByRoundId(roundId int) (int, error) {
 	return count, nil
 }
 
-func (r *repository) GetStatsByUserId(userId int) (*PaginationResponse[RoundWithStats], error) {
-	sqlStmt := `
-	SELECT
-		rs.id AS stats_id,
-		r.id AS round_id,
-		c.id AS course_id
+// roundStatsSorts is the columns that the round stats of a user can be sorted by.
+var roundStatsSorts = map[string]sortColumn[RoundWithStats]{
+	"id":               {column: "r.id", kind: sortKindInt, value: func(s *RoundWithStats) any { return s.Round.Id }},
+	"tee_time":         {column: "r.tee_time", kind: sortKindTime, value: func(s *RoundWithStats) any { return s.Round.TeeTime }},
+	"course_name":      {column: "c.name", kind: sortKindString, value: func(s *RoundWithStats) any { return s.Course.Name }},
+	"avg_fairways_hit": {column: "rs.avg_fairways_hit", kind: sortKindFloat, value: func(s *RoundWithStats) any { return s.Stats.AvgFairwaysHit }},
+	"avg_greens_hit":   {column: "rs.avg_greens_hit", kind: sortKindFloat, value: func(s *RoundWithStats) any { return s.Stats.AvgGreensHit }},
+	"avg_putts":        {column: "rs.avg_putts", kind: sortKindFloat, value: func(s *RoundWithStats) any { return s.Stats.AvgPutts }},
+	"penalties":        {column: "rs.penalties", kind: sortKindInt, value: func(s *RoundWithStats) any { return s.Stats.Penalties }},
+}
+
+func (r *repository) GetStatsByUserId(ctx context.Context, userId int, details *PaginationDetails) (*PaginationResponse[RoundWithStats], error) {
+	from := `
 	FROM round_stats rs
 		INNER JOIN round r ON rs.round_id = r.id
-		INNER JOIN course c ON c.round_id = r.id
-	WHERE r.user_id = ?
+		INNER JOIN course_details cd ON r.course_details_id = cd.id
+		INNER JOIN course c ON cd.course_id = c.id
 	`
 
-	type idStruct struct {
-		StatsId  int `db:"stats_id"`
-		RoundId  int `db:"round_id"`
-		CourseId int `db:"course_id"`
-	}
-
-	ids := make([]idStruct, 0)
-	err := r.db.Select(&ids, sqlStmt, userId)
+	roundStats, err := paginate(ctx, r.db, &paginatedQuery[RoundWithStats]{
+		name: "user_round_stats",
+		selectStmt: `
+		SELECT ` + columns(models.RoundStats{}, "rs", "stats") + `,
+			` + columns(models.Round{}, "r", "round") + `,
+			` + columns(models.Course{}, "c", "course") + from,
+		countStmt:   `SELECT COUNT(*)` + from,
+		where:       []string{"r.user_id = ?"},
+		args:        []any{userId},
+		idColumn:    "r.id",
+		id:          func(s *RoundWithStats) int { return s.Round.Id },
+		sorts:       roundStatsSorts,
+		defaultSort: "id",
+	}, details)
 	if err != nil {
-		switch {
-		case errors.Is(err, sql.ErrNoRows):
-			return nil, ErrNoStatsFound
-		default:
-			return nil, fmt.Errorf("failed to get round stats: %w", err)
-		}
-	}
-
-	holeStats := make([]*RoundWithStats, 0, len(ids))
-	for _, id := range ids {
-		round, err := models.RoundById(r.db, id.RoundId)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get round by ID: %w", err)
-		}
-
-		course, err := models.CourseById(r.db, id.CourseId)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get course by ID: %w", err)
-		}
-
-		roundStats, err := models.RoundStatsById(r.db, id.StatsId)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get round stats by ID: %w", err)
-		}
-
-		holeStats = append(holeStats, &RoundWithStats{
-			Round:  round,
-			Course: course,
-			Stats:  roundStats,
-		})
+		return nil, fmt.Errorf("failed to get round stats: %w", err)
 	}
 
-	return &PaginationResponse[RoundWithStats]{
-		Items: holeStats,
-		Total: int64(len(holeStats)),
-	}, nil
+	return roundStats, nil
 }
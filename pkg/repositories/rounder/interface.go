@@ -1,96 +1,321 @@
 package rounder
 
-import "github.com/Jacobbrewer1/golf-stats-tracker/pkg/models"
+import (
+	"context"
+	"time"
+
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/models"
+)
 
 type Repository interface {
 	// CreateUser creates a new user.
-	CreateUser(user *models.User) error
+	CreateUser(ctx context.Context, user *models.User) error
 
 	// UserByUsername returns the user with the given username.
-	UserByUsername(username string) (*models.User, error)
+	UserByUsername(ctx context.Context, username string) (*models.User, error)
+
+	// GetUserById gets a user by their ID.
+	GetUserById(ctx context.Context, id int) (*models.User, error)
+
+	// GetUsers gets all users.
+	GetUsers(ctx context.Context) (*PaginationResponse[models.User], error)
+
+	// UpdateUserRole updates the role of a user.
+	UpdateUserRole(ctx context.Context, userId int, role string) error
+
+	// UpdateUserProfile updates the name, email, gender, date of birth and home course of a user.
+	UpdateUserProfile(ctx context.Context, user *models.User) error
+
+	// UpdateUserLastLogin records when the user last logged in.
+	UpdateUserLastLogin(ctx context.Context, userId int, lastLogin time.Time) error
+
+	// GetUserPreference gets the preferences of a user.
+	GetUserPreference(ctx context.Context, userId int) (*models.UserPreference, error)
+
+	// SaveUserPreference creates or replaces the preferences of a user.
+	SaveUserPreference(ctx context.Context, pref *models.UserPreference) error
+
+	// CreateCoachGrant grants a coach read access to the rounds and stats of a player.
+	CreateCoachGrant(ctx context.Context, grant *models.CoachGrant) error
+
+	// GetCoachGrant gets the grant of a player to a coach.
+	GetCoachGrant(ctx context.Context, playerId int, coachId int) (*models.CoachGrant, error)
+
+	// DeleteCoachGrant revokes the access of a coach to the rounds and stats of a player.
+	DeleteCoachGrant(ctx context.Context, playerId int, coachId int) error
+
+	// GetCoachGrantsByPlayerId gets the grants a player has given to coaches.
+	GetCoachGrantsByPlayerId(ctx context.Context, playerId int) (*PaginationResponse[models.CoachGrant], error)
+
+	// GetCoachGrantsByCoachId gets the grants players have given to a coach.
+	GetCoachGrantsByCoachId(ctx context.Context, coachId int) (*PaginationResponse[models.CoachGrant], error)
+
+	// GetUserTotp gets the two-factor authentication enrolment of a user.
+	GetUserTotp(ctx context.Context, userId int) (*models.UserTotp, error)
+
+	// CreateUserTotp enrols a user in two-factor authentication, replacing any enrolment that was not confirmed.
+	CreateUserTotp(ctx context.Context, totp *models.UserTotp) error
+
+	// ConfirmUserTotp saves the confirmed enrolment and replaces the recovery codes of the user.
+	ConfirmUserTotp(ctx context.Context, totp *models.UserTotp, codeHashes []string) error
+
+	// UseTotpStep records that the code for the time step has been used, returning ErrTotpCodeUsed if the step or a
+	// later one has already been used.
+	UseTotpStep(ctx context.Context, totpId int, step int64) error
+
+	// UseRecoveryCode marks the recovery code of the user as used, returning ErrRecoveryCodeNotFound if it does not
+	// exist or has already been used.
+	UseRecoveryCode(ctx context.Context, userId int, codeHash string) error
+
+	// DeleteUserTotp removes the two-factor authentication enrolment and recovery codes of a user.
+	DeleteUserTotp(ctx context.Context, userId int) error
+
+	// CreateLoginChallenge creates a challenge for a login that still needs a two-factor code.
+	CreateLoginChallenge(ctx context.Context, challenge *models.LoginChallenge) error
+
+	// ConsumeLoginChallenge gets and deletes the login challenge by the hash of its token, so that it can only be used
+	// once. ErrLoginChallengeNotFound is returned if the challenge does not exist or has already been used.
+	ConsumeLoginChallenge(ctx context.Context, tokenHash string) (*models.LoginChallenge, error)
+
+	// UpdateUserPassword updates the password of a user and revokes all of their sessions except the given session.
+	UpdateUserPassword(ctx context.Context, userId int, password string, exceptSessionId int) error
+
+	// UpdateUserPasswordHash replaces the stored hash of a password that has not changed, such as when it is rehashed
+	// with stronger parameters. Unlike UpdateUserPassword, no sessions are revoked.
+	UpdateUserPasswordHash(ctx context.Context, userId int, password string) error
+
+	// CreatePasswordReset creates a new password reset.
+	CreatePasswordReset(ctx context.Context, reset *models.PasswordReset) error
+
+	// GetPasswordResetByTokenHash gets a password reset by the hash of its token.
+	GetPasswordResetByTokenHash(ctx context.Context, hash string) (*models.PasswordReset, error)
+
+	// CompletePasswordReset marks the password reset as used, updates the password of its user and revokes all of
+	// their sessions. ErrPasswordResetNotFound is returned if the reset has already been used.
+	CompletePasswordReset(ctx context.Context, reset *models.PasswordReset, password string) error
+
+	// CreateOidcLogin creates a new OIDC login that is waiting for the user to return from the issuer.
+	CreateOidcLogin(ctx context.Context, login *models.OidcLogin) error
+
+	// ConsumeOidcLogin gets and deletes the OIDC login by the hash of its state, so that it can only be used once.
+	// ErrOidcLoginNotFound is returned if the login does not exist or has already been used.
+	ConsumeOidcLogin(ctx context.Context, stateHash string) (*models.OidcLogin, error)
+
+	// GetUserIdentity gets the identity from an OIDC issuer by the issuer and the subject at the issuer.
+	GetUserIdentity(ctx context.Context, issuer string, subject string) (*models.UserIdentity, error)
+
+	// CreateUserIdentity links an identity from an OIDC issuer to an existing user.
+	CreateUserIdentity(ctx context.Context, identity *models.UserIdentity) error
+
+	// UpdateUserIdentity updates a user identity.
+	UpdateUserIdentity(ctx context.Context, identity *models.UserIdentity) error
+
+	// CreateUserWithIdentity creates a new user linked to the identity from an OIDC issuer.
+	CreateUserWithIdentity(ctx context.Context, user *models.User, identity *models.UserIdentity) error
+
+	// GetUserIdentitiesByUserId gets the identities from OIDC issuers that are linked to a user.
+	GetUserIdentitiesByUserId(ctx context.Context, userId int) (*PaginationResponse[models.UserIdentity], error)
+
+	// CreateSession creates a new session.
+	CreateSession(ctx context.Context, session *models.Session) error
+
+	// RotateSessionRefreshToken replaces the refresh token of an active session, returning ErrSessionNotFound if the
+	// old refresh token is no longer the current one or the session has been revoked or has expired.
+	RotateSessionRefreshToken(ctx context.Context, sessionId int, oldHash, newHash string, usedAt time.Time) error
+
+	// RevokeSession revokes a session, leaving it unchanged if it has already been revoked.
+	RevokeSession(ctx context.Context, sessionId int) error
+
+	// GetSessionById gets a session by its ID.
+	GetSessionById(ctx context.Context, id int) (*models.Session, error)
+
+	// GetSessionByRefreshTokenHash gets a session by the hash of its current refresh token.
+	GetSessionByRefreshTokenHash(ctx context.Context, hash string) (*models.Session, error)
+
+	// GetActiveSessionsByUserId gets the sessions of a user that have not been revoked or expired.
+	GetActiveSessionsByUserId(ctx context.Context, userId int) (*PaginationResponse[models.Session], error)
+
+	// CreateApiKey creates a new API key.
+	CreateApiKey(ctx context.Context, apiKey *models.ApiKey) error
+
+	// UpdateApiKeyLastUsed records when an API key was last used, unless it has been revoked.
+	UpdateApiKeyLastUsed(ctx context.Context, id int, usedAt time.Time) error
+
+	// RevokeApiKey revokes an API key, leaving it unchanged if it has already been revoked.
+	RevokeApiKey(ctx context.Context, id int) error
+
+	// GetApiKeyById gets an API key by its ID.
+	GetApiKeyById(ctx context.Context, id int) (*models.ApiKey, error)
+
+	// GetApiKeyByKeyHash gets an API key by the hash of the key.
+	GetApiKeyByKeyHash(ctx context.Context, hash string) (*models.ApiKey, error)
+
+	// GetActiveApiKeysByUserId gets the API keys of a user that have not been revoked or expired.
+	GetActiveApiKeysByUserId(ctx context.Context, userId int) (*PaginationResponse[models.ApiKey], error)
 
 	// CreateRound creates a new round.
-	CreateRound(round *models.Round) error
+	CreateRound(ctx context.Context, round *models.Round) error
+
+	// DeleteRound deletes a round along with its hole stats and round stats.
+	DeleteRound(ctx context.Context, roundId int) error
+
+	// CreateCourse creates a new catalogue course.
+	CreateCourse(ctx context.Context, course *models.Course) error
+
+	// UpdateCourse updates a catalogue course.
+	UpdateCourse(ctx context.Context, course *models.Course) error
+
+	// CreateCustomCourse creates a custom course along with its tee sets and their holes, writing nothing if any of
+	// them fails.
+	CreateCustomCourse(ctx context.Context, course *models.Course, teeSets []*TeeSet) error
+
+	// UpdateCustomCourse updates a custom course and adds the tee sets and their holes as new versions, writing nothing
+	// if any of them fails.
+	UpdateCustomCourse(ctx context.Context, course *models.Course, teeSets []*TeeSet) error
+
+	// GetCourseById gets a catalogue course by its ID.
+	GetCourseById(ctx context.Context, id int) (*models.Course, error)
 
-	// CreateCourse creates a new course.
-	CreateCourse(course *models.Course) error
+	// GetCoursesByUserId gets the custom courses created by a user.
+	GetCoursesByUserId(ctx context.Context, userId int) (*PaginationResponse[models.Course], error)
 
-	// CreateCourseDetails creates a new course details.
-	CreateCourseDetails(courseDetails *models.CourseDetails) error
+	// DeleteCourse deletes a catalogue course along with its tee sets and holes.
+	DeleteCourse(ctx context.Context, courseId int) error
+
+	// CountRoundsByCourseId counts the number of rounds played on a catalogue course.
+	CountRoundsByCourseId(ctx context.Context, courseId int) (int, error)
+
+	// GetCourseByExternalId gets a catalogue course by its golf data ID.
+	GetCourseByExternalId(ctx context.Context, externalId int) (*models.Course, error)
+
+	// CreateCourseDetails creates a new version of a catalogue tee set.
+	CreateCourseDetails(ctx context.Context, courseDetails *models.CourseDetails) error
+
+	// ImportTeeSet adds a golf data course to the catalogue, or updates its name if it has been imported before, and
+	// returns the ID of the latest version of the tee set. A new version of the tee set is only created when it differs
+	// from the latest version. The course is updated with the catalogue course.
+	ImportTeeSet(ctx context.Context, course *models.Course, teeSet *TeeSet) (int, error)
+
+	// GetLatestCourseDetails gets the latest version of a catalogue tee set by its golf data marker ID.
+	GetLatestCourseDetails(ctx context.Context, courseId int, externalId int) (*models.CourseDetails, error)
+
+	// GetCourseTeeSets gets the latest version of each tee set for a catalogue course.
+	GetCourseTeeSets(ctx context.Context, courseId int) (*PaginationResponse[models.CourseDetails], error)
+
+	// GetCourseDetailsHoles gets the holes for a catalogue tee set.
+	GetCourseDetailsHoles(ctx context.Context, courseDetailsId int) (*PaginationResponse[models.Hole], error)
 
 	// CreateHole creates a new hole.
-	CreateHole(hole *models.Hole) error
+	CreateHole(ctx context.Context, hole *models.Hole) error
 
 	// GetRoundById gets a round by its ID.
-	GetRoundById(id int) (*models.Round, error)
+	GetRoundById(ctx context.Context, id int) (*models.Round, error)
 
 	// GetRoundDetailsByRoundId gets the details for a round.
-	GetRoundDetailsByRoundId(roundId int) (*RoundDetails, error)
+	GetRoundDetailsByRoundId(ctx context.Context, roundId int) (*RoundDetails, error)
 
-	// GetRoundsByUserId gets the rounds for a user.
-	GetRoundsByUserId(userId int) (*PaginationResponse[models.Round], error)
+	// GetRoundsByUserId gets a page of the rounds for a user, along with the course and tee set of each. The holes of
+	// the rounds are not loaded. The rounds can be sorted by id, tee_time or course_name.
+	GetRoundsByUserId(ctx context.Context, userId int, details *PaginationDetails) (*PaginationResponse[RoundDetails], error)
 
-	// GetRoundHoles gets the holes for a round.
-	GetRoundHoles(roundId int) (*PaginationResponse[models.Hole], error)
+	// GetRoundHoles gets a page of the holes for a round, sorted by number unless the details say otherwise.
+	GetRoundHoles(ctx context.Context, roundId int, details *PaginationDetails) (*PaginationResponse[models.Hole], error)
 
 	// GetHoleById gets a hole by its ID.
-	GetHoleById(id int) (*models.Hole, error)
+	GetHoleById(ctx context.Context, id int) (*models.Hole, error)
 
-	// GetHoleStatsByHoleId gets the stats for a hole.
-	GetHoleStatsByHoleId(holeId int) (*models.HoleStats, error)
+	// GetHoleStatsByRoundAndHoleId gets the stats for a hole in a round.
+	GetHoleStatsByRoundAndHoleId(ctx context.Context, roundId int, holeId int) (*models.HoleStats, error)
 
 	// SaveHoleStats saves the stats for a hole.
-	SaveHoleStats(holeStats *models.HoleStats) error
+	SaveHoleStats(ctx context.Context, holeStats *models.HoleStats) error
 
 	// GetAllStatsForPar gets all stats for a user.
-	GetAllStatsForPar(userId int, par int64) (*PaginationResponse[HoleWithStats], error)
+	GetAllStatsForPar(ctx context.Context, userId int, par int64) (*PaginationResponse[HoleWithStats], error)
 
 	// CountHolesByRoundAndPar counts the number of holes for a round and par.
-	CountHolesByRoundAndPar(roundId int, par int64) (int, error)
+	CountHolesByRoundAndPar(ctx context.Context, roundId int, par int64) (int, error)
 
 	// GetStatsByRoundId gets the stats for a user.
-	GetStatsByRoundId(userId int, roundId int) (*PaginationResponse[HoleWithStats], error)
+	GetStatsByRoundId(ctx context.Context, userId int, roundId int) (*PaginationResponse[HoleWithStats], error)
 
 	// CountHolesByRoundId counts the number of holes for a round.
-	CountHolesByRoundId(roundId int) (int, error)
+	CountHolesByRoundId(ctx context.Context, roundId int) (int, error)
 
 	// SaveRoundStats saves the stats for a round.
-	SaveRoundStats(roundStats *models.RoundStats) error
+	SaveRoundStats(ctx context.Context, roundStats *models.RoundStats) error
 
 	// GetRoundStatsByRoundId gets the stats for a round.
-	GetRoundStatsByRoundId(roundId int) (*models.RoundStats, error)
+	GetRoundStatsByRoundId(ctx context.Context, roundId int) (*models.RoundStats, error)
 
-	// GetStatsByUserId gets all stats for a user.
-	GetStatsByUserId(userId int) (*PaginationResponse[RoundWithStats], error)
+	// GetStatsByUserId gets a page of the round stats for a user. The stats can be sorted by id, tee_time,
+	// course_name, avg_fairways_hit, avg_greens_hit, avg_putts or penalties.
+	GetStatsByUserId(ctx context.Context, userId int, details *PaginationDetails) (*PaginationResponse[RoundWithStats], error)
 
 	// SaveRoundHitStats saves the hit stats for a round.
-	SaveRoundHitStats(roundHitStats ...*models.RoundHitStats) error
+	SaveRoundHitStats(ctx context.Context, roundHitStats ...*models.RoundHitStats) error
 
 	// GetRoundHitStatsByRoundStatsId gets the hit stats for a round.
-	GetRoundHitStatsByRoundStatsId(roundStatsId int) (*PaginationResponse[models.RoundHitStats], error)
+	GetRoundHitStatsByRoundStatsId(ctx context.Context, roundStatsId int) (*PaginationResponse[models.RoundHitStats], error)
 
 	// GetRoundHitStats gets the hit stats for a round.
-	GetRoundHitStats(roundId int) (*PaginationResponse[models.RoundHitStats], error)
+	GetRoundHitStats(ctx context.Context, roundId int) (*PaginationResponse[models.RoundHitStats], error)
 
 	// GetUserHitStats gets the hit stats for a user.
-	GetUserHitStats(userId int) (*PaginationResponse[models.RoundHitStats], error)
+	GetUserHitStats(ctx context.Context, userId int) (*PaginationResponse[models.RoundHitStats], error)
+
+	// CreateAuditEvent records an audit event. Audit events are never updated or deleted.
+	CreateAuditEvent(ctx context.Context, event *models.AuditEvent) error
+
+	// GetAuditEvents gets the audit events that match the filter, most recent first.
+	GetAuditEvents(ctx context.Context, filter *AuditEventFilter) (*PaginationResponse[models.AuditEvent], error)
+
+	// GetUserPasswords gets up to limit encrypted user passwords, ordered by user ID and starting after the given ID.
+	GetUserPasswords(ctx context.Context, afterId, limit int) ([]*EncryptedValue, error)
+
+	// ReplaceUserPassword replaces the encrypted password of a user with the same password encrypted differently, such
+	// as with a newer key version. It returns false if the password changed since it was read.
+	ReplaceUserPassword(ctx context.Context, userId int, oldPassword, newPassword string) (bool, error)
+
+	// GetTotpSecrets gets up to limit encrypted TOTP secrets, ordered by ID and starting after the given ID.
+	GetTotpSecrets(ctx context.Context, afterId, limit int) ([]*EncryptedValue, error)
+
+	// ReplaceTotpSecret replaces an encrypted TOTP secret with the same secret encrypted differently. It returns false
+	// if the secret changed since it was read.
+	ReplaceTotpSecret(ctx context.Context, totpId int, oldSecret, newSecret string) (bool, error)
 }
 
+// HoleWithStats is the stats recorded for a hole in a round.
 type HoleWithStats struct {
-	Round *models.Round
-	Hole  *models.Hole
-	Stats *models.HoleStats
+	Round *models.Round     `db:"round"`
+	Hole  *models.Hole      `db:"hole"`
+	Stats *models.HoleStats `db:"stats"`
 }
 
+// RoundWithStats is the stats of a round, along with the course that it was played on.
 type RoundWithStats struct {
-	Round  *models.Round
-	Course *models.Course
-	Stats  *models.RoundStats
+	Round  *models.Round      `db:"round"`
+	Course *models.Course     `db:"course"`
+	Stats  *models.RoundStats `db:"stats"`
 }
 
+// RoundDetails is a round, along with the course and tee set that it was played on.
 type RoundDetails struct {
-	Round         *models.Round
-	Course        *models.Course
-	CourseDetails *models.CourseDetails
-	Holes         []*models.Hole
+	Round         *models.Round         `db:"round"`
+	Course        *models.Course        `db:"course"`
+	CourseDetails *models.CourseDetails `db:"course_details"`
+
+	// Holes are the holes of the tee set. They are only loaded for a single round, not for the rounds of a user.
+	Holes []*models.Hole `db:"-"`
+}
+
+// AuditEventFilter filters the audit events returned by GetAuditEvents. Fields that are not set do not filter.
+type AuditEventFilter struct {
+	ActorId    *int
+	Action     string
+	TargetType string
+	TargetId   *int
+	Since      *time.Time
+	Until      *time.Time
+	Limit      int
 }
@@ -0,0 +1,56 @@
+package rounder
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/models"
+)
+
+var (
+	// ErrLoginChallengeNotFound is returned when the login challenge is not found or has already been used.
+	ErrLoginChallengeNotFound = errors.New("login challenge not found")
+)
+
+func (r *repository) CreateLoginChallenge(ctx context.Context, challenge *models.LoginChallenge) error {
+	challenge.Id = 0
+	return challenge.Insert(ctx, r.db)
+}
+
+func (r *repository) ConsumeLoginChallenge(ctx context.Context, tokenHash string) (*models.LoginChallenge, error) {
+	challenge := new(models.LoginChallenge)
+	err := models.NewDBTransactionHandler(r.db).Handle(ctx, func(db models.DB) error {
+		c, err := models.LoginChallengeByTokenHash(ctx, db, tokenHash)
+		if err != nil {
+			switch {
+			case errors.Is(err, sql.ErrNoRows):
+				return ErrLoginChallengeNotFound
+			default:
+				return fmt.Errorf("error getting login challenge by token: %w", err)
+			}
+		}
+
+		// Only return the challenge if this call deleted it, so that it cannot be used twice concurrently.
+		res, err := db.ExecContext(ctx, `DELETE FROM login_challenge WHERE id = ?`, c.Id)
+		if err != nil {
+			return fmt.Errorf("error deleting login challenge: %w", err)
+		}
+
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("error getting affected rows: %w", err)
+		} else if affected == 0 {
+			return ErrLoginChallengeNotFound
+		}
+
+		challenge = c
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return challenge, nil
+}
@@ -3,8 +3,10 @@
 package rounder
 
 import (
+	context "context"
 	models "github.com/Jacobbrewer1/golf-stats-tracker/pkg/models"
 	mock "github.com/stretchr/testify/mock"
+	time "time"
 )
 
 // MockRepository is an autogenerated mock type for the Repository type
@@ -12,9 +14,105 @@ type MockRepository struct {
 	mock.Mock
 }
 
-// CountHolesByRoundAndPar provides a mock function with given fields: roundId, par
-func (_m *MockRepository) CountHolesByRoundAndPar(roundId int, par int64) (int, error) {
-	ret := _m.Called(roundId, par)
+// CompletePasswordReset provides a mock function with given fields: ctx, reset, password
+func (_m *MockRepository) CompletePasswordReset(ctx context.Context, reset *models.PasswordReset, password string) error {
+	ret := _m.Called(ctx, reset, password)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CompletePasswordReset")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.PasswordReset, string) error); ok {
+		r0 = rf(ctx, reset, password)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ConfirmUserTotp provides a mock function with given fields: ctx, totp, codeHashes
+func (_m *MockRepository) ConfirmUserTotp(ctx context.Context, totp *models.UserTotp, codeHashes []string) error {
+	ret := _m.Called(ctx, totp, codeHashes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConfirmUserTotp")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.UserTotp, []string) error); ok {
+		r0 = rf(ctx, totp, codeHashes)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ConsumeLoginChallenge provides a mock function with given fields: ctx, tokenHash
+func (_m *MockRepository) ConsumeLoginChallenge(ctx context.Context, tokenHash string) (*models.LoginChallenge, error) {
+	ret := _m.Called(ctx, tokenHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConsumeLoginChallenge")
+	}
+
+	var r0 *models.LoginChallenge
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.LoginChallenge, error)); ok {
+		return rf(ctx, tokenHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.LoginChallenge); ok {
+		r0 = rf(ctx, tokenHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.LoginChallenge)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ConsumeOidcLogin provides a mock function with given fields: ctx, stateHash
+func (_m *MockRepository) ConsumeOidcLogin(ctx context.Context, stateHash string) (*models.OidcLogin, error) {
+	ret := _m.Called(ctx, stateHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConsumeOidcLogin")
+	}
+
+	var r0 *models.OidcLogin
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.OidcLogin, error)); ok {
+		return rf(ctx, stateHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.OidcLogin); ok {
+		r0 = rf(ctx, stateHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.OidcLogin)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, stateHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CountHolesByRoundAndPar provides a mock function with given fields: ctx, roundId, par
+func (_m *MockRepository) CountHolesByRoundAndPar(ctx context.Context, roundId int, par int64) (int, error) {
+	ret := _m.Called(ctx, roundId, par)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CountHolesByRoundAndPar")
@@ -22,17 +120,17 @@ func (_m *MockRepository) CountHolesByRoundAndPar(roundId int, par int64) (int,
 
 	var r0 int
 	var r1 error
-	if rf, ok := ret.Get(0).(func(int, int64) (int, error)); ok {
-		return rf(roundId, par)
+	if rf, ok := ret.Get(0).(func(context.Context, int, int64) (int, error)); ok {
+		return rf(ctx, roundId, par)
 	}
-	if rf, ok := ret.Get(0).(func(int, int64) int); ok {
-		r0 = rf(roundId, par)
+	if rf, ok := ret.Get(0).(func(context.Context, int, int64) int); ok {
+		r0 = rf(ctx, roundId, par)
 	} else {
 		r0 = ret.Get(0).(int)
 	}
 
-	if rf, ok := ret.Get(1).(func(int, int64) error); ok {
-		r1 = rf(roundId, par)
+	if rf, ok := ret.Get(1).(func(context.Context, int, int64) error); ok {
+		r1 = rf(ctx, roundId, par)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -40,9 +138,9 @@ func (_m *MockRepository) CountHolesByRoundAndPar(roundId int, par int64) (int,
 	return r0, r1
 }
 
-// CountHolesByRoundId provides a mock function with given fields: roundId
-func (_m *MockRepository) CountHolesByRoundId(roundId int) (int, error) {
-	ret := _m.Called(roundId)
+// CountHolesByRoundId provides a mock function with given fields: ctx, roundId
+func (_m *MockRepository) CountHolesByRoundId(ctx context.Context, roundId int) (int, error) {
+	ret := _m.Called(ctx, roundId)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CountHolesByRoundId")
@@ -50,17 +148,45 @@ func (_m *MockRepository) CountHolesByRoundId(roundId int) (int, error) {
 
 	var r0 int
 	var r1 error
-	if rf, ok := ret.Get(0).(func(int) (int, error)); ok {
-		return rf(roundId)
+	if rf, ok := ret.Get(0).(func(context.Context, int) (int, error)); ok {
+		return rf(ctx, roundId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) int); ok {
+		r0 = rf(ctx, roundId)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, roundId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CountRoundsByCourseId provides a mock function with given fields: ctx, courseId
+func (_m *MockRepository) CountRoundsByCourseId(ctx context.Context, courseId int) (int, error) {
+	ret := _m.Called(ctx, courseId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountRoundsByCourseId")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (int, error)); ok {
+		return rf(ctx, courseId)
 	}
-	if rf, ok := ret.Get(0).(func(int) int); ok {
-		r0 = rf(roundId)
+	if rf, ok := ret.Get(0).(func(context.Context, int) int); ok {
+		r0 = rf(ctx, courseId)
 	} else {
 		r0 = ret.Get(0).(int)
 	}
 
-	if rf, ok := ret.Get(1).(func(int) error); ok {
-		r1 = rf(roundId)
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, courseId)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -68,17 +194,71 @@ func (_m *MockRepository) CountHolesByRoundId(roundId int) (int, error) {
 	return r0, r1
 }
 
-// CreateCourse provides a mock function with given fields: course
-func (_m *MockRepository) CreateCourse(course *models.Course) error {
-	ret := _m.Called(course)
+// CreateApiKey provides a mock function with given fields: ctx, apiKey
+func (_m *MockRepository) CreateApiKey(ctx context.Context, apiKey *models.ApiKey) error {
+	ret := _m.Called(ctx, apiKey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateApiKey")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.ApiKey) error); ok {
+		r0 = rf(ctx, apiKey)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateAuditEvent provides a mock function with given fields: ctx, event
+func (_m *MockRepository) CreateAuditEvent(ctx context.Context, event *models.AuditEvent) error {
+	ret := _m.Called(ctx, event)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateAuditEvent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.AuditEvent) error); ok {
+		r0 = rf(ctx, event)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateCoachGrant provides a mock function with given fields: ctx, grant
+func (_m *MockRepository) CreateCoachGrant(ctx context.Context, grant *models.CoachGrant) error {
+	ret := _m.Called(ctx, grant)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateCoachGrant")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.CoachGrant) error); ok {
+		r0 = rf(ctx, grant)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateCourse provides a mock function with given fields: ctx, course
+func (_m *MockRepository) CreateCourse(ctx context.Context, course *models.Course) error {
+	ret := _m.Called(ctx, course)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateCourse")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*models.Course) error); ok {
-		r0 = rf(course)
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Course) error); ok {
+		r0 = rf(ctx, course)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -86,17 +266,35 @@ func (_m *MockRepository) CreateCourse(course *models.Course) error {
 	return r0
 }
 
-// CreateCourseDetails provides a mock function with given fields: courseDetails
-func (_m *MockRepository) CreateCourseDetails(courseDetails *models.CourseDetails) error {
-	ret := _m.Called(courseDetails)
+// CreateCourseDetails provides a mock function with given fields: ctx, courseDetails
+func (_m *MockRepository) CreateCourseDetails(ctx context.Context, courseDetails *models.CourseDetails) error {
+	ret := _m.Called(ctx, courseDetails)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateCourseDetails")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*models.CourseDetails) error); ok {
-		r0 = rf(courseDetails)
+	if rf, ok := ret.Get(0).(func(context.Context, *models.CourseDetails) error); ok {
+		r0 = rf(ctx, courseDetails)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateCustomCourse provides a mock function with given fields: ctx, course, teeSets
+func (_m *MockRepository) CreateCustomCourse(ctx context.Context, course *models.Course, teeSets []*TeeSet) error {
+	ret := _m.Called(ctx, course, teeSets)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateCustomCourse")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Course, []*TeeSet) error); ok {
+		r0 = rf(ctx, course, teeSets)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -104,83 +302,1151 @@ func (_m *MockRepository) CreateCourseDetails(courseDetails *models.CourseDetail
 	return r0
 }
 
-// CreateHole provides a mock function with given fields: hole
-func (_m *MockRepository) CreateHole(hole *models.Hole) error {
-	ret := _m.Called(hole)
+// CreateHole provides a mock function with given fields: ctx, hole
+func (_m *MockRepository) CreateHole(ctx context.Context, hole *models.Hole) error {
+	ret := _m.Called(ctx, hole)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateHole")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*models.Hole) error); ok {
-		r0 = rf(hole)
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Hole) error); ok {
+		r0 = rf(ctx, hole)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateLoginChallenge provides a mock function with given fields: ctx, challenge
+func (_m *MockRepository) CreateLoginChallenge(ctx context.Context, challenge *models.LoginChallenge) error {
+	ret := _m.Called(ctx, challenge)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateLoginChallenge")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.LoginChallenge) error); ok {
+		r0 = rf(ctx, challenge)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateOidcLogin provides a mock function with given fields: ctx, login
+func (_m *MockRepository) CreateOidcLogin(ctx context.Context, login *models.OidcLogin) error {
+	ret := _m.Called(ctx, login)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateOidcLogin")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.OidcLogin) error); ok {
+		r0 = rf(ctx, login)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreatePasswordReset provides a mock function with given fields: ctx, reset
+func (_m *MockRepository) CreatePasswordReset(ctx context.Context, reset *models.PasswordReset) error {
+	ret := _m.Called(ctx, reset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreatePasswordReset")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.PasswordReset) error); ok {
+		r0 = rf(ctx, reset)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateRound provides a mock function with given fields: ctx, round
+func (_m *MockRepository) CreateRound(ctx context.Context, round *models.Round) error {
+	ret := _m.Called(ctx, round)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateRound")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Round) error); ok {
+		r0 = rf(ctx, round)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateSession provides a mock function with given fields: ctx, session
+func (_m *MockRepository) CreateSession(ctx context.Context, session *models.Session) error {
+	ret := _m.Called(ctx, session)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateSession")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Session) error); ok {
+		r0 = rf(ctx, session)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateUser provides a mock function with given fields: ctx, user
+func (_m *MockRepository) CreateUser(ctx context.Context, user *models.User) error {
+	ret := _m.Called(ctx, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.User) error); ok {
+		r0 = rf(ctx, user)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateUserIdentity provides a mock function with given fields: ctx, identity
+func (_m *MockRepository) CreateUserIdentity(ctx context.Context, identity *models.UserIdentity) error {
+	ret := _m.Called(ctx, identity)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateUserIdentity")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.UserIdentity) error); ok {
+		r0 = rf(ctx, identity)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateUserTotp provides a mock function with given fields: ctx, totp
+func (_m *MockRepository) CreateUserTotp(ctx context.Context, totp *models.UserTotp) error {
+	ret := _m.Called(ctx, totp)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateUserTotp")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.UserTotp) error); ok {
+		r0 = rf(ctx, totp)
 	} else {
 		r0 = ret.Error(0)
 	}
 
-	return r0
+	return r0
+}
+
+// CreateUserWithIdentity provides a mock function with given fields: ctx, user, identity
+func (_m *MockRepository) CreateUserWithIdentity(ctx context.Context, user *models.User, identity *models.UserIdentity) error {
+	ret := _m.Called(ctx, user, identity)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateUserWithIdentity")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.User, *models.UserIdentity) error); ok {
+		r0 = rf(ctx, user, identity)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteCoachGrant provides a mock function with given fields: ctx, playerId, coachId
+func (_m *MockRepository) DeleteCoachGrant(ctx context.Context, playerId int, coachId int) error {
+	ret := _m.Called(ctx, playerId, coachId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteCoachGrant")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = rf(ctx, playerId, coachId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteCourse provides a mock function with given fields: ctx, courseId
+func (_m *MockRepository) DeleteCourse(ctx context.Context, courseId int) error {
+	ret := _m.Called(ctx, courseId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteCourse")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, courseId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteRound provides a mock function with given fields: ctx, roundId
+func (_m *MockRepository) DeleteRound(ctx context.Context, roundId int) error {
+	ret := _m.Called(ctx, roundId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteRound")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, roundId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteUserTotp provides a mock function with given fields: ctx, userId
+func (_m *MockRepository) DeleteUserTotp(ctx context.Context, userId int) error {
+	ret := _m.Called(ctx, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteUserTotp")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, userId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetActiveApiKeysByUserId provides a mock function with given fields: ctx, userId
+func (_m *MockRepository) GetActiveApiKeysByUserId(ctx context.Context, userId int) (*PaginationResponse[models.ApiKey], error) {
+	ret := _m.Called(ctx, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetActiveApiKeysByUserId")
+	}
+
+	var r0 *PaginationResponse[models.ApiKey]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*PaginationResponse[models.ApiKey], error)); ok {
+		return rf(ctx, userId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *PaginationResponse[models.ApiKey]); ok {
+		r0 = rf(ctx, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*PaginationResponse[models.ApiKey])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetActiveSessionsByUserId provides a mock function with given fields: ctx, userId
+func (_m *MockRepository) GetActiveSessionsByUserId(ctx context.Context, userId int) (*PaginationResponse[models.Session], error) {
+	ret := _m.Called(ctx, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetActiveSessionsByUserId")
+	}
+
+	var r0 *PaginationResponse[models.Session]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*PaginationResponse[models.Session], error)); ok {
+		return rf(ctx, userId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *PaginationResponse[models.Session]); ok {
+		r0 = rf(ctx, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*PaginationResponse[models.Session])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAllStatsForPar provides a mock function with given fields: ctx, userId, par
+func (_m *MockRepository) GetAllStatsForPar(ctx context.Context, userId int, par int64) (*PaginationResponse[HoleWithStats], error) {
+	ret := _m.Called(ctx, userId, par)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllStatsForPar")
+	}
+
+	var r0 *PaginationResponse[HoleWithStats]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int64) (*PaginationResponse[HoleWithStats], error)); ok {
+		return rf(ctx, userId, par)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int64) *PaginationResponse[HoleWithStats]); ok {
+		r0 = rf(ctx, userId, par)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*PaginationResponse[HoleWithStats])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int64) error); ok {
+		r1 = rf(ctx, userId, par)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetApiKeyById provides a mock function with given fields: ctx, id
+func (_m *MockRepository) GetApiKeyById(ctx context.Context, id int) (*models.ApiKey, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetApiKeyById")
+	}
+
+	var r0 *models.ApiKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*models.ApiKey, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *models.ApiKey); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ApiKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetApiKeyByKeyHash provides a mock function with given fields: ctx, hash
+func (_m *MockRepository) GetApiKeyByKeyHash(ctx context.Context, hash string) (*models.ApiKey, error) {
+	ret := _m.Called(ctx, hash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetApiKeyByKeyHash")
+	}
+
+	var r0 *models.ApiKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.ApiKey, error)); ok {
+		return rf(ctx, hash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.ApiKey); ok {
+		r0 = rf(ctx, hash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ApiKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAuditEvents provides a mock function with given fields: ctx, filter
+func (_m *MockRepository) GetAuditEvents(ctx context.Context, filter *AuditEventFilter) (*PaginationResponse[models.AuditEvent], error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAuditEvents")
+	}
+
+	var r0 *PaginationResponse[models.AuditEvent]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *AuditEventFilter) (*PaginationResponse[models.AuditEvent], error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *AuditEventFilter) *PaginationResponse[models.AuditEvent]); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*PaginationResponse[models.AuditEvent])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *AuditEventFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCoachGrant provides a mock function with given fields: ctx, playerId, coachId
+func (_m *MockRepository) GetCoachGrant(ctx context.Context, playerId int, coachId int) (*models.CoachGrant, error) {
+	ret := _m.Called(ctx, playerId, coachId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCoachGrant")
+	}
+
+	var r0 *models.CoachGrant
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) (*models.CoachGrant, error)); ok {
+		return rf(ctx, playerId, coachId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) *models.CoachGrant); ok {
+		r0 = rf(ctx, playerId, coachId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.CoachGrant)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, playerId, coachId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCoachGrantsByCoachId provides a mock function with given fields: ctx, coachId
+func (_m *MockRepository) GetCoachGrantsByCoachId(ctx context.Context, coachId int) (*PaginationResponse[models.CoachGrant], error) {
+	ret := _m.Called(ctx, coachId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCoachGrantsByCoachId")
+	}
+
+	var r0 *PaginationResponse[models.CoachGrant]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*PaginationResponse[models.CoachGrant], error)); ok {
+		return rf(ctx, coachId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *PaginationResponse[models.CoachGrant]); ok {
+		r0 = rf(ctx, coachId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*PaginationResponse[models.CoachGrant])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, coachId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCoachGrantsByPlayerId provides a mock function with given fields: ctx, playerId
+func (_m *MockRepository) GetCoachGrantsByPlayerId(ctx context.Context, playerId int) (*PaginationResponse[models.CoachGrant], error) {
+	ret := _m.Called(ctx, playerId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCoachGrantsByPlayerId")
+	}
+
+	var r0 *PaginationResponse[models.CoachGrant]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*PaginationResponse[models.CoachGrant], error)); ok {
+		return rf(ctx, playerId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *PaginationResponse[models.CoachGrant]); ok {
+		r0 = rf(ctx, playerId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*PaginationResponse[models.CoachGrant])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, playerId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCourseByExternalId provides a mock function with given fields: ctx, externalId
+func (_m *MockRepository) GetCourseByExternalId(ctx context.Context, externalId int) (*models.Course, error) {
+	ret := _m.Called(ctx, externalId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCourseByExternalId")
+	}
+
+	var r0 *models.Course
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*models.Course, error)); ok {
+		return rf(ctx, externalId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *models.Course); ok {
+		r0 = rf(ctx, externalId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Course)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, externalId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCourseById provides a mock function with given fields: ctx, id
+func (_m *MockRepository) GetCourseById(ctx context.Context, id int) (*models.Course, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCourseById")
+	}
+
+	var r0 *models.Course
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*models.Course, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *models.Course); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Course)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCourseDetailsHoles provides a mock function with given fields: ctx, courseDetailsId
+func (_m *MockRepository) GetCourseDetailsHoles(ctx context.Context, courseDetailsId int) (*PaginationResponse[models.Hole], error) {
+	ret := _m.Called(ctx, courseDetailsId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCourseDetailsHoles")
+	}
+
+	var r0 *PaginationResponse[models.Hole]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*PaginationResponse[models.Hole], error)); ok {
+		return rf(ctx, courseDetailsId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *PaginationResponse[models.Hole]); ok {
+		r0 = rf(ctx, courseDetailsId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*PaginationResponse[models.Hole])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, courseDetailsId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCourseTeeSets provides a mock function with given fields: ctx, courseId
+func (_m *MockRepository) GetCourseTeeSets(ctx context.Context, courseId int) (*PaginationResponse[models.CourseDetails], error) {
+	ret := _m.Called(ctx, courseId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCourseTeeSets")
+	}
+
+	var r0 *PaginationResponse[models.CourseDetails]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*PaginationResponse[models.CourseDetails], error)); ok {
+		return rf(ctx, courseId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *PaginationResponse[models.CourseDetails]); ok {
+		r0 = rf(ctx, courseId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*PaginationResponse[models.CourseDetails])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, courseId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCoursesByUserId provides a mock function with given fields: ctx, userId
+func (_m *MockRepository) GetCoursesByUserId(ctx context.Context, userId int) (*PaginationResponse[models.Course], error) {
+	ret := _m.Called(ctx, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCoursesByUserId")
+	}
+
+	var r0 *PaginationResponse[models.Course]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*PaginationResponse[models.Course], error)); ok {
+		return rf(ctx, userId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *PaginationResponse[models.Course]); ok {
+		r0 = rf(ctx, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*PaginationResponse[models.Course])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetHoleById provides a mock function with given fields: ctx, id
+func (_m *MockRepository) GetHoleById(ctx context.Context, id int) (*models.Hole, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetHoleById")
+	}
+
+	var r0 *models.Hole
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*models.Hole, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *models.Hole); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Hole)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetHoleStatsByRoundAndHoleId provides a mock function with given fields: ctx, roundId, holeId
+func (_m *MockRepository) GetHoleStatsByRoundAndHoleId(ctx context.Context, roundId int, holeId int) (*models.HoleStats, error) {
+	ret := _m.Called(ctx, roundId, holeId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetHoleStatsByRoundAndHoleId")
+	}
+
+	var r0 *models.HoleStats
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) (*models.HoleStats, error)); ok {
+		return rf(ctx, roundId, holeId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) *models.HoleStats); ok {
+		r0 = rf(ctx, roundId, holeId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.HoleStats)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, roundId, holeId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLatestCourseDetails provides a mock function with given fields: ctx, courseId, externalId
+func (_m *MockRepository) GetLatestCourseDetails(ctx context.Context, courseId int, externalId int) (*models.CourseDetails, error) {
+	ret := _m.Called(ctx, courseId, externalId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLatestCourseDetails")
+	}
+
+	var r0 *models.CourseDetails
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) (*models.CourseDetails, error)); ok {
+		return rf(ctx, courseId, externalId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) *models.CourseDetails); ok {
+		r0 = rf(ctx, courseId, externalId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.CourseDetails)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, courseId, externalId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPasswordResetByTokenHash provides a mock function with given fields: ctx, hash
+func (_m *MockRepository) GetPasswordResetByTokenHash(ctx context.Context, hash string) (*models.PasswordReset, error) {
+	ret := _m.Called(ctx, hash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPasswordResetByTokenHash")
+	}
+
+	var r0 *models.PasswordReset
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.PasswordReset, error)); ok {
+		return rf(ctx, hash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.PasswordReset); ok {
+		r0 = rf(ctx, hash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PasswordReset)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRoundById provides a mock function with given fields: ctx, id
+func (_m *MockRepository) GetRoundById(ctx context.Context, id int) (*models.Round, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRoundById")
+	}
+
+	var r0 *models.Round
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*models.Round, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *models.Round); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Round)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRoundDetailsByRoundId provides a mock function with given fields: ctx, roundId
+func (_m *MockRepository) GetRoundDetailsByRoundId(ctx context.Context, roundId int) (*RoundDetails, error) {
+	ret := _m.Called(ctx, roundId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRoundDetailsByRoundId")
+	}
+
+	var r0 *RoundDetails
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*RoundDetails, error)); ok {
+		return rf(ctx, roundId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *RoundDetails); ok {
+		r0 = rf(ctx, roundId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*RoundDetails)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, roundId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRoundHitStats provides a mock function with given fields: ctx, roundId
+func (_m *MockRepository) GetRoundHitStats(ctx context.Context, roundId int) (*PaginationResponse[models.RoundHitStats], error) {
+	ret := _m.Called(ctx, roundId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRoundHitStats")
+	}
+
+	var r0 *PaginationResponse[models.RoundHitStats]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*PaginationResponse[models.RoundHitStats], error)); ok {
+		return rf(ctx, roundId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *PaginationResponse[models.RoundHitStats]); ok {
+		r0 = rf(ctx, roundId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*PaginationResponse[models.RoundHitStats])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, roundId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRoundHitStatsByRoundStatsId provides a mock function with given fields: ctx, roundStatsId
+func (_m *MockRepository) GetRoundHitStatsByRoundStatsId(ctx context.Context, roundStatsId int) (*PaginationResponse[models.RoundHitStats], error) {
+	ret := _m.Called(ctx, roundStatsId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRoundHitStatsByRoundStatsId")
+	}
+
+	var r0 *PaginationResponse[models.RoundHitStats]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*PaginationResponse[models.RoundHitStats], error)); ok {
+		return rf(ctx, roundStatsId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *PaginationResponse[models.RoundHitStats]); ok {
+		r0 = rf(ctx, roundStatsId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*PaginationResponse[models.RoundHitStats])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, roundStatsId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRoundHoles provides a mock function with given fields: ctx, roundId, details
+func (_m *MockRepository) GetRoundHoles(ctx context.Context, roundId int, details *PaginationDetails) (*PaginationResponse[models.Hole], error) {
+	ret := _m.Called(ctx, roundId, details)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRoundHoles")
+	}
+
+	var r0 *PaginationResponse[models.Hole]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, *PaginationDetails) (*PaginationResponse[models.Hole], error)); ok {
+		return rf(ctx, roundId, details)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, *PaginationDetails) *PaginationResponse[models.Hole]); ok {
+		r0 = rf(ctx, roundId, details)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*PaginationResponse[models.Hole])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, *PaginationDetails) error); ok {
+		r1 = rf(ctx, roundId, details)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRoundStatsByRoundId provides a mock function with given fields: ctx, roundId
+func (_m *MockRepository) GetRoundStatsByRoundId(ctx context.Context, roundId int) (*models.RoundStats, error) {
+	ret := _m.Called(ctx, roundId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRoundStatsByRoundId")
+	}
+
+	var r0 *models.RoundStats
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*models.RoundStats, error)); ok {
+		return rf(ctx, roundId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *models.RoundStats); ok {
+		r0 = rf(ctx, roundId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.RoundStats)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, roundId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRoundsByUserId provides a mock function with given fields: ctx, userId, details
+func (_m *MockRepository) GetRoundsByUserId(ctx context.Context, userId int, details *PaginationDetails) (*PaginationResponse[RoundDetails], error) {
+	ret := _m.Called(ctx, userId, details)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRoundsByUserId")
+	}
+
+	var r0 *PaginationResponse[RoundDetails]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, *PaginationDetails) (*PaginationResponse[RoundDetails], error)); ok {
+		return rf(ctx, userId, details)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, *PaginationDetails) *PaginationResponse[RoundDetails]); ok {
+		r0 = rf(ctx, userId, details)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*PaginationResponse[RoundDetails])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, *PaginationDetails) error); ok {
+		r1 = rf(ctx, userId, details)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSessionById provides a mock function with given fields: ctx, id
+func (_m *MockRepository) GetSessionById(ctx context.Context, id int) (*models.Session, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSessionById")
+	}
+
+	var r0 *models.Session
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*models.Session, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *models.Session); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Session)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSessionByRefreshTokenHash provides a mock function with given fields: ctx, hash
+func (_m *MockRepository) GetSessionByRefreshTokenHash(ctx context.Context, hash string) (*models.Session, error) {
+	ret := _m.Called(ctx, hash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSessionByRefreshTokenHash")
+	}
+
+	var r0 *models.Session
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.Session, error)); ok {
+		return rf(ctx, hash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Session); ok {
+		r0 = rf(ctx, hash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Session)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// CreateRound provides a mock function with given fields: round
-func (_m *MockRepository) CreateRound(round *models.Round) error {
-	ret := _m.Called(round)
+// GetStatsByRoundId provides a mock function with given fields: ctx, userId, roundId
+func (_m *MockRepository) GetStatsByRoundId(ctx context.Context, userId int, roundId int) (*PaginationResponse[HoleWithStats], error) {
+	ret := _m.Called(ctx, userId, roundId)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateRound")
+		panic("no return value specified for GetStatsByRoundId")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(*models.Round) error); ok {
-		r0 = rf(round)
+	var r0 *PaginationResponse[HoleWithStats]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) (*PaginationResponse[HoleWithStats], error)); ok {
+		return rf(ctx, userId, roundId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) *PaginationResponse[HoleWithStats]); ok {
+		r0 = rf(ctx, userId, roundId)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*PaginationResponse[HoleWithStats])
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, userId, roundId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// CreateUser provides a mock function with given fields: user
-func (_m *MockRepository) CreateUser(user *models.User) error {
-	ret := _m.Called(user)
+// GetStatsByUserId provides a mock function with given fields: ctx, userId, details
+func (_m *MockRepository) GetStatsByUserId(ctx context.Context, userId int, details *PaginationDetails) (*PaginationResponse[RoundWithStats], error) {
+	ret := _m.Called(ctx, userId, details)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateUser")
+		panic("no return value specified for GetStatsByUserId")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(*models.User) error); ok {
-		r0 = rf(user)
+	var r0 *PaginationResponse[RoundWithStats]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, *PaginationDetails) (*PaginationResponse[RoundWithStats], error)); ok {
+		return rf(ctx, userId, details)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, *PaginationDetails) *PaginationResponse[RoundWithStats]); ok {
+		r0 = rf(ctx, userId, details)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*PaginationResponse[RoundWithStats])
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, int, *PaginationDetails) error); ok {
+		r1 = rf(ctx, userId, details)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// GetAllStatsForPar provides a mock function with given fields: userId, par
-func (_m *MockRepository) GetAllStatsForPar(userId int, par int64) (*PaginationResponse[HoleWithStats], error) {
-	ret := _m.Called(userId, par)
+// GetTotpSecrets provides a mock function with given fields: ctx, afterId, limit
+func (_m *MockRepository) GetTotpSecrets(ctx context.Context, afterId int, limit int) ([]*EncryptedValue, error) {
+	ret := _m.Called(ctx, afterId, limit)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetAllStatsForPar")
+		panic("no return value specified for GetTotpSecrets")
 	}
 
-	var r0 *PaginationResponse[HoleWithStats]
+	var r0 []*EncryptedValue
 	var r1 error
-	if rf, ok := ret.Get(0).(func(int, int64) (*PaginationResponse[HoleWithStats], error)); ok {
-		return rf(userId, par)
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) ([]*EncryptedValue, error)); ok {
+		return rf(ctx, afterId, limit)
 	}
-	if rf, ok := ret.Get(0).(func(int, int64) *PaginationResponse[HoleWithStats]); ok {
-		r0 = rf(userId, par)
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) []*EncryptedValue); ok {
+		r0 = rf(ctx, afterId, limit)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*PaginationResponse[HoleWithStats])
+			r0 = ret.Get(0).([]*EncryptedValue)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(int, int64) error); ok {
-		r1 = rf(userId, par)
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, afterId, limit)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -188,29 +1454,29 @@ func (_m *MockRepository) GetAllStatsForPar(userId int, par int64) (*PaginationR
 	return r0, r1
 }
 
-// GetHoleById provides a mock function with given fields: id
-func (_m *MockRepository) GetHoleById(id int) (*models.Hole, error) {
-	ret := _m.Called(id)
+// GetUserById provides a mock function with given fields: ctx, id
+func (_m *MockRepository) GetUserById(ctx context.Context, id int) (*models.User, error) {
+	ret := _m.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetHoleById")
+		panic("no return value specified for GetUserById")
 	}
 
-	var r0 *models.Hole
+	var r0 *models.User
 	var r1 error
-	if rf, ok := ret.Get(0).(func(int) (*models.Hole, error)); ok {
-		return rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*models.User, error)); ok {
+		return rf(ctx, id)
 	}
-	if rf, ok := ret.Get(0).(func(int) *models.Hole); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, int) *models.User); ok {
+		r0 = rf(ctx, id)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.Hole)
+			r0 = ret.Get(0).(*models.User)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(int) error); ok {
-		r1 = rf(id)
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -218,29 +1484,29 @@ func (_m *MockRepository) GetHoleById(id int) (*models.Hole, error) {
 	return r0, r1
 }
 
-// GetHoleStatsByHoleId provides a mock function with given fields: holeId
-func (_m *MockRepository) GetHoleStatsByHoleId(holeId int) (*models.HoleStats, error) {
-	ret := _m.Called(holeId)
+// GetUserHitStats provides a mock function with given fields: ctx, userId
+func (_m *MockRepository) GetUserHitStats(ctx context.Context, userId int) (*PaginationResponse[models.RoundHitStats], error) {
+	ret := _m.Called(ctx, userId)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetHoleStatsByHoleId")
+		panic("no return value specified for GetUserHitStats")
 	}
 
-	var r0 *models.HoleStats
+	var r0 *PaginationResponse[models.RoundHitStats]
 	var r1 error
-	if rf, ok := ret.Get(0).(func(int) (*models.HoleStats, error)); ok {
-		return rf(holeId)
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*PaginationResponse[models.RoundHitStats], error)); ok {
+		return rf(ctx, userId)
 	}
-	if rf, ok := ret.Get(0).(func(int) *models.HoleStats); ok {
-		r0 = rf(holeId)
+	if rf, ok := ret.Get(0).(func(context.Context, int) *PaginationResponse[models.RoundHitStats]); ok {
+		r0 = rf(ctx, userId)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.HoleStats)
+			r0 = ret.Get(0).(*PaginationResponse[models.RoundHitStats])
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(int) error); ok {
-		r1 = rf(holeId)
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, userId)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -248,29 +1514,29 @@ func (_m *MockRepository) GetHoleStatsByHoleId(holeId int) (*models.HoleStats, e
 	return r0, r1
 }
 
-// GetRoundById provides a mock function with given fields: id
-func (_m *MockRepository) GetRoundById(id int) (*models.Round, error) {
-	ret := _m.Called(id)
+// GetUserIdentitiesByUserId provides a mock function with given fields: ctx, userId
+func (_m *MockRepository) GetUserIdentitiesByUserId(ctx context.Context, userId int) (*PaginationResponse[models.UserIdentity], error) {
+	ret := _m.Called(ctx, userId)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetRoundById")
+		panic("no return value specified for GetUserIdentitiesByUserId")
 	}
 
-	var r0 *models.Round
+	var r0 *PaginationResponse[models.UserIdentity]
 	var r1 error
-	if rf, ok := ret.Get(0).(func(int) (*models.Round, error)); ok {
-		return rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*PaginationResponse[models.UserIdentity], error)); ok {
+		return rf(ctx, userId)
 	}
-	if rf, ok := ret.Get(0).(func(int) *models.Round); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, int) *PaginationResponse[models.UserIdentity]); ok {
+		r0 = rf(ctx, userId)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.Round)
+			r0 = ret.Get(0).(*PaginationResponse[models.UserIdentity])
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(int) error); ok {
-		r1 = rf(id)
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, userId)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -278,29 +1544,29 @@ func (_m *MockRepository) GetRoundById(id int) (*models.Round, error) {
 	return r0, r1
 }
 
-// GetRoundDetailsByRoundId provides a mock function with given fields: roundId
-func (_m *MockRepository) GetRoundDetailsByRoundId(roundId int) (*RoundDetails, error) {
-	ret := _m.Called(roundId)
+// GetUserIdentity provides a mock function with given fields: ctx, issuer, subject
+func (_m *MockRepository) GetUserIdentity(ctx context.Context, issuer string, subject string) (*models.UserIdentity, error) {
+	ret := _m.Called(ctx, issuer, subject)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetRoundDetailsByRoundId")
+		panic("no return value specified for GetUserIdentity")
 	}
 
-	var r0 *RoundDetails
+	var r0 *models.UserIdentity
 	var r1 error
-	if rf, ok := ret.Get(0).(func(int) (*RoundDetails, error)); ok {
-		return rf(roundId)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.UserIdentity, error)); ok {
+		return rf(ctx, issuer, subject)
 	}
-	if rf, ok := ret.Get(0).(func(int) *RoundDetails); ok {
-		r0 = rf(roundId)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.UserIdentity); ok {
+		r0 = rf(ctx, issuer, subject)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*RoundDetails)
+			r0 = ret.Get(0).(*models.UserIdentity)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(int) error); ok {
-		r1 = rf(roundId)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, issuer, subject)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -308,29 +1574,29 @@ func (_m *MockRepository) GetRoundDetailsByRoundId(roundId int) (*RoundDetails,
 	return r0, r1
 }
 
-// GetRoundHitStats provides a mock function with given fields: roundId
-func (_m *MockRepository) GetRoundHitStats(roundId int) (*PaginationResponse[models.RoundHitStats], error) {
-	ret := _m.Called(roundId)
+// GetUserPasswords provides a mock function with given fields: ctx, afterId, limit
+func (_m *MockRepository) GetUserPasswords(ctx context.Context, afterId int, limit int) ([]*EncryptedValue, error) {
+	ret := _m.Called(ctx, afterId, limit)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetRoundHitStats")
+		panic("no return value specified for GetUserPasswords")
 	}
 
-	var r0 *PaginationResponse[models.RoundHitStats]
+	var r0 []*EncryptedValue
 	var r1 error
-	if rf, ok := ret.Get(0).(func(int) (*PaginationResponse[models.RoundHitStats], error)); ok {
-		return rf(roundId)
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) ([]*EncryptedValue, error)); ok {
+		return rf(ctx, afterId, limit)
 	}
-	if rf, ok := ret.Get(0).(func(int) *PaginationResponse[models.RoundHitStats]); ok {
-		r0 = rf(roundId)
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) []*EncryptedValue); ok {
+		r0 = rf(ctx, afterId, limit)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*PaginationResponse[models.RoundHitStats])
+			r0 = ret.Get(0).([]*EncryptedValue)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(int) error); ok {
-		r1 = rf(roundId)
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, afterId, limit)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -338,29 +1604,29 @@ func (_m *MockRepository) GetRoundHitStats(roundId int) (*PaginationResponse[mod
 	return r0, r1
 }
 
-// GetRoundHitStatsByRoundStatsId provides a mock function with given fields: roundStatsId
-func (_m *MockRepository) GetRoundHitStatsByRoundStatsId(roundStatsId int) (*PaginationResponse[models.RoundHitStats], error) {
-	ret := _m.Called(roundStatsId)
+// GetUserPreference provides a mock function with given fields: ctx, userId
+func (_m *MockRepository) GetUserPreference(ctx context.Context, userId int) (*models.UserPreference, error) {
+	ret := _m.Called(ctx, userId)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetRoundHitStatsByRoundStatsId")
+		panic("no return value specified for GetUserPreference")
 	}
 
-	var r0 *PaginationResponse[models.RoundHitStats]
+	var r0 *models.UserPreference
 	var r1 error
-	if rf, ok := ret.Get(0).(func(int) (*PaginationResponse[models.RoundHitStats], error)); ok {
-		return rf(roundStatsId)
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*models.UserPreference, error)); ok {
+		return rf(ctx, userId)
 	}
-	if rf, ok := ret.Get(0).(func(int) *PaginationResponse[models.RoundHitStats]); ok {
-		r0 = rf(roundStatsId)
+	if rf, ok := ret.Get(0).(func(context.Context, int) *models.UserPreference); ok {
+		r0 = rf(ctx, userId)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*PaginationResponse[models.RoundHitStats])
+			r0 = ret.Get(0).(*models.UserPreference)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(int) error); ok {
-		r1 = rf(roundStatsId)
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, userId)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -368,29 +1634,29 @@ func (_m *MockRepository) GetRoundHitStatsByRoundStatsId(roundStatsId int) (*Pag
 	return r0, r1
 }
 
-// GetRoundHoles provides a mock function with given fields: roundId
-func (_m *MockRepository) GetRoundHoles(roundId int) (*PaginationResponse[models.Hole], error) {
-	ret := _m.Called(roundId)
+// GetUserTotp provides a mock function with given fields: ctx, userId
+func (_m *MockRepository) GetUserTotp(ctx context.Context, userId int) (*models.UserTotp, error) {
+	ret := _m.Called(ctx, userId)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetRoundHoles")
+		panic("no return value specified for GetUserTotp")
 	}
 
-	var r0 *PaginationResponse[models.Hole]
+	var r0 *models.UserTotp
 	var r1 error
-	if rf, ok := ret.Get(0).(func(int) (*PaginationResponse[models.Hole], error)); ok {
-		return rf(roundId)
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*models.UserTotp, error)); ok {
+		return rf(ctx, userId)
 	}
-	if rf, ok := ret.Get(0).(func(int) *PaginationResponse[models.Hole]); ok {
-		r0 = rf(roundId)
+	if rf, ok := ret.Get(0).(func(context.Context, int) *models.UserTotp); ok {
+		r0 = rf(ctx, userId)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*PaginationResponse[models.Hole])
+			r0 = ret.Get(0).(*models.UserTotp)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(int) error); ok {
-		r1 = rf(roundId)
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, userId)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -398,29 +1664,29 @@ func (_m *MockRepository) GetRoundHoles(roundId int) (*PaginationResponse[models
 	return r0, r1
 }
 
-// GetRoundStatsByRoundId provides a mock function with given fields: roundId
-func (_m *MockRepository) GetRoundStatsByRoundId(roundId int) (*models.RoundStats, error) {
-	ret := _m.Called(roundId)
+// GetUsers provides a mock function with given fields: ctx
+func (_m *MockRepository) GetUsers(ctx context.Context) (*PaginationResponse[models.User], error) {
+	ret := _m.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetRoundStatsByRoundId")
+		panic("no return value specified for GetUsers")
 	}
 
-	var r0 *models.RoundStats
+	var r0 *PaginationResponse[models.User]
 	var r1 error
-	if rf, ok := ret.Get(0).(func(int) (*models.RoundStats, error)); ok {
-		return rf(roundId)
+	if rf, ok := ret.Get(0).(func(context.Context) (*PaginationResponse[models.User], error)); ok {
+		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func(int) *models.RoundStats); ok {
-		r0 = rf(roundId)
+	if rf, ok := ret.Get(0).(func(context.Context) *PaginationResponse[models.User]); ok {
+		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.RoundStats)
+			r0 = ret.Get(0).(*PaginationResponse[models.User])
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(int) error); ok {
-		r1 = rf(roundId)
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -428,29 +1694,27 @@ func (_m *MockRepository) GetRoundStatsByRoundId(roundId int) (*models.RoundStat
 	return r0, r1
 }
 
-// GetRoundsByUserId provides a mock function with given fields: userId
-func (_m *MockRepository) GetRoundsByUserId(userId int) (*PaginationResponse[models.Round], error) {
-	ret := _m.Called(userId)
+// ImportTeeSet provides a mock function with given fields: ctx, course, teeSet
+func (_m *MockRepository) ImportTeeSet(ctx context.Context, course *models.Course, teeSet *TeeSet) (int, error) {
+	ret := _m.Called(ctx, course, teeSet)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetRoundsByUserId")
+		panic("no return value specified for ImportTeeSet")
 	}
 
-	var r0 *PaginationResponse[models.Round]
+	var r0 int
 	var r1 error
-	if rf, ok := ret.Get(0).(func(int) (*PaginationResponse[models.Round], error)); ok {
-		return rf(userId)
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Course, *TeeSet) (int, error)); ok {
+		return rf(ctx, course, teeSet)
 	}
-	if rf, ok := ret.Get(0).(func(int) *PaginationResponse[models.Round]); ok {
-		r0 = rf(userId)
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Course, *TeeSet) int); ok {
+		r0 = rf(ctx, course, teeSet)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*PaginationResponse[models.Round])
-		}
+		r0 = ret.Get(0).(int)
 	}
 
-	if rf, ok := ret.Get(1).(func(int) error); ok {
-		r1 = rf(userId)
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Course, *TeeSet) error); ok {
+		r1 = rf(ctx, course, teeSet)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -458,29 +1722,27 @@ func (_m *MockRepository) GetRoundsByUserId(userId int) (*PaginationResponse[mod
 	return r0, r1
 }
 
-// GetStatsByRoundId provides a mock function with given fields: userId, roundId
-func (_m *MockRepository) GetStatsByRoundId(userId int, roundId int) (*PaginationResponse[HoleWithStats], error) {
-	ret := _m.Called(userId, roundId)
+// ReplaceTotpSecret provides a mock function with given fields: ctx, totpId, oldSecret, newSecret
+func (_m *MockRepository) ReplaceTotpSecret(ctx context.Context, totpId int, oldSecret string, newSecret string) (bool, error) {
+	ret := _m.Called(ctx, totpId, oldSecret, newSecret)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetStatsByRoundId")
+		panic("no return value specified for ReplaceTotpSecret")
 	}
 
-	var r0 *PaginationResponse[HoleWithStats]
+	var r0 bool
 	var r1 error
-	if rf, ok := ret.Get(0).(func(int, int) (*PaginationResponse[HoleWithStats], error)); ok {
-		return rf(userId, roundId)
+	if rf, ok := ret.Get(0).(func(context.Context, int, string, string) (bool, error)); ok {
+		return rf(ctx, totpId, oldSecret, newSecret)
 	}
-	if rf, ok := ret.Get(0).(func(int, int) *PaginationResponse[HoleWithStats]); ok {
-		r0 = rf(userId, roundId)
+	if rf, ok := ret.Get(0).(func(context.Context, int, string, string) bool); ok {
+		r0 = rf(ctx, totpId, oldSecret, newSecret)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*PaginationResponse[HoleWithStats])
-		}
+		r0 = ret.Get(0).(bool)
 	}
 
-	if rf, ok := ret.Get(1).(func(int, int) error); ok {
-		r1 = rf(userId, roundId)
+	if rf, ok := ret.Get(1).(func(context.Context, int, string, string) error); ok {
+		r1 = rf(ctx, totpId, oldSecret, newSecret)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -488,29 +1750,27 @@ func (_m *MockRepository) GetStatsByRoundId(userId int, roundId int) (*Paginatio
 	return r0, r1
 }
 
-// GetStatsByUserId provides a mock function with given fields: userId
-func (_m *MockRepository) GetStatsByUserId(userId int) (*PaginationResponse[RoundWithStats], error) {
-	ret := _m.Called(userId)
+// ReplaceUserPassword provides a mock function with given fields: ctx, userId, oldPassword, newPassword
+func (_m *MockRepository) ReplaceUserPassword(ctx context.Context, userId int, oldPassword string, newPassword string) (bool, error) {
+	ret := _m.Called(ctx, userId, oldPassword, newPassword)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetStatsByUserId")
+		panic("no return value specified for ReplaceUserPassword")
 	}
 
-	var r0 *PaginationResponse[RoundWithStats]
+	var r0 bool
 	var r1 error
-	if rf, ok := ret.Get(0).(func(int) (*PaginationResponse[RoundWithStats], error)); ok {
-		return rf(userId)
+	if rf, ok := ret.Get(0).(func(context.Context, int, string, string) (bool, error)); ok {
+		return rf(ctx, userId, oldPassword, newPassword)
 	}
-	if rf, ok := ret.Get(0).(func(int) *PaginationResponse[RoundWithStats]); ok {
-		r0 = rf(userId)
+	if rf, ok := ret.Get(0).(func(context.Context, int, string, string) bool); ok {
+		r0 = rf(ctx, userId, oldPassword, newPassword)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*PaginationResponse[RoundWithStats])
-		}
+		r0 = ret.Get(0).(bool)
 	}
 
-	if rf, ok := ret.Get(1).(func(int) error); ok {
-		r1 = rf(userId)
+	if rf, ok := ret.Get(1).(func(context.Context, int, string, string) error); ok {
+		r1 = rf(ctx, userId, oldPassword, newPassword)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -518,47 +1778,71 @@ func (_m *MockRepository) GetStatsByUserId(userId int) (*PaginationResponse[Roun
 	return r0, r1
 }
 
-// GetUserHitStats provides a mock function with given fields: userId
-func (_m *MockRepository) GetUserHitStats(userId int) (*PaginationResponse[models.RoundHitStats], error) {
-	ret := _m.Called(userId)
+// RevokeApiKey provides a mock function with given fields: ctx, id
+func (_m *MockRepository) RevokeApiKey(ctx context.Context, id int) error {
+	ret := _m.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetUserHitStats")
+		panic("no return value specified for RevokeApiKey")
 	}
 
-	var r0 *PaginationResponse[models.RoundHitStats]
-	var r1 error
-	if rf, ok := ret.Get(0).(func(int) (*PaginationResponse[models.RoundHitStats], error)); ok {
-		return rf(userId)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeSession provides a mock function with given fields: ctx, sessionId
+func (_m *MockRepository) RevokeSession(ctx context.Context, sessionId int) error {
+	ret := _m.Called(ctx, sessionId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeSession")
 	}
-	if rf, ok := ret.Get(0).(func(int) *PaginationResponse[models.RoundHitStats]); ok {
-		r0 = rf(userId)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, sessionId)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*PaginationResponse[models.RoundHitStats])
-		}
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RotateSessionRefreshToken provides a mock function with given fields: ctx, sessionId, oldHash, newHash, usedAt
+func (_m *MockRepository) RotateSessionRefreshToken(ctx context.Context, sessionId int, oldHash string, newHash string, usedAt time.Time) error {
+	ret := _m.Called(ctx, sessionId, oldHash, newHash, usedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RotateSessionRefreshToken")
 	}
 
-	if rf, ok := ret.Get(1).(func(int) error); ok {
-		r1 = rf(userId)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string, string, time.Time) error); ok {
+		r0 = rf(ctx, sessionId, oldHash, newHash, usedAt)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// SaveHoleStats provides a mock function with given fields: holeStats
-func (_m *MockRepository) SaveHoleStats(holeStats *models.HoleStats) error {
-	ret := _m.Called(holeStats)
+// SaveHoleStats provides a mock function with given fields: ctx, holeStats
+func (_m *MockRepository) SaveHoleStats(ctx context.Context, holeStats *models.HoleStats) error {
+	ret := _m.Called(ctx, holeStats)
 
 	if len(ret) == 0 {
 		panic("no return value specified for SaveHoleStats")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*models.HoleStats) error); ok {
-		r0 = rf(holeStats)
+	if rf, ok := ret.Get(0).(func(context.Context, *models.HoleStats) error); ok {
+		r0 = rf(ctx, holeStats)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -566,13 +1850,14 @@ func (_m *MockRepository) SaveHoleStats(holeStats *models.HoleStats) error {
 	return r0
 }
 
-// SaveRoundHitStats provides a mock function with given fields: roundHitStats
-func (_m *MockRepository) SaveRoundHitStats(roundHitStats ...*models.RoundHitStats) error {
+// SaveRoundHitStats provides a mock function with given fields: ctx, roundHitStats
+func (_m *MockRepository) SaveRoundHitStats(ctx context.Context, roundHitStats ...*models.RoundHitStats) error {
 	_va := make([]interface{}, len(roundHitStats))
 	for _i := range roundHitStats {
 		_va[_i] = roundHitStats[_i]
 	}
 	var _ca []interface{}
+	_ca = append(_ca, ctx)
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
@@ -581,8 +1866,8 @@ func (_m *MockRepository) SaveRoundHitStats(roundHitStats ...*models.RoundHitSta
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(...*models.RoundHitStats) error); ok {
-		r0 = rf(roundHitStats...)
+	if rf, ok := ret.Get(0).(func(context.Context, ...*models.RoundHitStats) error); ok {
+		r0 = rf(ctx, roundHitStats...)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -590,17 +1875,233 @@ func (_m *MockRepository) SaveRoundHitStats(roundHitStats ...*models.RoundHitSta
 	return r0
 }
 
-// SaveRoundStats provides a mock function with given fields: roundStats
-func (_m *MockRepository) SaveRoundStats(roundStats *models.RoundStats) error {
-	ret := _m.Called(roundStats)
+// SaveRoundStats provides a mock function with given fields: ctx, roundStats
+func (_m *MockRepository) SaveRoundStats(ctx context.Context, roundStats *models.RoundStats) error {
+	ret := _m.Called(ctx, roundStats)
 
 	if len(ret) == 0 {
 		panic("no return value specified for SaveRoundStats")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*models.RoundStats) error); ok {
-		r0 = rf(roundStats)
+	if rf, ok := ret.Get(0).(func(context.Context, *models.RoundStats) error); ok {
+		r0 = rf(ctx, roundStats)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveUserPreference provides a mock function with given fields: ctx, pref
+func (_m *MockRepository) SaveUserPreference(ctx context.Context, pref *models.UserPreference) error {
+	ret := _m.Called(ctx, pref)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveUserPreference")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.UserPreference) error); ok {
+		r0 = rf(ctx, pref)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateApiKeyLastUsed provides a mock function with given fields: ctx, id, usedAt
+func (_m *MockRepository) UpdateApiKeyLastUsed(ctx context.Context, id int, usedAt time.Time) error {
+	ret := _m.Called(ctx, id, usedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateApiKeyLastUsed")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Time) error); ok {
+		r0 = rf(ctx, id, usedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateCourse provides a mock function with given fields: ctx, course
+func (_m *MockRepository) UpdateCourse(ctx context.Context, course *models.Course) error {
+	ret := _m.Called(ctx, course)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateCourse")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Course) error); ok {
+		r0 = rf(ctx, course)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateCustomCourse provides a mock function with given fields: ctx, course, teeSets
+func (_m *MockRepository) UpdateCustomCourse(ctx context.Context, course *models.Course, teeSets []*TeeSet) error {
+	ret := _m.Called(ctx, course, teeSets)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateCustomCourse")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Course, []*TeeSet) error); ok {
+		r0 = rf(ctx, course, teeSets)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateUserIdentity provides a mock function with given fields: ctx, identity
+func (_m *MockRepository) UpdateUserIdentity(ctx context.Context, identity *models.UserIdentity) error {
+	ret := _m.Called(ctx, identity)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateUserIdentity")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.UserIdentity) error); ok {
+		r0 = rf(ctx, identity)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateUserLastLogin provides a mock function with given fields: ctx, userId, lastLogin
+func (_m *MockRepository) UpdateUserLastLogin(ctx context.Context, userId int, lastLogin time.Time) error {
+	ret := _m.Called(ctx, userId, lastLogin)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateUserLastLogin")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Time) error); ok {
+		r0 = rf(ctx, userId, lastLogin)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateUserPassword provides a mock function with given fields: ctx, userId, password, exceptSessionId
+func (_m *MockRepository) UpdateUserPassword(ctx context.Context, userId int, password string, exceptSessionId int) error {
+	ret := _m.Called(ctx, userId, password, exceptSessionId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateUserPassword")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string, int) error); ok {
+		r0 = rf(ctx, userId, password, exceptSessionId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateUserPasswordHash provides a mock function with given fields: ctx, userId, password
+func (_m *MockRepository) UpdateUserPasswordHash(ctx context.Context, userId int, password string) error {
+	ret := _m.Called(ctx, userId, password)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateUserPasswordHash")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) error); ok {
+		r0 = rf(ctx, userId, password)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateUserProfile provides a mock function with given fields: ctx, user
+func (_m *MockRepository) UpdateUserProfile(ctx context.Context, user *models.User) error {
+	ret := _m.Called(ctx, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateUserProfile")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.User) error); ok {
+		r0 = rf(ctx, user)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateUserRole provides a mock function with given fields: ctx, userId, role
+func (_m *MockRepository) UpdateUserRole(ctx context.Context, userId int, role string) error {
+	ret := _m.Called(ctx, userId, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateUserRole")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) error); ok {
+		r0 = rf(ctx, userId, role)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UseRecoveryCode provides a mock function with given fields: ctx, userId, codeHash
+func (_m *MockRepository) UseRecoveryCode(ctx context.Context, userId int, codeHash string) error {
+	ret := _m.Called(ctx, userId, codeHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UseRecoveryCode")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) error); ok {
+		r0 = rf(ctx, userId, codeHash)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UseTotpStep provides a mock function with given fields: ctx, totpId, step
+func (_m *MockRepository) UseTotpStep(ctx context.Context, totpId int, step int64) error {
+	ret := _m.Called(ctx, totpId, step)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UseTotpStep")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int64) error); ok {
+		r0 = rf(ctx, totpId, step)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -608,9 +2109,9 @@ func (_m *MockRepository) SaveRoundStats(roundStats *models.RoundStats) error {
 	return r0
 }
 
-// UserByUsername provides a mock function with given fields: username
-func (_m *MockRepository) UserByUsername(username string) (*models.User, error) {
-	ret := _m.Called(username)
+// UserByUsername provides a mock function with given fields: ctx, username
+func (_m *MockRepository) UserByUsername(ctx context.Context, username string) (*models.User, error) {
+	ret := _m.Called(ctx, username)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UserByUsername")
@@ -618,19 +2119,19 @@ func (_m *MockRepository) UserByUsername(username string) (*models.User, error)
 
 	var r0 *models.User
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (*models.User, error)); ok {
-		return rf(username)
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.User, error)); ok {
+		return rf(ctx, username)
 	}
-	if rf, ok := ret.Get(0).(func(string) *models.User); ok {
-		r0 = rf(username)
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.User); ok {
+		r0 = rf(ctx, username)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.User)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(username)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, username)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1,6 +1,36 @@
 package rounder
 
-type PaginationResponse[T comparable] struct {
+import (
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/models"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+type PaginationResponse[T any] struct {
 	Items []*T  `json:"items"`
 	Total int64 `json:"total"`
+
+	// Next is the cursor of the next page, which is nil on the last page.
+	Next *PageCursor `json:"next,omitempty"`
+}
+
+// EncryptedValue is a value encrypted with the vault transit key, along with the ID of the row that it is stored in.
+type EncryptedValue struct {
+	Id         int    `db:"id"`
+	Ciphertext string `db:"ciphertext"`
+}
+
+// TeeSet is a version of a tee set of a catalogue course along with its holes.
+type TeeSet struct {
+	Details *models.CourseDetails
+	Holes   []*models.Hole
+}
+
+// Equal reports whether the tee data of two versions of a tee set is the same.
+func (ts *TeeSet) Equal(other *TeeSet) bool {
+	detailsOpts := cmpopts.IgnoreFields(models.CourseDetails{}, "Id", "Version")
+	holeOpts := cmpopts.IgnoreFields(models.Hole{}, "Id", "CourseDetailsId")
+	sortHoles := cmpopts.SortSlices(func(x, y *models.Hole) bool { return x.Number < y.Number })
+
+	return cmp.Equal(ts.Details, other.Details, detailsOpts) && cmp.Equal(ts.Holes, other.Holes, holeOpts, sortHoles)
 }
@@ -0,0 +1,119 @@
+package rounder
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/models"
+)
+
+var (
+	// ErrOidcLoginNotFound is returned when the OIDC login is not found or has already been used.
+	ErrOidcLoginNotFound = errors.New("oidc login not found")
+
+	// ErrUserIdentityNotFound is returned when the user identity is not found.
+	ErrUserIdentityNotFound = errors.New("user identity not found")
+)
+
+func (r *repository) CreateOidcLogin(ctx context.Context, login *models.OidcLogin) error {
+	login.Id = 0
+	return login.Insert(ctx, r.db)
+}
+
+func (r *repository) ConsumeOidcLogin(ctx context.Context, stateHash string) (*models.OidcLogin, error) {
+	login := new(models.OidcLogin)
+	err := models.NewDBTransactionHandler(r.db).Handle(ctx, func(db models.DB) error {
+		l, err := models.OidcLoginByStateHash(ctx, db, stateHash)
+		if err != nil {
+			switch {
+			case errors.Is(err, sql.ErrNoRows):
+				return ErrOidcLoginNotFound
+			default:
+				return fmt.Errorf("error getting oidc login by state: %w", err)
+			}
+		}
+
+		// Only return the login if this call deleted it, so that it cannot be used twice concurrently.
+		res, err := db.ExecContext(ctx, `DELETE FROM oidc_login WHERE id = ?`, l.Id)
+		if err != nil {
+			return fmt.Errorf("error deleting oidc login: %w", err)
+		}
+
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("error getting affected rows: %w", err)
+		} else if affected == 0 {
+			return ErrOidcLoginNotFound
+		}
+
+		login = l
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return login, nil
+}
+
+func (r *repository) GetUserIdentity(ctx context.Context, issuer string, subject string) (*models.UserIdentity, error) {
+	identity, err := models.UserIdentityByIssuerSubject(ctx, r.db, issuer, subject)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrUserIdentityNotFound
+		default:
+			return nil, fmt.Errorf("error getting user identity: %w", err)
+		}
+	}
+
+	return identity, nil
+}
+
+func (r *repository) CreateUserIdentity(ctx context.Context, identity *models.UserIdentity) error {
+	identity.Id = 0
+	return identity.Insert(ctx, r.db)
+}
+
+func (r *repository) UpdateUserIdentity(ctx context.Context, identity *models.UserIdentity) error {
+	err := identity.Update(ctx, r.db)
+	if err != nil && !errors.Is(err, models.ErrNoAffectedRows) {
+		return fmt.Errorf("error updating user identity: %w", err)
+	}
+
+	return nil
+}
+
+func (r *repository) CreateUserWithIdentity(ctx context.Context, user *models.User, identity *models.UserIdentity) error {
+	return models.NewDBTransactionHandler(r.db).Handle(ctx, func(db models.DB) error {
+		user.Id = 0
+		if err := user.Insert(ctx, db); err != nil {
+			return fmt.Errorf("error creating user: %w", err)
+		}
+
+		identity.Id = 0
+		identity.UserId = user.Id
+		if err := identity.Insert(ctx, db); err != nil {
+			return fmt.Errorf("error creating user identity: %w", err)
+		}
+
+		return nil
+	})
+}
+
+func (r *repository) GetUserIdentitiesByUserId(ctx context.Context, userId int) (*PaginationResponse[models.UserIdentity], error) {
+	sqlStmt := `SELECT ` + columns(models.UserIdentity{}, "user_identity", "") + ` FROM user_identity WHERE user_id = ? ORDER BY created_at`
+
+	identities := make([]*models.UserIdentity, 0)
+	err := r.db.SelectContext(ctx, &identities, sqlStmt, userId)
+	if err != nil {
+		return nil, fmt.Errorf("error getting user identities: %w", err)
+	}
+
+	return &PaginationResponse[models.UserIdentity]{
+		Items: identities,
+		Total: int64(len(identities)),
+	}, nil
+}
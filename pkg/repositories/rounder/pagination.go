@@ -0,0 +1,253 @@
+package rounder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/models"
+)
+
+const (
+	// DefaultPageLimit is the number of items on a page when no limit is given.
+	DefaultPageLimit = 100
+
+	// MaxPageLimit is the most items that can be on a page.
+	MaxPageLimit = 1000
+
+	// SortDirAsc sorts the items in ascending order.
+	SortDirAsc = "asc"
+
+	// SortDirDesc sorts the items in descending order.
+	SortDirDesc = "desc"
+)
+
+var (
+	// ErrInvalidSortColumn is returned when a list cannot be sorted by the requested column.
+	ErrInvalidSortColumn = errors.New("invalid sort column")
+
+	// ErrInvalidSortDirection is returned when the sort direction is neither asc nor desc.
+	ErrInvalidSortDirection = errors.New("invalid sort direction")
+
+	// ErrInvalidCursor is returned when the last value or last ID of the previous page cannot be parsed.
+	ErrInvalidCursor = errors.New("invalid pagination cursor")
+
+	// ErrInvalidLimit is returned when the limit of a page is negative.
+	ErrInvalidLimit = errors.New("invalid limit")
+)
+
+// PaginationDetails selects a page of a list. The page starts after the item with the last value and last ID, which
+// are taken from the Next cursor of the previous page. A nil PaginationDetails selects the whole list.
+type PaginationDetails struct {
+	// Limit is the most items on the page. It defaults to DefaultPageLimit, is capped at MaxPageLimit and cannot be
+	// negative.
+	Limit int
+
+	// LastVal is the value of the sort column of the last item on the previous page.
+	LastVal string
+
+	// LastId is the ID of the last item on the previous page.
+	LastId string
+
+	// SortBy is the column to sort by. Each list has its own sort columns and default.
+	SortBy string
+
+	// SortDir is the direction to sort in, which defaults to ascending.
+	SortDir string
+}
+
+// PageCursor is the position of the last item on a page, which the next page starts after.
+type PageCursor struct {
+	LastVal string `json:"last_val"`
+	LastId  string `json:"last_id"`
+}
+
+// sortKind is the type of the values of a sort column, which the last value of a cursor is parsed as.
+type sortKind int
+
+const (
+	sortKindInt sortKind = iota
+	sortKindFloat
+	sortKindString
+	sortKindTime
+)
+
+// sortColumn is a column that a list can be sorted by.
+type sortColumn[T any] struct {
+	// column is the SQL expression of the column.
+	column string
+
+	// kind is the type of the values of the column.
+	kind sortKind
+
+	// value gets the value of the column from an item.
+	value func(*T) any
+}
+
+// paginatedQuery is a query of a list that can be sorted and paginated.
+type paginatedQuery[T any] struct {
+	// name names the queries in models.DatabaseLatency.
+	name string
+
+	// selectStmt selects the items, without a WHERE clause.
+	selectStmt string
+
+	// countStmt counts the items, without a WHERE clause.
+	countStmt string
+
+	// where is the conditions that the items must match.
+	where []string
+
+	// args is the arguments of the conditions.
+	args []any
+
+	// idColumn is the SQL expression of the unique ID of an item, which breaks ties between equal sort values.
+	idColumn string
+
+	// id gets the unique ID of an item.
+	id func(*T) int
+
+	// sorts is the columns that the list can be sorted by, by their API name.
+	sorts map[string]sortColumn[T]
+
+	// defaultSort is the API name of the column to sort by when none is given.
+	defaultSort string
+}
+
+// paginate gets the page of the list selected by the details, along with the total number of items in the list and
+// the cursor of the next page. The items are ordered by the sort column and then by ID, so that the cursor is unique
+// even when the sort values are not.
+func paginate[T any](ctx context.Context, db models.DB, q *paginatedQuery[T], details *PaginationDetails) (*PaginationResponse[T], error) {
+	// Only a nil details selects every item, so a limit from a request can never remove the LIMIT clause.
+	all := details == nil
+	if all {
+		details = new(PaginationDetails)
+	} else if details.Limit < 0 {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidLimit, details.Limit)
+	}
+
+	sortBy := details.SortBy
+	if sortBy == "" {
+		sortBy = q.defaultSort
+	}
+
+	sort, ok := q.sorts[sortBy]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidSortColumn, sortBy)
+	}
+
+	var cmp, dir string
+	switch strings.ToLower(details.SortDir) {
+	case "", SortDirAsc:
+		cmp, dir = ">", "ASC"
+	case SortDirDesc:
+		cmp, dir = "<", "DESC"
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrInvalidSortDirection, details.SortDir)
+	}
+
+	where := strings.Join(q.where, " AND ")
+
+	var total int64
+	err := db.GetContext(models.WithQueryName(ctx, "count_"+q.name), &total, q.countStmt+" WHERE "+where, q.args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count items: %w", err)
+	}
+
+	args := append([]any{}, q.args...)
+	if details.LastId != "" {
+		lastId, err := strconv.Atoi(details.LastId)
+		if err != nil {
+			return nil, fmt.Errorf("%w: last ID %q", ErrInvalidCursor, details.LastId)
+		}
+
+		if sort.column == q.idColumn {
+			where += fmt.Sprintf(" AND %s %s ?", q.idColumn, cmp)
+			args = append(args, lastId)
+		} else {
+			lastVal, err := parseSortValue(sort.kind, details.LastVal)
+			if err != nil {
+				return nil, fmt.Errorf("%w: last value %q", ErrInvalidCursor, details.LastVal)
+			}
+
+			where += fmt.Sprintf(" AND (%[1]s %[2]s ? OR (%[1]s = ? AND %[3]s %[2]s ?))", sort.column, cmp, q.idColumn)
+			args = append(args, lastVal, lastVal, lastId)
+		}
+	}
+
+	sqlStmt := fmt.Sprintf("%s WHERE %s ORDER BY %s %s, %s %s", q.selectStmt, where, sort.column, dir, q.idColumn, dir)
+
+	// One more item than the limit is selected to find out whether there is a next page.
+	limit := 0
+	if !all {
+		limit = pageLimit(details.Limit)
+		sqlStmt += " LIMIT ?"
+		args = append(args, limit+1)
+	}
+
+	items := make([]*T, 0)
+	err = db.SelectContext(models.WithQueryName(ctx, "select_"+q.name), &items, sqlStmt, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get items: %w", err)
+	}
+
+	resp := &PaginationResponse[T]{
+		Items: items,
+		Total: total,
+	}
+
+	if !all && len(items) > limit {
+		resp.Items = items[:limit]
+
+		last := resp.Items[limit-1]
+		resp.Next = &PageCursor{
+			LastVal: formatSortValue(sort.value(last)),
+			LastId:  strconv.Itoa(q.id(last)),
+		}
+	}
+
+	return resp, nil
+}
+
+// pageLimit gets the number of items on a page for the requested limit.
+func pageLimit(limit int) int {
+	switch {
+	case limit == 0:
+		return DefaultPageLimit
+	case limit > MaxPageLimit:
+		return MaxPageLimit
+	default:
+		return limit
+	}
+}
+
+func parseSortValue(kind sortKind, value string) (any, error) {
+	switch kind {
+	case sortKindInt:
+		return strconv.Atoi(value)
+	case sortKindFloat:
+		return strconv.ParseFloat(value, 64)
+	case sortKindTime:
+		t, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return nil, err
+		}
+		return t.UTC(), nil
+	default:
+		return value, nil
+	}
+}
+
+func formatSortValue(value any) string {
+	switch v := value.(type) {
+	case time.Time:
+		return v.UTC().Format(time.RFC3339Nano)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -1,16 +1,17 @@
 package rounder
 
 import (
-	"github.com/Jacobbrewer1/vaulty/repositories"
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/models"
 )
 
 type repository struct {
 	// db is the database used by the repository.
-	db *repositories.Database
+	db models.DBTransactioner
 }
 
-// NewRepository creates a new repository.
-func NewRepository(db *repositories.Database) Repository {
+// NewRepository creates a new repository. The queries run with the context of each call, so wrap the database with
+// models.NewTimeoutDB to also give each query a deadline.
+func NewRepository(db models.DBTransactioner) Repository {
 	return &repository{
 		db: db,
 	}
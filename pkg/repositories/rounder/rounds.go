@@ -1,6 +1,7 @@
 package rounder
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
@@ -8,102 +9,112 @@ import (
 	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/models"
 )
 
-func (r *repository) CreateRound(round *models.Round) error {
+func (r *repository) CreateRound(ctx context.Context, round *models.Round) error {
 	round.Id = 0
-	return round.Insert(r.db)
+	return round.Insert(ctx, r.db)
 }
 
-func (r *repository) GetRoundById(id int) (*models.Round, error) {
-	return models.RoundById(r.db, id)
-}
+func (r *repository) DeleteRound(ctx context.Context, roundId int) error {
+	return models.NewDBTransactionHandler(r.db).Handle(ctx, func(db models.DB) error {
+		sqlStmt := `
+		DELETE FROM round_hit_stats
+		WHERE round_stats_id IN (SELECT id FROM round_stats WHERE round_id = ?)
+		`
 
-func (r *repository) GetRoundDetailsByRoundId(roundId int) (*RoundDetails, error) {
-	round, err := r.GetRoundById(roundId)
-	if err != nil {
-		return nil, err
-	}
+		_, err := db.ExecContext(ctx, sqlStmt, roundId)
+		if err != nil {
+			return fmt.Errorf("failed to delete round hit stats: %w", err)
+		}
 
-	sqlStmt := `SELECT id FROM course WHERE round_id = ?`
+		_, err = db.ExecContext(ctx, `DELETE FROM round_stats WHERE round_id = ?`, roundId)
+		if err != nil {
+			return fmt.Errorf("failed to delete round stats: %w", err)
+		}
 
-	var courseID int
-	err = r.db.Get(&courseID, sqlStmt, roundId)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get course ID: %w", err)
-	}
+		_, err = db.ExecContext(ctx, `DELETE FROM hole_stats WHERE round_id = ?`, roundId)
+		if err != nil {
+			return fmt.Errorf("failed to delete hole stats: %w", err)
+		}
 
-	course, err := models.CourseById(r.db, courseID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get course by ID: %w", err)
-	}
+		err = (&models.Round{Id: roundId}).Delete(ctx, db)
+		if err != nil {
+			return fmt.Errorf("failed to delete round: %w", err)
+		}
 
-	sqlStmt = `SELECT id FROM course_details WHERE course_id = ?`
+		return nil
+	})
+}
 
-	var courseDetailsID int
-	err = r.db.Get(&courseDetailsID, sqlStmt, courseID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get course details ID: %w", err)
-	}
+func (r *repository) GetRoundById(ctx context.Context, id int) (*models.Round, error) {
+	return models.RoundById(ctx, r.db, id)
+}
 
-	courseDetails, err := models.CourseDetailsById(r.db, courseDetailsID)
+// roundDetailsQuery selects the rounds along with their course and tee set.
+func roundDetailsQuery() string {
+	return `
+	SELECT ` + columns(models.Round{}, "r", "round") + `,
+		` + columns(models.CourseDetails{}, "cd", "course_details") + `,
+		` + columns(models.Course{}, "c", "course") + `
+	FROM round r
+		INNER JOIN course_details cd ON r.course_details_id = cd.id
+		INNER JOIN course c ON cd.course_id = c.id
+	`
+}
+
+func (r *repository) GetRoundDetailsByRoundId(ctx context.Context, roundId int) (*RoundDetails, error) {
+	details := new(RoundDetails)
+	err := r.db.GetContext(models.WithQueryName(ctx, "select_round_details"), details, roundDetailsQuery()+`WHERE r.id = ?`, roundId)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get course details by ID: %w", err)
+		return nil, fmt.Errorf("failed to get round details: %w", err)
 	}
 
-	sqlStmt = `SELECT id FROM hole WHERE course_details_id = ?`
-
-	var holeIDs []int
-	err = r.db.Select(&holeIDs, sqlStmt, courseDetailsID)
+	holes, err := r.GetCourseDetailsHoles(ctx, details.CourseDetails.Id)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get hole IDs: %w", err)
+		return nil, err
 	}
 
-	holes := make([]*models.Hole, 0, len(holeIDs))
-	for _, id := range holeIDs {
-		h, err := models.HoleById(r.db, id)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get hole by ID: %w", err)
-		}
-		holes = append(holes, h)
-	}
+	details.Holes = holes.Items
 
-	return &RoundDetails{
-		Round:         round,
-		Course:        course,
-		CourseDetails: courseDetails,
-		Holes:         holes,
-	}, nil
+	return details, nil
 }
 
-func (r *repository) GetRoundsByUserId(userId int) (*PaginationResponse[models.Round], error) {
-	sqlStmt := `SELECT id FROM round WHERE user_id = ?`
+// roundSorts is the columns that the rounds of a user can be sorted by.
+var roundSorts = map[string]sortColumn[RoundDetails]{
+	"id":          {column: "r.id", kind: sortKindInt, value: func(d *RoundDetails) any { return d.Round.Id }},
+	"tee_time":    {column: "r.tee_time", kind: sortKindTime, value: func(d *RoundDetails) any { return d.Round.TeeTime }},
+	"course_name": {column: "c.name", kind: sortKindString, value: func(d *RoundDetails) any { return d.Course.Name }},
+}
 
-	var roundIDs []int
-	err := r.db.Select(&roundIDs, sqlStmt, userId)
+func (r *repository) GetRoundsByUserId(ctx context.Context, userId int, details *PaginationDetails) (*PaginationResponse[RoundDetails], error) {
+	rounds, err := paginate(ctx, r.db, &paginatedQuery[RoundDetails]{
+		name:       "user_rounds",
+		selectStmt: roundDetailsQuery(),
+		countStmt: `
+		SELECT COUNT(*)
+		FROM round r
+			INNER JOIN course_details cd ON r.course_details_id = cd.id
+			INNER JOIN course c ON cd.course_id = c.id
+		`,
+		where:       []string{"r.user_id = ?"},
+		args:        []any{userId},
+		idColumn:    "r.id",
+		id:          func(d *RoundDetails) int { return d.Round.Id },
+		sorts:       roundSorts,
+		defaultSort: "id",
+	}, details)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get round IDs: %w", err)
-	}
-
-	rounds := make([]*models.Round, 0, len(roundIDs))
-	for _, id := range roundIDs {
-		round, err := models.RoundById(r.db, id)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get round by ID: %w", err)
-		}
-		rounds = append(rounds, round)
+		return nil, fmt.Errorf("failed to get rounds: %w", err)
 	}
 
-	return &PaginationResponse[models.Round]{
-		Items: rounds,
-		Total: int64(len(rounds)),
-	}, nil
+	return rounds, nil
 }
 
-func (r *repository) SaveRoundStats(roundStats *models.RoundStats) error {
+func (r *repository) SaveRoundStats(ctx context.Context, roundStats *models.RoundStats) error {
 	// Check if there is already some round stats to update.
 	sqlStmt := `SELECT id FROM round_stats WHERE round_id = ?`
 
 	var id int
-	err := r.db.Get(&id, sqlStmt, roundStats.RoundId)
+	err := r.db.GetContext(ctx, &id, sqlStmt, roundStats.RoundId)
 	if err != nil {
 		switch {
 		case errors.Is(err, sql.ErrNoRows):
@@ -114,11 +125,11 @@ func (r *repository) SaveRoundStats(roundStats *models.RoundStats) error {
 	}
 
 	if id == 0 {
-		return roundStats.Insert(r.db)
+		return roundStats.Insert(ctx, r.db)
 	}
 
 	roundStats.Id = id
-	err = roundStats.Update(r.db)
+	err = roundStats.Update(ctx, r.db)
 	if err != nil && !errors.Is(err, models.ErrNoAffectedRows) {
 		return fmt.Errorf("failed to update round stats: %w", err)
 	}
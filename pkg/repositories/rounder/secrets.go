@@ -0,0 +1,56 @@
+package rounder
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/models"
+)
+
+func (r *repository) GetUserPasswords(ctx context.Context, afterId, limit int) ([]*EncryptedValue, error) {
+	return getEncryptedValues(ctx, r.db, "user", "password", afterId, limit)
+}
+
+func (r *repository) ReplaceUserPassword(ctx context.Context, userId int, oldPassword, newPassword string) (bool, error) {
+	return replaceEncryptedValue(ctx, r.db, "user", "password", userId, oldPassword, newPassword)
+}
+
+func (r *repository) GetTotpSecrets(ctx context.Context, afterId, limit int) ([]*EncryptedValue, error) {
+	return getEncryptedValues(ctx, r.db, "user_totp", "secret", afterId, limit)
+}
+
+func (r *repository) ReplaceTotpSecret(ctx context.Context, totpId int, oldSecret, newSecret string) (bool, error) {
+	return replaceEncryptedValue(ctx, r.db, "user_totp", "secret", totpId, oldSecret, newSecret)
+}
+
+// getEncryptedValues gets the encrypted values in the column of the table, ordered by ID and starting after the
+// given ID. The table and column are never user input.
+func getEncryptedValues(ctx context.Context, db models.DB, table, column string, afterId, limit int) ([]*EncryptedValue, error) {
+	sqlStmt := fmt.Sprintf("SELECT id, %s AS ciphertext FROM %s WHERE id > ? ORDER BY id LIMIT ?", column, table)
+
+	values := make([]*EncryptedValue, 0)
+	err := db.SelectContext(ctx, &values, sqlStmt, afterId, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error getting %s.%s values: %w", table, column, err)
+	}
+
+	return values, nil
+}
+
+// replaceEncryptedValue replaces the encrypted value in the column of the row, only if it still holds the old value
+// so that a value changed since it was read is not overwritten. It returns whether the value was replaced.
+func replaceEncryptedValue(ctx context.Context, db models.DB, table, column string, id int, oldValue, newValue string) (bool, error) {
+	sqlStmt := fmt.Sprintf("UPDATE %s SET %s = ? WHERE id = ? AND %s = ?", table, column, column)
+
+	res, err := db.ExecContext(ctx, sqlStmt, newValue, id, oldValue)
+	if err != nil {
+		return false, fmt.Errorf("error replacing %s.%s value: %w", table, column, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("error getting affected rows: %w", err)
+	}
+
+	return affected > 0, nil
+}
@@ -0,0 +1,108 @@
+package rounder
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/models"
+)
+
+var (
+	// ErrSessionNotFound is returned when the session is not found.
+	ErrSessionNotFound = errors.New("session not found")
+)
+
+func (r *repository) CreateSession(ctx context.Context, session *models.Session) error {
+	session.Id = 0
+	return session.Insert(ctx, r.db)
+}
+
+func (r *repository) RotateSessionRefreshToken(ctx context.Context, sessionId int, oldHash, newHash string, usedAt time.Time) error {
+	// The refresh token is only replaced if it is still the current one and the session is still active, so that a
+	// refresh token cannot be used twice, even by concurrent refreshes, and a revoked session cannot be refreshed.
+	sqlStmt := `
+	UPDATE session
+	SET refresh_token_hash = ?,
+	    last_used_at = ?
+	WHERE id = ?
+	  AND refresh_token_hash = ?
+	  AND revoked_at IS NULL
+	  AND expires_at > ?
+	`
+
+	res, err := r.db.ExecContext(ctx, sqlStmt, newHash, usedAt, sessionId, oldHash, usedAt)
+	if err != nil {
+		return fmt.Errorf("failed to rotate refresh token: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	} else if affected == 0 {
+		return ErrSessionNotFound
+	}
+
+	return nil
+}
+
+func (r *repository) RevokeSession(ctx context.Context, sessionId int) error {
+	_, err := r.db.ExecContext(ctx, `UPDATE session SET revoked_at = ? WHERE id = ? AND revoked_at IS NULL`, time.Now().UTC(), sessionId)
+	if err != nil {
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+
+	return nil
+}
+
+func (r *repository) GetSessionById(ctx context.Context, id int) (*models.Session, error) {
+	session, err := models.SessionById(ctx, r.db, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrSessionNotFound
+		default:
+			return nil, fmt.Errorf("failed to get session by ID: %w", err)
+		}
+	}
+
+	return session, nil
+}
+
+func (r *repository) GetSessionByRefreshTokenHash(ctx context.Context, hash string) (*models.Session, error) {
+	session, err := models.SessionByRefreshTokenHash(ctx, r.db, hash)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrSessionNotFound
+		default:
+			return nil, fmt.Errorf("failed to get session by refresh token: %w", err)
+		}
+	}
+
+	return session, nil
+}
+
+func (r *repository) GetActiveSessionsByUserId(ctx context.Context, userId int) (*PaginationResponse[models.Session], error) {
+	sqlStmt := `
+		SELECT ` + columns(models.Session{}, "session", "") + `
+		FROM session
+		WHERE user_id = ?
+		  AND revoked_at IS NULL
+		  AND expires_at > ?
+		ORDER BY last_used_at DESC
+	`
+
+	sessions := make([]*models.Session, 0)
+	err := r.db.SelectContext(ctx, &sessions, sqlStmt, userId, time.Now().UTC())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sessions: %w", err)
+	}
+
+	return &PaginationResponse[models.Session]{
+		Items: sessions,
+		Total: int64(len(sessions)),
+	}, nil
+}
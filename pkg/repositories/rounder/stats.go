@@ -1,14 +1,15 @@
 package rounder
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/models"
 )
 
-func (r *repository) SaveRoundHitStats(roundHitStats ...*models.RoundHitStats) error {
+func (r *repository) SaveRoundHitStats(ctx context.Context, roundHitStats ...*models.RoundHitStats) error {
 	for _, rhs := range roundHitStats {
-		err := rhs.SaveOrUpdate(r.db)
+		err := rhs.SaveOrUpdate(ctx, r.db)
 		if err != nil {
 			return fmt.Errorf("failed to save round hit stats: %w", err)
 		}
@@ -17,87 +18,54 @@ func (r *repository) SaveRoundHitStats(roundHitStats ...*models.RoundHitStats) e
 	return nil
 }
 
-func (r *repository) GetRoundHitStatsByRoundStatsId(roundStatsId int) (*PaginationResponse[models.RoundHitStats], error) {
-	sqlStmt := `SELECT id FROM round_hit_stats WHERE round_stats_id = ?`
+func (r *repository) GetRoundHitStatsByRoundStatsId(ctx context.Context, roundStatsId int) (*PaginationResponse[models.RoundHitStats], error) {
+	sqlStmt := `SELECT ` + columns(models.RoundHitStats{}, "rhs", "") + ` FROM round_hit_stats rhs WHERE rhs.round_stats_id = ? ORDER BY rhs.id`
 
-	ids := make([]int, 0)
-	err := r.db.Select(&ids, sqlStmt, roundStatsId)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get round hit stats: %w", err)
-	}
-
-	roundHitStats := make([]*models.RoundHitStats, 0, len(ids))
-	for _, id := range ids {
-		rhs, err := models.RoundHitStatsById(r.db, id)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get round hit stats by ID: %w", err)
-		}
-		roundHitStats = append(roundHitStats, rhs)
-	}
-
-	return &PaginationResponse[models.RoundHitStats]{
-		Items: roundHitStats,
-		Total: int64(len(roundHitStats)),
-	}, nil
+	return r.selectRoundHitStats(ctx, "select_round_hit_stats_by_round_stats", sqlStmt, roundStatsId)
 }
 
-func (r *repository) GetRoundStatsByRoundId(roundId int) (*models.RoundStats, error) {
-	sqlStmt := `SELECT id FROM round_stats WHERE round_id = ?`
+func (r *repository) GetRoundStatsByRoundId(ctx context.Context, roundId int) (*models.RoundStats, error) {
+	sqlStmt := `SELECT ` + columns(models.RoundStats{}, "rs", "") + ` FROM round_stats rs WHERE rs.round_id = ?`
 
-	var id int
-	err := r.db.Get(&id, sqlStmt, roundId)
+	roundStats := new(models.RoundStats)
+	err := r.db.GetContext(ctx, roundStats, sqlStmt, roundId)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get round stats: %w", err)
 	}
 
-	return models.RoundStatsById(r.db, id)
+	return roundStats, nil
 }
 
-func (r *repository) GetRoundHitStats(roundId int) (*PaginationResponse[models.RoundHitStats], error) {
-	sqlStmt := `SELECT id FROM round_hit_stats WHERE round_id = ?`
-
-	ids := make([]int, 0)
-	err := r.db.Select(&ids, sqlStmt, roundId)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get round hit stats: %w", err)
-	}
-
-	roundHitStats := make([]*models.RoundHitStats, 0, len(ids))
-	for _, id := range ids {
-		rhs, err := models.RoundHitStatsById(r.db, id)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get round hit stats by ID: %w", err)
-		}
-		roundHitStats = append(roundHitStats, rhs)
-	}
+func (r *repository) GetRoundHitStats(ctx context.Context, roundId int) (*PaginationResponse[models.RoundHitStats], error) {
+	sqlStmt := `
+		SELECT ` + columns(models.RoundHitStats{}, "rhs", "") + `
+		FROM round_hit_stats rhs
+			JOIN round_stats rs ON rhs.round_stats_id = rs.id
+		WHERE rs.round_id = ?
+		ORDER BY rhs.id`
 
-	return &PaginationResponse[models.RoundHitStats]{
-		Items: roundHitStats,
-		Total: int64(len(roundHitStats)),
-	}, nil
+	return r.selectRoundHitStats(ctx, "select_round_hit_stats_by_round", sqlStmt, roundId)
 }
 
-func (r *repository) GetUserHitStats(userId int) (*PaginationResponse[models.RoundHitStats], error) {
+func (r *repository) GetUserHitStats(ctx context.Context, userId int) (*PaginationResponse[models.RoundHitStats], error) {
 	sqlStmt := `
-		SELECT rhs.id
+		SELECT ` + columns(models.RoundHitStats{}, "rhs", "") + `
 		FROM round_hit_stats rhs
 			JOIN round_stats rs ON rhs.round_stats_id = rs.id
 			JOIN round r ON rs.round_id = r.id
-		WHERE r.user_id = ?`
+		WHERE r.user_id = ?
+		ORDER BY rhs.id`
 
-	ids := make([]int, 0)
-	err := r.db.Select(&ids, sqlStmt, userId)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get user hit stats: %w", err)
-	}
+	return r.selectRoundHitStats(ctx, "select_round_hit_stats_by_user", sqlStmt, userId)
+}
 
-	roundHitStats := make([]*models.RoundHitStats, 0, len(ids))
-	for _, id := range ids {
-		rhs, err := models.RoundHitStatsById(r.db, id)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get round hit stats by ID: %w", err)
-		}
-		roundHitStats = append(roundHitStats, rhs)
+// selectRoundHitStats runs a query that selects the columns of round hit stats, under the name in
+// models.DatabaseLatency.
+func (r *repository) selectRoundHitStats(ctx context.Context, name, sqlStmt string, args ...any) (*PaginationResponse[models.RoundHitStats], error) {
+	roundHitStats := make([]*models.RoundHitStats, 0)
+	err := r.db.SelectContext(models.WithQueryName(ctx, name), &roundHitStats, sqlStmt, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get round hit stats: %w", err)
 	}
 
 	return &PaginationResponse[models.RoundHitStats]{
@@ -0,0 +1,154 @@
+package rounder
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/models"
+)
+
+var (
+	// ErrUserTotpNotFound is returned when the user has not enrolled in two-factor authentication.
+	ErrUserTotpNotFound = errors.New("user totp not found")
+
+	// ErrTotpCodeUsed is returned when a TOTP code has already been used.
+	ErrTotpCodeUsed = errors.New("totp code already used")
+
+	// ErrRecoveryCodeNotFound is returned when the recovery code is not found or has already been used.
+	ErrRecoveryCodeNotFound = errors.New("recovery code not found")
+)
+
+func (r *repository) GetUserTotp(ctx context.Context, userId int) (*models.UserTotp, error) {
+	totp, err := models.UserTotpByUserId(ctx, r.db, userId)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrUserTotpNotFound
+		default:
+			return nil, fmt.Errorf("error getting user totp: %w", err)
+		}
+	}
+
+	return totp, nil
+}
+
+func (r *repository) CreateUserTotp(ctx context.Context, totp *models.UserTotp) error {
+	return models.NewDBTransactionHandler(r.db).Handle(ctx, func(db models.DB) error {
+		// Only an enrolment that was never confirmed can be replaced, a confirmed one must be reset first.
+		_, err := db.ExecContext(ctx, `DELETE FROM user_totp WHERE user_id = ? AND confirmed_at IS NULL`, totp.UserId)
+		if err != nil {
+			return fmt.Errorf("error deleting unconfirmed user totp: %w", err)
+		}
+
+		totp.Id = 0
+		err = totp.Insert(ctx, db)
+		if err != nil {
+			return fmt.Errorf("error creating user totp: %w", err)
+		}
+
+		return nil
+	})
+}
+
+func (r *repository) ConfirmUserTotp(ctx context.Context, totp *models.UserTotp, codeHashes []string) error {
+	return models.NewDBTransactionHandler(r.db).Handle(ctx, func(db models.DB) error {
+		err := totp.Update(ctx, db)
+		if err != nil {
+			return fmt.Errorf("error updating user totp: %w", err)
+		}
+
+		_, err = db.ExecContext(ctx, `DELETE FROM recovery_code WHERE user_id = ?`, totp.UserId)
+		if err != nil {
+			return fmt.Errorf("error deleting recovery codes: %w", err)
+		}
+
+		codes := make([]*models.RecoveryCode, 0, len(codeHashes))
+		for _, hash := range codeHashes {
+			codes = append(codes, &models.RecoveryCode{
+				UserId:   totp.UserId,
+				CodeHash: hash,
+			})
+		}
+
+		err = models.InsertManyRecoveryCodes(ctx, db, codes...)
+		if err != nil {
+			return fmt.Errorf("error creating recovery codes: %w", err)
+		}
+
+		return nil
+	})
+}
+
+func (r *repository) UseTotpStep(ctx context.Context, totpId int, step int64) error {
+	// The step only moves forwards, so that a code cannot be used twice, even by concurrent logins.
+	sqlStmt := `
+	UPDATE user_totp
+	SET last_used_step = ?
+	WHERE id = ?
+	  AND (last_used_step IS NULL OR last_used_step < ?)
+	`
+
+	res, err := r.db.ExecContext(ctx, sqlStmt, step, totpId, step)
+	if err != nil {
+		return fmt.Errorf("error updating totp step: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error getting affected rows: %w", err)
+	} else if affected == 0 {
+		return ErrTotpCodeUsed
+	}
+
+	return nil
+}
+
+func (r *repository) UseRecoveryCode(ctx context.Context, userId int, codeHash string) error {
+	sqlStmt := `
+	UPDATE recovery_code
+	SET used_at = ?
+	WHERE user_id = ?
+	  AND code_hash = ?
+	  AND used_at IS NULL
+	`
+
+	res, err := r.db.ExecContext(ctx, sqlStmt, time.Now().UTC(), userId, codeHash)
+	if err != nil {
+		return fmt.Errorf("error using recovery code: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error getting affected rows: %w", err)
+	} else if affected == 0 {
+		return ErrRecoveryCodeNotFound
+	}
+
+	return nil
+}
+
+func (r *repository) DeleteUserTotp(ctx context.Context, userId int) error {
+	return models.NewDBTransactionHandler(r.db).Handle(ctx, func(db models.DB) error {
+		_, err := db.ExecContext(ctx, `DELETE FROM recovery_code WHERE user_id = ?`, userId)
+		if err != nil {
+			return fmt.Errorf("error deleting recovery codes: %w", err)
+		}
+
+		res, err := db.ExecContext(ctx, `DELETE FROM user_totp WHERE user_id = ?`, userId)
+		if err != nil {
+			return fmt.Errorf("error deleting user totp: %w", err)
+		}
+
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("error getting affected rows: %w", err)
+		} else if affected == 0 {
+			return ErrUserTotpNotFound
+		}
+
+		return nil
+	})
+}
@@ -1,9 +1,11 @@
 package rounder
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/models"
 )
@@ -11,16 +13,22 @@ import (
 var (
 	// ErrUserNotFound is returned when the user is not found.
 	ErrUserNotFound = errors.New("user not found")
+
+	// ErrUserPreferenceNotFound is returned when the user has not set any preferences.
+	ErrUserPreferenceNotFound = errors.New("user preference not found")
+
+	// ErrPasswordResetNotFound is returned when the password reset is not found or has already been used.
+	ErrPasswordResetNotFound = errors.New("password reset not found")
 )
 
-func (r *repository) CreateUser(user *models.User) error {
-	return user.Save(r.db)
+func (r *repository) CreateUser(ctx context.Context, user *models.User) error {
+	return user.Save(ctx, r.db)
 }
 
-func (r *repository) UserByUsername(username string) (*models.User, error) {
+func (r *repository) UserByUsername(ctx context.Context, username string) (*models.User, error) {
 	sqlStmt := `SELECT id FROM user WHERE username = ?`
 	var id int
-	err := r.db.Get(&id, sqlStmt, username)
+	err := r.db.GetContext(ctx, &id, sqlStmt, username)
 	if err != nil {
 		switch {
 		case errors.Is(err, sql.ErrNoRows):
@@ -30,5 +38,163 @@ func (r *repository) UserByUsername(username string) (*models.User, error) {
 		}
 	}
 
-	return models.UserById(r.db, id)
+	return models.UserById(ctx, r.db, id)
+}
+
+func (r *repository) GetUserById(ctx context.Context, id int) (*models.User, error) {
+	user, err := models.UserById(ctx, r.db, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrUserNotFound
+		default:
+			return nil, fmt.Errorf("error getting user by ID: %w", err)
+		}
+	}
+
+	return user, nil
+}
+
+func (r *repository) GetUsers(ctx context.Context) (*PaginationResponse[models.User], error) {
+	users := make([]*models.User, 0)
+	err := r.db.SelectContext(ctx, &users, `SELECT `+columns(models.User{}, "user", "")+` FROM user ORDER BY id`)
+	if err != nil {
+		return nil, fmt.Errorf("error getting users: %w", err)
+	}
+
+	return &PaginationResponse[models.User]{
+		Items: users,
+		Total: int64(len(users)),
+	}, nil
+}
+
+func (r *repository) UpdateUserRole(ctx context.Context, userId int, role string) error {
+	_, err := r.db.ExecContext(ctx, `UPDATE user SET role = ? WHERE id = ?`, role, userId)
+	if err != nil {
+		return fmt.Errorf("error updating role: %w", err)
+	}
+
+	return nil
+}
+
+func (r *repository) UpdateUserProfile(ctx context.Context, user *models.User) error {
+	sqlStmt := `
+	UPDATE user
+	SET name           = ?,
+	    email          = ?,
+	    gender         = ?,
+	    date_of_birth  = ?,
+	    home_course_id = ?
+	WHERE id = ?
+	`
+
+	_, err := r.db.ExecContext(ctx, sqlStmt, user.Name, user.Email, user.Gender, user.DateOfBirth, user.HomeCourseId, user.Id)
+	if err != nil {
+		return fmt.Errorf("error updating profile: %w", err)
+	}
+
+	return nil
+}
+
+func (r *repository) UpdateUserLastLogin(ctx context.Context, userId int, lastLogin time.Time) error {
+	_, err := r.db.ExecContext(ctx, `UPDATE user SET last_login = ? WHERE id = ?`, lastLogin, userId)
+	if err != nil {
+		return fmt.Errorf("error updating last login: %w", err)
+	}
+
+	return nil
+}
+
+func (r *repository) GetUserPreference(ctx context.Context, userId int) (*models.UserPreference, error) {
+	pref, err := models.UserPreferenceByUserId(ctx, r.db, userId)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrUserPreferenceNotFound
+		default:
+			return nil, fmt.Errorf("error getting user preference: %w", err)
+		}
+	}
+
+	return pref, nil
+}
+
+func (r *repository) SaveUserPreference(ctx context.Context, pref *models.UserPreference) error {
+	return pref.InsertWithUpdate(ctx, r.db)
+}
+
+func (r *repository) UpdateUserPassword(ctx context.Context, userId int, password string, exceptSessionId int) error {
+	return models.NewDBTransactionHandler(r.db).Handle(ctx, func(db models.DB) error {
+		return updatePassword(ctx, db, userId, password, exceptSessionId)
+	})
+}
+
+func (r *repository) UpdateUserPasswordHash(ctx context.Context, userId int, password string) error {
+	_, err := r.db.ExecContext(ctx, `UPDATE user SET password = ? WHERE id = ?`, password, userId)
+	if err != nil {
+		return fmt.Errorf("error updating password hash: %w", err)
+	}
+
+	return nil
+}
+
+func (r *repository) CreatePasswordReset(ctx context.Context, reset *models.PasswordReset) error {
+	reset.Id = 0
+	return reset.Insert(ctx, r.db)
+}
+
+func (r *repository) GetPasswordResetByTokenHash(ctx context.Context, hash string) (*models.PasswordReset, error) {
+	reset, err := models.PasswordResetByTokenHash(ctx, r.db, hash)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrPasswordResetNotFound
+		default:
+			return nil, fmt.Errorf("error getting password reset by token: %w", err)
+		}
+	}
+
+	return reset, nil
+}
+
+func (r *repository) CompletePasswordReset(ctx context.Context, reset *models.PasswordReset, password string) error {
+	return models.NewDBTransactionHandler(r.db).Handle(ctx, func(db models.DB) error {
+		// Only mark the reset as used if it has not been used already, so that it cannot be used twice concurrently.
+		res, err := db.ExecContext(ctx, `UPDATE password_reset SET used_at = ? WHERE id = ? AND used_at IS NULL`, time.Now().UTC(), reset.Id)
+		if err != nil {
+			return fmt.Errorf("error marking password reset as used: %w", err)
+		}
+
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("error getting affected rows: %w", err)
+		} else if affected == 0 {
+			return ErrPasswordResetNotFound
+		}
+
+		return updatePassword(ctx, db, reset.UserId, password, 0)
+	})
+}
+
+// updatePassword updates the password of the user and revokes all of their sessions except the given session.
+func updatePassword(ctx context.Context, db models.DB, userId int, password string, exceptSessionId int) error {
+	_, err := db.ExecContext(ctx, `UPDATE user SET password = ? WHERE id = ?`, password, userId)
+	if err != nil {
+		return fmt.Errorf("error updating password: %w", err)
+	}
+
+	sqlStmt := `
+		UPDATE session
+		SET revoked_at = ?
+		WHERE user_id = ?
+		  AND id != ?
+		  AND revoked_at IS NULL
+	`
+
+	_, err = db.ExecContext(ctx, sqlStmt, time.Now().UTC(), userId, exceptSessionId)
+	if err != nil {
+		return fmt.Errorf("error revoking sessions: %w", err)
+	}
+
+	return nil
 }
@@ -0,0 +1,66 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/Jacobbrewer1/vaulty/repositories"
+	"github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	// databaseTimeout is the timeout for establishing connections to the database.
+	databaseTimeout = 90 * time.Second
+
+	// databasePingTimeout is the time allowed for the database to respond when it is first connected to.
+	databasePingTimeout = 5 * time.Second
+)
+
+type staticDatabaseConnector struct {
+	host     string
+	schema   string
+	username string
+	password string
+}
+
+// NewStaticDatabaseConnector creates a connector to MariaDB that uses fixed credentials, such as from the config or
+// the environment, instead of credentials generated by vault.
+func NewStaticDatabaseConnector(host, schema, username, password string) repositories.DatabaseConnector {
+	return &staticDatabaseConnector{
+		host:     host,
+		schema:   schema,
+		username: username,
+		password: password,
+	}
+}
+
+func (c *staticDatabaseConnector) ConnectDB() (*repositories.Database, error) {
+	cfg := mysql.NewConfig()
+	cfg.User = c.username
+	cfg.Passwd = c.password
+	cfg.Net = "tcp"
+	cfg.Addr = c.host
+	cfg.DBName = c.schema
+	cfg.Timeout = databaseTimeout
+	cfg.MultiStatements = true
+	cfg.ParseTime = true
+
+	db, err := sqlx.Open("mysql", cfg.FormatDSN())
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to database: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), databasePingTimeout)
+	defer cancel()
+
+	if err := db.PingContext(ctx); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("error connecting to database: %w", err)
+	}
+
+	slog.Info("Database connection established with static credentials")
+	return repositories.NewDatabase(db), nil
+}
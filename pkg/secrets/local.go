@@ -0,0 +1,90 @@
+package secrets
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"strings"
+)
+
+const (
+	// localKeyLength is the length in bytes of the AES-256 keys used by the local encrypter.
+	localKeyLength = 32
+
+	// localCiphertextPrefix is the prefix of ciphertexts from the local encrypter, in the style of vault transit
+	// ciphertexts so that it is clear which encrypter a stored value came from.
+	localCiphertextPrefix = "local:v1:"
+)
+
+type localEncrypter struct {
+	aead cipher.AEAD
+}
+
+// NewLocalEncrypter creates an encrypter that encrypts with AES-256-GCM using the key, for deployments without vault.
+func NewLocalEncrypter(key []byte) (Encrypter, error) {
+	if len(key) != localKeyLength {
+		return nil, fmt.Errorf("key must be %d bytes, got %d", localKeyLength, len(key))
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gcm: %w", err)
+	}
+
+	return &localEncrypter{aead: aead}, nil
+}
+
+// NewLocalEncrypterFromFile creates a local encrypter with the base64 encoded key in the file. A key can be generated
+// with "head -c 32 /dev/urandom | base64".
+func NewLocalEncrypterFromFile(path string) (Encrypter, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key file: %w", err)
+	}
+
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(content)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode key file: %w", err)
+	}
+
+	return NewLocalEncrypter(key)
+}
+
+func (e *localEncrypter) Encrypt(_ context.Context, plaintext string) (string, error) {
+	nonce := make([]byte, e.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	sealed := e.aead.Seal(nonce, nonce, []byte(plaintext), nil)
+	return localCiphertextPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+func (e *localEncrypter) Decrypt(_ context.Context, ciphertext string) (string, error) {
+	encoded, ok := strings.CutPrefix(ciphertext, localCiphertextPrefix)
+	if !ok {
+		return "", ErrInvalidCiphertext
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(sealed) < e.aead.NonceSize() {
+		return "", ErrInvalidCiphertext
+	}
+
+	nonce, sealed := sealed[:e.aead.NonceSize()], sealed[e.aead.NonceSize():]
+	plaintext, err := e.aead.Open(nil, nonce, sealed, nil)
+	if err != nil {
+		return "", ErrInvalidCiphertext
+	}
+
+	return string(plaintext), nil
+}
@@ -0,0 +1,109 @@
+package secrets
+
+import (
+	"context"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalEncrypter(t *testing.T) {
+	key := make([]byte, localKeyLength)
+	for i := range key {
+		key[i] = byte(i)
+	}
+
+	e, err := NewLocalEncrypter(key)
+	require.NoError(t, err)
+
+	otherKey := make([]byte, localKeyLength)
+	other, err := NewLocalEncrypter(otherKey)
+	require.NoError(t, err)
+
+	ciphertext, err := e.Encrypt(context.Background(), "secret")
+	require.NoError(t, err)
+	require.NotContains(t, ciphertext, "secret")
+
+	again, err := e.Encrypt(context.Background(), "secret")
+	require.NoError(t, err)
+	require.NotEqual(t, ciphertext, again, "each encryption should use a new nonce")
+
+	tests := []struct {
+		name       string
+		encrypter  Encrypter
+		ciphertext string
+		want       string
+		wantErr    error
+	}{
+		{
+			name:       "round trip",
+			encrypter:  e,
+			ciphertext: ciphertext,
+			want:       "secret",
+		},
+		{
+			name:       "wrong key",
+			encrypter:  other,
+			ciphertext: ciphertext,
+			wantErr:    ErrInvalidCiphertext,
+		},
+		{
+			name:       "vault ciphertext",
+			encrypter:  e,
+			ciphertext: "vault:v1:abcdef",
+			wantErr:    ErrInvalidCiphertext,
+		},
+		{
+			name:       "truncated",
+			encrypter:  e,
+			ciphertext: ciphertext[:len(localCiphertextPrefix)+8],
+			wantErr:    ErrInvalidCiphertext,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.encrypter.Decrypt(context.Background(), tt.ciphertext)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNewLocalEncrypterFromFile(t *testing.T) {
+	dir := t.TempDir()
+
+	valid := filepath.Join(dir, "valid.key")
+	require.NoError(t, os.WriteFile(valid, []byte(base64.StdEncoding.EncodeToString(make([]byte, localKeyLength))+"\n"), 0o600))
+
+	short := filepath.Join(dir, "short.key")
+	require.NoError(t, os.WriteFile(short, []byte(base64.StdEncoding.EncodeToString(make([]byte, 16))), 0o600))
+
+	tests := []struct {
+		name    string
+		path    string
+		wantErr bool
+	}{
+		{name: "valid key", path: valid},
+		{name: "short key", path: short, wantErr: true},
+		{name: "missing file", path: filepath.Join(dir, "missing.key"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewLocalEncrypterFromFile(tt.path)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
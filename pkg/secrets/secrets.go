@@ -0,0 +1,19 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrInvalidCiphertext is returned when a value cannot be decrypted because it was not encrypted by the encrypter.
+var ErrInvalidCiphertext = errors.New("invalid ciphertext")
+
+// Encrypter encrypts the secrets that are stored in the database, such as password hashes and TOTP secrets, so that
+// reading the database alone does not reveal them.
+type Encrypter interface {
+	// Encrypt encrypts the plaintext, returning the ciphertext to store.
+	Encrypt(ctx context.Context, plaintext string) (string, error)
+
+	// Decrypt decrypts a ciphertext that was returned by Encrypt.
+	Decrypt(ctx context.Context, ciphertext string) (string, error)
+}
@@ -0,0 +1,39 @@
+package secrets
+
+import (
+	"context"
+
+	"github.com/Jacobbrewer1/vaulty"
+)
+
+type vaultEncrypter struct {
+	vc vaulty.Client
+
+	// mount is the path the transit engine is mounted at.
+	mount string
+
+	// key is the name of the transit key.
+	key string
+}
+
+// NewVaultEncrypter creates an encrypter that encrypts with a vault transit key, so that the key never leaves vault.
+func NewVaultEncrypter(vc vaulty.Client, mount, key string) Encrypter {
+	return &vaultEncrypter{
+		vc:    vc,
+		mount: mount,
+		key:   key,
+	}
+}
+
+func (e *vaultEncrypter) Encrypt(ctx context.Context, plaintext string) (string, error) {
+	encrypted, err := e.vc.Path(e.key, vaulty.WithPrefix(e.mount)).TransitEncrypt(ctx, plaintext)
+	if err != nil {
+		return "", err
+	}
+
+	return vaulty.GetTransitCipherText(encrypted), nil
+}
+
+func (e *vaultEncrypter) Decrypt(ctx context.Context, ciphertext string) (string, error) {
+	return e.vc.Path(e.key, vaulty.WithPrefix(e.mount)).TransitDecrypt(ctx, ciphertext)
+}
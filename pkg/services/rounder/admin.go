@@ -0,0 +1,230 @@
+package rounder
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/auth"
+	api "github.com/Jacobbrewer1/golf-stats-tracker/pkg/codegen/apis/rounder"
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/logging"
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/models"
+	repo "github.com/Jacobbrewer1/golf-stats-tracker/pkg/repositories/rounder"
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/utils"
+	"github.com/Jacobbrewer1/uhttp"
+)
+
+// maxAuditEventLimit is the maximum number of audit events that can be requested at once.
+const maxAuditEventLimit = 1000
+
+func (s *service) GetUsers(w http.ResponseWriter, r *http.Request) {
+	err := s.policy.AuthorizeRole(r.Context(), utils.UserIdFromContext(r.Context()), auth.RoleAdmin)
+	if err != nil {
+		sendPolicyError(w, err, "admin role required")
+		return
+	}
+
+	users, err := s.r.GetUsers(r.Context())
+	if err != nil {
+		slog.Error("error getting users", slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error getting users", err)
+		return
+	}
+
+	resp := &api.UsersResponse{
+		Users: make([]api.User, 0, len(users.Items)),
+		Total: users.Total,
+	}
+
+	for _, user := range users.Items {
+		resp.Users = append(resp.Users, *s.modelAsUser(user))
+	}
+
+	err = uhttp.Encode(w, http.StatusOK, resp)
+	if err != nil {
+		slog.Error("error encoding response", slog.String(logging.KeyError, err.Error()))
+		return
+	}
+}
+
+func (s *service) UpdateUser(w http.ResponseWriter, r *http.Request, userId api.PathUserId) {
+	adminId := utils.UserIdFromContext(r.Context())
+	err := s.policy.AuthorizeRole(r.Context(), adminId, auth.RoleAdmin)
+	if err != nil {
+		sendPolicyError(w, err, "admin role required")
+		return
+	}
+
+	req := new(api.UserUpdate)
+	err = uhttp.DecodeRequestJSON(r, req)
+	if err != nil {
+		uhttp.SendErrorMessageWithStatus(w, http.StatusBadRequest, "error decoding request body", err)
+		return
+	}
+
+	role, err := auth.ParseRole(req.Role)
+	if err != nil {
+		uhttp.SendErrorMessageWithStatus(w, http.StatusBadRequest, "invalid role", err)
+		return
+	} else if int(userId) == adminId && role != auth.RoleAdmin {
+		// Stop the last admin from locking everyone out of user management.
+		uhttp.SendMessageWithStatus(w, http.StatusBadRequest, "cannot remove your own admin role")
+		return
+	}
+
+	user, err := s.r.GetUserById(r.Context(), int(userId))
+	if err != nil {
+		switch {
+		case errors.Is(err, repo.ErrUserNotFound):
+			uhttp.SendMessageWithStatus(w, http.StatusNotFound, "user not found")
+		default:
+			slog.Error("error getting user", slog.String(logging.KeyError, err.Error()))
+			uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error getting user", err)
+		}
+		return
+	}
+
+	err = s.r.UpdateUserRole(r.Context(), user.Id, string(role))
+	if err != nil {
+		slog.Error("error updating role", slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error updating role", err)
+		return
+	}
+
+	s.audit(r, auditEvent{
+		actorId:    adminId,
+		action:     auditActionUserRoleUpdate,
+		targetType: auditTargetUser,
+		targetId:   user.Id,
+		details:    fmt.Sprintf("role changed from %s to %s", user.Role, role),
+	})
+
+	user.Role = string(role)
+
+	err = uhttp.Encode(w, http.StatusOK, s.modelAsUser(user))
+	if err != nil {
+		slog.Error("error encoding response", slog.String(logging.KeyError, err.Error()))
+		return
+	}
+}
+
+func (s *service) GetAuditEvents(w http.ResponseWriter, r *http.Request, params api.GetAuditEventsParams) {
+	err := s.policy.AuthorizeRole(r.Context(), utils.UserIdFromContext(r.Context()), auth.RoleAdmin)
+	if err != nil {
+		sendPolicyError(w, err, "admin role required")
+		return
+	}
+
+	filter := &repo.AuditEventFilter{
+		Since: params.Since,
+		Until: params.Until,
+	}
+
+	if params.ActorId != nil {
+		filter.ActorId = utils.Ptr(int(*params.ActorId))
+	}
+
+	if params.Action != nil {
+		filter.Action = *params.Action
+	}
+
+	if params.TargetType != nil {
+		filter.TargetType = *params.TargetType
+	}
+
+	if params.TargetId != nil {
+		filter.TargetId = utils.Ptr(int(*params.TargetId))
+	}
+
+	if params.Limit != nil {
+		if *params.Limit < 1 || *params.Limit > maxAuditEventLimit {
+			uhttp.SendMessageWithStatus(w, http.StatusBadRequest, fmt.Sprintf("limit must be between 1 and %d", maxAuditEventLimit))
+			return
+		}
+		filter.Limit = int(*params.Limit)
+	}
+
+	events, err := s.r.GetAuditEvents(r.Context(), filter)
+	if err != nil {
+		slog.Error("error getting audit events", slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error getting audit events", err)
+		return
+	}
+
+	resp := &api.AuditEventsResponse{
+		Events: make([]api.AuditEvent, 0, len(events.Items)),
+		Total:  events.Total,
+	}
+
+	for _, event := range events.Items {
+		resp.Events = append(resp.Events, *modelAuditEventAsApiAuditEvent(event))
+	}
+
+	err = uhttp.Encode(w, http.StatusOK, resp)
+	if err != nil {
+		slog.Error("error encoding response", slog.String(logging.KeyError, err.Error()))
+		return
+	}
+}
+
+// modelAuditEventAsApiAuditEvent maps an audit event to the API model.
+func modelAuditEventAsApiAuditEvent(event *models.AuditEvent) *api.AuditEvent {
+	apiEvent := &api.AuditEvent{
+		Id:        utils.Ptr(int64(event.Id)),
+		Action:    utils.Ptr(event.Action),
+		CreatedAt: utils.Ptr(event.CreatedAt),
+	}
+
+	if event.ActorId.Valid {
+		apiEvent.ActorId = utils.Ptr(event.ActorId.Int64)
+	}
+
+	if event.TargetType.Valid {
+		apiEvent.TargetType = utils.Ptr(event.TargetType.String)
+	}
+
+	if event.TargetId.Valid {
+		apiEvent.TargetId = utils.Ptr(event.TargetId.Int64)
+	}
+
+	if event.IpAddress.Valid {
+		apiEvent.IpAddress = utils.Ptr(event.IpAddress.String)
+	}
+
+	if event.Details.Valid {
+		apiEvent.Details = utils.Ptr(event.Details.String)
+	}
+
+	return apiEvent
+}
+
+func (s *service) ResetUserTotp(w http.ResponseWriter, r *http.Request, userId api.PathUserId) {
+	adminId := utils.UserIdFromContext(r.Context())
+	err := s.policy.AuthorizeRole(r.Context(), adminId, auth.RoleAdmin)
+	if err != nil {
+		sendPolicyError(w, err, "admin role required")
+		return
+	}
+
+	err = s.r.DeleteUserTotp(r.Context(), int(userId))
+	if err != nil {
+		switch {
+		case errors.Is(err, repo.ErrUserTotpNotFound):
+			uhttp.SendMessageWithStatus(w, http.StatusNotFound, "two-factor authentication is not enabled for the user")
+		default:
+			slog.Error("error resetting user totp", slog.String(logging.KeyError, err.Error()))
+			uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error resetting user totp", err)
+		}
+		return
+	}
+
+	s.audit(r, auditEvent{
+		actorId:    adminId,
+		action:     auditActionUserTotpReset,
+		targetType: auditTargetUser,
+		targetId:   int(userId),
+	})
+
+	w.WriteHeader(http.StatusNoContent)
+}
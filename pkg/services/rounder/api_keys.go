@@ -0,0 +1,191 @@
+package rounder
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/auth"
+	api "github.com/Jacobbrewer1/golf-stats-tracker/pkg/codegen/apis/rounder"
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/logging"
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/models"
+	repo "github.com/Jacobbrewer1/golf-stats-tracker/pkg/repositories/rounder"
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/utils"
+	usql "github.com/Jacobbrewer1/golf-stats-tracker/pkg/utils/sql"
+	"github.com/Jacobbrewer1/uhttp"
+)
+
+const (
+	// maxApiKeyNameLength is the maximum length of the name of an API key.
+	maxApiKeyNameLength = 100
+
+	// apiKeyLastUsedInterval is how often the last used time of an API key is updated, so that every request made
+	// with the key does not write to the database.
+	apiKeyLastUsedInterval = time.Minute
+)
+
+func (s *service) GetApiKeys(w http.ResponseWriter, r *http.Request) {
+	apiKeys, err := s.r.GetActiveApiKeysByUserId(r.Context(), utils.UserIdFromContext(r.Context()))
+	if err != nil {
+		slog.Error("error getting api keys", slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error getting api keys", err)
+		return
+	}
+
+	resp := &api.ApiKeysResponse{
+		ApiKeys: make([]api.ApiKey, 0, len(apiKeys.Items)),
+		Total:   apiKeys.Total,
+	}
+
+	for _, apiKey := range apiKeys.Items {
+		apiApiKey, err := modelApiKeyAsApiApiKey(apiKey)
+		if err != nil {
+			slog.Error("error converting api key", slog.String(logging.KeyError, err.Error()))
+			uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error converting api key", err)
+			return
+		}
+
+		resp.ApiKeys = append(resp.ApiKeys, *apiApiKey)
+	}
+
+	err = uhttp.Encode(w, http.StatusOK, resp)
+	if err != nil {
+		slog.Error("error encoding response", slog.String(logging.KeyError, err.Error()))
+		return
+	}
+}
+
+func (s *service) CreateApiKey(w http.ResponseWriter, r *http.Request) {
+	req := new(api.ApiKeyCreate)
+	err := uhttp.DecodeRequestJSON(r, req)
+	if err != nil {
+		uhttp.SendErrorMessageWithStatus(w, http.StatusBadRequest, "error decoding request body", err)
+		return
+	}
+
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" || len(req.Name) > maxApiKeyNameLength {
+		uhttp.SendMessageWithStatus(w, http.StatusBadRequest, fmt.Sprintf("name must be between 1 and %d characters", maxApiKeyNameLength))
+		return
+	} else if len(req.Scopes) == 0 {
+		uhttp.SendMessageWithStatus(w, http.StatusBadRequest, "at least one scope is required")
+		return
+	}
+
+	scopes, err := auth.NewScopes(req.Scopes...)
+	if err != nil {
+		uhttp.SendErrorMessageWithStatus(w, http.StatusBadRequest, "invalid scopes", err)
+		return
+	}
+
+	now := time.Now().UTC()
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(now) {
+		uhttp.SendMessageWithStatus(w, http.StatusBadRequest, "expires_at must be in the future")
+		return
+	}
+
+	key, hash, err := auth.NewAPIKey()
+	if err != nil {
+		uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error creating api key", err)
+		return
+	}
+
+	apiKey := &models.ApiKey{
+		UserId:    utils.UserIdFromContext(r.Context()),
+		Name:      req.Name,
+		KeyHash:   hash,
+		Scopes:    scopes.String(),
+		CreatedAt: now,
+	}
+
+	if req.ExpiresAt != nil {
+		apiKey.ExpiresAt = *usql.NewNullTime(req.ExpiresAt.UTC())
+	}
+
+	err = s.r.CreateApiKey(r.Context(), apiKey)
+	if err != nil {
+		slog.Error("error creating api key", slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error creating api key", err)
+		return
+	}
+
+	resp, err := modelApiKeyAsApiApiKey(apiKey)
+	if err != nil {
+		slog.Error("error converting api key", slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error converting api key", err)
+		return
+	}
+
+	// The key is only returned now, as only its hash is stored.
+	resp.Key = utils.Ptr(key)
+
+	err = uhttp.Encode(w, http.StatusCreated, resp)
+	if err != nil {
+		slog.Error("error encoding response", slog.String(logging.KeyError, err.Error()))
+		return
+	}
+}
+
+func (s *service) RevokeApiKey(w http.ResponseWriter, r *http.Request, apiKeyId api.PathApiKeyId) {
+	apiKey, err := s.r.GetApiKeyById(r.Context(), int(apiKeyId))
+	if err != nil {
+		switch {
+		case errors.Is(err, repo.ErrApiKeyNotFound):
+			uhttp.SendMessageWithStatus(w, http.StatusNotFound, "api key not found")
+		default:
+			slog.Error("error getting api key", slog.String(logging.KeyError, err.Error()))
+			uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error getting api key", err)
+		}
+		return
+	} else if apiKey.UserId != utils.UserIdFromContext(r.Context()) {
+		uhttp.SendMessageWithStatus(w, http.StatusNotFound, "api key not found")
+		return
+	}
+
+	err = s.r.RevokeApiKey(r.Context(), apiKey.Id)
+	if err != nil {
+		slog.Error("error revoking api key", slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error revoking api key", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// modelApiKeyAsApiApiKey converts the API key to its API representation, without the key itself.
+func modelApiKeyAsApiApiKey(apiKey *models.ApiKey) (*api.ApiKey, error) {
+	scopes, err := auth.ParseScopes(apiKey.Scopes)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing scopes of api key %d: %w", apiKey.Id, err)
+	}
+
+	apiScopes := make([]api.ApiKeyScope, len(scopes))
+	for i, scope := range scopes {
+		apiScopes[i] = string(scope)
+	}
+
+	apiApiKey := &api.ApiKey{
+		Id:        utils.Ptr(int64(apiKey.Id)),
+		Name:      utils.Ptr(apiKey.Name),
+		Scopes:    utils.Ptr(apiScopes),
+		CreatedAt: utils.Ptr(apiKey.CreatedAt),
+	}
+
+	if apiKey.LastUsedAt.Valid {
+		apiApiKey.LastUsedAt = utils.Ptr(apiKey.LastUsedAt.Time)
+	}
+
+	if apiKey.ExpiresAt.Valid {
+		apiApiKey.ExpiresAt = utils.Ptr(apiKey.ExpiresAt.Time)
+	}
+
+	return apiApiKey, nil
+}
+
+// apiKeyActive returns whether the API key can still be used.
+func apiKeyActive(apiKey *models.ApiKey) bool {
+	return !apiKey.RevokedAt.Valid && (!apiKey.ExpiresAt.Valid || time.Now().UTC().Before(apiKey.ExpiresAt.Time))
+}
@@ -0,0 +1,78 @@
+package rounder
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/logging"
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/models"
+	usql "github.com/Jacobbrewer1/golf-stats-tracker/pkg/utils/sql"
+)
+
+const (
+	auditActionLoginSuccess       = "login.success"
+	auditActionLoginFailure       = "login.failure"
+	auditActionRoundCreate        = "round.create"
+	auditActionRoundDelete        = "round.delete"
+	auditActionHoleStatsUpdate    = "hole_stats.update"
+	auditActionUserCreate         = "user.create"
+	auditActionUserRoleUpdate     = "user.role_update"
+	auditActionUserPasswordChange = "user.password_change"
+	auditActionUserPasswordReset  = "user.password_reset"
+	auditActionUserTotpEnable     = "user.totp_enable"
+	auditActionUserTotpReset      = "user.totp_reset"
+	auditActionUserProfileUpdate  = "user.profile_update"
+
+	auditTargetUser  = "user"
+	auditTargetRound = "round"
+)
+
+// auditEvent is an action to record in the audit log.
+type auditEvent struct {
+	// actorId is the ID of the user that performed the action, or zero if it is not known.
+	actorId int
+
+	// action is what was done.
+	action string
+
+	// targetType is the type of resource that was changed, if any.
+	targetType string
+
+	// targetId is the ID of the resource that was changed, if any.
+	targetId int
+
+	// details describes the action, such as the changes made.
+	details string
+}
+
+// audit records the event in the audit log. The action has already happened by the time it is audited, so a failure
+// to record it is logged rather than failing the request.
+func (s *service) audit(r *http.Request, event auditEvent) {
+	mdl := &models.AuditEvent{
+		Action:    event.action,
+		IpAddress: *usql.NewNullString(clientIP(r)),
+		CreatedAt: time.Now().UTC(),
+	}
+
+	if event.actorId > 0 {
+		mdl.ActorId = *usql.NewNullInt64(int64(event.actorId))
+	}
+
+	if event.targetType != "" {
+		mdl.TargetType = *usql.NewNullString(event.targetType)
+		mdl.TargetId = *usql.NewNullInt64(int64(event.targetId))
+	}
+
+	if event.details != "" {
+		mdl.Details = *usql.NewNullString(event.details)
+	}
+
+	err := s.r.CreateAuditEvent(r.Context(), mdl)
+	if err != nil {
+		slog.Error("error recording audit event",
+			slog.String(logging.KeyError, err.Error()),
+			slog.String("action", event.action),
+		)
+	}
+}
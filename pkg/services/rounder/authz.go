@@ -5,25 +5,81 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"strings"
+	"time"
 
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/auth"
 	api "github.com/Jacobbrewer1/golf-stats-tracker/pkg/codegen/apis/rounder"
 	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/logging"
 	repo "github.com/Jacobbrewer1/golf-stats-tracker/pkg/repositories/rounder"
 	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/utils"
 	"github.com/Jacobbrewer1/uhttp"
-	"github.com/Jacobbrewer1/vaulty"
-	"github.com/spf13/viper"
 )
 
 type authz struct {
-	next api.ServerInterface
-	db   repo.Repository
-	vc   vaulty.Client
-	vip  *viper.Viper
+	next    api.ServerInterface
+	db      repo.Repository
+	tokens  auth.Tokens
+	lockout auth.Lockout
+}
+
+func (a *authz) DeleteCourse(w http.ResponseWriter, r *http.Request, courseId api.PathCourseId) {
+	r, err := a.WithAuthorization(r, sessionOnly)
+	if err != nil {
+		slog.Debug("failed to authorize request", slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusUnauthorized, "failed to authorize request", err)
+		return
+	}
+
+	a.next.DeleteCourse(w, r, courseId)
+}
+
+func (a *authz) UpdateCourse(w http.ResponseWriter, r *http.Request, courseId api.PathCourseId) {
+	r, err := a.WithAuthorization(r, sessionOnly)
+	if err != nil {
+		slog.Debug("failed to authorize request", slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusUnauthorized, "failed to authorize request", err)
+		return
+	}
+
+	a.next.UpdateCourse(w, r, courseId)
+}
+
+func (a *authz) GetCourse(w http.ResponseWriter, r *http.Request, courseId api.PathCourseId) {
+	r, err := a.WithAuthorization(r, auth.ScopeReadRounds)
+	if err != nil {
+		slog.Debug("failed to authorize request", slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusUnauthorized, "failed to authorize request", err)
+		return
+	}
+
+	a.next.GetCourse(w, r, courseId)
+}
+
+func (a *authz) GetCourses(w http.ResponseWriter, r *http.Request) {
+	r, err := a.WithAuthorization(r, auth.ScopeReadRounds)
+	if err != nil {
+		slog.Debug("failed to authorize request", slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusUnauthorized, "failed to authorize request", err)
+		return
+	}
+
+	a.next.GetCourses(w, r)
+}
+
+func (a *authz) CreateCourse(w http.ResponseWriter, r *http.Request) {
+	r, err := a.WithAuthorization(r, sessionOnly)
+	if err != nil {
+		slog.Debug("failed to authorize request", slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusUnauthorized, "failed to authorize request", err)
+		return
+	}
+
+	a.next.CreateCourse(w, r)
 }
 
 func (a *authz) GetPieChartAverages(w http.ResponseWriter, r *http.Request, params api.GetPieChartAveragesParams) {
-	r, err := a.WithAuthorization(r)
+	r, err := a.WithAuthorization(r, auth.ScopeReadCharts)
 	if err != nil {
 		slog.Debug("failed to authorize request", slog.String(logging.KeyError, err.Error()))
 		uhttp.SendErrorMessageWithStatus(w, http.StatusUnauthorized, "failed to authorize request", err)
@@ -34,7 +90,7 @@ func (a *authz) GetPieChartAverages(w http.ResponseWriter, r *http.Request, para
 }
 
 func (a *authz) GetLineChartAverages(w http.ResponseWriter, r *http.Request, params api.GetLineChartAveragesParams) {
-	r, err := a.WithAuthorization(r)
+	r, err := a.WithAuthorization(r, auth.ScopeReadCharts)
 	if err != nil {
 		slog.Debug("failed to authorize request", slog.String(logging.KeyError, err.Error()))
 		uhttp.SendErrorMessageWithStatus(w, http.StatusUnauthorized, "failed to authorize request", err)
@@ -45,7 +101,7 @@ func (a *authz) GetLineChartAverages(w http.ResponseWriter, r *http.Request, par
 }
 
 func (a *authz) UpdateHoleStats(w http.ResponseWriter, r *http.Request, roundId api.PathRoundId, holeId api.PathHoleId) {
-	r, err := a.WithAuthorization(r)
+	r, err := a.WithAuthorization(r, auth.ScopeWriteStats)
 	if err != nil {
 		slog.Debug("failed to authorize request", slog.String(logging.KeyError, err.Error()))
 		uhttp.SendErrorMessageWithStatus(w, http.StatusUnauthorized, "failed to authorize request", err)
@@ -55,8 +111,19 @@ func (a *authz) UpdateHoleStats(w http.ResponseWriter, r *http.Request, roundId
 	a.next.UpdateHoleStats(w, r, roundId, holeId)
 }
 
+func (a *authz) DeleteRound(w http.ResponseWriter, r *http.Request, roundId api.PathRoundId) {
+	r, err := a.WithAuthorization(r, sessionOnly)
+	if err != nil {
+		slog.Debug("failed to authorize request", slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusUnauthorized, "failed to authorize request", err)
+		return
+	}
+
+	a.next.DeleteRound(w, r, roundId)
+}
+
 func (a *authz) GetHoleStats(w http.ResponseWriter, r *http.Request, roundId api.PathRoundId, holeId api.PathHoleId) {
-	r, err := a.WithAuthorization(r)
+	r, err := a.WithAuthorization(r, auth.ScopeReadRounds)
 	if err != nil {
 		slog.Debug("failed to authorize request", slog.String(logging.KeyError, err.Error()))
 		uhttp.SendErrorMessageWithStatus(w, http.StatusUnauthorized, "failed to authorize request", err)
@@ -66,30 +133,41 @@ func (a *authz) GetHoleStats(w http.ResponseWriter, r *http.Request, roundId api
 	a.next.GetHoleStats(w, r, roundId, holeId)
 }
 
-func (a *authz) GetRoundHoles(w http.ResponseWriter, r *http.Request, roundId api.PathRoundId) {
-	r, err := a.WithAuthorization(r)
+func (a *authz) GetRoundHoles(w http.ResponseWriter, r *http.Request, roundId api.PathRoundId, params api.GetRoundHolesParams) {
+	r, err := a.WithAuthorization(r, auth.ScopeReadRounds)
+	if err != nil {
+		slog.Debug("failed to authorize request", slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusUnauthorized, "failed to authorize request", err)
+		return
+	}
+
+	a.next.GetRoundHoles(w, r, roundId, params)
+}
+
+func (a *authz) GetRounds(w http.ResponseWriter, r *http.Request, params api.GetRoundsParams) {
+	r, err := a.WithAuthorization(r, auth.ScopeReadRounds)
 	if err != nil {
 		slog.Debug("failed to authorize request", slog.String(logging.KeyError, err.Error()))
 		uhttp.SendErrorMessageWithStatus(w, http.StatusUnauthorized, "failed to authorize request", err)
 		return
 	}
 
-	a.next.GetRoundHoles(w, r, roundId)
+	a.next.GetRounds(w, r, params)
 }
 
-func (a *authz) GetRounds(w http.ResponseWriter, r *http.Request) {
-	r, err := a.WithAuthorization(r)
+func (a *authz) GetRoundStats(w http.ResponseWriter, r *http.Request, params api.GetRoundStatsParams) {
+	r, err := a.WithAuthorization(r, auth.ScopeReadRounds)
 	if err != nil {
 		slog.Debug("failed to authorize request", slog.String(logging.KeyError, err.Error()))
 		uhttp.SendErrorMessageWithStatus(w, http.StatusUnauthorized, "failed to authorize request", err)
 		return
 	}
 
-	a.next.GetRounds(w, r)
+	a.next.GetRoundStats(w, r, params)
 }
 
 func (a *authz) CreateRound(w http.ResponseWriter, r *http.Request) {
-	r, err := a.WithAuthorization(r)
+	r, err := a.WithAuthorization(r, sessionOnly)
 	if err != nil {
 		slog.Debug("failed to authorize request", slog.String(logging.KeyError, err.Error()))
 		uhttp.SendErrorMessageWithStatus(w, http.StatusUnauthorized, "failed to authorize request", err)
@@ -104,7 +182,7 @@ func (a *authz) Login(w http.ResponseWriter, r *http.Request) {
 }
 
 func (a *authz) GetNewRoundCourses(w http.ResponseWriter, r *http.Request, params api.GetNewRoundCoursesParams) {
-	r, err := a.WithAuthorization(r)
+	r, err := a.WithAuthorization(r, sessionOnly)
 	if err != nil {
 		slog.Debug("failed to authorize request", slog.String(logging.KeyError, err.Error()))
 		uhttp.SendErrorMessageWithStatus(w, http.StatusUnauthorized, "failed to authorize request", err)
@@ -115,7 +193,7 @@ func (a *authz) GetNewRoundCourses(w http.ResponseWriter, r *http.Request, param
 }
 
 func (a *authz) GetNewRoundMarker(w http.ResponseWriter, r *http.Request, courseId api.PathCourseId) {
-	r, err := a.WithAuthorization(r)
+	r, err := a.WithAuthorization(r, sessionOnly)
 	if err != nil {
 		slog.Debug("failed to authorize request", slog.String(logging.KeyError, err.Error()))
 		uhttp.SendErrorMessageWithStatus(w, http.StatusUnauthorized, "failed to authorize request", err)
@@ -125,43 +203,378 @@ func (a *authz) GetNewRoundMarker(w http.ResponseWriter, r *http.Request, course
 	a.next.GetNewRoundMarker(w, r, courseId)
 }
 
+func (a *authz) RefreshToken(w http.ResponseWriter, r *http.Request) {
+	a.next.RefreshToken(w, r)
+}
+
+func (a *authz) Logout(w http.ResponseWriter, r *http.Request) {
+	r, err := a.WithAuthorization(r, sessionOnly)
+	if err != nil {
+		slog.Debug("failed to authorize request", slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusUnauthorized, "failed to authorize request", err)
+		return
+	}
+
+	a.next.Logout(w, r)
+}
+
+func (a *authz) GetSessions(w http.ResponseWriter, r *http.Request) {
+	r, err := a.WithAuthorization(r, sessionOnly)
+	if err != nil {
+		slog.Debug("failed to authorize request", slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusUnauthorized, "failed to authorize request", err)
+		return
+	}
+
+	a.next.GetSessions(w, r)
+}
+
+func (a *authz) RevokeSession(w http.ResponseWriter, r *http.Request, sessionId api.PathSessionId) {
+	r, err := a.WithAuthorization(r, sessionOnly)
+	if err != nil {
+		slog.Debug("failed to authorize request", slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusUnauthorized, "failed to authorize request", err)
+		return
+	}
+
+	a.next.RevokeSession(w, r, sessionId)
+}
+
+func (a *authz) ChangePassword(w http.ResponseWriter, r *http.Request) {
+	r, err := a.WithAuthorization(r, sessionOnly)
+	if err != nil {
+		slog.Debug("failed to authorize request", slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusUnauthorized, "failed to authorize request", err)
+		return
+	}
+
+	a.next.ChangePassword(w, r)
+}
+
+func (a *authz) RequestPasswordReset(w http.ResponseWriter, r *http.Request) {
+	a.next.RequestPasswordReset(w, r)
+}
+
+func (a *authz) ConfirmPasswordReset(w http.ResponseWriter, r *http.Request) {
+	a.next.ConfirmPasswordReset(w, r)
+}
+
 func (a *authz) CreateUser(w http.ResponseWriter, r *http.Request) {
 	a.next.CreateUser(w, r)
 }
 
-func NewAuthz(next api.ServerInterface, db repo.Repository, vc vaulty.Client, vip *viper.Viper) api.ServerInterface {
+func (a *authz) GetApiKeys(w http.ResponseWriter, r *http.Request) {
+	r, err := a.WithAuthorization(r, sessionOnly)
+	if err != nil {
+		slog.Debug("failed to authorize request", slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusUnauthorized, "failed to authorize request", err)
+		return
+	}
+
+	a.next.GetApiKeys(w, r)
+}
+
+func (a *authz) CreateApiKey(w http.ResponseWriter, r *http.Request) {
+	r, err := a.WithAuthorization(r, sessionOnly)
+	if err != nil {
+		slog.Debug("failed to authorize request", slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusUnauthorized, "failed to authorize request", err)
+		return
+	}
+
+	a.next.CreateApiKey(w, r)
+}
+
+func (a *authz) RevokeApiKey(w http.ResponseWriter, r *http.Request, apiKeyId api.PathApiKeyId) {
+	r, err := a.WithAuthorization(r, sessionOnly)
+	if err != nil {
+		slog.Debug("failed to authorize request", slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusUnauthorized, "failed to authorize request", err)
+		return
+	}
+
+	a.next.RevokeApiKey(w, r, apiKeyId)
+}
+
+func (a *authz) GetProfile(w http.ResponseWriter, r *http.Request) {
+	r, err := a.WithAuthorization(r, sessionOnly)
+	if err != nil {
+		slog.Debug("failed to authorize request", slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusUnauthorized, "failed to authorize request", err)
+		return
+	}
+
+	a.next.GetProfile(w, r)
+}
+
+func (a *authz) UpdateProfile(w http.ResponseWriter, r *http.Request) {
+	r, err := a.WithAuthorization(r, sessionOnly)
+	if err != nil {
+		slog.Debug("failed to authorize request", slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusUnauthorized, "failed to authorize request", err)
+		return
+	}
+
+	a.next.UpdateProfile(w, r)
+}
+
+func (a *authz) GetPreferences(w http.ResponseWriter, r *http.Request) {
+	r, err := a.WithAuthorization(r, sessionOnly)
+	if err != nil {
+		slog.Debug("failed to authorize request", slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusUnauthorized, "failed to authorize request", err)
+		return
+	}
+
+	a.next.GetPreferences(w, r)
+}
+
+func (a *authz) UpdatePreferences(w http.ResponseWriter, r *http.Request) {
+	r, err := a.WithAuthorization(r, sessionOnly)
+	if err != nil {
+		slog.Debug("failed to authorize request", slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusUnauthorized, "failed to authorize request", err)
+		return
+	}
+
+	a.next.UpdatePreferences(w, r)
+}
+
+func (a *authz) EnrolTotp(w http.ResponseWriter, r *http.Request) {
+	r, err := a.WithAuthorization(r, sessionOnly)
+	if err != nil {
+		slog.Debug("failed to authorize request", slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusUnauthorized, "failed to authorize request", err)
+		return
+	}
+
+	a.next.EnrolTotp(w, r)
+}
+
+func (a *authz) ConfirmTotp(w http.ResponseWriter, r *http.Request) {
+	r, err := a.WithAuthorization(r, sessionOnly)
+	if err != nil {
+		slog.Debug("failed to authorize request", slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusUnauthorized, "failed to authorize request", err)
+		return
+	}
+
+	a.next.ConfirmTotp(w, r)
+}
+
+func (a *authz) GetIdentities(w http.ResponseWriter, r *http.Request) {
+	r, err := a.WithAuthorization(r, sessionOnly)
+	if err != nil {
+		slog.Debug("failed to authorize request", slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusUnauthorized, "failed to authorize request", err)
+		return
+	}
+
+	a.next.GetIdentities(w, r)
+}
+
+func (a *authz) LinkIdentity(w http.ResponseWriter, r *http.Request, provider api.PathOidcProvider) {
+	r, err := a.WithAuthorization(r, sessionOnly)
+	if err != nil {
+		slog.Debug("failed to authorize request", slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusUnauthorized, "failed to authorize request", err)
+		return
+	}
+
+	a.next.LinkIdentity(w, r, provider)
+}
+
+func (a *authz) GetCoaches(w http.ResponseWriter, r *http.Request) {
+	r, err := a.WithAuthorization(r, sessionOnly)
+	if err != nil {
+		slog.Debug("failed to authorize request", slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusUnauthorized, "failed to authorize request", err)
+		return
+	}
+
+	a.next.GetCoaches(w, r)
+}
+
+func (a *authz) GrantCoach(w http.ResponseWriter, r *http.Request) {
+	r, err := a.WithAuthorization(r, sessionOnly)
+	if err != nil {
+		slog.Debug("failed to authorize request", slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusUnauthorized, "failed to authorize request", err)
+		return
+	}
+
+	a.next.GrantCoach(w, r)
+}
+
+func (a *authz) RevokeCoach(w http.ResponseWriter, r *http.Request, coachId api.PathCoachId) {
+	r, err := a.WithAuthorization(r, sessionOnly)
+	if err != nil {
+		slog.Debug("failed to authorize request", slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusUnauthorized, "failed to authorize request", err)
+		return
+	}
+
+	a.next.RevokeCoach(w, r, coachId)
+}
+
+func (a *authz) GetPlayers(w http.ResponseWriter, r *http.Request) {
+	r, err := a.WithAuthorization(r, sessionOnly)
+	if err != nil {
+		slog.Debug("failed to authorize request", slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusUnauthorized, "failed to authorize request", err)
+		return
+	}
+
+	a.next.GetPlayers(w, r)
+}
+
+func (a *authz) GetUsers(w http.ResponseWriter, r *http.Request) {
+	r, err := a.WithAuthorization(r, sessionOnly)
+	if err != nil {
+		slog.Debug("failed to authorize request", slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusUnauthorized, "failed to authorize request", err)
+		return
+	}
+
+	a.next.GetUsers(w, r)
+}
+
+func (a *authz) ResetUserTotp(w http.ResponseWriter, r *http.Request, userId api.PathUserId) {
+	r, err := a.WithAuthorization(r, sessionOnly)
+	if err != nil {
+		slog.Debug("failed to authorize request", slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusUnauthorized, "failed to authorize request", err)
+		return
+	}
+
+	a.next.ResetUserTotp(w, r, userId)
+}
+
+func (a *authz) GetAuditEvents(w http.ResponseWriter, r *http.Request, params api.GetAuditEventsParams) {
+	r, err := a.WithAuthorization(r, sessionOnly)
+	if err != nil {
+		slog.Debug("failed to authorize request", slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusUnauthorized, "failed to authorize request", err)
+		return
+	}
+
+	a.next.GetAuditEvents(w, r, params)
+}
+
+func (a *authz) UpdateUser(w http.ResponseWriter, r *http.Request, userId api.PathUserId) {
+	r, err := a.WithAuthorization(r, sessionOnly)
+	if err != nil {
+		slog.Debug("failed to authorize request", slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusUnauthorized, "failed to authorize request", err)
+		return
+	}
+
+	a.next.UpdateUser(w, r, userId)
+}
+
+func (a *authz) StartOidcLogin(w http.ResponseWriter, r *http.Request, provider api.PathOidcProvider) {
+	a.next.StartOidcLogin(w, r, provider)
+}
+
+func (a *authz) OidcCallback(w http.ResponseWriter, r *http.Request, provider api.PathOidcProvider, params api.OidcCallbackParams) {
+	a.next.OidcCallback(w, r, provider, params)
+}
+
+func NewAuthz(next api.ServerInterface, db repo.Repository, tokens auth.Tokens, lockout auth.Lockout) api.ServerInterface {
 	return &authz{
-		next: next,
-		db:   db,
-		vc:   vc,
-		vip:  vip,
+		next:    next,
+		db:      db,
+		tokens:  tokens,
+		lockout: lockout,
+	}
+}
+
+// sessionOnly is the scope of the operations that cannot be called with an API key.
+const sessionOnly auth.Scope = ""
+
+// WithAuthorization verifies the bearer token of the request, adding the user ID to the context. Access tokens must
+// belong to a session that has not been revoked, and the session ID is also added to the context. API keys must not
+// have been revoked and must have been granted the scope.
+func (a *authz) WithAuthorization(r *http.Request, scope auth.Scope) (*http.Request, error) {
+	authed, err := a.authorize(r, scope)
+	if err != nil {
+		// Failures are counted against the IP address so that tokens cannot be guessed.
+		a.lockout.Failure(ipLockoutKey(r))
+		return nil, err
 	}
+
+	return authed, nil
 }
 
-func (a *authz) WithAuthorization(r *http.Request) (*http.Request, error) {
-	username, password, ok := r.BasicAuth()
+// authorize verifies the bearer token of the request.
+func (a *authz) authorize(r *http.Request, scope auth.Scope) (*http.Request, error) {
+	token, ok := bearerToken(r)
 	if !ok {
-		return nil, errors.New("missing basic auth")
+		return nil, errors.New("missing bearer token")
+	}
+
+	if auth.IsAPIKey(token) {
+		return a.authorizeApiKey(r, token, scope)
 	}
 
-	user, err := a.db.UserByUsername(username)
+	claims, err := a.tokens.Verify(token)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get user by username: %w", err)
+		return nil, fmt.Errorf("failed to verify token: %w", err)
 	}
 
-	unvaultedPassword, err := a.vc.Path(
-		a.vip.GetString("vault.transit.key"),
-		vaulty.WithPrefix(a.vip.GetString("vault.transit.name")),
-	).TransitDecrypt(r.Context(), user.Password)
+	session, err := a.db.GetSessionById(r.Context(), claims.SessionId)
 	if err != nil {
-		return nil, fmt.Errorf("failed to decrypt password: %w", err)
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	} else if session.UserId != claims.UserId || !sessionActive(session) {
+		return nil, errors.New("session is no longer active")
 	}
 
-	if !utils.ComparePassword(unvaultedPassword, password) {
-		return nil, errors.New("invalid password")
+	ctx := utils.UserIdToContext(r.Context(), claims.UserId)
+	ctx = utils.SessionIdToContext(ctx, claims.SessionId)
+	r = r.WithContext(ctx)
+
+	return r, nil
+}
+
+// authorizeApiKey verifies the API key and that it has been granted the scope.
+func (a *authz) authorizeApiKey(r *http.Request, key string, scope auth.Scope) (*http.Request, error) {
+	if scope == sessionOnly {
+		return nil, errors.New("api keys cannot be used for this operation")
 	}
 
-	r = r.WithContext(utils.UserIdToContext(r.Context(), user.Id))
+	apiKey, err := a.db.GetApiKeyByKeyHash(r.Context(), auth.HashOpaqueToken(key))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get api key: %w", err)
+	} else if !apiKeyActive(apiKey) {
+		return nil, errors.New("api key is no longer active")
+	}
+
+	scopes, err := auth.ParseScopes(apiKey.Scopes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse api key scopes: %w", err)
+	} else if !scopes.Has(scope) {
+		return nil, fmt.Errorf("api key does not have the %s scope", scope)
+	}
+
+	now := time.Now().UTC()
+	if !apiKey.LastUsedAt.Valid || now.Sub(apiKey.LastUsedAt.Time) > apiKeyLastUsedInterval {
+		// The request is still allowed if the last used time cannot be saved.
+		if err := a.db.UpdateApiKeyLastUsed(r.Context(), apiKey.Id, now); err != nil {
+			slog.Error("failed to update api key", slog.String(logging.KeyError, err.Error()))
+		}
+	}
+
+	r = r.WithContext(utils.UserIdToContext(r.Context(), apiKey.UserId))
 
 	return r, nil
 }
+
+// bearerToken gets the bearer token from the Authorization header of the request.
+func bearerToken(r *http.Request) (string, bool) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, auth.TokenTypeBearer) || token == "" {
+		return "", false
+	}
+
+	return token, true
+}
@@ -17,10 +17,14 @@ import (
 )
 
 func (s *service) GetLineChartAverages(w http.ResponseWriter, r *http.Request, params api.GetLineChartAveragesParams) {
-	userId := utils.UserIdFromContext(r.Context())
+	userId, err := s.dataUserId(r, params.UserId)
+	if err != nil {
+		sendPolicyError(w, err, "not allowed to read the stats of the user")
+		return
+	}
 
 	// Get the line chart data.
-	lineChartData, err := s.r.GetStatsByUserId(userId)
+	lineChartData, err := s.r.GetStatsByUserId(r.Context(), userId, nil)
 	if err != nil {
 		switch {
 		case errors.Is(err, repo.ErrNoStatsFound):
@@ -126,10 +130,14 @@ func filterRoundStatsByDate(data []*repo.RoundWithStats, fromDate time.Time) []*
 }
 
 func (s *service) GetPieChartAverages(w http.ResponseWriter, r *http.Request, params api.GetPieChartAveragesParams) {
-	userId := utils.UserIdFromContext(r.Context())
+	userId, err := s.dataUserId(r, params.UserId)
+	if err != nil {
+		sendPolicyError(w, err, "not allowed to read the stats of the user")
+		return
+	}
 
 	// Get the pie chart data.
-	userRounds, err := s.r.GetUserHitStats(userId)
+	userRounds, err := s.r.GetUserHitStats(r.Context(), userId)
 	if err != nil {
 		switch {
 		case errors.Is(err, repo.ErrNoStatsFound):
@@ -0,0 +1,171 @@
+package rounder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/auth"
+	api "github.com/Jacobbrewer1/golf-stats-tracker/pkg/codegen/apis/rounder"
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/logging"
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/models"
+	repo "github.com/Jacobbrewer1/golf-stats-tracker/pkg/repositories/rounder"
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/utils"
+	"github.com/Jacobbrewer1/uhttp"
+)
+
+func (s *service) GetCoaches(w http.ResponseWriter, r *http.Request) {
+	grants, err := s.r.GetCoachGrantsByPlayerId(r.Context(), utils.UserIdFromContext(r.Context()))
+	if err != nil {
+		slog.Error("error getting coach grants", slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error getting coach grants", err)
+		return
+	}
+
+	s.sendCoachGrants(r.Context(), w, grants)
+}
+
+func (s *service) GetPlayers(w http.ResponseWriter, r *http.Request) {
+	grants, err := s.r.GetCoachGrantsByCoachId(r.Context(), utils.UserIdFromContext(r.Context()))
+	if err != nil {
+		slog.Error("error getting coach grants", slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error getting coach grants", err)
+		return
+	}
+
+	s.sendCoachGrants(r.Context(), w, grants)
+}
+
+func (s *service) GrantCoach(w http.ResponseWriter, r *http.Request) {
+	req := new(api.CoachGrantCreate)
+	err := uhttp.DecodeRequestJSON(r, req)
+	if err != nil {
+		uhttp.SendErrorMessageWithStatus(w, http.StatusBadRequest, "error decoding request body", err)
+		return
+	} else if req.Username == "" {
+		uhttp.SendMessageWithStatus(w, http.StatusBadRequest, "username is required")
+		return
+	}
+
+	playerId := utils.UserIdFromContext(r.Context())
+
+	coach, err := s.r.UserByUsername(r.Context(), strings.ToLower(req.Username))
+	if err != nil {
+		switch {
+		case errors.Is(err, repo.ErrUserNotFound):
+			uhttp.SendMessageWithStatus(w, http.StatusNotFound, "coach not found")
+		default:
+			slog.Error("error getting user", slog.String(logging.KeyError, err.Error()))
+			uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error getting user", err)
+		}
+		return
+	} else if coach.Role != string(auth.RoleCoach) {
+		// Do not tell players which users exist but are not coaches.
+		uhttp.SendMessageWithStatus(w, http.StatusNotFound, "coach not found")
+		return
+	} else if coach.Id == playerId {
+		uhttp.SendMessageWithStatus(w, http.StatusBadRequest, "cannot grant access to yourself")
+		return
+	}
+
+	_, err = s.r.GetCoachGrant(r.Context(), playerId, coach.Id)
+	switch {
+	case err == nil:
+		uhttp.SendMessageWithStatus(w, http.StatusConflict, "coach already has access")
+		return
+	case !errors.Is(err, repo.ErrCoachGrantNotFound):
+		slog.Error("error getting coach grant", slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error getting coach grant", err)
+		return
+	}
+
+	grant := &models.CoachGrant{
+		PlayerId:  playerId,
+		CoachId:   coach.Id,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	err = s.r.CreateCoachGrant(r.Context(), grant)
+	if err != nil {
+		slog.Error("error creating coach grant", slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error creating coach grant", err)
+		return
+	}
+
+	resp, err := s.modelCoachGrantAsApiCoachGrant(r.Context(), grant)
+	if err != nil {
+		slog.Error("error converting coach grant", slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error converting coach grant", err)
+		return
+	}
+
+	err = uhttp.Encode(w, http.StatusCreated, resp)
+	if err != nil {
+		slog.Error("error encoding response", slog.String(logging.KeyError, err.Error()))
+		return
+	}
+}
+
+func (s *service) RevokeCoach(w http.ResponseWriter, r *http.Request, coachId api.PathCoachId) {
+	err := s.r.DeleteCoachGrant(r.Context(), utils.UserIdFromContext(r.Context()), int(coachId))
+	if err != nil {
+		switch {
+		case errors.Is(err, repo.ErrCoachGrantNotFound):
+			uhttp.SendMessageWithStatus(w, http.StatusNotFound, "coach not found")
+		default:
+			slog.Error("error deleting coach grant", slog.String(logging.KeyError, err.Error()))
+			uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error deleting coach grant", err)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// sendCoachGrants writes the response for a list of coach grants.
+func (s *service) sendCoachGrants(ctx context.Context, w http.ResponseWriter, grants *repo.PaginationResponse[models.CoachGrant]) {
+	resp := &api.CoachGrantsResponse{
+		Grants: make([]api.CoachGrant, 0, len(grants.Items)),
+		Total:  grants.Total,
+	}
+
+	for _, grant := range grants.Items {
+		apiGrant, err := s.modelCoachGrantAsApiCoachGrant(ctx, grant)
+		if err != nil {
+			slog.Error("error converting coach grant", slog.String(logging.KeyError, err.Error()))
+			uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error converting coach grant", err)
+			return
+		}
+
+		resp.Grants = append(resp.Grants, *apiGrant)
+	}
+
+	err := uhttp.Encode(w, http.StatusOK, resp)
+	if err != nil {
+		slog.Error("error encoding response", slog.String(logging.KeyError, err.Error()))
+		return
+	}
+}
+
+// modelCoachGrantAsApiCoachGrant converts the coach grant to its API representation with the player and coach.
+func (s *service) modelCoachGrantAsApiCoachGrant(ctx context.Context, grant *models.CoachGrant) (*api.CoachGrant, error) {
+	player, err := s.r.GetUserById(ctx, grant.PlayerId)
+	if err != nil {
+		return nil, fmt.Errorf("error getting player: %w", err)
+	}
+
+	coach, err := s.r.GetUserById(ctx, grant.CoachId)
+	if err != nil {
+		return nil, fmt.Errorf("error getting coach: %w", err)
+	}
+
+	return &api.CoachGrant{
+		Player:    s.modelAsUser(player),
+		Coach:     s.modelAsUser(coach),
+		CreatedAt: utils.Ptr(grant.CreatedAt),
+	}, nil
+}
@@ -1,14 +1,15 @@
 package rounder
 
 import (
-	"context"
-	"fmt"
+	"errors"
 	"log/slog"
 	"net/http"
 	"sort"
 
 	api "github.com/Jacobbrewer1/golf-stats-tracker/pkg/codegen/apis/rounder"
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/golfdata"
 	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/logging"
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/utils"
 	"github.com/Jacobbrewer1/uhttp"
 )
 
@@ -18,11 +19,17 @@ func (s *service) GetNewRoundCourses(w http.ResponseWriter, r *http.Request, par
 		name = *params.Name
 	}
 
-	courses, err := s.getGolfDataCourses(r.Context(), name)
+	courses, err := s.gd.GetCourses(r.Context(), name)
 	if err != nil {
-		slog.Error("failed to get courses", slog.String(logging.KeyError, err.Error()))
-		uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "failed to get courses", err)
-		return
+		switch {
+		case errors.Is(err, golfdata.ErrCircuitOpen):
+			uhttp.SendErrorMessageWithStatus(w, http.StatusServiceUnavailable, "golf data service unavailable", err)
+			return
+		default:
+			slog.Error("failed to get courses", slog.String(logging.KeyError, err.Error()))
+			uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "failed to get courses", err)
+			return
+		}
 	}
 
 	resp := &api.CoursesResponse{
@@ -37,56 +44,36 @@ func (s *service) GetNewRoundCourses(w http.ResponseWriter, r *http.Request, par
 	}
 }
 
-func (s *service) getGolfDataCourses(ctx context.Context, name string) (*api.CoursesResponse, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/courses", s.golfDataHost), nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	if name != "" {
-		params := req.URL.Query()
-		params.Add("name", name)
-		req.URL.RawQuery = params.Encode()
-	}
-
-	resp, err := http.DefaultClient.Do(req)
+func (s *service) GetNewRoundMarker(w http.ResponseWriter, r *http.Request, courseId api.PathCourseId) {
+	marker, err := s.gd.GetCourse(r.Context(), int(courseId))
 	if err != nil {
-		return nil, fmt.Errorf("failed to make request: %w", err)
-	}
-
-	defer func() {
-		err := resp.Body.Close()
-		if err != nil {
-			slog.Error("failed to close response body", slog.String("error", err.Error()))
+		switch {
+		case errors.Is(err, golfdata.ErrNotFound):
+			uhttp.SendMessageWithStatus(w, http.StatusNotFound, "course not found")
+			return
+		case errors.Is(err, golfdata.ErrCircuitOpen):
+			uhttp.SendErrorMessageWithStatus(w, http.StatusServiceUnavailable, "golf data service unavailable", err)
+			return
+		default:
+			slog.Error("failed to get marker", slog.String(logging.KeyError, err.Error()))
+			uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "failed to get marker", err)
+			return
 		}
-	}()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
-	}
-
-	courses := new(api.CoursesResponse)
-	err = uhttp.DecodeJSON(resp.Body, &courses)
-	if err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
 
-	return courses, nil
-}
+	details := make([]api.CourseDetails, 0)
+	details = append(details, marker.Details...)
 
-func (s *service) GetNewRoundMarker(w http.ResponseWriter, r *http.Request, courseId api.PathCourseId) {
-	marker, err := s.getGolfDataMarker(r.Context(), int(courseId))
+	prefs, err := s.userPreferences(r.Context(), utils.UserIdFromContext(r.Context()))
 	if err != nil {
-		slog.Error("failed to get marker", slog.String(logging.KeyError, err.Error()))
-		uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "failed to get marker", err)
+		slog.Error("error getting preferences", slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error getting preferences", err)
 		return
 	}
 
-	details := make([]api.CourseDetails, 0)
-	details = append(details, marker.Details...)
-
 	for i := range details {
 		details[i].Holes = nil
+		prefs.applyToCourseDetails(&details[i])
 	}
 
 	sort.Slice(details, func(i, j int) bool {
@@ -107,34 +94,3 @@ func (s *service) GetNewRoundMarker(w http.ResponseWriter, r *http.Request, cour
 		return
 	}
 }
-
-func (s *service) getGolfDataMarker(ctx context.Context, courseId int) (*api.Course, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/courses/%d", s.golfDataHost, courseId), nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to make request: %w", err)
-	}
-
-	defer func() {
-		err := resp.Body.Close()
-		if err != nil {
-			slog.Error("failed to close response body", slog.String("error", err.Error()))
-		}
-	}()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
-	}
-
-	marker := new(api.Course)
-	err = uhttp.DecodeJSON(resp.Body, marker)
-	if err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
-	}
-
-	return marker, nil
-}
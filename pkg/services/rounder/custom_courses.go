@@ -0,0 +1,458 @@
+package rounder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	api "github.com/Jacobbrewer1/golf-stats-tracker/pkg/codegen/apis/rounder"
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/logging"
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/models"
+	repo "github.com/Jacobbrewer1/golf-stats-tracker/pkg/repositories/rounder"
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/utils"
+	usql "github.com/Jacobbrewer1/golf-stats-tracker/pkg/utils/sql"
+	"github.com/Jacobbrewer1/uhttp"
+)
+
+func (s *service) CreateCourse(w http.ResponseWriter, r *http.Request) {
+	if r.Body == http.NoBody {
+		uhttp.SendMessageWithStatus(w, http.StatusBadRequest, "request body required")
+		return
+	}
+
+	userId := utils.UserIdFromContext(r.Context())
+	if userId <= 0 {
+		slog.Debug("user_id not found in context")
+		uhttp.SendMessageWithStatus(w, http.StatusUnauthorized, "user_id not found in context")
+		return
+	}
+
+	reqCourse := new(api.CustomCourse)
+	err := uhttp.DecodeRequestJSON(r, reqCourse)
+	if err != nil {
+		uhttp.SendErrorMessageWithStatus(w, http.StatusBadRequest, "error decoding request body", err)
+		return
+	}
+
+	teeSets, err := s.customCourseAsTeeSets(reqCourse)
+	if err != nil {
+		uhttp.SendErrorMessageWithStatus(w, http.StatusBadRequest, "error mapping course to model", err)
+		return
+	}
+
+	c := &models.Course{
+		UserId: *usql.NewNullInt64(int64(userId)),
+		Name:   reqCourse.Name,
+	}
+
+	for i, ts := range teeSets {
+		ts.Details.ExternalId = i + 1
+		ts.Details.Version = 1
+	}
+
+	err = s.r.CreateCustomCourse(r.Context(), c, teeSets)
+	if err != nil {
+		slog.Error("error creating course", slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error creating course", err)
+		return
+	}
+
+	prefs, err := s.userPreferences(r.Context(), utils.UserIdFromContext(r.Context()))
+	if err != nil {
+		slog.Error("error getting preferences", slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error getting preferences", err)
+		return
+	}
+
+	respCourse, err := s.courseById(r.Context(), c, prefs)
+	if err != nil {
+		slog.Error("error getting course", slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error getting course", err)
+		return
+	}
+
+	err = uhttp.Encode(w, http.StatusCreated, respCourse)
+	if err != nil {
+		slog.Error("error encoding response", slog.String(logging.KeyError, err.Error()))
+		return
+	}
+}
+
+func (s *service) GetCourses(w http.ResponseWriter, r *http.Request) {
+	userId := utils.UserIdFromContext(r.Context())
+	if userId <= 0 {
+		slog.Debug("user_id not found in context")
+		uhttp.SendMessageWithStatus(w, http.StatusUnauthorized, "user_id not found in context")
+		return
+	}
+
+	courses, err := s.r.GetCoursesByUserId(r.Context(), userId)
+	if err != nil {
+		slog.Error("error getting courses", slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error getting courses", err)
+		return
+	}
+
+	prefs, err := s.userPreferences(r.Context(), userId)
+	if err != nil {
+		slog.Error("error getting preferences", slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error getting preferences", err)
+		return
+	}
+
+	respCourses := make([]api.Course, 0, len(courses.Items))
+	for _, c := range courses.Items {
+		respCourse, err := s.courseById(r.Context(), c, prefs)
+		if err != nil {
+			slog.Error("error getting course", slog.String(logging.KeyError, err.Error()))
+			uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error getting course", err)
+			return
+		}
+
+		respCourses = append(respCourses, *respCourse)
+	}
+
+	resp := &api.CoursesResponse{
+		Courses: respCourses,
+		Total:   courses.Total,
+	}
+
+	err = uhttp.Encode(w, http.StatusOK, resp)
+	if err != nil {
+		slog.Error("error encoding response", slog.String(logging.KeyError, err.Error()))
+		return
+	}
+}
+
+func (s *service) GetCourse(w http.ResponseWriter, r *http.Request, courseId api.PathCourseId) {
+	c, ok := s.customCourseForRequest(w, r, courseId)
+	if !ok {
+		return
+	}
+
+	prefs, err := s.userPreferences(r.Context(), utils.UserIdFromContext(r.Context()))
+	if err != nil {
+		slog.Error("error getting preferences", slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error getting preferences", err)
+		return
+	}
+
+	respCourse, err := s.courseById(r.Context(), c, prefs)
+	if err != nil {
+		slog.Error("error getting course", slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error getting course", err)
+		return
+	}
+
+	err = uhttp.Encode(w, http.StatusOK, respCourse)
+	if err != nil {
+		slog.Error("error encoding response", slog.String(logging.KeyError, err.Error()))
+		return
+	}
+}
+
+func (s *service) UpdateCourse(w http.ResponseWriter, r *http.Request, courseId api.PathCourseId) {
+	if r.Body == http.NoBody {
+		uhttp.SendMessageWithStatus(w, http.StatusBadRequest, "request body required")
+		return
+	}
+
+	c, ok := s.customCourseForRequest(w, r, courseId)
+	if !ok {
+		return
+	}
+
+	reqCourse := new(api.CustomCourse)
+	err := uhttp.DecodeRequestJSON(r, reqCourse)
+	if err != nil {
+		uhttp.SendErrorMessageWithStatus(w, http.StatusBadRequest, "error decoding request body", err)
+		return
+	}
+
+	teeSets, err := s.customCourseAsTeeSets(reqCourse)
+	if err != nil {
+		uhttp.SendErrorMessageWithStatus(w, http.StatusBadRequest, "error mapping course to model", err)
+		return
+	}
+
+	current, err := s.r.GetCourseTeeSets(r.Context(), c.Id)
+	if err != nil {
+		slog.Error("error getting tee sets", slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error getting tee sets", err)
+		return
+	}
+
+	latest := make(map[int]*models.CourseDetails, len(current.Items))
+	nextExternalId := 1
+	for _, d := range current.Items {
+		latest[d.ExternalId] = d
+		if d.ExternalId >= nextExternalId {
+			nextExternalId = d.ExternalId + 1
+		}
+	}
+
+	// Validate all the tee sets before anything is written.
+	for i, d := range reqCourse.Details {
+		if d.Id == 0 {
+			continue
+		} else if _, ok := latest[int(d.Id)]; !ok {
+			uhttp.SendMessageWithStatus(w, http.StatusBadRequest, fmt.Sprintf("tee set %d not found", d.Id))
+			return
+		}
+		teeSets[i].Details.ExternalId = int(d.Id)
+	}
+
+	// Only the tee sets that were added or changed get a new version. Tee sets that are not in the request are kept,
+	// as removing them would change the rounds that were played on them.
+	changed := make([]*repo.TeeSet, 0, len(teeSets))
+	for _, ts := range teeSets {
+		ts.Details.CourseId = c.Id
+		ts.Details.Version = 1
+
+		if ts.Details.ExternalId == 0 {
+			ts.Details.ExternalId = nextExternalId
+			nextExternalId++
+		} else {
+			prev := latest[ts.Details.ExternalId]
+			prevHoles, err := s.r.GetCourseDetailsHoles(r.Context(), prev.Id)
+			if err != nil {
+				slog.Error("error getting holes", slog.String(logging.KeyError, err.Error()))
+				uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error getting holes", err)
+				return
+			}
+
+			if ts.Equal(&repo.TeeSet{Details: prev, Holes: prevHoles.Items}) {
+				continue
+			}
+
+			ts.Details.Version = prev.Version + 1
+		}
+
+		changed = append(changed, ts)
+	}
+
+	c.Name = reqCourse.Name
+	err = s.r.UpdateCustomCourse(r.Context(), c, changed)
+	if err != nil {
+		slog.Error("error updating course", slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error updating course", err)
+		return
+	}
+
+	prefs, err := s.userPreferences(r.Context(), utils.UserIdFromContext(r.Context()))
+	if err != nil {
+		slog.Error("error getting preferences", slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error getting preferences", err)
+		return
+	}
+
+	respCourse, err := s.courseById(r.Context(), c, prefs)
+	if err != nil {
+		slog.Error("error getting course", slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error getting course", err)
+		return
+	}
+
+	err = uhttp.Encode(w, http.StatusOK, respCourse)
+	if err != nil {
+		slog.Error("error encoding response", slog.String(logging.KeyError, err.Error()))
+		return
+	}
+}
+
+func (s *service) DeleteCourse(w http.ResponseWriter, r *http.Request, courseId api.PathCourseId) {
+	c, ok := s.customCourseForRequest(w, r, courseId)
+	if !ok {
+		return
+	}
+
+	rounds, err := s.r.CountRoundsByCourseId(r.Context(), c.Id)
+	if err != nil {
+		slog.Error("error counting rounds", slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error counting rounds", err)
+		return
+	} else if rounds > 0 {
+		uhttp.SendMessageWithStatus(w, http.StatusConflict, "course is used by a round")
+		return
+	}
+
+	err = s.r.DeleteCourse(r.Context(), c.Id)
+	if err != nil {
+		slog.Error("error deleting course", slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error deleting course", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// customCourseForRequest gets the custom course for the request, writing the response and returning false if the
+// course does not exist or does not belong to the user.
+func (s *service) customCourseForRequest(w http.ResponseWriter, r *http.Request, courseId api.PathCourseId) (*models.Course, bool) {
+	userId := utils.UserIdFromContext(r.Context())
+	if userId <= 0 {
+		slog.Debug("user_id not found in context")
+		uhttp.SendMessageWithStatus(w, http.StatusUnauthorized, "user_id not found in context")
+		return nil, false
+	}
+
+	c, err := s.customCourse(r.Context(), userId, int(courseId))
+	if err != nil {
+		switch {
+		case errors.Is(err, repo.ErrCourseNotFound):
+			uhttp.SendMessageWithStatus(w, http.StatusNotFound, "course not found")
+			return nil, false
+		default:
+			slog.Error("error getting course", slog.String(logging.KeyError, err.Error()))
+			uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error getting course", err)
+			return nil, false
+		}
+	}
+
+	return c, true
+}
+
+// customCourse gets a custom course owned by the user.
+func (s *service) customCourse(ctx context.Context, userId int, courseId int) (*models.Course, error) {
+	c, err := s.r.GetCourseById(ctx, courseId)
+	if err != nil {
+		return nil, err
+	} else if !c.UserId.Valid || int(c.UserId.Int64) != userId {
+		return nil, repo.ErrCourseNotFound
+	}
+
+	return c, nil
+}
+
+// customCourseTeeSet gets the ID of the latest version of a tee set of a custom course owned by the user.
+func (s *service) customCourseTeeSet(ctx context.Context, userId int, courseId int, markerId int) (int, error) {
+	c, err := s.customCourse(ctx, userId, courseId)
+	if err != nil {
+		return 0, err
+	}
+
+	details, err := s.r.GetLatestCourseDetails(ctx, c.Id, markerId)
+	if err != nil {
+		return 0, err
+	}
+
+	return details.Id, nil
+}
+
+// courseById gets the course with its tee sets, shown with the preferences of the user.
+func (s *service) courseById(ctx context.Context, c *models.Course, prefs *preferences) (*api.Course, error) {
+	teeSets, err := s.r.GetCourseTeeSets(ctx, c.Id)
+	if err != nil {
+		return nil, fmt.Errorf("error getting tee sets: %w", err)
+	}
+
+	respCourse := &api.Course{
+		Id:      int64(c.Id),
+		Name:    c.Name,
+		Details: make([]api.CourseDetails, 0, len(teeSets.Items)),
+	}
+
+	for _, d := range teeSets.Items {
+		holes, err := s.r.GetCourseDetailsHoles(ctx, d.Id)
+		if err != nil {
+			return nil, fmt.Errorf("error getting holes: %w", err)
+		}
+
+		respCourse.Details = append(respCourse.Details, *modelDetailsAsApiDetails(d, holes.Items))
+	}
+
+	prefs.applyToCourse(respCourse)
+
+	return respCourse, nil
+}
+
+func (s *service) customCourseAsTeeSets(course *api.CustomCourse) ([]*repo.TeeSet, error) {
+	if course.Name == "" {
+		return nil, errors.New("name is required")
+	} else if len(course.Details) == 0 {
+		return nil, errors.New("details are required")
+	}
+
+	teeSets := make([]*repo.TeeSet, 0, len(course.Details))
+	for _, d := range course.Details {
+		details, err := s.detailsAsModel(&d)
+		if err != nil {
+			return nil, err
+		}
+
+		err = validateHoles(d.Holes)
+		if err != nil {
+			return nil, err
+		}
+
+		holes := make([]*models.Hole, 0, len(d.Holes))
+		for _, h := range d.Holes {
+			hole, err := s.holeAsModel(&h)
+			if err != nil {
+				return nil, err
+			}
+			holes = append(holes, hole)
+		}
+
+		teeSets = append(teeSets, &repo.TeeSet{
+			Details: details,
+			Holes:   holes,
+		})
+	}
+
+	return teeSets, nil
+}
+
+// validateHoles validates that the holes make up a nine or eighteen hole course, with each hole number and stroke
+// index used once.
+func validateHoles(holes []api.Hole) error {
+	if len(holes) != 9 && len(holes) != 18 {
+		return errors.New("a course must have 9 or 18 holes")
+	}
+
+	numbers := make(map[int64]bool, len(holes))
+	strokes := make(map[int64]bool, len(holes))
+	for _, h := range holes {
+		if h.Number == nil || *h.Number < 1 || *h.Number > int64(len(holes)) {
+			return fmt.Errorf("hole number must be between 1 and %d", len(holes))
+		} else if numbers[*h.Number] {
+			return fmt.Errorf("hole number %d is duplicated", *h.Number)
+		}
+		numbers[*h.Number] = true
+
+		if h.StrokeIndex == nil || *h.StrokeIndex < 1 || *h.StrokeIndex > 18 {
+			return errors.New("stroke_index must be between 1 and 18")
+		} else if strokes[*h.StrokeIndex] {
+			return fmt.Errorf("stroke_index %d is duplicated", *h.StrokeIndex)
+		}
+		strokes[*h.StrokeIndex] = true
+	}
+
+	return nil
+}
+
+func modelDetailsAsApiDetails(details *models.CourseDetails, holes []*models.Hole) *api.CourseDetails {
+	respHoles := make([]api.Hole, len(holes))
+	for i, h := range holes {
+		respHoles[i] = *modelHoleAsApiRoundHole(h)
+	}
+
+	return &api.CourseDetails{
+		Id:               int64(details.ExternalId),
+		Marker:           utils.Ptr(details.Marker.String),
+		Slope:            utils.Ptr(int64(details.Slope)),
+		Rating:           utils.Ptr(details.CourseRating),
+		ParFrontNine:     utils.Ptr(int64(details.FrontNinePar)),
+		ParBackNine:      utils.Ptr(int64(details.BackNinePar)),
+		ParTotal:         utils.Ptr(int64(details.TotalPar)),
+		YardageFrontNine: utils.Ptr(int64(details.FrontNineYards)),
+		YardageBackNine:  utils.Ptr(int64(details.BackNineYards)),
+		YardageTotal:     utils.Ptr(int64(details.TotalYards)),
+		MetersFrontNine:  utils.Ptr(int64(details.FrontNineMeters)),
+		MetersBackNine:   utils.Ptr(int64(details.BackNineMeters)),
+		MetersTotal:      utils.Ptr(int64(details.TotalMeters)),
+		Holes:            respHoles,
+	}
+}
@@ -1,6 +1,7 @@
 package rounder
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
@@ -12,6 +13,7 @@ import (
 	api "github.com/Jacobbrewer1/golf-stats-tracker/pkg/codegen/apis/rounder"
 	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/logging"
 	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/models"
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/policy"
 	repo "github.com/Jacobbrewer1/golf-stats-tracker/pkg/repositories/rounder"
 	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/utils"
 	usql "github.com/Jacobbrewer1/golf-stats-tracker/pkg/utils/sql"
@@ -20,9 +22,9 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 )
 
-func (s *service) GetRoundHoles(w http.ResponseWriter, r *http.Request, roundId api.PathRoundId) {
+func (s *service) GetRoundHoles(w http.ResponseWriter, r *http.Request, roundId api.PathRoundId, params api.GetRoundHolesParams) {
 	// Get the round by the ID.
-	round, err := s.r.GetRoundById(int(roundId))
+	round, err := s.r.GetRoundById(r.Context(), int(roundId))
 	if err != nil {
 		switch {
 		case errors.Is(err, sql.ErrNoRows):
@@ -33,36 +35,45 @@ func (s *service) GetRoundHoles(w http.ResponseWriter, r *http.Request, roundId
 			uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error getting round", err)
 			return
 		}
-	} else if round.UserId != utils.UserIdFromContext(r.Context()) {
-		uhttp.SendMessageWithStatus(w, http.StatusForbidden, "round not found")
+	}
+
+	err = s.policy.AuthorizeRound(r.Context(), utils.UserIdFromContext(r.Context()), round, policy.ActionRead)
+	if err != nil {
+		sendPolicyError(w, err, "round not found")
 		return
 	}
 
-	// Get the holes for the round.
-	holes, err := s.r.GetRoundHoles(round.Id)
+	// Get the page of holes for the round.
+	details, err := paginationDetails(params.Limit, params.LastVal, params.LastId, params.SortBy, params.SortDir)
 	if err != nil {
-		switch {
-		case errors.Is(err, repo.ErrNoHolesFound):
-			holes = &repo.PaginationResponse[models.Hole]{
-				Items: make([]*models.Hole, 0),
-				Total: 0,
-			}
-		default:
-			slog.Error("Error getting holes", slog.String(logging.KeyError, err.Error()))
-			uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error getting holes", err)
-			return
-		}
+		sendPaginationError(w, err, "error getting holes")
+		return
+	}
+
+	holes, err := s.r.GetRoundHoles(r.Context(), round.Id, details)
+	if err != nil {
+		sendPaginationError(w, err, "error getting holes")
+		return
+	}
+
+	prefs, err := s.userPreferences(r.Context(), utils.UserIdFromContext(r.Context()))
+	if err != nil {
+		slog.Error("error getting preferences", slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error getting preferences", err)
+		return
 	}
 
 	// Map the holes to the API model.
 	respHoles := make([]api.Hole, len(holes.Items))
 	for i, hole := range holes.Items {
 		respHoles[i] = *modelHoleAsApiRoundHole(hole)
+		prefs.applyToHole(&respHoles[i])
 	}
 
 	resp := &api.HolesResponse{
 		Holes: respHoles,
 		Total: holes.Total,
+		Next:  pageCursorAsApi(holes.Next),
 	}
 
 	err = uhttp.Encode(w, http.StatusOK, resp)
@@ -85,7 +96,7 @@ func modelHoleAsApiRoundHole(hole *models.Hole) *api.Hole {
 
 func (s *service) GetHoleStats(w http.ResponseWriter, r *http.Request, roundId api.PathRoundId, holeId api.PathHoleId) {
 	// Get the round by the ID.
-	round, err := s.r.GetRoundById(int(roundId))
+	round, err := s.r.GetRoundById(r.Context(), int(roundId))
 	if err != nil {
 		switch {
 		case errors.Is(err, sql.ErrNoRows):
@@ -97,13 +108,16 @@ func (s *service) GetHoleStats(w http.ResponseWriter, r *http.Request, roundId a
 			uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error getting round", err)
 			return
 		}
-	} else if round.UserId != utils.UserIdFromContext(r.Context()) {
-		uhttp.SendMessageWithStatus(w, http.StatusForbidden, "round not found")
+	}
+
+	err = s.policy.AuthorizeRound(r.Context(), utils.UserIdFromContext(r.Context()), round, policy.ActionRead)
+	if err != nil {
+		sendPolicyError(w, err, "round not found")
 		return
 	}
 
 	// Get the hole by the ID.
-	hole, err := s.r.GetHoleById(int(holeId))
+	hole, err := s.r.GetHoleById(r.Context(), int(holeId))
 	if err != nil {
 		switch {
 		case errors.Is(err, sql.ErrNoRows):
@@ -115,14 +129,18 @@ func (s *service) GetHoleStats(w http.ResponseWriter, r *http.Request, roundId a
 			uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error getting hole", err)
 			return
 		}
+	} else if hole.CourseDetailsId != round.CourseDetailsId {
+		uhttp.SendMessageWithStatus(w, http.StatusNotFound, "hole not found")
+		return
 	}
 
 	// Get the stats for the hole.
-	stats, err := s.r.GetHoleStatsByHoleId(hole.Id)
+	stats, err := s.r.GetHoleStatsByRoundAndHoleId(r.Context(), round.Id, hole.Id)
 	if err != nil {
 		switch {
 		case errors.Is(err, repo.ErrNoStatsFound):
 			stats = new(models.HoleStats)
+			stats.RoundId = round.Id
 			stats.HoleId = hole.Id
 		default:
 			slog.Error("Error getting hole stats", slog.String(logging.KeyError, err.Error()))
@@ -159,7 +177,7 @@ func (s *service) UpdateHoleStats(w http.ResponseWriter, r *http.Request, roundI
 	}
 
 	// Get the round by the ID.
-	round, err := s.r.GetRoundById(int(roundId))
+	round, err := s.r.GetRoundById(r.Context(), int(roundId))
 	if err != nil {
 		switch {
 		case errors.Is(err, sql.ErrNoRows):
@@ -170,13 +188,16 @@ func (s *service) UpdateHoleStats(w http.ResponseWriter, r *http.Request, roundI
 			uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error getting round", err)
 			return
 		}
-	} else if round.UserId != utils.UserIdFromContext(r.Context()) {
-		uhttp.SendMessageWithStatus(w, http.StatusForbidden, "round not found")
+	}
+
+	err = s.policy.AuthorizeRound(r.Context(), utils.UserIdFromContext(r.Context()), round, policy.ActionWrite)
+	if err != nil {
+		sendPolicyError(w, err, "round not found")
 		return
 	}
 
 	// Get the hole by the ID.
-	hole, err := s.r.GetHoleById(int(holeId))
+	hole, err := s.r.GetHoleById(r.Context(), int(holeId))
 	if err != nil {
 		switch {
 		case errors.Is(err, sql.ErrNoRows):
@@ -187,6 +208,9 @@ func (s *service) UpdateHoleStats(w http.ResponseWriter, r *http.Request, roundI
 			uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error getting hole", err)
 			return
 		}
+	} else if hole.CourseDetailsId != round.CourseDetailsId {
+		uhttp.SendMessageWithStatus(w, http.StatusNotFound, "hole not found")
+		return
 	}
 
 	// Decode the request body into the API model.
@@ -199,11 +223,12 @@ func (s *service) UpdateHoleStats(w http.ResponseWriter, r *http.Request, roundI
 	}
 
 	// Get the stats for the hole.
-	stats, err := s.r.GetHoleStatsByHoleId(hole.Id)
+	stats, err := s.r.GetHoleStatsByRoundAndHoleId(r.Context(), round.Id, hole.Id)
 	if err != nil {
 		switch {
 		case errors.Is(err, repo.ErrNoStatsFound):
 			stats = new(models.HoleStats)
+			stats.RoundId = round.Id
 			stats.HoleId = hole.Id
 		default:
 			slog.Error("Error getting hole stats", slog.String(logging.KeyError, err.Error()))
@@ -221,7 +246,7 @@ func (s *service) UpdateHoleStats(w http.ResponseWriter, r *http.Request, roundI
 	}
 
 	// Is there any difference between the existing stats and the new stats?
-	opts := cmpopts.IgnoreFields(models.HoleStats{}, "Id", "HoleId")
+	opts := cmpopts.IgnoreFields(models.HoleStats{}, "Id", "RoundId", "HoleId")
 	if diff := cmp.Diff(stats, newStats, opts); diff == "" {
 		slog.Debug("hole stats are the same", slog.Int("round_id", round.Id), slog.Int("hole_id", hole.Id))
 	} else {
@@ -230,18 +255,29 @@ func (s *service) UpdateHoleStats(w http.ResponseWriter, r *http.Request, roundI
 		if stats.Id != 0 {
 			newStats.Id = stats.Id
 		}
+		newStats.RoundId = stats.RoundId
 		newStats.HoleId = stats.HoleId
 
-		err = s.r.SaveHoleStats(newStats)
+		err = s.r.SaveHoleStats(r.Context(), newStats)
 		if err != nil {
 			slog.Error("Error saving hole stats", slog.String(logging.KeyError, err.Error()))
 			uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error saving hole stats", err)
 			return
 		}
+
+		s.audit(r, auditEvent{
+			actorId:    utils.UserIdFromContext(r.Context()),
+			action:     auditActionHoleStatsUpdate,
+			targetType: auditTargetRound,
+			targetId:   round.Id,
+			details:    fmt.Sprintf("hole %d\n%s", hole.Id, diff),
+		})
 	}
 
+	// The stats are calculated after the response is sent, so they must not be cancelled with the request.
+	ctx := context.WithoutCancel(r.Context())
 	go func() {
-		csErr := s.calculateStats(round.UserId, round.Id)
+		csErr := s.calculateStats(ctx, round.UserId, round.Id)
 		if csErr != nil {
 			slog.Error("Error calculating stats", slog.String(logging.KeyError, csErr.Error()))
 		}
@@ -254,9 +290,9 @@ func (s *service) UpdateHoleStats(w http.ResponseWriter, r *http.Request, roundI
 	}
 }
 
-func (s *service) calculateStats(userId int, roundId int) error {
+func (s *service) calculateStats(ctx context.Context, userId int, roundId int) error {
 	// Get the line chart data.
-	roundData, err := s.r.GetStatsByRoundId(userId, roundId)
+	roundData, err := s.r.GetStatsByRoundId(ctx, userId, roundId)
 	if err != nil {
 		return fmt.Errorf("error getting line chart data: %w", err)
 	}
@@ -319,12 +355,12 @@ func (s *service) calculateStats(userId int, roundId int) error {
 		AvgPar5:        avgPar5,
 	}
 
-	err = s.r.SaveRoundStats(m)
+	err = s.r.SaveRoundStats(ctx, m)
 	if err != nil {
 		return fmt.Errorf("error saving round stats: %w", err)
 	}
 
-	err = s.calculatePieStats(roundId)
+	err = s.calculatePieStats(ctx, roundId)
 	if err != nil {
 		return fmt.Errorf("error calculating pie stats: %w", err)
 	}
@@ -332,13 +368,13 @@ func (s *service) calculateStats(userId int, roundId int) error {
 	return nil
 }
 
-func (s *service) calculatePieStats(roundId int) error {
-	holes, err := s.r.GetRoundHoles(roundId)
+func (s *service) calculatePieStats(ctx context.Context, roundId int) error {
+	holes, err := s.r.GetRoundHoles(ctx, roundId, nil)
 	if err != nil {
 		return fmt.Errorf("error getting holes: %w", err)
 	}
 
-	roundStats, err := s.r.GetRoundStatsByRoundId(roundId)
+	roundStats, err := s.r.GetRoundStatsByRoundId(ctx, roundId)
 	if err != nil {
 		return fmt.Errorf("error getting round stats: %w", err)
 	}
@@ -347,7 +383,7 @@ func (s *service) calculatePieStats(roundId int) error {
 	greenData := make(map[string]int)
 
 	for _, h := range holes.Items {
-		stats, err := s.r.GetHoleStatsByHoleId(h.Id)
+		stats, err := s.r.GetHoleStatsByRoundAndHoleId(ctx, roundId, h.Id)
 		if err != nil {
 			return fmt.Errorf("error getting hole stats: %w", err)
 		}
@@ -356,7 +392,7 @@ func (s *service) calculatePieStats(roundId int) error {
 		greenData[string(stats.GreenHit)] += 1
 	}
 
-	currentHitStats, err := s.r.GetRoundHitStatsByRoundStatsId(roundId)
+	currentHitStats, err := s.r.GetRoundHitStatsByRoundStatsId(ctx, roundId)
 	if err != nil {
 		return fmt.Errorf("error getting current hit stats: %w", err)
 	}
@@ -416,12 +452,12 @@ func (s *service) calculatePieStats(roundId int) error {
 
 	wg.Wait()
 
-	err = s.r.SaveRoundHitStats(fairwayModel...)
+	err = s.r.SaveRoundHitStats(ctx, fairwayModel...)
 	if err != nil {
 		return fmt.Errorf("error saving fairway stats: %w", err)
 	}
 
-	err = s.r.SaveRoundHitStats(greenModel...)
+	err = s.r.SaveRoundHitStats(ctx, greenModel...)
 	if err != nil {
 		return fmt.Errorf("error saving green stats: %w", err)
 	}
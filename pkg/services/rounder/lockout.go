@@ -0,0 +1,53 @@
+package rounder
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/auth"
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/utils"
+	"github.com/Jacobbrewer1/uhttp"
+)
+
+// errLockedOut is returned by the rate limiter when the client is locked out.
+var errLockedOut = errors.New("too many failed attempts")
+
+// sendLockedOut writes a 429 response telling the client how long to wait before trying again.
+func sendLockedOut(w http.ResponseWriter, lockedFor time.Duration) {
+	w.Header().Set("Retry-After", strconv.Itoa(ceilSeconds(lockedFor)))
+	uhttp.SendMessageWithStatus(w, http.StatusTooManyRequests, "too many failed attempts, try again later")
+}
+
+// usernameLockoutKey returns the lockout key for the username.
+func usernameLockoutKey(username string) string {
+	return "user:" + strings.ToLower(username)
+}
+
+// ipLockoutKey returns the lockout key for the IP address of the request.
+func ipLockoutKey(r *http.Request) string {
+	return "ip:" + clientIP(r)
+}
+
+// ClientIPMiddleware adds the IP address of the client to the context of each request, reading X-Forwarded-For only
+// if the request came from one of the trusted proxies.
+func ClientIPMiddleware(proxies auth.TrustedProxies) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r.WithContext(utils.ClientIPToContext(r.Context(), proxies.ClientIP(r))))
+		})
+	}
+}
+
+// clientIP returns the IP address of the client that made the request, as found by ClientIPMiddleware. The address
+// that the request came from is used if the middleware has not run, as X-Forwarded-For cannot be trusted without
+// knowing the proxies.
+func clientIP(r *http.Request) string {
+	if ip := utils.ClientIPFromContext(r.Context()); ip != "" {
+		return ip
+	}
+
+	return auth.TrustedProxies(nil).ClientIP(r)
+}
@@ -2,51 +2,205 @@ package rounder
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
 	"strings"
+	"time"
 
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/auth"
 	api "github.com/Jacobbrewer1/golf-stats-tracker/pkg/codegen/apis/rounder"
 	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/logging"
-	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/utils"
+	repo "github.com/Jacobbrewer1/golf-stats-tracker/pkg/repositories/rounder"
 	"github.com/Jacobbrewer1/uhttp"
-	"github.com/Jacobbrewer1/vaulty"
 )
 
 func (s *service) Login(w http.ResponseWriter, r *http.Request) {
-	username, password, ok := r.BasicAuth()
+	creds, ok := loginCredentials(r)
 	if !ok {
-		slog.Debug("basic auth not provided")
-		uhttp.SendMessageWithStatus(w, http.StatusUnauthorized, "basic auth required")
+		slog.Debug("login credentials not provided")
+		uhttp.SendMessageWithStatus(w, http.StatusUnauthorized, "username and password required")
+		return
+	} else if creds.Challenge != nil {
+		s.completeLoginChallenge(w, r, creds)
+		return
+	}
+	username, password := *creds.Username, *creds.Password
+
+	userKey := usernameLockoutKey(username)
+	if lockedFor := s.lockout.LockedFor(userKey); lockedFor > 0 {
+		sendLockedOut(w, lockedFor)
 		return
 	}
 
 	// Get the user from the database
-	user, err := s.r.UserByUsername(strings.ToLower(username))
+	user, err := s.r.UserByUsername(r.Context(), strings.ToLower(username))
 	if err != nil {
-		uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error getting user", err)
+		switch {
+		case errors.Is(err, repo.ErrUserNotFound):
+			s.lockout.Failure(userKey, ipLockoutKey(r))
+			s.audit(r, auditEvent{
+				action:  auditActionLoginFailure,
+				details: fmt.Sprintf("unknown username %q", username),
+			})
+			uhttp.SendMessageWithStatus(w, http.StatusUnauthorized, "invalid username or password")
+		default:
+			uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error getting user", err)
+		}
 		return
 	}
 
 	// Check the password
-	err = s.checkPassword(r.Context(), password, user.Password)
+	needsRehash, err := s.checkPassword(r.Context(), user.Id, password, user.Password)
 	if err != nil {
 		slog.Debug("error checking password", slog.String(logging.KeyError, err.Error()))
+		s.lockout.Failure(userKey, ipLockoutKey(r))
+		s.audit(r, auditEvent{
+			actorId:    user.Id,
+			action:     auditActionLoginFailure,
+			targetType: auditTargetUser,
+			targetId:   user.Id,
+			details:    "invalid password",
+		})
 		uhttp.SendMessageWithStatus(w, http.StatusUnauthorized, "invalid username or password")
 		return
 	}
 
-	mockRequest, err := http.NewRequest(http.MethodPost, "http://localhost:8200/v1/auth/token/lookup-self", nil)
+	factor, err := s.checkSecondFactor(r.Context(), user.Id, creds.TotpCode, creds.RecoveryCode)
+	if err != nil {
+		switch {
+		case errors.Is(err, errSecondFactorRequired):
+			uhttp.SendMessageWithStatus(w, http.StatusUnauthorized, "two-factor code required")
+		case errors.Is(err, errInvalidSecondFactor):
+			s.lockout.Failure(userKey, ipLockoutKey(r))
+			s.audit(r, auditEvent{
+				actorId:    user.Id,
+				action:     auditActionLoginFailure,
+				targetType: auditTargetUser,
+				targetId:   user.Id,
+				details:    "invalid two-factor code",
+			})
+			uhttp.SendMessageWithStatus(w, http.StatusUnauthorized, "invalid two-factor code")
+		default:
+			slog.Error("error checking two-factor code", slog.String(logging.KeyError, err.Error()))
+			uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error checking two-factor code", err)
+		}
+		return
+	}
+
+	if needsRehash {
+		s.rehashPassword(r.Context(), user.Id, password)
+	}
+
+	details := "password"
+	if factor != "" {
+		details += " and " + factor
+	}
+
+	// Only the username is cleared, so that logging in to one account does not reset the failures of the IP address.
+	s.lockout.Success(userKey)
+	s.audit(r, auditEvent{
+		actorId:    user.Id,
+		action:     auditActionLoginSuccess,
+		targetType: auditTargetUser,
+		targetId:   user.Id,
+		details:    details,
+	})
+
+	t, err := s.startSession(r, user.Id)
+	if err != nil {
+		slog.Error("error starting session", slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error starting session", err)
+		return
+	}
+
+	err = uhttp.Encode(w, http.StatusOK, t)
+	if err != nil {
+		uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error encoding response", err)
+		return
+	}
+}
+
+// completeLoginChallenge logs in the user of a login challenge once they have sent their two-factor code. The
+// challenge is used up by any attempt, so a wrong code means the user has to start the login again.
+func (s *service) completeLoginChallenge(w http.ResponseWriter, r *http.Request, creds *api.LoginJSONRequestBody) {
+	if (creds.TotpCode == nil || *creds.TotpCode == "") && (creds.RecoveryCode == nil || *creds.RecoveryCode == "") {
+		uhttp.SendMessageWithStatus(w, http.StatusUnauthorized, "two-factor code required")
+		return
+	}
+
+	challenge, err := s.r.ConsumeLoginChallenge(r.Context(), auth.HashOpaqueToken(*creds.Challenge))
+	if err != nil {
+		switch {
+		case errors.Is(err, repo.ErrLoginChallengeNotFound):
+			s.lockout.Failure(ipLockoutKey(r))
+			uhttp.SendMessageWithStatus(w, http.StatusUnauthorized, "invalid or expired challenge")
+		default:
+			slog.Error("error getting login challenge", slog.String(logging.KeyError, err.Error()))
+			uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error getting login challenge", err)
+		}
+		return
+	}
+
+	if !time.Now().UTC().Before(challenge.ExpiresAt) {
+		uhttp.SendMessageWithStatus(w, http.StatusUnauthorized, "invalid or expired challenge")
+		return
+	}
+
+	user, err := s.r.GetUserById(r.Context(), challenge.UserId)
 	if err != nil {
-		uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error creating request", err)
+		slog.Error("error getting user", slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error getting user", err)
 		return
 	}
-	mockRequest.SetBasicAuth(user.Username, password)
 
-	t := new(api.Token)
-	// Trim the Basic prefix from the Authorization header
-	t.Token = utils.Ptr(strings.TrimPrefix(mockRequest.Header.Get("Authorization"), "Basic "))
+	userKey := usernameLockoutKey(user.Username)
+	if lockedFor := s.lockout.LockedFor(userKey); lockedFor > 0 {
+		sendLockedOut(w, lockedFor)
+		return
+	}
+
+	factor, err := s.checkSecondFactor(r.Context(), user.Id, creds.TotpCode, creds.RecoveryCode)
+	if err != nil {
+		switch {
+		case errors.Is(err, errInvalidSecondFactor):
+			s.lockout.Failure(userKey, ipLockoutKey(r))
+			s.audit(r, auditEvent{
+				actorId:    user.Id,
+				action:     auditActionLoginFailure,
+				targetType: auditTargetUser,
+				targetId:   user.Id,
+				details:    "invalid two-factor code",
+			})
+			uhttp.SendMessageWithStatus(w, http.StatusUnauthorized, "invalid two-factor code")
+		default:
+			slog.Error("error checking two-factor code", slog.String(logging.KeyError, err.Error()))
+			uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error checking two-factor code", err)
+		}
+		return
+	}
+
+	details := challenge.Details
+	if factor != "" {
+		details += " and " + factor
+	}
+
+	s.lockout.Success(userKey)
+	s.audit(r, auditEvent{
+		actorId:    user.Id,
+		action:     auditActionLoginSuccess,
+		targetType: auditTargetUser,
+		targetId:   user.Id,
+		details:    details,
+	})
+
+	t, err := s.startSession(r, user.Id)
+	if err != nil {
+		slog.Error("error starting session", slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error starting session", err)
+		return
+	}
 
 	err = uhttp.Encode(w, http.StatusOK, t)
 	if err != nil {
@@ -55,17 +209,66 @@ func (s *service) Login(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-func (s *service) checkPassword(ctx context.Context, password, hashedPassword string) error {
-	unhashedPassword, err := s.vc.Path(
-		s.vip.GetString("vault.transit.key"),
-		vaulty.WithPrefix(s.vip.GetString("vault.transit.name")),
-	).TransitDecrypt(ctx, hashedPassword)
+// loginCredentials gets the credentials from the request body, falling back to basic auth for clients that still
+// send the username and password in the Authorization header. Two-factor codes can only be sent in the body.
+func loginCredentials(r *http.Request) (*api.LoginJSONRequestBody, bool) {
+	if r.Body != nil && r.Body != http.NoBody {
+		body := new(api.LoginJSONRequestBody)
+		err := uhttp.DecodeRequestJSON(r, body)
+		if err == nil && ((body.Username != nil && body.Password != nil) || body.Challenge != nil) {
+			return body, true
+		}
+	}
+
+	username, password, ok := r.BasicAuth()
+	if !ok {
+		return nil, false
+	}
+
+	return &api.LoginJSONRequestBody{
+		Username: &username,
+		Password: &password,
+	}, true
+}
+
+// checkPassword checks the password against the stored password of the user. Passwords that were recently verified
+// are accepted without decrypting the stored password again. It also returns whether the stored hash should be
+// replaced because it was created with an older algorithm or weaker parameters.
+func (s *service) checkPassword(ctx context.Context, userId int, password, hashedPassword string) (bool, error) {
+	if s.credentials.Verified(userId, password, hashedPassword) {
+		return false, nil
+	}
+
+	unhashedPassword, err := s.encrypter.Decrypt(ctx, hashedPassword)
+	if err != nil {
+		return false, fmt.Errorf("error decrypting password: %w", err)
+	}
+
+	ok, err := s.passwords.Verify(unhashedPassword, password)
+	if err != nil {
+		return false, fmt.Errorf("error verifying password: %w", err)
+	} else if !ok {
+		return false, fmt.Errorf("invalid password")
+	}
+
+	s.credentials.Add(userId, password, hashedPassword)
+	return s.passwords.NeedsRehash(unhashedPassword), nil
+}
+
+// rehashPassword replaces the stored hash of the password with one created with the current algorithm and parameters.
+// Failures are only logged, as the user has already been authenticated and the old hash is still valid.
+func (s *service) rehashPassword(ctx context.Context, userId int, password string) {
+	encryptedPassword, err := s.encryptPassword(ctx, password)
 	if err != nil {
-		return fmt.Errorf("error decrypting password: %w", err)
+		slog.Error("error rehashing password", slog.String(logging.KeyError, err.Error()))
+		return
 	}
 
-	if !utils.ComparePassword(unhashedPassword, password) {
-		return fmt.Errorf("invalid password")
+	err = s.r.UpdateUserPasswordHash(ctx, userId, encryptedPassword)
+	if err != nil {
+		slog.Error("error updating password hash", slog.String(logging.KeyError, err.Error()))
+		return
 	}
-	return nil
+
+	s.credentials.Invalidate(userId)
 }
@@ -0,0 +1,444 @@
+package rounder
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/auth"
+	api "github.com/Jacobbrewer1/golf-stats-tracker/pkg/codegen/apis/rounder"
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/logging"
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/models"
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/oidc"
+	repo "github.com/Jacobbrewer1/golf-stats-tracker/pkg/repositories/rounder"
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/utils"
+	usql "github.com/Jacobbrewer1/golf-stats-tracker/pkg/utils/sql"
+	"github.com/Jacobbrewer1/uhttp"
+)
+
+const (
+	// defaultOidcLoginTTL is the default time the user has to authenticate with the provider.
+	defaultOidcLoginTTL = 10 * time.Minute
+
+	// oidcStateCookie is the cookie that ties an OIDC login to the browser that started it.
+	oidcStateCookie = "rounder_oidc_state"
+
+	// maxUsernameSuffix is the highest number appended to the username of a new OIDC user whose username is taken.
+	maxUsernameSuffix = 100
+
+	// maxUsernameLength is the maximum length of a username.
+	maxUsernameLength = 100
+
+	// maxNameLength is the maximum length of the name of a user.
+	maxNameLength = 50
+)
+
+func (s *service) StartOidcLogin(w http.ResponseWriter, r *http.Request, providerName api.PathOidcProvider) {
+	authURL, err := s.beginOidcLogin(w, r, providerName, 0)
+	if err != nil {
+		sendOidcError(w, err)
+		return
+	}
+
+	http.Redirect(w, r, authURL, http.StatusFound)
+}
+
+func (s *service) LinkIdentity(w http.ResponseWriter, r *http.Request, providerName api.PathOidcProvider) {
+	authURL, err := s.beginOidcLogin(w, r, providerName, utils.UserIdFromContext(r.Context()))
+	if err != nil {
+		sendOidcError(w, err)
+		return
+	}
+
+	err = uhttp.Encode(w, http.StatusOK, &api.OidcAuthorization{
+		AuthorizationUrl: authURL,
+	})
+	if err != nil {
+		slog.Error("error encoding response", slog.String(logging.KeyError, err.Error()))
+		return
+	}
+}
+
+func (s *service) OidcCallback(w http.ResponseWriter, r *http.Request, providerName api.PathOidcProvider, params api.OidcCallbackParams) {
+	provider, ok := s.oidcProviders[providerName]
+	if !ok {
+		uhttp.SendMessageWithStatus(w, http.StatusNotFound, "provider not found")
+		return
+	}
+
+	// The state must come from the browser that started the login, otherwise an attacker could complete a login they
+	// started themselves in the browser of someone else and sign them in to, or link, the account of the attacker.
+	cookie, err := r.Cookie(oidcStateCookie)
+	if err != nil || subtle.ConstantTimeCompare([]byte(cookie.Value), []byte(params.State)) != 1 {
+		s.lockout.Failure(ipLockoutKey(r))
+		uhttp.SendMessageWithStatus(w, http.StatusBadRequest, "invalid or expired state")
+		return
+	}
+
+	http.SetCookie(w, oidcCookie(r, "", -1))
+
+	// Consume the login first so that the state cannot be used again, even if the provider returned an error.
+	login, err := s.r.ConsumeOidcLogin(r.Context(), auth.HashOpaqueToken(params.State))
+	if err != nil {
+		switch {
+		case errors.Is(err, repo.ErrOidcLoginNotFound):
+			s.lockout.Failure(ipLockoutKey(r))
+			uhttp.SendMessageWithStatus(w, http.StatusBadRequest, "invalid or expired state")
+		default:
+			slog.Error("error getting oidc login", slog.String(logging.KeyError, err.Error()))
+			uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error getting oidc login", err)
+		}
+		return
+	}
+
+	if login.Provider != providerName || !time.Now().UTC().Before(login.ExpiresAt) {
+		uhttp.SendMessageWithStatus(w, http.StatusBadRequest, "invalid or expired state")
+		return
+	}
+
+	if params.Error != nil {
+		uhttp.SendMessageWithStatus(w, http.StatusUnauthorized, fmt.Sprintf("provider returned an error: %s", *params.Error))
+		return
+	} else if params.Code == nil || *params.Code == "" {
+		uhttp.SendMessageWithStatus(w, http.StatusBadRequest, "code is required")
+		return
+	}
+
+	identity, err := provider.Exchange(r.Context(), *params.Code, login.CodeVerifier, login.Nonce)
+	if err != nil {
+		slog.Debug("error exchanging authorization code", slog.String(logging.KeyError, err.Error()))
+		s.lockout.Failure(ipLockoutKey(r))
+		s.audit(r, auditEvent{
+			action:  auditActionLoginFailure,
+			details: fmt.Sprintf("oidc provider %s", providerName),
+		})
+		uhttp.SendMessageWithStatus(w, http.StatusUnauthorized, "failed to authenticate with provider")
+		return
+	}
+
+	var linkUserId int
+	if login.UserId.Valid {
+		linkUserId = int(login.UserId.Int64)
+	}
+
+	userId, err := s.oidcUser(r, providerName, identity, linkUserId)
+	if err != nil {
+		var httpErr *utils.HttpError
+		switch {
+		case errors.As(err, &httpErr):
+			uhttp.SendMessageWithStatus(w, httpErr.Code, httpErr.Message)
+		default:
+			slog.Error("error getting oidc user", slog.String(logging.KeyError, err.Error()))
+			uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error getting user", err)
+		}
+		return
+	}
+
+	details := fmt.Sprintf("oidc provider %s", providerName)
+
+	// The provider only replaces the password, users with two-factor authentication must still send a code.
+	_, err = s.checkSecondFactor(r.Context(), userId, nil, nil)
+	switch {
+	case errors.Is(err, errSecondFactorRequired):
+		challenge, err := s.createLoginChallenge(r.Context(), userId, details)
+		if err != nil {
+			slog.Error("error creating login challenge", slog.String(logging.KeyError, err.Error()))
+			uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error creating login challenge", err)
+			return
+		}
+
+		err = uhttp.Encode(w, http.StatusUnauthorized, challenge)
+		if err != nil {
+			slog.Error("error encoding response", slog.String(logging.KeyError, err.Error()))
+		}
+		return
+	case err != nil:
+		slog.Error("error checking two-factor code", slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error checking two-factor code", err)
+		return
+	}
+
+	s.audit(r, auditEvent{
+		actorId:    userId,
+		action:     auditActionLoginSuccess,
+		targetType: auditTargetUser,
+		targetId:   userId,
+		details:    details,
+	})
+
+	t, err := s.startSession(r, userId)
+	if err != nil {
+		slog.Error("error starting session", slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error starting session", err)
+		return
+	}
+
+	err = uhttp.Encode(w, http.StatusOK, t)
+	if err != nil {
+		slog.Error("error encoding response", slog.String(logging.KeyError, err.Error()))
+		return
+	}
+}
+
+func (s *service) GetIdentities(w http.ResponseWriter, r *http.Request) {
+	identities, err := s.r.GetUserIdentitiesByUserId(r.Context(), utils.UserIdFromContext(r.Context()))
+	if err != nil {
+		slog.Error("error getting identities", slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error getting identities", err)
+		return
+	}
+
+	resp := &api.IdentitiesResponse{
+		Identities: make([]api.Identity, 0, len(identities.Items)),
+		Total:      identities.Total,
+	}
+
+	for _, identity := range identities.Items {
+		apiIdentity := api.Identity{
+			Id:        utils.Ptr(int64(identity.Id)),
+			Provider:  utils.Ptr(identity.Provider),
+			CreatedAt: utils.Ptr(identity.CreatedAt),
+		}
+
+		if identity.Email.Valid {
+			apiIdentity.Email = utils.Ptr(identity.Email.String)
+		}
+
+		if identity.LastLoginAt.Valid {
+			apiIdentity.LastLoginAt = utils.Ptr(identity.LastLoginAt.Time)
+		}
+
+		resp.Identities = append(resp.Identities, apiIdentity)
+	}
+
+	err = uhttp.Encode(w, http.StatusOK, resp)
+	if err != nil {
+		slog.Error("error encoding response", slog.String(logging.KeyError, err.Error()))
+		return
+	}
+}
+
+// beginOidcLogin creates the login that is waiting for the user to return from the provider and returns the URL to
+// send the user to. If the user ID is set, the identity is linked to that user when the user returns.
+func (s *service) beginOidcLogin(w http.ResponseWriter, r *http.Request, providerName string, userId int) (string, error) {
+	provider, ok := s.oidcProviders[providerName]
+	if !ok {
+		return "", utils.NewHttpError(http.StatusNotFound, "provider not found")
+	}
+
+	state, stateHash, err := auth.NewOpaqueToken()
+	if err != nil {
+		return "", fmt.Errorf("error creating state: %w", err)
+	}
+
+	nonce, _, err := auth.NewOpaqueToken()
+	if err != nil {
+		return "", fmt.Errorf("error creating nonce: %w", err)
+	}
+
+	verifier, err := oidc.NewCodeVerifier()
+	if err != nil {
+		return "", err
+	}
+
+	ttl := defaultOidcLoginTTL
+	if s.vip.IsSet("auth.oidc.login_ttl") {
+		ttl = s.vip.GetDuration("auth.oidc.login_ttl")
+	}
+
+	now := time.Now().UTC()
+	login := &models.OidcLogin{
+		StateHash:    stateHash,
+		Provider:     providerName,
+		CodeVerifier: verifier,
+		Nonce:        nonce,
+		CreatedAt:    now,
+		ExpiresAt:    now.Add(ttl),
+	}
+
+	if userId > 0 {
+		login.UserId = *usql.NewNullInt64(int64(userId))
+	}
+
+	authURL, err := provider.AuthCodeURL(r.Context(), state, nonce, oidc.CodeChallenge(verifier))
+	if err != nil {
+		return "", fmt.Errorf("error creating authorization url: %w", err)
+	}
+
+	err = s.r.CreateOidcLogin(r.Context(), login)
+	if err != nil {
+		return "", fmt.Errorf("error creating oidc login: %w", err)
+	}
+
+	http.SetCookie(w, oidcCookie(r, state, int(ttl.Seconds())))
+
+	return authURL, nil
+}
+
+// oidcCookie returns the state cookie of an OIDC login. It is sent back when the provider redirects to the callback,
+// as SameSite=Lax cookies are included in top-level navigations from other sites.
+func oidcCookie(r *http.Request, state string, maxAge int) *http.Cookie {
+	return &http.Cookie{
+		Name:     oidcStateCookie,
+		Value:    state,
+		Path:     "/",
+		MaxAge:   maxAge,
+		Secure:   r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https",
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	}
+}
+
+// oidcUser returns the ID of the user to log in for the identity. An identity that is not linked yet is linked to the
+// user with the link user ID, or to a new user if the link user ID is not set.
+func (s *service) oidcUser(r *http.Request, providerName string, identity *oidc.Identity, linkUserId int) (int, error) {
+	now := time.Now().UTC()
+
+	existing, err := s.r.GetUserIdentity(r.Context(), identity.Issuer, identity.Subject)
+	switch {
+	case err == nil:
+		if linkUserId > 0 && existing.UserId != linkUserId {
+			return 0, utils.NewHttpError(http.StatusConflict, "identity is linked to another user")
+		}
+
+		existing.LastLoginAt = *usql.NewNullTime(now)
+		if identity.Email != "" {
+			existing.Email = *usql.NewNullString(identity.Email)
+		}
+
+		err = s.r.UpdateUserIdentity(r.Context(), existing)
+		if err != nil {
+			return 0, err
+		}
+
+		return existing.UserId, nil
+	case !errors.Is(err, repo.ErrUserIdentityNotFound):
+		return 0, err
+	}
+
+	userIdentity := &models.UserIdentity{
+		UserId:      linkUserId,
+		Provider:    providerName,
+		Issuer:      identity.Issuer,
+		Subject:     identity.Subject,
+		CreatedAt:   now,
+		LastLoginAt: *usql.NewNullTime(now),
+	}
+
+	if identity.Email != "" {
+		userIdentity.Email = *usql.NewNullString(identity.Email)
+	}
+
+	if linkUserId > 0 {
+		err = s.r.CreateUserIdentity(r.Context(), userIdentity)
+		if err != nil {
+			return 0, err
+		}
+
+		return linkUserId, nil
+	}
+
+	user, err := s.oidcNewUser(r, providerName, identity)
+	if err != nil {
+		return 0, err
+	}
+
+	err = s.r.CreateUserWithIdentity(r.Context(), user, userIdentity)
+	if err != nil {
+		return 0, err
+	}
+
+	s.audit(r, auditEvent{
+		actorId:    user.Id,
+		action:     auditActionUserCreate,
+		targetType: auditTargetUser,
+		targetId:   user.Id,
+		details:    fmt.Sprintf("oidc provider %s", providerName),
+	})
+
+	return user.Id, nil
+}
+
+// oidcNewUser creates the user for an identity that is not linked to a user. The user is given a random password, so
+// they can only log in with the provider until they reset their password. The username claims are only a suggestion,
+// as the provider does not know the users of this service: a number is added when the username is already taken.
+func (s *service) oidcNewUser(r *http.Request, providerName string, identity *oidc.Identity) (*models.User, error) {
+	username := identity.PreferredUsername
+	if username == "" && identity.EmailVerified {
+		username = identity.Email
+	}
+	if username == "" {
+		username = providerName + "-" + identity.Subject
+	}
+
+	username, err := s.availableUsername(r.Context(), strings.ToLower(username))
+	if err != nil {
+		return nil, err
+	}
+
+	name := identity.Name
+	if name == "" {
+		name = username
+	}
+	if len(name) > maxNameLength {
+		name = name[:maxNameLength]
+	}
+
+	password, _, err := auth.NewOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+
+	encryptedPassword, err := s.encryptPassword(r.Context(), password)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.User{
+		Name:     name,
+		Username: username,
+		Password: encryptedPassword,
+		Role:     string(auth.RolePlayer),
+	}, nil
+}
+
+// availableUsername returns the username, or the username with the lowest number added that is not taken yet.
+func (s *service) availableUsername(ctx context.Context, username string) (string, error) {
+	for i := 1; i <= maxUsernameSuffix; i++ {
+		candidate := username
+		if i > 1 {
+			suffix := "-" + strconv.Itoa(i)
+			candidate = username[:min(len(username), maxUsernameLength-len(suffix))] + suffix
+		} else if len(candidate) > maxUsernameLength {
+			candidate = candidate[:maxUsernameLength]
+		}
+
+		_, err := s.r.UserByUsername(ctx, candidate)
+		switch {
+		case errors.Is(err, repo.ErrUserNotFound):
+			return candidate, nil
+		case err != nil:
+			return "", err
+		}
+	}
+
+	return "", utils.NewHttpError(http.StatusConflict, "a user with this username already exists, log in and link the identity instead")
+}
+
+// sendOidcError writes the response for an error starting an OIDC login.
+func sendOidcError(w http.ResponseWriter, err error) {
+	var httpErr *utils.HttpError
+	switch {
+	case errors.As(err, &httpErr):
+		uhttp.SendMessageWithStatus(w, httpErr.Code, httpErr.Message)
+	default:
+		slog.Error("error starting oidc login", slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error starting login", err)
+	}
+}
@@ -0,0 +1,77 @@
+package rounder
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/codegen/apis/common"
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/logging"
+	repo "github.com/Jacobbrewer1/golf-stats-tracker/pkg/repositories/rounder"
+	"github.com/Jacobbrewer1/uhttp"
+)
+
+// paginationDetails gets the pagination details from the query parameters of a list endpoint. The generated server
+// does not check the minimum of the limit, so it is checked here.
+func paginationDetails(
+	limit *common.LimitParam,
+	lastVal *common.LastValue,
+	lastId *common.LastId,
+	sortBy *common.SortBy,
+	sortDir *common.SortDirection,
+) (*repo.PaginationDetails, error) {
+	details := new(repo.PaginationDetails)
+
+	if limit != nil {
+		if *limit < 1 {
+			return nil, fmt.Errorf("%w: must be at least 1", repo.ErrInvalidLimit)
+		}
+		details.Limit = *limit
+	}
+
+	if lastVal != nil {
+		details.LastVal = *lastVal
+	}
+
+	if lastId != nil {
+		details.LastId = *lastId
+	}
+
+	if sortBy != nil {
+		details.SortBy = *sortBy
+	}
+
+	if sortDir != nil {
+		details.SortDir = *sortDir
+	}
+
+	return details, nil
+}
+
+// pageCursorAsApi maps the cursor of the next page to the API model, which is nil on the last page.
+func pageCursorAsApi(cursor *repo.PageCursor) *common.PageCursor {
+	if cursor == nil {
+		return nil
+	}
+
+	return &common.PageCursor{
+		LastVal: cursor.LastVal,
+		LastId:  cursor.LastId,
+	}
+}
+
+// sendPaginationError sends the error of getting a page of a list, which is a bad request if the pagination details
+// are invalid.
+func sendPaginationError(w http.ResponseWriter, err error, message string) {
+	switch {
+	case errors.Is(err, repo.ErrInvalidSortColumn),
+		errors.Is(err, repo.ErrInvalidSortDirection),
+		errors.Is(err, repo.ErrInvalidCursor),
+		errors.Is(err, repo.ErrInvalidLimit):
+		uhttp.SendMessageWithStatus(w, http.StatusBadRequest, err.Error())
+	default:
+		slog.Error(message, slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, message, err)
+	}
+}
@@ -0,0 +1,52 @@
+package rounder
+
+import (
+	"testing"
+
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/codegen/apis/common"
+	repo "github.com/Jacobbrewer1/golf-stats-tracker/pkg/repositories/rounder"
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPaginationDetails(t *testing.T) {
+	tests := []struct {
+		name    string
+		limit   *common.LimitParam
+		want    int
+		wantErr error
+	}{
+		{
+			name: "no limit",
+			want: 0,
+		},
+		{
+			name:  "limit",
+			limit: utils.Ptr(5),
+			want:  5,
+		},
+		{
+			name:    "zero limit",
+			limit:   utils.Ptr(0),
+			wantErr: repo.ErrInvalidLimit,
+		},
+		{
+			name:    "negative limit",
+			limit:   utils.Ptr(-1),
+			wantErr: repo.ErrInvalidLimit,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := paginationDetails(tt.limit, nil, nil, nil, nil)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got.Limit)
+		})
+	}
+}
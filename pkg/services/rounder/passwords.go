@@ -0,0 +1,250 @@
+package rounder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/auth"
+	api "github.com/Jacobbrewer1/golf-stats-tracker/pkg/codegen/apis/rounder"
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/logging"
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/models"
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/notify"
+	repo "github.com/Jacobbrewer1/golf-stats-tracker/pkg/repositories/rounder"
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/utils"
+	"github.com/Jacobbrewer1/uhttp"
+)
+
+const (
+	// defaultPasswordResetTTL is the default time a password reset token can be used for.
+	defaultPasswordResetTTL = time.Hour
+
+	// minPasswordLength is the minimum length of a new password.
+	minPasswordLength = 8
+)
+
+func (s *service) ChangePassword(w http.ResponseWriter, r *http.Request) {
+	req := new(api.PasswordChange)
+	err := uhttp.DecodeRequestJSON(r, req)
+	if err != nil {
+		uhttp.SendErrorMessageWithStatus(w, http.StatusBadRequest, "error decoding request body", err)
+		return
+	}
+
+	err = validatePassword(req.NewPassword)
+	if err != nil {
+		uhttp.SendErrorMessageWithStatus(w, http.StatusBadRequest, "invalid password", err)
+		return
+	}
+
+	userId := utils.UserIdFromContext(r.Context())
+	user, err := s.r.GetUserById(r.Context(), userId)
+	if err != nil {
+		slog.Error("error getting user", slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error getting user", err)
+		return
+	}
+
+	_, err = s.checkPassword(r.Context(), user.Id, req.CurrentPassword, user.Password)
+	if err != nil {
+		slog.Debug("error checking password", slog.String(logging.KeyError, err.Error()))
+		s.lockout.Failure(usernameLockoutKey(user.Username), ipLockoutKey(r))
+		uhttp.SendMessageWithStatus(w, http.StatusUnauthorized, "invalid password")
+		return
+	}
+
+	encryptedPassword, err := s.encryptPassword(r.Context(), req.NewPassword)
+	if err != nil {
+		uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error encrypting password", err)
+		return
+	}
+
+	// Keep the current session so that the user is not logged out of the device they changed their password on.
+	err = s.r.UpdateUserPassword(r.Context(), user.Id, encryptedPassword, utils.SessionIdFromContext(r.Context()))
+	if err != nil {
+		slog.Error("error updating password", slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error updating password", err)
+		return
+	}
+
+	s.credentials.Invalidate(user.Id)
+	s.audit(r, auditEvent{
+		actorId:    user.Id,
+		action:     auditActionUserPasswordChange,
+		targetType: auditTargetUser,
+		targetId:   user.Id,
+	})
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *service) RequestPasswordReset(w http.ResponseWriter, r *http.Request) {
+	req := new(api.PasswordResetRequest)
+	err := uhttp.DecodeRequestJSON(r, req)
+	if err != nil {
+		uhttp.SendErrorMessageWithStatus(w, http.StatusBadRequest, "error decoding request body", err)
+		return
+	} else if req.Username == "" {
+		uhttp.SendMessageWithStatus(w, http.StatusBadRequest, "username is required")
+		return
+	}
+
+	// The response is the same whether the user exists or not, so that it cannot be used to find usernames.
+	const accepted = "if the user exists, a password reset has been sent"
+
+	user, err := s.r.UserByUsername(r.Context(), strings.ToLower(req.Username))
+	if err != nil {
+		switch {
+		case errors.Is(err, repo.ErrUserNotFound):
+			uhttp.SendMessageWithStatus(w, http.StatusAccepted, accepted)
+		default:
+			slog.Error("error getting user", slog.String(logging.KeyError, err.Error()))
+			uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error getting user", err)
+		}
+		return
+	}
+
+	// The reset is created and sent after the response, so that the response takes as long for a known user as for
+	// an unknown one, and its timing cannot be used to find usernames either.
+	ctx := context.WithoutCancel(r.Context())
+	go func() {
+		if err := s.sendPasswordReset(ctx, user); err != nil {
+			slog.Error("error sending password reset", slog.String(logging.KeyError, err.Error()))
+		}
+	}()
+
+	uhttp.SendMessageWithStatus(w, http.StatusAccepted, accepted)
+}
+
+func (s *service) ConfirmPasswordReset(w http.ResponseWriter, r *http.Request) {
+	req := new(api.PasswordResetConfirm)
+	err := uhttp.DecodeRequestJSON(r, req)
+	if err != nil {
+		uhttp.SendErrorMessageWithStatus(w, http.StatusBadRequest, "error decoding request body", err)
+		return
+	} else if req.Token == "" {
+		uhttp.SendMessageWithStatus(w, http.StatusBadRequest, "token is required")
+		return
+	}
+
+	err = validatePassword(req.NewPassword)
+	if err != nil {
+		uhttp.SendErrorMessageWithStatus(w, http.StatusBadRequest, "invalid password", err)
+		return
+	}
+
+	reset, err := s.r.GetPasswordResetByTokenHash(r.Context(), auth.HashOpaqueToken(req.Token))
+	if err != nil {
+		switch {
+		case errors.Is(err, repo.ErrPasswordResetNotFound):
+			s.lockout.Failure(ipLockoutKey(r))
+			uhttp.SendMessageWithStatus(w, http.StatusBadRequest, "invalid or expired token")
+		default:
+			slog.Error("error getting password reset", slog.String(logging.KeyError, err.Error()))
+			uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error getting password reset", err)
+		}
+		return
+	}
+
+	if reset.UsedAt.Valid || !time.Now().UTC().Before(reset.ExpiresAt) {
+		uhttp.SendMessageWithStatus(w, http.StatusBadRequest, "invalid or expired token")
+		return
+	}
+
+	encryptedPassword, err := s.encryptPassword(r.Context(), req.NewPassword)
+	if err != nil {
+		uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error encrypting password", err)
+		return
+	}
+
+	err = s.r.CompletePasswordReset(r.Context(), reset, encryptedPassword)
+	if err != nil {
+		switch {
+		case errors.Is(err, repo.ErrPasswordResetNotFound):
+			uhttp.SendMessageWithStatus(w, http.StatusBadRequest, "invalid or expired token")
+		default:
+			slog.Error("error resetting password", slog.String(logging.KeyError, err.Error()))
+			uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error resetting password", err)
+		}
+		return
+	}
+
+	s.credentials.Invalidate(reset.UserId)
+	s.audit(r, auditEvent{
+		actorId:    reset.UserId,
+		action:     auditActionUserPasswordReset,
+		targetType: auditTargetUser,
+		targetId:   reset.UserId,
+	})
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// sendPasswordReset creates a password reset for the user and sends its token to them.
+func (s *service) sendPasswordReset(ctx context.Context, user *models.User) error {
+	token, hash, err := auth.NewOpaqueToken()
+	if err != nil {
+		return fmt.Errorf("error creating password reset token: %w", err)
+	}
+
+	ttl := defaultPasswordResetTTL
+	if s.vip.IsSet("auth.password_reset.ttl") {
+		ttl = s.vip.GetDuration("auth.password_reset.ttl")
+	}
+
+	now := time.Now().UTC()
+	reset := &models.PasswordReset{
+		UserId:    user.Id,
+		TokenHash: hash,
+		CreatedAt: now,
+		ExpiresAt: now.Add(ttl),
+	}
+
+	err = s.r.CreatePasswordReset(ctx, reset)
+	if err != nil {
+		return fmt.Errorf("error creating password reset: %w", err)
+	}
+
+	err = s.notifier.Notify(ctx, s.passwordResetNotification(user, token, reset.ExpiresAt))
+	if err != nil {
+		return fmt.Errorf("error notifying user: %w", err)
+	}
+
+	return nil
+}
+
+// passwordResetNotification creates the notification that sends the password reset token to the user. If a reset
+// URL is configured, a link to it with the token is included.
+func (s *service) passwordResetNotification(user *models.User, token string, expiresAt time.Time) *notify.Notification {
+	body := new(strings.Builder)
+	body.WriteString(fmt.Sprintf("Hi %s,\n\n", user.Name))
+	body.WriteString("A password reset was requested for your account. ")
+	body.WriteString(fmt.Sprintf("Use the token below to set a new password before %s.\n\n", expiresAt.Format(time.RFC1123)))
+	body.WriteString(token + "\n")
+
+	if resetUrl := s.vip.GetString("auth.password_reset.url"); resetUrl != "" {
+		body.WriteString(fmt.Sprintf("\nOr follow this link: %s?token=%s\n", resetUrl, url.QueryEscape(token)))
+	}
+
+	body.WriteString("\nIf you did not request a password reset, you can ignore this message.\n")
+
+	return &notify.Notification{
+		To:      user.Username,
+		Subject: "Reset your password",
+		Body:    body.String(),
+	}
+}
+
+// validatePassword validates a new password.
+func validatePassword(password string) error {
+	if len(password) < minPasswordLength {
+		return fmt.Errorf("password must be at least %d characters", minPasswordLength)
+	}
+
+	return nil
+}
@@ -0,0 +1,40 @@
+package rounder
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/logging"
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/policy"
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/utils"
+	"github.com/Jacobbrewer1/uhttp"
+)
+
+// dataUserId returns the ID of the user whose data is requested, checking that the user making the request can read
+// it. The user making the request is used if no user is requested.
+func (s *service) dataUserId(r *http.Request, requested *int64) (int, error) {
+	userId := utils.UserIdFromContext(r.Context())
+	if requested == nil {
+		return userId, nil
+	}
+
+	ownerId := int(*requested)
+	err := s.policy.AuthorizeUserData(r.Context(), userId, ownerId, policy.ActionRead)
+	if err != nil {
+		return 0, err
+	}
+
+	return ownerId, nil
+}
+
+// sendPolicyError writes the response for an error from the policy, sending the message if the request is forbidden.
+func sendPolicyError(w http.ResponseWriter, err error, message string) {
+	switch {
+	case errors.Is(err, policy.ErrForbidden):
+		uhttp.SendMessageWithStatus(w, http.StatusForbidden, message)
+	default:
+		slog.Error("error checking policy", slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error checking policy", err)
+	}
+}
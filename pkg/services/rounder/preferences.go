@@ -0,0 +1,225 @@
+package rounder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	api "github.com/Jacobbrewer1/golf-stats-tracker/pkg/codegen/apis/rounder"
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/logging"
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/models"
+	repo "github.com/Jacobbrewer1/golf-stats-tracker/pkg/repositories/rounder"
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/utils"
+	usql "github.com/Jacobbrewer1/golf-stats-tracker/pkg/utils/sql"
+	"github.com/Jacobbrewer1/uhttp"
+)
+
+const (
+	// defaultDistanceUnit is the unit distances are shown in for users that have not chosen one.
+	defaultDistanceUnit = api.DistanceUnit_yards
+
+	// defaultTimezone is the timezone times are shown in for users that have not chosen one.
+	defaultTimezone = "UTC"
+
+	// maxMarkerLength is the maximum length of the default marker.
+	maxMarkerLength = 255
+)
+
+// preferences are the preferences of a user that change how responses are shown to them.
+type preferences struct {
+	// distanceUnit is the unit distances are shown in.
+	distanceUnit api.DistanceUnit
+
+	// defaultMarker is the tee marker the user usually plays from, if any.
+	defaultMarker string
+
+	// location is the timezone times are shown in.
+	location *time.Location
+}
+
+func (s *service) GetPreferences(w http.ResponseWriter, r *http.Request) {
+	pref, err := s.r.GetUserPreference(r.Context(), utils.UserIdFromContext(r.Context()))
+	if err != nil {
+		switch {
+		case errors.Is(err, repo.ErrUserPreferenceNotFound):
+			pref = defaultUserPreference()
+		default:
+			slog.Error("error getting preferences", slog.String(logging.KeyError, err.Error()))
+			uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error getting preferences", err)
+			return
+		}
+	}
+
+	err = uhttp.Encode(w, http.StatusOK, modelPreferenceAsApiPreferences(pref))
+	if err != nil {
+		slog.Error("error encoding response", slog.String(logging.KeyError, err.Error()))
+		return
+	}
+}
+
+func (s *service) UpdatePreferences(w http.ResponseWriter, r *http.Request) {
+	req := new(api.Preferences)
+	err := uhttp.DecodeRequestJSON(r, req)
+	if err != nil {
+		uhttp.SendErrorMessageWithStatus(w, http.StatusBadRequest, "error decoding request body", err)
+		return
+	}
+
+	pref, err := apiPreferencesAsModel(req)
+	if err != nil {
+		uhttp.SendErrorMessageWithStatus(w, http.StatusBadRequest, "invalid preferences", err)
+		return
+	}
+	pref.UserId = utils.UserIdFromContext(r.Context())
+
+	err = s.r.SaveUserPreference(r.Context(), pref)
+	if err != nil {
+		slog.Error("error saving preferences", slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error saving preferences", err)
+		return
+	}
+
+	err = uhttp.Encode(w, http.StatusOK, modelPreferenceAsApiPreferences(pref))
+	if err != nil {
+		slog.Error("error encoding response", slog.String(logging.KeyError, err.Error()))
+		return
+	}
+}
+
+// userPreferences gets the preferences of the user, falling back to the defaults for a user that has not set any.
+func (s *service) userPreferences(ctx context.Context, userId int) (*preferences, error) {
+	pref, err := s.r.GetUserPreference(ctx, userId)
+	if err != nil {
+		switch {
+		case errors.Is(err, repo.ErrUserPreferenceNotFound):
+			pref = defaultUserPreference()
+		default:
+			return nil, fmt.Errorf("error getting preferences: %w", err)
+		}
+	}
+
+	location, err := time.LoadLocation(pref.Timezone)
+	if err != nil {
+		// The timezone was valid when it was saved, so only fall back rather than failing the request.
+		slog.Warn("error loading timezone",
+			slog.String(logging.KeyError, err.Error()),
+			slog.String("timezone", pref.Timezone),
+		)
+		location = time.UTC
+	}
+
+	return &preferences{
+		distanceUnit:  pref.DistanceUnit,
+		defaultMarker: pref.DefaultMarker.String,
+		location:      location,
+	}, nil
+}
+
+// applyToHole sets the distance of the hole in the preferred unit.
+func (p *preferences) applyToHole(hole *api.Hole) {
+	hole.DistanceUnit = utils.Ptr(p.distanceUnit)
+	hole.Distance = p.distance(hole.Yardage, hole.Meters)
+}
+
+// applyToCourseDetails sets the distances of the tee set and its holes in the preferred unit, and marks the tee set
+// if it is the default marker.
+func (p *preferences) applyToCourseDetails(details *api.CourseDetails) {
+	details.DistanceUnit = utils.Ptr(p.distanceUnit)
+	details.DistanceFrontNine = p.distance(details.YardageFrontNine, details.MetersFrontNine)
+	details.DistanceBackNine = p.distance(details.YardageBackNine, details.MetersBackNine)
+	details.DistanceTotal = p.distance(details.YardageTotal, details.MetersTotal)
+	details.IsDefault = utils.Ptr(p.defaultMarker != "" && details.Marker != nil &&
+		strings.EqualFold(*details.Marker, p.defaultMarker))
+
+	for i := range details.Holes {
+		p.applyToHole(&details.Holes[i])
+	}
+}
+
+// applyToCourse applies the preferences to each tee set of the course.
+func (p *preferences) applyToCourse(course *api.Course) {
+	for i := range course.Details {
+		p.applyToCourseDetails(&course.Details[i])
+	}
+}
+
+// applyToRound shows the tee time of the round in the preferred timezone.
+func (p *preferences) applyToRound(round *api.Round) {
+	if round.TeeTime != nil {
+		round.TeeTime = utils.Ptr(round.TeeTime.In(p.location))
+	}
+}
+
+// applyToRoundStats shows the tee time of the round in the preferred timezone.
+func (p *preferences) applyToRoundStats(stats *api.RoundStats) {
+	if stats.TeeTime != nil {
+		stats.TeeTime = utils.Ptr(stats.TeeTime.In(p.location))
+	}
+}
+
+// distance returns whichever of the yards or meters is in the preferred unit.
+func (p *preferences) distance(yards, meters *int64) *int64 {
+	if p.distanceUnit == api.DistanceUnit_meters {
+		return meters
+	}
+
+	return yards
+}
+
+// defaultUserPreference returns the preferences of a user that has not set any.
+func defaultUserPreference() *models.UserPreference {
+	return &models.UserPreference{
+		DistanceUnit: defaultDistanceUnit,
+		Timezone:     defaultTimezone,
+	}
+}
+
+// apiPreferencesAsModel maps the preferences to the model, using the defaults for any that are not given.
+func apiPreferencesAsModel(req *api.Preferences) (*models.UserPreference, error) {
+	pref := defaultUserPreference()
+
+	if req.DistanceUnit != nil {
+		switch *req.DistanceUnit {
+		case api.DistanceUnit_yards, api.DistanceUnit_meters:
+			pref.DistanceUnit = *req.DistanceUnit
+		default:
+			return nil, fmt.Errorf("distance_unit must be %s or %s", api.DistanceUnit_yards, api.DistanceUnit_meters)
+		}
+	}
+
+	if req.DefaultMarker != nil {
+		marker := strings.TrimSpace(*req.DefaultMarker)
+		if len(marker) > maxMarkerLength {
+			return nil, fmt.Errorf("default_marker must be at most %d characters", maxMarkerLength)
+		} else if marker != "" {
+			pref.DefaultMarker = *usql.NewNullString(marker)
+		}
+	}
+
+	if req.Timezone != nil && *req.Timezone != "" {
+		if _, err := time.LoadLocation(*req.Timezone); err != nil {
+			return nil, fmt.Errorf("unknown timezone %q", *req.Timezone)
+		}
+		pref.Timezone = *req.Timezone
+	}
+
+	return pref, nil
+}
+
+// modelPreferenceAsApiPreferences maps the preferences to the API model.
+func modelPreferenceAsApiPreferences(pref *models.UserPreference) *api.Preferences {
+	resp := &api.Preferences{
+		DistanceUnit: utils.Ptr(pref.DistanceUnit),
+		Timezone:     utils.Ptr(pref.Timezone),
+	}
+
+	if pref.DefaultMarker.Valid {
+		resp.DefaultMarker = utils.Ptr(pref.DefaultMarker.String)
+	}
+
+	return resp
+}
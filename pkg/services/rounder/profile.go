@@ -0,0 +1,200 @@
+package rounder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/mail"
+	"strings"
+	"time"
+
+	api "github.com/Jacobbrewer1/golf-stats-tracker/pkg/codegen/apis/rounder"
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/logging"
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/models"
+	repo "github.com/Jacobbrewer1/golf-stats-tracker/pkg/repositories/rounder"
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/utils"
+	usql "github.com/Jacobbrewer1/golf-stats-tracker/pkg/utils/sql"
+	"github.com/Jacobbrewer1/uhttp"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// maxEmailLength is the maximum length of the email address of a user.
+const maxEmailLength = 255
+
+func (s *service) GetProfile(w http.ResponseWriter, r *http.Request) {
+	user, err := s.r.GetUserById(r.Context(), utils.UserIdFromContext(r.Context()))
+	if err != nil {
+		slog.Error("error getting user", slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error getting user", err)
+		return
+	}
+
+	err = uhttp.Encode(w, http.StatusOK, modelUserAsApiProfile(user))
+	if err != nil {
+		slog.Error("error encoding response", slog.String(logging.KeyError, err.Error()))
+		return
+	}
+}
+
+func (s *service) UpdateProfile(w http.ResponseWriter, r *http.Request) {
+	req := new(api.ProfileUpdate)
+	err := uhttp.DecodeRequestJSON(r, req)
+	if err != nil {
+		uhttp.SendErrorMessageWithStatus(w, http.StatusBadRequest, "error decoding request body", err)
+		return
+	}
+
+	userId := utils.UserIdFromContext(r.Context())
+	user, err := s.r.GetUserById(r.Context(), userId)
+	if err != nil {
+		slog.Error("error getting user", slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error getting user", err)
+		return
+	}
+
+	changed, err := s.applyProfileUpdate(r.Context(), user, req)
+	if err != nil {
+		var httpErr *utils.HttpError
+		switch {
+		case errors.As(err, &httpErr):
+			uhttp.SendMessageWithStatus(w, httpErr.Code, httpErr.Message)
+		default:
+			slog.Error("error updating profile", slog.String(logging.KeyError, err.Error()))
+			uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error updating profile", err)
+		}
+		return
+	}
+
+	if len(changed) > 0 {
+		err = s.r.UpdateUserProfile(r.Context(), user)
+		if err != nil {
+			slog.Error("error updating profile", slog.String(logging.KeyError, err.Error()))
+			uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error updating profile", err)
+			return
+		}
+
+		s.audit(r, auditEvent{
+			actorId:    userId,
+			action:     auditActionUserProfileUpdate,
+			targetType: auditTargetUser,
+			targetId:   userId,
+			details:    "changed " + strings.Join(changed, ", "),
+		})
+	}
+
+	err = uhttp.Encode(w, http.StatusOK, modelUserAsApiProfile(user))
+	if err != nil {
+		slog.Error("error encoding response", slog.String(logging.KeyError, err.Error()))
+		return
+	}
+}
+
+// applyProfileUpdate validates the update and applies it to the user, returning the names of the fields that were
+// given.
+func (s *service) applyProfileUpdate(ctx context.Context, user *models.User, req *api.ProfileUpdate) ([]string, error) {
+	changed := make([]string, 0)
+
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" || len(name) > maxNameLength {
+			return nil, utils.NewHttpError(http.StatusBadRequest, fmt.Sprintf("name must be between 1 and %d characters", maxNameLength))
+		}
+
+		user.Name = name
+		changed = append(changed, "name")
+	}
+
+	if req.Email != nil {
+		email := strings.TrimSpace(*req.Email)
+		if email == "" {
+			user.Email = usql.NullString{}
+		} else if _, err := mail.ParseAddress(email); err != nil || len(email) > maxEmailLength {
+			return nil, utils.NewHttpError(http.StatusBadRequest, "invalid email address")
+		} else {
+			user.Email = *usql.NewNullString(email)
+		}
+
+		changed = append(changed, "email")
+	}
+
+	if req.Gender != nil {
+		switch *req.Gender {
+		case api.Gender_male, api.Gender_female, api.Gender_other:
+			user.Gender = *usql.NewNullString(*req.Gender)
+		default:
+			return nil, utils.NewHttpError(http.StatusBadRequest, "invalid gender")
+		}
+
+		changed = append(changed, "gender")
+	}
+
+	if req.DateOfBirth != nil {
+		if req.DateOfBirth.Time.After(time.Now()) {
+			return nil, utils.NewHttpError(http.StatusBadRequest, "date_of_birth cannot be in the future")
+		}
+
+		user.DateOfBirth = *usql.NewNullTime(req.DateOfBirth.Time)
+		changed = append(changed, "date_of_birth")
+	}
+
+	if req.HomeCourseId != nil {
+		if *req.HomeCourseId == 0 {
+			user.HomeCourseId = usql.NullInt64{}
+		} else {
+			course, err := s.r.GetCourseById(ctx, int(*req.HomeCourseId))
+			if err != nil {
+				switch {
+				case errors.Is(err, repo.ErrCourseNotFound):
+					return nil, utils.NewHttpError(http.StatusBadRequest, "home course not found")
+				default:
+					return nil, err
+				}
+			}
+
+			// Custom courses can only be used by the user that created them.
+			if course.UserId.Valid && int(course.UserId.Int64) != user.Id {
+				return nil, utils.NewHttpError(http.StatusBadRequest, "home course not found")
+			}
+
+			user.HomeCourseId = *usql.NewNullInt64(int64(course.Id))
+		}
+
+		changed = append(changed, "home_course_id")
+	}
+
+	return changed, nil
+}
+
+// modelUserAsApiProfile maps the user to their profile.
+func modelUserAsApiProfile(user *models.User) *api.Profile {
+	profile := &api.Profile{
+		Id:       utils.Ptr(int64(user.Id)),
+		Username: utils.Ptr(user.Username),
+		Name:     utils.Ptr(user.Name),
+		Role:     utils.Ptr(user.Role),
+	}
+
+	if user.Email.Valid {
+		profile.Email = utils.Ptr(user.Email.String)
+	}
+
+	if user.Gender.Valid {
+		profile.Gender = utils.Ptr(user.Gender.String)
+	}
+
+	if user.DateOfBirth.Valid {
+		profile.DateOfBirth = &openapi_types.Date{Time: user.DateOfBirth.Time}
+	}
+
+	if user.HomeCourseId.Valid {
+		profile.HomeCourseId = utils.Ptr(user.HomeCourseId.Int64)
+	}
+
+	if user.LastLogin.Valid {
+		profile.LastLogin = utils.Ptr(user.LastLogin.Time)
+	}
+
+	return profile
+}
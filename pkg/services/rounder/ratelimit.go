@@ -0,0 +1,106 @@
+package rounder
+
+import (
+	"errors"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/auth"
+	api "github.com/Jacobbrewer1/golf-stats-tracker/pkg/codegen/apis/rounder"
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/logging"
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/ratelimit"
+	"github.com/Jacobbrewer1/uhttp"
+)
+
+const (
+	// RateLimitPolicyDefault is the policy used by routes that do not name a policy, or name one that is not configured.
+	RateLimitPolicyDefault = "default"
+
+	// RateLimitPolicyAuth is the policy used by the routes that take credentials.
+	RateLimitPolicyAuth = "auth"
+
+	// RateLimitPolicyCharts is the policy used by the routes that calculate the chart averages.
+	RateLimitPolicyCharts = "charts"
+)
+
+// errRateLimited is returned by the rate limiter when the client has made too many requests.
+var errRateLimited = errors.New("rate limit exceeded")
+
+// DefaultRateLimitPolicies returns the limits used for the policies that are not configured.
+func DefaultRateLimitPolicies() map[string]ratelimit.Limit {
+	return map[string]ratelimit.Limit{
+		RateLimitPolicyDefault: {Rate: 10, Burst: 20},
+		RateLimitPolicyAuth:    {Rate: 1, Burst: 5},
+		RateLimitPolicyCharts:  {Rate: 2, Burst: 5},
+	}
+}
+
+// NewRateLimiter creates a rate limiter that rejects requests from IP addresses that are locked out after too many
+// failed authentication attempts, and then takes a token from the client's bucket for the route. The client is the
+// user of a valid bearer token, or the IP address of the request otherwise. If the store is nil, only the lockout is
+// checked.
+func NewRateLimiter(lockout auth.Lockout, tokens auth.Tokens, store ratelimit.Store, policies map[string]ratelimit.Limit) api.RateLimiterFunc {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		lockedFor := lockout.LockedFor(ipLockoutKey(r))
+		if lockedFor > 0 {
+			sendLockedOut(w, lockedFor)
+			return errLockedOut
+		}
+
+		if store == nil {
+			return nil
+		}
+
+		route, ok := api.RateLimitRouteFromContext(r.Context())
+		if !ok {
+			return nil
+		}
+
+		limit, ok := policies[route.Policy]
+		if !ok {
+			limit = policies[RateLimitPolicyDefault]
+		}
+
+		key := route.Policy + ":" + route.OperationId + ":" + rateLimitClient(r, tokens)
+		res, err := store.Take(r.Context(), key, limit)
+		if err != nil {
+			// Do not stop the API from being used if the store is unavailable.
+			slog.Error("Error taking rate limit token", slog.String(logging.KeyError, err.Error()))
+			return nil
+		}
+
+		w.Header().Set("X-RateLimit-Limit", strconv.Itoa(res.Limit))
+		w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(res.Remaining))
+		w.Header().Set("X-RateLimit-Reset", strconv.Itoa(ceilSeconds(res.ResetAfter)))
+
+		if !res.Allowed {
+			slog.Debug("Rate limit exceeded", slog.String("key", key))
+			w.Header().Set("Retry-After", strconv.Itoa(ceilSeconds(res.RetryAfter)))
+			uhttp.SendMessageWithStatus(w, http.StatusTooManyRequests, "rate limit exceeded, try again later")
+			return errRateLimited
+		}
+
+		return nil
+	}
+}
+
+// rateLimitClient returns the client that the request is rate limited as. The bearer token is only verified, not
+// checked against the session, so that rate limiting does not need the database.
+func rateLimitClient(r *http.Request, tokens auth.Tokens) string {
+	if token, ok := bearerToken(r); ok {
+		claims, err := tokens.Verify(token)
+		if err == nil {
+			return "user:" + strconv.Itoa(claims.UserId)
+		}
+	}
+
+	return ipLockoutKey(r)
+}
+
+// ceilSeconds returns the duration in whole seconds, rounded up.
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -2,14 +2,17 @@ package rounder
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
+	"time"
 
 	api "github.com/Jacobbrewer1/golf-stats-tracker/pkg/codegen/apis/rounder"
 	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/logging"
 	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/models"
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/policy"
 	repo "github.com/Jacobbrewer1/golf-stats-tracker/pkg/repositories/rounder"
 	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/utils"
 	usql "github.com/Jacobbrewer1/golf-stats-tracker/pkg/utils/sql"
@@ -29,8 +32,14 @@ func (s *service) CreateRound(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if rnd.CourseId == nil {
-		uhttp.SendMessageWithStatus(w, http.StatusBadRequest, "course_id is required")
+	if rnd.CourseId == nil && rnd.CustomCourseId == nil {
+		uhttp.SendMessageWithStatus(w, http.StatusBadRequest, "course_id or custom_course_id is required")
+		return
+	} else if rnd.CourseId != nil && rnd.CustomCourseId != nil {
+		uhttp.SendMessageWithStatus(w, http.StatusBadRequest, "only one of course_id or custom_course_id can be provided")
+		return
+	} else if rnd.MarkerId == nil {
+		uhttp.SendMessageWithStatus(w, http.StatusBadRequest, "marker_id is required")
 		return
 	}
 
@@ -41,26 +50,58 @@ func (s *service) CreateRound(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	var courseDetailsId int
+	if rnd.CustomCourseId != nil {
+		courseDetailsId, err = s.customCourseTeeSet(r.Context(), userId, int(*rnd.CustomCourseId), int(*rnd.MarkerId))
+		if err != nil {
+			switch {
+			case errors.Is(err, repo.ErrCourseNotFound), errors.Is(err, repo.ErrCourseDetailsNotFound):
+				uhttp.SendMessageWithStatus(w, http.StatusNotFound, "course not found")
+				return
+			default:
+				slog.Error("error getting custom course", slog.String(logging.KeyError, err.Error()))
+				uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error getting custom course", err)
+				return
+			}
+		}
+	} else {
+		courseDetailsId, err = s.importCourse(r.Context(), int(*rnd.CourseId), int(*rnd.MarkerId))
+		if err != nil {
+			slog.Error("error importing course", slog.String(logging.KeyError, err.Error()))
+			uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error importing course", err)
+			return
+		}
+	}
+
 	mdl, err := s.roundAsModel(rnd, userId)
 	if err != nil {
 		uhttp.SendErrorMessageWithStatus(w, http.StatusBadRequest, "error mapping round to model", err)
 		return
 	}
+	mdl.CourseDetailsId = courseDetailsId
 
-	err = s.r.CreateRound(mdl)
+	err = s.r.CreateRound(r.Context(), mdl)
 	if err != nil {
 		uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error creating round", err)
 		return
 	}
 
-	err = s.importCourse(r.Context(), mdl.Id, int(*rnd.CourseId), int(*rnd.MarkerId))
+	s.audit(r, auditEvent{
+		actorId:    userId,
+		action:     auditActionRoundCreate,
+		targetType: auditTargetRound,
+		targetId:   mdl.Id,
+		details:    fmt.Sprintf("course details %d", mdl.CourseDetailsId),
+	})
+
+	prefs, err := s.userPreferences(r.Context(), userId)
 	if err != nil {
-		slog.Error("error importing course", slog.String(logging.KeyError, err.Error()))
-		uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error importing course", err)
+		slog.Error("error getting preferences", slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error getting preferences", err)
 		return
 	}
 
-	respRound, err := s.roundById(mdl.Id)
+	respRound, err := s.roundById(r.Context(), mdl.Id, prefs)
 	if err != nil {
 		slog.Error("error getting round by id", slog.String(logging.KeyError, err.Error()))
 		uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error getting round by id", err)
@@ -74,13 +115,117 @@ func (s *service) CreateRound(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-func (s *service) roundById(id int) (*api.Round, error) {
-	r, err := s.r.GetRoundDetailsByRoundId(id)
+func (s *service) DeleteRound(w http.ResponseWriter, r *http.Request, roundId api.PathRoundId) {
+	round, err := s.r.GetRoundById(r.Context(), int(roundId))
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			uhttp.SendMessageWithStatus(w, http.StatusNotFound, "round not found")
+		default:
+			slog.Error("error getting round", slog.String(logging.KeyError, err.Error()))
+			uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error getting round", err)
+		}
+		return
+	}
+
+	userId := utils.UserIdFromContext(r.Context())
+	err = s.policy.AuthorizeRound(r.Context(), userId, round, policy.ActionWrite)
+	if err != nil {
+		sendPolicyError(w, err, "round not found")
+		return
+	}
+
+	err = s.r.DeleteRound(r.Context(), round.Id)
+	if err != nil {
+		slog.Error("error deleting round", slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error deleting round", err)
+		return
+	}
+
+	s.audit(r, auditEvent{
+		actorId:    userId,
+		action:     auditActionRoundDelete,
+		targetType: auditTargetRound,
+		targetId:   round.Id,
+		details:    fmt.Sprintf("course details %d, tee time %s", round.CourseDetailsId, round.TeeTime.Format(time.RFC3339)),
+	})
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// roundById gets the round, shown with the preferences of the user.
+func (s *service) roundById(ctx context.Context, id int, prefs *preferences) (*api.Round, error) {
+	r, err := s.r.GetRoundDetailsByRoundId(ctx, id)
 	if err != nil {
 		return nil, fmt.Errorf("error getting round by id: %w", err)
 	}
 
-	return s.roundAsApiRound(r), nil
+	round := s.roundAsApiRound(r)
+	prefs.applyToRound(round)
+
+	return round, nil
+}
+
+func (s *service) GetRoundStats(w http.ResponseWriter, r *http.Request, params api.GetRoundStatsParams) {
+	userId, err := s.dataUserId(r, params.UserId)
+	if err != nil {
+		sendPolicyError(w, err, "not allowed to read the stats of the user")
+		return
+	}
+
+	details, err := paginationDetails(params.Limit, params.LastVal, params.LastId, params.SortBy, params.SortDir)
+	if err != nil {
+		sendPaginationError(w, err, "error getting round stats")
+		return
+	}
+
+	stats, err := s.r.GetStatsByUserId(r.Context(), userId, details)
+	if err != nil {
+		sendPaginationError(w, err, "error getting round stats")
+		return
+	}
+
+	prefs, err := s.userPreferences(r.Context(), utils.UserIdFromContext(r.Context()))
+	if err != nil {
+		slog.Error("error getting preferences", slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error getting preferences", err)
+		return
+	}
+
+	respStats := make([]api.RoundStats, 0, len(stats.Items))
+	for _, st := range stats.Items {
+		respStat := roundStatsAsApi(st)
+		prefs.applyToRoundStats(respStat)
+
+		respStats = append(respStats, *respStat)
+	}
+
+	resp := &api.RoundStatsResponse{
+		Stats: respStats,
+		Total: stats.Total,
+		Next:  pageCursorAsApi(stats.Next),
+	}
+
+	err = uhttp.Encode(w, http.StatusOK, resp)
+	if err != nil {
+		slog.Error("error encoding response", slog.String(logging.KeyError, err.Error()))
+		return
+	}
+}
+
+func roundStatsAsApi(st *repo.RoundWithStats) *api.RoundStats {
+	return &api.RoundStats{
+		RoundId:        utils.Ptr(int64(st.Round.Id)),
+		CourseName:     utils.Ptr(st.Course.Name),
+		TeeTime:        utils.Ptr(st.Round.TeeTime),
+		AvgFairwaysHit: utils.Ptr(st.Stats.AvgFairwaysHit),
+		AvgGreensHit:   utils.Ptr(st.Stats.AvgGreensHit),
+		AvgPutts:       utils.Ptr(st.Stats.AvgPutts),
+		Penalties:      utils.Ptr(int64(st.Stats.Penalties)),
+		AvgPar3:        utils.Ptr(st.Stats.AvgPar3),
+		AvgPar4:        utils.Ptr(st.Stats.AvgPar4),
+		AvgPar5:        utils.Ptr(st.Stats.AvgPar5),
+	}
 }
 
 func (s *service) roundAsApiRound(r *repo.RoundDetails) *api.Round {
@@ -92,23 +237,23 @@ func (s *service) roundAsApiRound(r *repo.RoundDetails) *api.Round {
 	}
 }
 
-// importCourse imports a course from the golf data service.
-func (s *service) importCourse(ctx context.Context, roundId int, courseId int, markerId int) error {
-	if roundId == 0 {
-		return errors.New("round_id is required")
-	} else if courseId == 0 {
-		return errors.New("course_id is required")
+// importCourse imports a course from the golf data service into the course catalogue, returning the ID of the
+// catalogue tee set for the marker. A new version of the tee set is only created when the golf data has changed
+// since the last import.
+func (s *service) importCourse(ctx context.Context, courseId int, markerId int) (int, error) {
+	if courseId == 0 {
+		return 0, errors.New("course_id is required")
 	} else if markerId == 0 {
-		return errors.New("marker_id is required")
+		return 0, errors.New("marker_id is required")
 	}
 
-	course, err := s.getDataCourse(ctx, courseId)
+	course, err := s.gd.GetCourse(ctx, courseId)
 	if err != nil {
-		return fmt.Errorf("failed to get course data: %w", err)
+		return 0, fmt.Errorf("failed to get course data: %w", err)
 	}
 
 	if course.Details == nil {
-		return errors.New("course details are required")
+		return 0, errors.New("course details are required")
 	}
 
 	details := new(models.CourseDetails)
@@ -121,17 +266,17 @@ func (s *service) importCourse(ctx context.Context, roundId int, courseId int, m
 
 		courseDetails, err := s.detailsAsModel(&d)
 		if err != nil {
-			return fmt.Errorf("error mapping course details to model: %w", err)
+			return 0, fmt.Errorf("error mapping course details to model: %w", err)
 		}
 
 		if d.Holes == nil {
-			return errors.New("holes are required")
+			return 0, errors.New("holes are required")
 		}
 
 		for _, h := range d.Holes {
 			hole, err := s.holeAsModel(&h)
 			if err != nil {
-				return fmt.Errorf("error mapping hole to model: %w", err)
+				return 0, fmt.Errorf("error mapping hole to model: %w", err)
 			}
 			holes = append(holes, hole)
 		}
@@ -142,35 +287,21 @@ func (s *service) importCourse(ctx context.Context, roundId int, courseId int, m
 		break
 	}
 	if !found {
-		return fmt.Errorf("course details not found for marker_id: %d", markerId)
+		return 0, fmt.Errorf("course details not found for marker_id: %d", markerId)
 	}
 
 	c, err := s.courseAsModel(course)
 	if err != nil {
-		return fmt.Errorf("error mapping course to model: %w", err)
+		return 0, fmt.Errorf("error mapping course to model: %w", err)
 	}
-	c.RoundId = roundId
 
-	err = s.r.CreateCourse(c)
+	details.ExternalId = markerId
+	detailsId, err := s.r.ImportTeeSet(ctx, c, &repo.TeeSet{Details: details, Holes: holes})
 	if err != nil {
-		return fmt.Errorf("error creating course: %w", err)
+		return 0, fmt.Errorf("error importing tee set: %w", err)
 	}
 
-	details.CourseId = c.Id
-	err = s.r.CreateCourseDetails(details)
-	if err != nil {
-		return fmt.Errorf("error creating course details: %w", err)
-	}
-
-	for _, h := range holes {
-		h.CourseDetailsId = c.Id
-		err = s.r.CreateHole(h)
-		if err != nil {
-			return fmt.Errorf("error creating hole: %w", err)
-		}
-	}
-
-	return nil
+	return detailsId, nil
 }
 
 func (s *service) holeAsModel(hole *api.Hole) (*models.Hole, error) {
@@ -206,6 +337,7 @@ func (s *service) holeAsModel(hole *api.Hole) (*models.Hole, error) {
 
 func (s *service) courseAsModel(course *api.Course) (*models.Course, error) {
 	c := new(models.Course)
+	c.ExternalId = *usql.NewNullInt64(course.Id)
 	c.Name = course.Name
 	return c, nil
 }
@@ -276,37 +408,6 @@ func (s *service) detailsAsModel(details *api.CourseDetails) (*models.CourseDeta
 	return d, nil
 }
 
-func (s *service) getDataCourse(ctx context.Context, courseId int) (*api.Course, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/courses/%d", s.golfDataHost, courseId), nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to make request: %w", err)
-	}
-
-	defer func() {
-		err := resp.Body.Close()
-		if err != nil {
-			slog.Error("failed to close response body", slog.String("error", err.Error()))
-		}
-	}()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
-	}
-
-	course := new(api.Course)
-	err = uhttp.DecodeJSON(resp.Body, course)
-	if err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
-	}
-
-	return course, nil
-}
-
 func (s *service) roundAsModel(rnd *api.RoundCreate, userId int) (*models.Round, error) {
 	r := new(models.Round)
 
@@ -323,7 +424,7 @@ func (s *service) roundAsModel(rnd *api.RoundCreate, userId int) (*models.Round,
 	return r, nil
 }
 
-func (s *service) GetRounds(w http.ResponseWriter, r *http.Request) {
+func (s *service) GetRounds(w http.ResponseWriter, r *http.Request, params api.GetRoundsParams) {
 	userId := utils.UserIdFromContext(r.Context())
 	if userId <= 0 {
 		slog.Debug("user_id not found in context")
@@ -331,21 +432,35 @@ func (s *service) GetRounds(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	rounds, err := s.r.GetRoundsByUserId(userId)
+	userId, err := s.dataUserId(r, params.UserId)
+	if err != nil {
+		sendPolicyError(w, err, "not allowed to read the rounds of the user")
+		return
+	}
+
+	details, err := paginationDetails(params.Limit, params.LastVal, params.LastId, params.SortBy, params.SortDir)
+	if err != nil {
+		sendPaginationError(w, err, "error getting rounds")
+		return
+	}
+
+	rounds, err := s.r.GetRoundsByUserId(r.Context(), userId, details)
+	if err != nil {
+		sendPaginationError(w, err, "error getting rounds")
+		return
+	}
+
+	prefs, err := s.userPreferences(r.Context(), utils.UserIdFromContext(r.Context()))
 	if err != nil {
-		slog.Error("error getting rounds", slog.String(logging.KeyError, err.Error()))
-		uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error getting rounds", err)
+		slog.Error("error getting preferences", slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error getting preferences", err)
 		return
 	}
 
 	respRounds := make([]api.Round, 0)
 	for _, r := range rounds.Items {
-		respRound, err := s.roundById(r.Id)
-		if err != nil {
-			slog.Error("error getting round by id", slog.String(logging.KeyError, err.Error()))
-			uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error getting round by id", err)
-			return
-		}
+		respRound := s.roundAsApiRound(r)
+		prefs.applyToRound(respRound)
 
 		respRounds = append(respRounds, *respRound)
 	}
@@ -353,6 +468,7 @@ func (s *service) GetRounds(w http.ResponseWriter, r *http.Request) {
 	resp := &api.RoundsResponse{
 		Rounds: respRounds,
 		Total:  rounds.Total,
+		Next:   pageCursorAsApi(rounds.Next),
 	}
 
 	err = uhttp.Encode(w, http.StatusOK, resp)
@@ -1,25 +1,65 @@
 package rounder
 
 import (
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/auth"
 	api "github.com/Jacobbrewer1/golf-stats-tracker/pkg/codegen/apis/rounder"
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/golfdata"
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/notify"
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/oidc"
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/policy"
 	repo "github.com/Jacobbrewer1/golf-stats-tracker/pkg/repositories/rounder"
-	"github.com/Jacobbrewer1/vaulty"
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/secrets"
 	"github.com/spf13/viper"
 )
 
 type service struct {
-	r            repo.Repository
-	vc           vaulty.Client
-	golfDataHost string
-	vip          *viper.Viper
+	r        repo.Repository
+	gd       golfdata.Client
+	tokens   auth.Tokens
+	notifier notify.Notifier
+	lockout  auth.Lockout
+	policy   policy.Policy
+	vip      *viper.Viper
+
+	// credentials remembers recently verified passwords so that they are not decrypted on every check.
+	credentials auth.CredentialCache
+
+	// encrypter encrypts the password hashes and TOTP secrets that are stored in the database.
+	encrypter secrets.Encrypter
+
+	// passwords hashes new passwords and verifies passwords against the stored hashes.
+	passwords auth.PasswordHasher
+
+	// oidcProviders are the OpenID Connect providers that users can log in with, by name.
+	oidcProviders map[string]oidc.Provider
 }
 
 // NewService creates a new service.
-func NewService(r repo.Repository, vc vaulty.Client, golfDataHost string, vip *viper.Viper) api.ServerInterface {
+func NewService(
+	r repo.Repository,
+	gd golfdata.Client,
+	tokens auth.Tokens,
+	notifier notify.Notifier,
+	lockout auth.Lockout,
+	encrypter secrets.Encrypter,
+	credentials auth.CredentialCache,
+	passwords auth.PasswordHasher,
+	oidcProviders map[string]oidc.Provider,
+	policy policy.Policy,
+	vip *viper.Viper,
+) api.ServerInterface {
 	return &service{
-		r:            r,
-		vc:           vc,
-		golfDataHost: golfDataHost,
-		vip:          vip,
+		r:        r,
+		gd:       gd,
+		tokens:   tokens,
+		notifier: notifier,
+		lockout:  lockout,
+		policy:   policy,
+		vip:      vip,
+
+		encrypter:     encrypter,
+		credentials:   credentials,
+		passwords:     passwords,
+		oidcProviders: oidcProviders,
 	}
 }
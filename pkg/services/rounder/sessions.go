@@ -0,0 +1,232 @@
+package rounder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/auth"
+	api "github.com/Jacobbrewer1/golf-stats-tracker/pkg/codegen/apis/rounder"
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/logging"
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/models"
+	repo "github.com/Jacobbrewer1/golf-stats-tracker/pkg/repositories/rounder"
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/utils"
+	usql "github.com/Jacobbrewer1/golf-stats-tracker/pkg/utils/sql"
+	"github.com/Jacobbrewer1/uhttp"
+)
+
+const (
+	// defaultRefreshTokenTTL is the default time a session can be refreshed for without logging in again.
+	defaultRefreshTokenTTL = 30 * 24 * time.Hour
+
+	// maxUserAgentLength is the maximum length of the user agent stored against a session.
+	maxUserAgentLength = 255
+)
+
+func (s *service) RefreshToken(w http.ResponseWriter, r *http.Request) {
+	req := new(api.RefreshTokenRequest)
+	err := uhttp.DecodeRequestJSON(r, req)
+	if err != nil {
+		uhttp.SendErrorMessageWithStatus(w, http.StatusBadRequest, "error decoding request body", err)
+		return
+	} else if req.RefreshToken == "" {
+		uhttp.SendMessageWithStatus(w, http.StatusBadRequest, "refresh_token is required")
+		return
+	}
+
+	oldHash := auth.HashOpaqueToken(req.RefreshToken)
+	session, err := s.r.GetSessionByRefreshTokenHash(r.Context(), oldHash)
+	if err != nil {
+		switch {
+		case errors.Is(err, repo.ErrSessionNotFound):
+			s.lockout.Failure(ipLockoutKey(r))
+			uhttp.SendMessageWithStatus(w, http.StatusUnauthorized, "invalid refresh token")
+		default:
+			slog.Error("error getting session", slog.String(logging.KeyError, err.Error()))
+			uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error getting session", err)
+		}
+		return
+	}
+
+	if !sessionActive(session) {
+		uhttp.SendMessageWithStatus(w, http.StatusUnauthorized, "session has expired")
+		return
+	}
+
+	// Rotate the refresh token so that a stolen refresh token can only be used once.
+	refreshToken, hash, err := auth.NewOpaqueToken()
+	if err != nil {
+		uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error creating refresh token", err)
+		return
+	}
+
+	err = s.r.RotateSessionRefreshToken(r.Context(), session.Id, oldHash, hash, time.Now().UTC())
+	if err != nil {
+		switch {
+		case errors.Is(err, repo.ErrSessionNotFound):
+			// The refresh token was used by another refresh, or the session was revoked, since it was read.
+			s.lockout.Failure(ipLockoutKey(r))
+			uhttp.SendMessageWithStatus(w, http.StatusUnauthorized, "invalid refresh token")
+		default:
+			slog.Error("error updating session", slog.String(logging.KeyError, err.Error()))
+			uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error updating session", err)
+		}
+		return
+	}
+
+	token, err := s.sessionToken(session, refreshToken)
+	if err != nil {
+		slog.Error("error issuing token", slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error issuing token", err)
+		return
+	}
+
+	err = uhttp.Encode(w, http.StatusOK, token)
+	if err != nil {
+		slog.Error("error encoding response", slog.String(logging.KeyError, err.Error()))
+		return
+	}
+}
+
+func (s *service) Logout(w http.ResponseWriter, r *http.Request) {
+	err := s.revokeSession(r.Context(), utils.UserIdFromContext(r.Context()), utils.SessionIdFromContext(r.Context()))
+	if err != nil && !errors.Is(err, repo.ErrSessionNotFound) {
+		slog.Error("error revoking session", slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error revoking session", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *service) GetSessions(w http.ResponseWriter, r *http.Request) {
+	sessions, err := s.r.GetActiveSessionsByUserId(r.Context(), utils.UserIdFromContext(r.Context()))
+	if err != nil {
+		slog.Error("error getting sessions", slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error getting sessions", err)
+		return
+	}
+
+	currentId := utils.SessionIdFromContext(r.Context())
+
+	resp := &api.SessionsResponse{
+		Sessions: make([]api.Session, 0, len(sessions.Items)),
+		Total:    sessions.Total,
+	}
+
+	for _, session := range sessions.Items {
+		apiSession := api.Session{
+			Id:         utils.Ptr(int64(session.Id)),
+			CreatedAt:  utils.Ptr(session.CreatedAt),
+			LastUsedAt: utils.Ptr(session.LastUsedAt),
+			ExpiresAt:  utils.Ptr(session.ExpiresAt),
+			Current:    utils.Ptr(session.Id == currentId),
+		}
+
+		if session.UserAgent.Valid {
+			apiSession.UserAgent = utils.Ptr(session.UserAgent.String)
+		}
+
+		resp.Sessions = append(resp.Sessions, apiSession)
+	}
+
+	err = uhttp.Encode(w, http.StatusOK, resp)
+	if err != nil {
+		slog.Error("error encoding response", slog.String(logging.KeyError, err.Error()))
+		return
+	}
+}
+
+func (s *service) RevokeSession(w http.ResponseWriter, r *http.Request, sessionId api.PathSessionId) {
+	err := s.revokeSession(r.Context(), utils.UserIdFromContext(r.Context()), int(sessionId))
+	if err != nil {
+		switch {
+		case errors.Is(err, repo.ErrSessionNotFound):
+			uhttp.SendMessageWithStatus(w, http.StatusNotFound, "session not found")
+		default:
+			slog.Error("error revoking session", slog.String(logging.KeyError, err.Error()))
+			uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error revoking session", err)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// startSession starts a new session for the user, returning the tokens for the session.
+func (s *service) startSession(r *http.Request, userId int) (*api.Token, error) {
+	refreshToken, hash, err := auth.NewOpaqueToken()
+	if err != nil {
+		return nil, fmt.Errorf("error creating refresh token: %w", err)
+	}
+
+	ttl := defaultRefreshTokenTTL
+	if s.vip.IsSet("auth.refresh_token.ttl") {
+		ttl = s.vip.GetDuration("auth.refresh_token.ttl")
+	}
+
+	now := time.Now().UTC()
+	session := &models.Session{
+		UserId:           userId,
+		RefreshTokenHash: hash,
+		CreatedAt:        now,
+		LastUsedAt:       now,
+		ExpiresAt:        now.Add(ttl),
+	}
+
+	if ua := r.UserAgent(); ua != "" {
+		if len(ua) > maxUserAgentLength {
+			ua = ua[:maxUserAgentLength]
+		}
+		session.UserAgent = *usql.NewNullString(ua)
+	}
+
+	err = s.r.CreateSession(r.Context(), session)
+	if err != nil {
+		return nil, fmt.Errorf("error creating session: %w", err)
+	}
+
+	// A session is only started when the user logs in, so the login is recorded here. Failing to record it does not
+	// stop the user from logging in.
+	err = s.r.UpdateUserLastLogin(r.Context(), userId, now)
+	if err != nil {
+		slog.Error("error updating last login", slog.String(logging.KeyError, err.Error()))
+	}
+
+	return s.sessionToken(session, refreshToken)
+}
+
+// sessionToken issues an access token for the session.
+func (s *service) sessionToken(session *models.Session, refreshToken string) (*api.Token, error) {
+	token, err := s.tokens.Issue(session.UserId, session.Id)
+	if err != nil {
+		return nil, fmt.Errorf("error issuing token: %w", err)
+	}
+
+	return &api.Token{
+		Token:        utils.Ptr(token.Value),
+		TokenType:    utils.Ptr(auth.TokenTypeBearer),
+		ExpiresAt:    utils.Ptr(token.ExpiresAt),
+		RefreshToken: utils.Ptr(refreshToken),
+	}, nil
+}
+
+// revokeSession revokes the session, returning repo.ErrSessionNotFound if the session does not belong to the user.
+func (s *service) revokeSession(ctx context.Context, userId int, sessionId int) error {
+	session, err := s.r.GetSessionById(ctx, sessionId)
+	if err != nil {
+		return err
+	} else if session.UserId != userId {
+		return repo.ErrSessionNotFound
+	}
+
+	return s.r.RevokeSession(ctx, sessionId)
+}
+
+// sessionActive returns whether the session can still be used.
+func sessionActive(session *models.Session) bool {
+	return !session.RevokedAt.Valid && time.Now().UTC().Before(session.ExpiresAt)
+}
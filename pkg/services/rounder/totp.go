@@ -0,0 +1,252 @@
+package rounder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/auth"
+	api "github.com/Jacobbrewer1/golf-stats-tracker/pkg/codegen/apis/rounder"
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/logging"
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/models"
+	repo "github.com/Jacobbrewer1/golf-stats-tracker/pkg/repositories/rounder"
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/utils"
+	usql "github.com/Jacobbrewer1/golf-stats-tracker/pkg/utils/sql"
+	"github.com/Jacobbrewer1/uhttp"
+)
+
+const (
+	// defaultTotpIssuer is the default issuer shown in authenticator apps.
+	defaultTotpIssuer = "rounder"
+
+	// defaultLoginChallengeTTL is the default time the user has to send the two-factor code after the first factor.
+	defaultLoginChallengeTTL = 5 * time.Minute
+
+	secondFactorTotp         = "totp"
+	secondFactorRecoveryCode = "recovery code"
+)
+
+var (
+	// errSecondFactorRequired is returned when the user has two-factor authentication enabled but sent no code.
+	errSecondFactorRequired = errors.New("two-factor code required")
+
+	// errInvalidSecondFactor is returned when the totp or recovery code is wrong or has already been used.
+	errInvalidSecondFactor = errors.New("invalid two-factor code")
+)
+
+func (s *service) EnrolTotp(w http.ResponseWriter, r *http.Request) {
+	userId := utils.UserIdFromContext(r.Context())
+	user, err := s.r.GetUserById(r.Context(), userId)
+	if err != nil {
+		slog.Error("error getting user", slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error getting user", err)
+		return
+	}
+
+	existing, err := s.r.GetUserTotp(r.Context(), userId)
+	if err != nil && !errors.Is(err, repo.ErrUserTotpNotFound) {
+		slog.Error("error getting user totp", slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error getting user totp", err)
+		return
+	} else if err == nil && existing.ConfirmedAt.Valid {
+		uhttp.SendMessageWithStatus(w, http.StatusConflict, "two-factor authentication is already enabled")
+		return
+	}
+
+	secret, err := auth.NewTOTPSecret()
+	if err != nil {
+		uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error creating totp secret", err)
+		return
+	}
+
+	encryptedSecret, err := s.encrypter.Encrypt(r.Context(), secret)
+	if err != nil {
+		slog.Error("error encrypting totp secret", slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error encrypting totp secret", err)
+		return
+	}
+
+	err = s.r.CreateUserTotp(r.Context(), &models.UserTotp{
+		UserId:    userId,
+		Secret:    encryptedSecret,
+		CreatedAt: time.Now().UTC(),
+	})
+	if err != nil {
+		slog.Error("error creating user totp", slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error creating user totp", err)
+		return
+	}
+
+	issuer := defaultTotpIssuer
+	if s.vip.IsSet("auth.totp.issuer") {
+		issuer = s.vip.GetString("auth.totp.issuer")
+	}
+
+	resp := &api.TotpEnrolment{
+		Secret:     secret,
+		OtpauthUri: auth.TOTPURI(issuer, user.Username, secret),
+	}
+
+	err = uhttp.Encode(w, http.StatusOK, resp)
+	if err != nil {
+		slog.Error("error encoding response", slog.String(logging.KeyError, err.Error()))
+		return
+	}
+}
+
+func (s *service) ConfirmTotp(w http.ResponseWriter, r *http.Request) {
+	req := new(api.TotpConfirm)
+	err := uhttp.DecodeRequestJSON(r, req)
+	if err != nil {
+		uhttp.SendErrorMessageWithStatus(w, http.StatusBadRequest, "error decoding request body", err)
+		return
+	} else if req.Code == "" {
+		uhttp.SendMessageWithStatus(w, http.StatusBadRequest, "code is required")
+		return
+	}
+
+	userId := utils.UserIdFromContext(r.Context())
+	totp, err := s.r.GetUserTotp(r.Context(), userId)
+	if err != nil {
+		switch {
+		case errors.Is(err, repo.ErrUserTotpNotFound):
+			uhttp.SendMessageWithStatus(w, http.StatusNotFound, "not enrolled in two-factor authentication")
+		default:
+			slog.Error("error getting user totp", slog.String(logging.KeyError, err.Error()))
+			uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error getting user totp", err)
+		}
+		return
+	} else if totp.ConfirmedAt.Valid {
+		uhttp.SendMessageWithStatus(w, http.StatusConflict, "two-factor authentication is already enabled")
+		return
+	}
+
+	secret, err := s.encrypter.Decrypt(r.Context(), totp.Secret)
+	if err != nil {
+		slog.Error("error decrypting totp secret", slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error decrypting totp secret", err)
+		return
+	}
+
+	step, err := auth.VerifyTOTP(secret, req.Code, time.Now())
+	if err != nil {
+		uhttp.SendMessageWithStatus(w, http.StatusBadRequest, "invalid code")
+		return
+	}
+
+	codes, hashes, err := auth.NewRecoveryCodes(auth.RecoveryCodeCount)
+	if err != nil {
+		uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error creating recovery codes", err)
+		return
+	}
+
+	totp.ConfirmedAt = *usql.NewNullTime(time.Now().UTC())
+	totp.LastUsedStep = *usql.NewNullInt64(step)
+
+	err = s.r.ConfirmUserTotp(r.Context(), totp, hashes)
+	if err != nil {
+		slog.Error("error confirming user totp", slog.String(logging.KeyError, err.Error()))
+		uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error confirming user totp", err)
+		return
+	}
+
+	s.audit(r, auditEvent{
+		actorId:    userId,
+		action:     auditActionUserTotpEnable,
+		targetType: auditTargetUser,
+		targetId:   userId,
+	})
+
+	err = uhttp.Encode(w, http.StatusOK, &api.RecoveryCodes{RecoveryCodes: codes})
+	if err != nil {
+		slog.Error("error encoding response", slog.String(logging.KeyError, err.Error()))
+		return
+	}
+}
+
+// checkSecondFactor checks the totp or recovery code of a user that has two-factor authentication enabled, returning
+// the factor that was used. Nothing is checked for users without two-factor authentication.
+func (s *service) checkSecondFactor(ctx context.Context, userId int, totpCode, recoveryCode *string) (string, error) {
+	totp, err := s.r.GetUserTotp(ctx, userId)
+	switch {
+	case errors.Is(err, repo.ErrUserTotpNotFound):
+		return "", nil
+	case err != nil:
+		return "", fmt.Errorf("error getting user totp: %w", err)
+	case !totp.ConfirmedAt.Valid:
+		return "", nil
+	}
+
+	switch {
+	case recoveryCode != nil && *recoveryCode != "":
+		err = s.r.UseRecoveryCode(ctx, userId, auth.HashRecoveryCode(*recoveryCode))
+		if errors.Is(err, repo.ErrRecoveryCodeNotFound) {
+			return "", errInvalidSecondFactor
+		} else if err != nil {
+			return "", err
+		}
+
+		return secondFactorRecoveryCode, nil
+	case totpCode != nil && *totpCode != "":
+		secret, err := s.encrypter.Decrypt(ctx, totp.Secret)
+		if err != nil {
+			return "", fmt.Errorf("error decrypting totp secret: %w", err)
+		}
+
+		step, err := auth.VerifyTOTP(secret, *totpCode, time.Now())
+		if errors.Is(err, auth.ErrInvalidTOTPCode) {
+			return "", errInvalidSecondFactor
+		} else if err != nil {
+			return "", err
+		}
+
+		// Each code can only be used once, so that a code seen over someone's shoulder cannot be replayed.
+		err = s.r.UseTotpStep(ctx, totp.Id, step)
+		if errors.Is(err, repo.ErrTotpCodeUsed) {
+			return "", errInvalidSecondFactor
+		} else if err != nil {
+			return "", err
+		}
+
+		return secondFactorTotp, nil
+	default:
+		return "", errSecondFactorRequired
+	}
+}
+
+// createLoginChallenge creates a challenge for a user that passed the first factor described by details and still
+// needs to send a two-factor code to log in.
+func (s *service) createLoginChallenge(ctx context.Context, userId int, details string) (*api.TwoFactorChallenge, error) {
+	token, tokenHash, err := auth.NewOpaqueToken()
+	if err != nil {
+		return nil, fmt.Errorf("error creating challenge: %w", err)
+	}
+
+	ttl := defaultLoginChallengeTTL
+	if s.vip.IsSet("auth.totp.challenge_ttl") {
+		ttl = s.vip.GetDuration("auth.totp.challenge_ttl")
+	}
+
+	now := time.Now().UTC()
+	challenge := &models.LoginChallenge{
+		UserId:    userId,
+		TokenHash: tokenHash,
+		Details:   details,
+		CreatedAt: now,
+		ExpiresAt: now.Add(ttl),
+	}
+
+	err = s.r.CreateLoginChallenge(ctx, challenge)
+	if err != nil {
+		return nil, fmt.Errorf("error creating login challenge: %w", err)
+	}
+
+	return &api.TwoFactorChallenge{
+		Message:   errSecondFactorRequired.Error(),
+		Challenge: &token,
+		ExpiresAt: &challenge.ExpiresAt,
+	}, nil
+}
@@ -1,18 +1,20 @@
 package rounder
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
 	"strings"
 
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/auth"
 	api "github.com/Jacobbrewer1/golf-stats-tracker/pkg/codegen/apis/rounder"
 	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/logging"
 	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/models"
 	repo "github.com/Jacobbrewer1/golf-stats-tracker/pkg/repositories/rounder"
 	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/utils"
 	"github.com/Jacobbrewer1/uhttp"
-	"github.com/Jacobbrewer1/vaulty"
 )
 
 func (s *service) CreateUser(w http.ResponseWriter, r *http.Request) {
@@ -30,7 +32,7 @@ func (s *service) CreateUser(w http.ResponseWriter, r *http.Request) {
 	user.Id = nil
 
 	// Ensure that the username is unique
-	_, err = s.r.UserByUsername(strings.ToLower(*user.Username))
+	_, err = s.r.UserByUsername(r.Context(), strings.ToLower(*user.Username))
 	if err != nil {
 		switch {
 		case errors.Is(err, repo.ErrUserNotFound):
@@ -50,30 +52,25 @@ func (s *service) CreateUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Hash the password
-	hashedPassword, err := utils.HashPassword(u.Password)
-	if err != nil {
-		uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error hashing password", err)
-		return
-	}
-	u.Password = hashedPassword
-
-	// Encrypt the password with vault
-	encryptedPassword, err := s.vc.Path(
-		s.vip.GetString("vault.transit.key"),
-		vaulty.WithPrefix(s.vip.GetString("vault.transit.name")),
-	).TransitEncrypt(r.Context(), u.Password)
+	encryptedPassword, err := s.encryptPassword(r.Context(), u.Password)
 	if err != nil {
 		uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error encrypting password", err)
 		return
 	}
-	u.Password = vaulty.GetTransitCipherText(encryptedPassword)
+	u.Password = encryptedPassword
 
-	if err := s.r.CreateUser(u); err != nil {
+	if err := s.r.CreateUser(r.Context(), u); err != nil {
 		uhttp.SendErrorMessageWithStatus(w, http.StatusInternalServerError, "error creating user", err)
 		return
 	}
 
+	s.audit(r, auditEvent{
+		actorId:    u.Id,
+		action:     auditActionUserCreate,
+		targetType: auditTargetUser,
+		targetId:   u.Id,
+	})
+
 	err = uhttp.Encode(w, http.StatusCreated, s.modelAsUser(u))
 	if err != nil {
 		slog.Error("error encoding response", slog.String(logging.KeyError, err.Error()))
@@ -82,10 +79,16 @@ func (s *service) CreateUser(w http.ResponseWriter, r *http.Request) {
 }
 
 func (s *service) modelAsUser(u *models.User) *api.User {
+	role := u.Role
+	if role == "" {
+		role = string(auth.RolePlayer)
+	}
+
 	return &api.User{
 		Id:       utils.Ptr(int64(u.Id)),
 		Name:     utils.Ptr(u.Name),
 		Username: utils.Ptr(u.Username),
+		Role:     utils.Ptr(role),
 	}
 }
 
@@ -99,6 +102,8 @@ func (s *service) userAsModel(user api.User) (*models.User, error) {
 
 	if user.Password == nil {
 		return nil, utils.NewHttpError(http.StatusBadRequest, "password is required")
+	} else if err := validatePassword(*user.Password); err != nil {
+		return nil, utils.NewHttpError(http.StatusBadRequest, err.Error())
 	}
 	u.Password = *user.Password
 
@@ -107,5 +112,23 @@ func (s *service) userAsModel(user api.User) (*models.User, error) {
 	}
 	u.Name = *user.Name
 
+	// New users are always players, only admins can change the role.
+	u.Role = string(auth.RolePlayer)
+
 	return u, nil
 }
+
+// encryptPassword hashes the password and encrypts the hash, returning the value to store.
+func (s *service) encryptPassword(ctx context.Context, password string) (string, error) {
+	hashedPassword, err := s.passwords.Hash(password)
+	if err != nil {
+		return "", fmt.Errorf("error hashing password: %w", err)
+	}
+
+	encryptedPassword, err := s.encrypter.Encrypt(ctx, hashedPassword)
+	if err != nil {
+		return "", fmt.Errorf("error encrypting password: %w", err)
+	}
+
+	return encryptedPassword, nil
+}
@@ -0,0 +1,124 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+// DriverName is the name of the database/sql driver that runs the MySQL dialect queries of the models and
+// repositories on SQLite.
+const DriverName = "sqlite3_mysql"
+
+// ErrUnsupportedQuery is returned for a query that uses a part of the MySQL dialect that the driver cannot rewrite for
+// SQLite.
+var ErrUnsupportedQuery = errors.New("query is not supported on sqlite")
+
+var (
+	// upsertRegex matches the MySQL upsert clause, which SQLite writes as ON CONFLICT DO UPDATE SET.
+	upsertRegex = regexp.MustCompile(`(?i)\s+ON DUPLICATE KEY UPDATE\s+`)
+
+	// upsertValuesRegex matches the references to the inserted values in a MySQL upsert, which SQLite calls excluded.
+	upsertValuesRegex = regexp.MustCompile("(?i)VALUES\\((`?\\w+`?)\\)")
+
+	// forUpdateRegex matches row locks, which SQLite does not need as only one connection can write at a time.
+	forUpdateRegex = regexp.MustCompile(`(?i)\s+FOR UPDATE\b`)
+
+	// unsupported matches the parts of the MySQL dialect that SQLite does not understand and that have no rewrite.
+	unsupported = []struct {
+		construct string
+		regex     *regexp.Regexp
+	}{
+		{"named lock", regexp.MustCompile(`(?i)\b(GET_LOCK|RELEASE_LOCK|RELEASE_ALL_LOCKS|IS_FREE_LOCK|IS_USED_LOCK)\s*\(`)},
+		{"shared row lock", regexp.MustCompile(`(?i)\b(LOCK IN SHARE MODE|FOR SHARE)\b`)},
+		{"row lock option", regexp.MustCompile(`(?i)\bFOR UPDATE\s+(NOWAIT|SKIP LOCKED|WAIT\b)`)},
+		{"multiple table delete", regexp.MustCompile(`(?i)^\s*DELETE\s+\w+(\s*,\s*\w+)*\s+FROM\b`)},
+		{"multiple table update", regexp.MustCompile(`(?i)^\s*UPDATE\s+\S+(\s+\w+)?\s+((INNER|LEFT|RIGHT|CROSS)\s+)?JOIN\b`)},
+		{"insert ignore", regexp.MustCompile(`(?i)^\s*INSERT\s+IGNORE\b`)},
+		{"upsert row alias", regexp.MustCompile(`(?i)\)\s+AS\s+\w+\s+ON DUPLICATE KEY UPDATE\b`)},
+		{"mysql date function", regexp.MustCompile(`(?i)\b(NOW|CURDATE|CURTIME|UTC_TIMESTAMP|UNIX_TIMESTAMP|DATE_ADD|DATE_SUB|TIMESTAMPDIFF|DATE_FORMAT)\s*\(`)},
+		{"mysql information function", regexp.MustCompile(`(?i)\b(LAST_INSERT_ID|FOUND_ROWS|ROW_COUNT)\s*\(|\bSQL_CALC_FOUND_ROWS\b`)},
+	}
+)
+
+func init() {
+	sql.Register(DriverName, &dialectDriver{parent: new(sqlite3.SQLiteDriver)})
+}
+
+// rewriteQuery rewrites the upserts and row locks of a query to their SQLite equivalents. Any other query must be
+// written in SQL that both databases understand, such as deleting with a subquery rather than a join, and the parts of
+// the MySQL dialect that are known to have no SQLite equivalent are rejected with ErrUnsupportedQuery rather than left
+// to fail, or worse to behave differently, when they are run.
+func rewriteQuery(query string) (string, error) {
+	for _, u := range unsupported {
+		if u.regex.MatchString(query) {
+			return "", fmt.Errorf("%w: %s", ErrUnsupportedQuery, u.construct)
+		}
+	}
+
+	if loc := upsertRegex.FindStringIndex(query); loc != nil {
+		query = query[:loc[0]] + " ON CONFLICT DO UPDATE SET " + upsertValuesRegex.ReplaceAllString(query[loc[1]:], "excluded.$1")
+	}
+
+	return forUpdateRegex.ReplaceAllString(query, ""), nil
+}
+
+// dialectDriver opens SQLite connections that rewrite each query before it is run.
+type dialectDriver struct {
+	parent *sqlite3.SQLiteDriver
+}
+
+func (d *dialectDriver) Open(dsn string) (driver.Conn, error) {
+	conn, err := d.parent.Open(dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dialectConn{SQLiteConn: conn.(*sqlite3.SQLiteConn)}, nil
+}
+
+// dialectConn is a SQLite connection that rewrites each query before it is run.
+type dialectConn struct {
+	*sqlite3.SQLiteConn
+}
+
+func (c *dialectConn) Prepare(query string) (driver.Stmt, error) {
+	query, err := rewriteQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.SQLiteConn.Prepare(query)
+}
+
+func (c *dialectConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	query, err := rewriteQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.SQLiteConn.PrepareContext(ctx, query)
+}
+
+func (c *dialectConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	query, err := rewriteQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.SQLiteConn.ExecContext(ctx, query, args)
+}
+
+func (c *dialectConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	query, err := rewriteQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.SQLiteConn.QueryContext(ctx, query, args)
+}
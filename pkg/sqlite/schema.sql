@@ -0,0 +1,300 @@
+-- The schema of pkg/models/ddl/golfstats.sql translated to SQLite, for running locally and in tests without MariaDB.
+-- Every migration in pkg/migrations/sql must be made here too, which TestSchemaParity checks.
+
+create table if not exists user
+(
+    id             integer primary key autoincrement,
+    name           varchar(50)  not null,
+    username       varchar(100) not null,
+    password       text         not null,
+    last_login     datetime     null,
+    role           varchar(20)  default 'player' not null,
+    email          varchar(255) null,
+    gender         varchar(20)  null,
+    date_of_birth  date         null,
+    home_course_id int          null,
+    constraint user_username_uindex
+        unique (username)
+);
+
+create table if not exists course
+(
+    id          integer primary key autoincrement,
+    external_id int          null,
+    user_id     int          null,
+    name        varchar(255) not null,
+    constraint course_external_id_uindex
+        unique (external_id),
+    constraint course_user_id_fk
+        foreign key (user_id) references user (id)
+);
+
+create table if not exists course_details
+(
+    id                integer primary key autoincrement,
+    course_id         int           not null,
+    external_id       int           not null,
+    version           int           not null,
+    marker            varchar(255)  null,
+    slope             int           not null,
+    course_rating     decimal(4, 1) not null,
+    front_nine_par    int           not null,
+    back_nine_par     int           not null,
+    total_par         int           not null,
+    front_nine_yards  int           not null,
+    back_nine_yards   int           not null,
+    total_yards       int           not null,
+    front_nine_meters int           not null,
+    back_nine_meters  int           not null,
+    total_meters      int           not null,
+    constraint course_details_course_id_external_id_version_uindex
+        unique (course_id, external_id, version),
+    constraint course_details_course_id_fk
+        foreign key (course_id) references course (id)
+);
+
+create table if not exists hole
+(
+    id                integer primary key autoincrement,
+    course_details_id int not null,
+    number            int not null,
+    par               int not null,
+    stroke            int not null,
+    distance_yards    int not null,
+    distance_meters   int not null,
+    constraint hole_course_details_id_fk
+        foreign key (course_details_id) references course_details (id)
+);
+
+create table if not exists round
+(
+    id                integer primary key autoincrement,
+    user_id           int                                not null,
+    course_details_id int                                not null,
+    tee_time          datetime default current_timestamp not null,
+    constraint round_user_id_fk
+        foreign key (user_id) references user (id),
+    constraint round_course_details_id_fk
+        foreign key (course_details_id) references course_details (id)
+);
+
+create table if not exists hole_stats
+(
+    id           integer primary key autoincrement,
+    round_id     int          not null,
+    hole_id      int          not null,
+    score        int          not null,
+    fairway_hit  varchar(20)  not null check (fairway_hit in ('HIT', 'LEFT', 'RIGHT', 'SHORT', 'LONG', 'NOT_APPLICABLE')),
+    green_hit    varchar(20)  not null check (green_hit in ('HIT', 'LEFT', 'RIGHT', 'SHORT', 'LONG')),
+    pin_location varchar(100) not null,
+    putts        int          not null,
+    penalties    int          not null,
+    constraint hole_stats_round_id_hole_id_uindex
+        unique (round_id, hole_id),
+    constraint hole_stats_round_id_fk
+        foreign key (round_id) references round (id),
+    constraint hole_stats_hole_id_fk
+        foreign key (hole_id) references hole (id)
+);
+
+create table if not exists round_stats
+(
+    id               integer primary key autoincrement,
+    round_id         int           not null,
+    avg_fairways_hit decimal(5, 2) not null,
+    avg_greens_hit   decimal(5, 2) not null,
+    avg_putts        decimal(5, 2) not null,
+    penalties        int           not null,
+    avg_par_3        decimal(5, 2) not null,
+    avg_par_4        decimal(5, 2) not null,
+    avg_par_5        decimal(5, 2) not null,
+    constraint round_stats_round_id_fk
+        foreign key (round_id) references round (id)
+);
+
+create table if not exists round_hit_stats
+(
+    id             integer primary key autoincrement,
+    round_stats_id int         not null,
+    type           varchar(20) not null check (type in ('GREEN', 'FAIRWAY')),
+    miss           varchar(15) not null,
+    count          int         not null,
+    constraint round_hit_stats_round_stats_id_fk
+        foreign key (round_stats_id) references round_stats (id)
+);
+
+create table if not exists golf_data_cache
+(
+    cache_key  varchar(255) not null
+        primary key,
+    value      text         not null,
+    fetched_at datetime     not null
+);
+
+create table if not exists session
+(
+    id                 integer primary key autoincrement,
+    user_id            int          not null,
+    refresh_token_hash char(64)     not null,
+    user_agent         varchar(255) null,
+    created_at         datetime     not null,
+    last_used_at       datetime     not null,
+    expires_at         datetime     not null,
+    revoked_at         datetime     null,
+    constraint session_refresh_token_hash_uindex
+        unique (refresh_token_hash),
+    constraint session_user_id_fk
+        foreign key (user_id) references user (id)
+);
+
+create table if not exists password_reset
+(
+    id         integer primary key autoincrement,
+    user_id    int      not null,
+    token_hash char(64) not null,
+    created_at datetime not null,
+    expires_at datetime not null,
+    used_at    datetime null,
+    constraint password_reset_token_hash_uindex
+        unique (token_hash),
+    constraint password_reset_user_id_fk
+        foreign key (user_id) references user (id)
+);
+
+create table if not exists rate_limit_bucket
+(
+    bucket_key varchar(255) not null
+        primary key,
+    tokens     double       not null,
+    updated_at datetime     not null
+);
+
+create table if not exists api_key
+(
+    id           integer primary key autoincrement,
+    user_id      int          not null,
+    name         varchar(100) not null,
+    key_hash     char(64)     not null,
+    scopes       varchar(255) not null,
+    created_at   datetime     not null,
+    last_used_at datetime     null,
+    expires_at   datetime     null,
+    revoked_at   datetime     null,
+    constraint api_key_key_hash_uindex
+        unique (key_hash),
+    constraint api_key_user_id_fk
+        foreign key (user_id) references user (id)
+);
+
+create table if not exists oidc_login
+(
+    id            integer primary key autoincrement,
+    state_hash    char(64)     not null,
+    provider      varchar(50)  not null,
+    code_verifier varchar(128) not null,
+    nonce         varchar(64)  not null,
+    user_id       int          null,
+    created_at    datetime     not null,
+    expires_at    datetime     not null,
+    constraint oidc_login_state_hash_uindex
+        unique (state_hash)
+);
+
+create table if not exists user_identity
+(
+    id            integer primary key autoincrement,
+    user_id       int          not null,
+    provider      varchar(50)  not null,
+    issuer        varchar(255) not null,
+    subject       varchar(255) not null,
+    email         varchar(255) null,
+    created_at    datetime     not null,
+    last_login_at datetime     null,
+    constraint user_identity_issuer_subject_uindex
+        unique (issuer, subject),
+    constraint user_identity_user_id_fk
+        foreign key (user_id) references user (id)
+);
+
+create table if not exists coach_grant
+(
+    id         integer primary key autoincrement,
+    player_id  int      not null,
+    coach_id   int      not null,
+    created_at datetime not null,
+    constraint coach_grant_player_id_coach_id_uindex
+        unique (player_id, coach_id),
+    constraint coach_grant_player_id_fk
+        foreign key (player_id) references user (id)
+);
+
+create table if not exists audit_event
+(
+    id          integer primary key autoincrement,
+    actor_id    int          null,
+    action      varchar(50)  not null,
+    target_type varchar(50)  null,
+    target_id   int          null,
+    ip_address  varchar(45)  null,
+    details     text         null,
+    created_at  datetime     not null
+);
+
+create index if not exists audit_event_actor_id_index
+    on audit_event (actor_id);
+
+create index if not exists audit_event_created_at_index
+    on audit_event (created_at);
+
+create table if not exists user_totp
+(
+    id             integer primary key autoincrement,
+    user_id        int          not null,
+    secret         varchar(255) not null,
+    created_at     datetime     not null,
+    confirmed_at   datetime     null,
+    last_used_step bigint       null,
+    constraint user_totp_user_id_uindex
+        unique (user_id),
+    constraint user_totp_user_id_fk
+        foreign key (user_id) references user (id)
+);
+
+create table if not exists recovery_code
+(
+    id        integer primary key autoincrement,
+    user_id   int      not null,
+    code_hash char(64) not null,
+    used_at   datetime null,
+    constraint recovery_code_code_hash_uindex
+        unique (code_hash),
+    constraint recovery_code_user_id_fk
+        foreign key (user_id) references user (id)
+);
+
+create table if not exists user_preference
+(
+    id             integer primary key autoincrement,
+    user_id        int                   not null,
+    distance_unit  varchar(10)  default 'yards' not null,
+    default_marker varchar(255)          null,
+    timezone       varchar(64)  default 'UTC' not null,
+    constraint user_preference_user_id_uindex
+        unique (user_id),
+    constraint user_preference_user_id_fk
+        foreign key (user_id) references user (id)
+);
+
+create table if not exists login_challenge
+(
+    id         integer primary key autoincrement,
+    user_id    int          not null,
+    token_hash char(64)     not null,
+    details    varchar(255) not null,
+    created_at datetime     not null,
+    expires_at datetime     not null,
+    constraint login_challenge_token_hash_uindex
+        unique (token_hash),
+    constraint login_challenge_user_id_fk
+        foreign key (user_id) references user (id)
+);
@@ -0,0 +1,222 @@
+package sqlite
+
+import (
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/migrations"
+	"github.com/stretchr/testify/require"
+)
+
+// schemaTable is the part of a table that the SQLite schema must keep in step with the migrations.
+type schemaTable struct {
+	Columns    []string
+	PrimaryKey []string
+	UniqueKeys []string
+}
+
+var (
+	createTableRegex = regexp.MustCompile(`(?is)^create table (?:if not exists )?(\w+)\s*\((.*)\)$`)
+	alterTableRegex  = regexp.MustCompile(`(?is)^alter table (\w+)\s+(.*)$`)
+	dropTableRegex   = regexp.MustCompile(`(?is)^drop table (?:if exists )?(\w+)$`)
+	keyColumnsRegex  = regexp.MustCompile(`\(([^)]*)\)`)
+)
+
+// TestSchemaParity checks that the SQLite schema and the MariaDB DDL have the tables, columns and keys that the
+// migrations create.
+func TestSchemaParity(t *testing.T) {
+	all, err := migrations.All()
+	require.NoError(t, err)
+
+	want := make(map[string]*schemaTable)
+	for _, m := range all {
+		applySchemaStatements(t, want, m.Up)
+	}
+
+	ddl, err := os.ReadFile("../models/ddl/golfstats.sql")
+	require.NoError(t, err)
+
+	fromDDL := make(map[string]*schemaTable)
+	applySchemaStatements(t, fromDDL, string(ddl))
+	require.Equal(t, normaliseSchema(want), normaliseSchema(fromDDL), "pkg/models/ddl/golfstats.sql")
+
+	db, err := Open(MemoryPath)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	var tables []string
+	require.NoError(t, db.Select(&tables, `SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%'`))
+
+	got := make(map[string]*schemaTable)
+	for _, table := range tables {
+		var columns []struct {
+			Name string `db:"name"`
+			Pk   int    `db:"pk"`
+		}
+		require.NoError(t, db.Select(&columns, `SELECT name, pk FROM pragma_table_info(?) ORDER BY pk, cid`, table))
+
+		st := new(schemaTable)
+		for _, c := range columns {
+			st.Columns = append(st.Columns, c.Name)
+			if c.Pk > 0 {
+				st.PrimaryKey = append(st.PrimaryKey, c.Name)
+			}
+		}
+
+		var indexes []string
+		require.NoError(t, db.Select(&indexes, `SELECT name FROM pragma_index_list(?) WHERE "unique" = 1 AND origin != 'pk'`, table))
+
+		for _, index := range indexes {
+			var keyColumns []string
+			require.NoError(t, db.Select(&keyColumns, `SELECT name FROM pragma_index_info(?) ORDER BY seqno`, index))
+			st.UniqueKeys = append(st.UniqueKeys, strings.Join(keyColumns, ","))
+		}
+
+		got[table] = st
+	}
+
+	require.Equal(t, normaliseSchema(want), normaliseSchema(got), "pkg/sqlite/schema.sql")
+}
+
+// applySchemaStatements applies the changes that the statements make to the tables, columns and keys of the schema.
+// Statements that do not change them, such as updates and non-unique indexes, are skipped. A change that cannot be
+// followed fails the test, so that a migration cannot drift from the SQLite schema unnoticed.
+func applySchemaStatements(t *testing.T, schema map[string]*schemaTable, sql string) {
+	t.Helper()
+
+	for _, stmt := range splitSchemaStatements(sql) {
+		if m := createTableRegex.FindStringSubmatch(stmt); m != nil {
+			st := new(schemaTable)
+			for _, item := range splitSchemaList(m[2]) {
+				applyTableItem(t, st, item)
+			}
+			schema[strings.ToLower(m[1])] = st
+			continue
+		}
+
+		if m := alterTableRegex.FindStringSubmatch(stmt); m != nil {
+			st, ok := schema[strings.ToLower(m[1])]
+			require.True(t, ok, "alter of unknown table: %s", stmt)
+
+			for _, clause := range splitSchemaList(m[2]) {
+				applyAlterClause(t, st, clause)
+			}
+			continue
+		}
+
+		if m := dropTableRegex.FindStringSubmatch(stmt); m != nil {
+			delete(schema, strings.ToLower(m[1]))
+		}
+	}
+}
+
+// applyTableItem applies a column or key of a create table statement.
+func applyTableItem(t *testing.T, st *schemaTable, item string) {
+	t.Helper()
+
+	fields := strings.Fields(strings.ToLower(item))
+	if fields[0] == "constraint" {
+		fields = fields[2:]
+	}
+
+	switch fields[0] {
+	case "primary":
+		st.PrimaryKey = keyColumns(t, item)
+	case "unique":
+		st.UniqueKeys = append(st.UniqueKeys, strings.Join(keyColumns(t, item), ","))
+	case "foreign", "key", "index", "check":
+	default:
+		column := strings.Trim(fields[0], "`")
+		st.Columns = append(st.Columns, column)
+		if strings.Contains(strings.Join(fields, " "), "primary key") {
+			st.PrimaryKey = []string{column}
+		}
+	}
+}
+
+// applyAlterClause applies a clause of an alter table statement.
+func applyAlterClause(t *testing.T, st *schemaTable, clause string) {
+	t.Helper()
+
+	fields := strings.Fields(strings.ToLower(strings.ReplaceAll(clause, "if not exists", "")))
+	fields = slices.DeleteFunc(fields, func(f string) bool { return f == "if" || f == "exists" })
+
+	switch {
+	case fields[0] == "add" && fields[1] == "column":
+		st.Columns = append(st.Columns, strings.Trim(fields[2], "`"))
+	case fields[0] == "drop" && fields[1] == "column":
+		column := strings.Trim(fields[2], "`")
+		st.Columns = slices.DeleteFunc(st.Columns, func(c string) bool { return c == column })
+	case fields[0] == "add" && fields[1] == "unique":
+		st.UniqueKeys = append(st.UniqueKeys, strings.Join(keyColumns(t, clause), ","))
+	case fields[0] == "add" && fields[1] == "constraint" && fields[3] == "foreign":
+	case fields[0] == "drop" && fields[1] == "foreign":
+	case fields[0] == "modify":
+	default:
+		require.FailNow(t, "alter table clause is not followed by the schema parity test", clause)
+	}
+}
+
+// keyColumns gets the columns of a key from the first list in parentheses.
+func keyColumns(t *testing.T, item string) []string {
+	t.Helper()
+
+	m := keyColumnsRegex.FindStringSubmatch(item)
+	require.NotNil(t, m, "key without columns: %s", item)
+
+	columns := strings.Split(m[1], ",")
+	for i, c := range columns {
+		columns[i] = strings.Trim(strings.TrimSpace(strings.ToLower(c)), "`")
+	}
+	return columns
+}
+
+// splitSchemaStatements splits SQL into its statements, without their comments.
+func splitSchemaStatements(sql string) []string {
+	var lines []string
+	for _, line := range strings.Split(sql, "\n") {
+		if !strings.HasPrefix(strings.TrimSpace(line), "--") {
+			lines = append(lines, line)
+		}
+	}
+
+	var statements []string
+	for _, stmt := range strings.Split(strings.Join(lines, "\n"), ";") {
+		if stmt = strings.Join(strings.Fields(stmt), " "); stmt != "" {
+			statements = append(statements, stmt)
+		}
+	}
+	return statements
+}
+
+// splitSchemaList splits a list on the commas that are not in parentheses.
+func splitSchemaList(list string) []string {
+	var items []string
+	depth, start := 0, 0
+	for i, r := range list {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				items = append(items, strings.TrimSpace(list[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	return append(items, strings.TrimSpace(list[start:]))
+}
+
+// normaliseSchema orders the columns and keys of each table, as their order differs between the schemas.
+func normaliseSchema(schema map[string]*schemaTable) map[string]*schemaTable {
+	for _, st := range schema {
+		slices.Sort(st.Columns)
+		slices.Sort(st.UniqueKeys)
+	}
+	return schema
+}
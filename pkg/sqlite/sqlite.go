@@ -0,0 +1,44 @@
+package sqlite
+
+import (
+	_ "embed"
+	"fmt"
+	"net/url"
+
+	"github.com/Jacobbrewer1/vaulty/repositories"
+	"github.com/jmoiron/sqlx"
+)
+
+// MemoryPath is the path that opens a database that only lives in memory for as long as it is open.
+const MemoryPath = ":memory:"
+
+//go:embed schema.sql
+var schema string
+
+// Open opens the SQLite database at the path, creating it and its schema if they do not exist. The database is
+// returned in the same wrapper as the MariaDB connection, so that the repositories and stores work unchanged.
+//
+// Only a single connection is opened, which serialises the queries. That avoids SQLite's busy errors when two
+// connections write at the same time, and keeps an in memory database alive for as long as the pool is open.
+func Open(path string) (*repositories.Database, error) {
+	params := url.Values{}
+	params.Set("_foreign_keys", "on")
+	params.Set("_busy_timeout", "5000")
+
+	db, err := sqlx.Open(DriverName, fmt.Sprintf("file:%s?%s", path, params.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("error opening sqlite database: %w", err)
+	}
+
+	db.SetMaxOpenConns(1)
+	db.SetMaxIdleConns(1)
+	db.SetConnMaxLifetime(0)
+	db.SetConnMaxIdleTime(0)
+
+	if _, err := db.Exec(schema); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("error creating sqlite schema: %w", err)
+	}
+
+	return repositories.NewDatabase(db), nil
+}
@@ -0,0 +1,716 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/models"
+	"github.com/Jacobbrewer1/golf-stats-tracker/pkg/ratelimit"
+	repo "github.com/Jacobbrewer1/golf-stats-tracker/pkg/repositories/rounder"
+	usql "github.com/Jacobbrewer1/golf-stats-tracker/pkg/utils/sql"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRewriteQuery(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:  "plain query",
+			query: "SELECT `id` FROM user WHERE `username` = ?",
+			want:  "SELECT `id` FROM user WHERE `username` = ?",
+		},
+		{
+			name:  "upsert",
+			query: "INSERT INTO t (`a`, `b`) VALUES (?, ?) ON DUPLICATE KEY UPDATE `a` = VALUES(`a`), `b` = VALUES(`b`)",
+			want:  "INSERT INTO t (`a`, `b`) VALUES (?, ?) ON CONFLICT DO UPDATE SET `a` = excluded.`a`, `b` = excluded.`b`",
+		},
+		{
+			name:  "row lock",
+			query: "SELECT tokens FROM rate_limit_bucket WHERE bucket_key = ? FOR UPDATE",
+			want:  "SELECT tokens FROM rate_limit_bucket WHERE bucket_key = ?",
+		},
+		{
+			name:  "delete with subquery",
+			query: "DELETE FROM hole WHERE course_details_id IN (SELECT id FROM course_details WHERE course_id = ?)",
+			want:  "DELETE FROM hole WHERE course_details_id IN (SELECT id FROM course_details WHERE course_id = ?)",
+		},
+		{
+			name:    "named lock",
+			query:   "SELECT GET_LOCK(?, 10)",
+			wantErr: true,
+		},
+		{
+			name:    "shared row lock",
+			query:   "SELECT tokens FROM rate_limit_bucket WHERE bucket_key = ? LOCK IN SHARE MODE",
+			wantErr: true,
+		},
+		{
+			name:    "row lock option",
+			query:   "SELECT tokens FROM rate_limit_bucket WHERE bucket_key = ? FOR UPDATE SKIP LOCKED",
+			wantErr: true,
+		},
+		{
+			name:    "multiple table delete",
+			query:   "DELETE h FROM hole h INNER JOIN course_details cd ON h.course_details_id = cd.id WHERE cd.course_id = ?",
+			wantErr: true,
+		},
+		{
+			name:    "multiple table update",
+			query:   "UPDATE round r INNER JOIN course_details cd ON cd.id = r.course_details_id SET r.user_id = ?",
+			wantErr: true,
+		},
+		{
+			name:    "insert ignore",
+			query:   "INSERT IGNORE INTO t (`a`) VALUES (?)",
+			wantErr: true,
+		},
+		{
+			name:    "upsert row alias",
+			query:   "INSERT INTO t (`a`) VALUES (?) AS new ON DUPLICATE KEY UPDATE `a` = new.`a`",
+			wantErr: true,
+		},
+		{
+			name:    "date function",
+			query:   "UPDATE session SET revoked_at = NOW() WHERE id = ?",
+			wantErr: true,
+		},
+		{
+			name:    "information function",
+			query:   "SELECT LAST_INSERT_ID()",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := rewriteQuery(tt.query)
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrUnsupportedQuery)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestUnsupportedQuery(t *testing.T) {
+	db, err := Open(MemoryPath)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	_, err = db.Exec("SELECT GET_LOCK(?, 10)", "test")
+	require.ErrorIs(t, err, ErrUnsupportedQuery)
+
+	_, err = db.Query("SELECT id FROM user WHERE id = ? LOCK IN SHARE MODE", 1)
+	require.ErrorIs(t, err, ErrUnsupportedQuery)
+}
+
+func TestRepository(t *testing.T) {
+	db, err := Open(MemoryPath)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	ctx := context.Background()
+	r := repo.NewRepository(db)
+
+	user := &models.User{Name: "Test User", Username: "test", Password: "password", Role: "player"}
+	require.NoError(t, r.CreateUser(ctx, user))
+	require.NotZero(t, user.Id)
+
+	got, err := r.UserByUsername(ctx, "test")
+	require.NoError(t, err)
+	require.Equal(t, user.Id, got.Id)
+
+	_, err = r.UserByUsername(ctx, "missing")
+	require.ErrorIs(t, err, repo.ErrUserNotFound)
+
+	users, err := r.GetUsers(ctx)
+	require.NoError(t, err)
+	require.Len(t, users.Items, 1)
+	require.Equal(t, "test", users.Items[0].Username)
+
+	// Saving the preferences twice updates the existing row through the rewritten upsert.
+	require.NoError(t, r.SaveUserPreference(ctx, &models.UserPreference{UserId: user.Id, DistanceUnit: "yards", Timezone: "UTC"}))
+	require.NoError(t, r.SaveUserPreference(ctx, &models.UserPreference{UserId: user.Id, DistanceUnit: "meters", Timezone: "Europe/London"}))
+
+	pref, err := r.GetUserPreference(ctx, user.Id)
+	require.NoError(t, err)
+	require.Equal(t, "meters", pref.DistanceUnit)
+	require.Equal(t, "Europe/London", pref.Timezone)
+
+	require.NoError(t, r.UpdateUserPassword(ctx, user.Id, "new password", 0))
+
+	got, err = r.UserByUsername(ctx, "test")
+	require.NoError(t, err)
+	require.Equal(t, "new password", got.Password)
+}
+
+func TestRepositoryJoins(t *testing.T) {
+	db, err := Open(MemoryPath)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	ctx := context.Background()
+	r := repo.NewRepository(models.NewTimeoutDB(db, time.Minute))
+
+	// The joined queries are observed under their own names.
+	joinedQueries := []string{
+		"count_user_rounds", "select_user_rounds",
+		"select_round_details",
+		"count_round_holes", "select_round_holes",
+		"select_hole_stats_by_par",
+		"count_user_round_stats", "select_user_round_stats",
+		"select_round_hit_stats_by_user",
+	}
+	observed := make(map[string]uint64)
+	for _, query := range joinedQueries {
+		observed[query] = queryLatencyCount(t, query, models.QueryStatusOK)
+	}
+
+	user := &models.User{Name: "Test User", Username: "test", Password: "password", Role: "player"}
+	require.NoError(t, r.CreateUser(ctx, user))
+
+	course := &models.Course{Name: "Test Course"}
+	require.NoError(t, r.CreateCourse(ctx, course))
+
+	details := &models.CourseDetails{CourseId: course.Id, ExternalId: 1, Version: 1, Marker: *usql.NewNullString("White")}
+	require.NoError(t, r.CreateCourseDetails(ctx, details))
+
+	for i, par := range []int{4, 3, 5} {
+		require.NoError(t, r.CreateHole(ctx, &models.Hole{CourseDetailsId: details.Id, Number: i + 1, Par: par}))
+	}
+
+	round := &models.Round{UserId: user.Id, CourseDetailsId: details.Id, TeeTime: time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)}
+	require.NoError(t, r.CreateRound(ctx, round))
+
+	rounds, err := r.GetRoundsByUserId(ctx, user.Id, nil)
+	require.NoError(t, err)
+	require.Len(t, rounds.Items, 1)
+	require.Equal(t, round.Id, rounds.Items[0].Round.Id)
+	require.Equal(t, "Test Course", rounds.Items[0].Course.Name)
+	require.Equal(t, "White", rounds.Items[0].CourseDetails.Marker.String)
+
+	roundDetails, err := r.GetRoundDetailsByRoundId(ctx, round.Id)
+	require.NoError(t, err)
+	require.Equal(t, course.Id, roundDetails.Course.Id)
+	require.Len(t, roundDetails.Holes, 3)
+
+	holes, err := r.GetRoundHoles(ctx, round.Id, nil)
+	require.NoError(t, err)
+	require.Len(t, holes.Items, 3)
+	require.Equal(t, []int{1, 2, 3}, []int{holes.Items[0].Number, holes.Items[1].Number, holes.Items[2].Number})
+
+	for _, hole := range holes.Items {
+		require.NoError(t, r.SaveHoleStats(ctx, &models.HoleStats{
+			RoundId:    round.Id,
+			HoleId:     hole.Id,
+			Score:      hole.Par,
+			FairwayHit: "HIT",
+			GreenHit:   "HIT",
+			Putts:      2,
+		}))
+	}
+
+	parFours, err := r.GetAllStatsForPar(ctx, user.Id, 4)
+	require.NoError(t, err)
+	require.Len(t, parFours.Items, 1)
+	require.Equal(t, round.Id, parFours.Items[0].Round.Id)
+	require.Equal(t, 4, parFours.Items[0].Hole.Par)
+	require.Equal(t, 4, parFours.Items[0].Stats.Score)
+
+	roundStats := &models.RoundStats{RoundId: round.Id, AvgPutts: 2}
+	require.NoError(t, r.SaveRoundStats(ctx, roundStats))
+	require.NoError(t, r.SaveRoundHitStats(ctx,
+		&models.RoundHitStats{RoundStatsId: roundStats.Id, Type: usql.NewEnum(models.RoundHitStatsTypeFAIRWAY), Miss: "HIT", Count: 2},
+		&models.RoundHitStats{RoundStatsId: roundStats.Id, Type: usql.NewEnum(models.RoundHitStatsTypeGREEN), Miss: "HIT", Count: 3},
+	))
+
+	userStats, err := r.GetStatsByUserId(ctx, user.Id, nil)
+	require.NoError(t, err)
+	require.Len(t, userStats.Items, 1)
+	require.Equal(t, "Test Course", userStats.Items[0].Course.Name)
+	require.Equal(t, 2.0, userStats.Items[0].Stats.AvgPutts)
+
+	hitStats, err := r.GetUserHitStats(ctx, user.Id)
+	require.NoError(t, err)
+	require.Len(t, hitStats.Items, 2)
+	require.Equal(t, 3, hitStats.Items[1].Count)
+
+	for _, query := range joinedQueries {
+		require.Greater(t, queryLatencyCount(t, query, models.QueryStatusOK), observed[query], query)
+	}
+
+	// Deleting the round deletes its stats, after which the course can be deleted along with its tee sets and holes.
+	require.NoError(t, r.DeleteRound(ctx, round.Id))
+
+	for table, want := range map[string]int{"round": 0, "hole_stats": 0, "round_stats": 0, "round_hit_stats": 0, "hole": 3} {
+		var count int
+		require.NoError(t, db.Get(&count, "SELECT COUNT(*) FROM "+table))
+		require.Equal(t, want, count, table)
+	}
+
+	require.NoError(t, r.DeleteCourse(ctx, course.Id))
+
+	for _, table := range []string{"course", "course_details", "hole"} {
+		var count int
+		require.NoError(t, db.Get(&count, "SELECT COUNT(*) FROM "+table))
+		require.Zero(t, count, table)
+	}
+}
+
+func TestRepositoryPagination(t *testing.T) {
+	db, err := Open(MemoryPath)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	ctx := context.Background()
+	r := repo.NewRepository(db)
+
+	user := &models.User{Name: "Test User", Username: "test", Password: "password", Role: "player"}
+	require.NoError(t, r.CreateUser(ctx, user))
+
+	course := &models.Course{Name: "Test Course"}
+	require.NoError(t, r.CreateCourse(ctx, course))
+
+	details := &models.CourseDetails{CourseId: course.Id, ExternalId: 1, Version: 1}
+	require.NoError(t, r.CreateCourseDetails(ctx, details))
+
+	// Two rounds share each tee time, so that the ID breaks the ties between the pages.
+	start := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	for i := 0; i < 5; i++ {
+		round := &models.Round{UserId: user.Id, CourseDetailsId: details.Id, TeeTime: start.Add(time.Duration(i/2) * time.Hour)}
+		require.NoError(t, r.CreateRound(ctx, round))
+	}
+
+	tests := []struct {
+		name    string
+		sortBy  string
+		sortDir string
+		want    []int
+	}{
+		{name: "default", want: []int{1, 2, 3, 4, 5}},
+		{name: "id descending", sortBy: "id", sortDir: repo.SortDirDesc, want: []int{5, 4, 3, 2, 1}},
+		{name: "tee time", sortBy: "tee_time", want: []int{1, 2, 3, 4, 5}},
+		{name: "tee time descending", sortBy: "tee_time", sortDir: repo.SortDirDesc, want: []int{5, 4, 3, 2, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page := &repo.PaginationDetails{Limit: 2, SortBy: tt.sortBy, SortDir: tt.sortDir}
+			got := make([]int, 0)
+			pages := 0
+
+			for {
+				rounds, err := r.GetRoundsByUserId(ctx, user.Id, page)
+				require.NoError(t, err)
+				require.Equal(t, int64(5), rounds.Total)
+				pages++
+
+				for _, round := range rounds.Items {
+					got = append(got, round.Round.Id)
+				}
+
+				if rounds.Next == nil {
+					break
+				}
+
+				page.LastVal = rounds.Next.LastVal
+				page.LastId = rounds.Next.LastId
+			}
+
+			require.Equal(t, tt.want, got)
+			require.Equal(t, 3, pages)
+		})
+	}
+
+	_, err = r.GetRoundsByUserId(ctx, user.Id, &repo.PaginationDetails{SortBy: "password"})
+	require.ErrorIs(t, err, repo.ErrInvalidSortColumn)
+
+	_, err = r.GetRoundsByUserId(ctx, user.Id, &repo.PaginationDetails{SortBy: "tee_time", LastVal: "yesterday", LastId: "1"})
+	require.ErrorIs(t, err, repo.ErrInvalidCursor)
+
+	// A negative limit is rejected rather than selecting every round, which only nil details do.
+	_, err = r.GetRoundsByUserId(ctx, user.Id, &repo.PaginationDetails{Limit: -1})
+	require.ErrorIs(t, err, repo.ErrInvalidLimit)
+
+	all, err := r.GetRoundsByUserId(ctx, user.Id, nil)
+	require.NoError(t, err)
+	require.Len(t, all.Items, int(all.Total))
+	require.Nil(t, all.Next)
+}
+
+func TestRateLimitStore(t *testing.T) {
+	db, err := Open(MemoryPath)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	store := ratelimit.NewDatabaseStore(db)
+	limit := ratelimit.Limit{Rate: 0.001, Burst: 2}
+
+	for _, want := range []bool{true, true, false} {
+		res, err := store.Take(context.Background(), "key", limit)
+		require.NoError(t, err)
+		require.Equal(t, want, res.Allowed)
+	}
+
+	// The first request of a key creates its bucket, less the token that it took.
+	res, err := store.Take(context.Background(), "new", limit)
+	require.NoError(t, err)
+	require.True(t, res.Allowed)
+
+	var tokens float64
+	require.NoError(t, db.Get(&tokens, "SELECT tokens FROM rate_limit_bucket WHERE bucket_key = ?", "new"))
+	require.InDelta(t, 1, tokens, 0.01)
+
+	// Concurrent first requests of a key share one bucket.
+	results := make(chan bool, 5)
+	errs := make(chan error, 5)
+	for range 5 {
+		go func() {
+			res, err := store.Take(context.Background(), "concurrent", limit)
+			if err != nil {
+				errs <- err
+				return
+			}
+			results <- res.Allowed
+		}()
+	}
+
+	allowed := 0
+	for range 5 {
+		select {
+		case err := <-errs:
+			require.NoError(t, err)
+		case ok := <-results:
+			if ok {
+				allowed++
+			}
+		}
+	}
+	require.Equal(t, limit.Burst, allowed)
+}
+
+func TestRepositoryContext(t *testing.T) {
+	db, err := Open(MemoryPath)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	r := repo.NewRepository(models.NewTimeoutDB(db, time.Minute))
+
+	user := &models.User{Name: "Test User", Username: "test", Password: "password", Role: "player"}
+	require.NoError(t, r.CreateUser(context.Background(), user))
+
+	got, err := r.UserByUsername(context.Background(), "test")
+	require.NoError(t, err)
+	require.Equal(t, user.Id, got.Id)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = r.UserByUsername(ctx, "test")
+	require.ErrorIs(t, err, context.Canceled)
+	require.Equal(t, models.QueryStatusCancelled, models.QueryStatus(ctx))
+
+	ctx, cancel = context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	t.Cleanup(cancel)
+
+	_, err = r.UserByUsername(ctx, "test")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Equal(t, models.QueryStatusTimeout, models.QueryStatus(ctx))
+}
+
+func TestTimeoutDB(t *testing.T) {
+	db, err := Open(MemoryPath)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	ctx := context.Background()
+
+	// Rows are read after the query returns, so the deadline must not end when it does.
+	timeoutDB := models.NewTimeoutDB(db, time.Minute)
+	rows, err := timeoutDB.QueryContext(ctx, "SELECT 1 UNION ALL SELECT 2")
+	require.NoError(t, err)
+
+	var got []int
+	for rows.Next() {
+		var n int
+		require.NoError(t, rows.Scan(&n))
+		got = append(got, n)
+	}
+	require.NoError(t, rows.Err())
+	require.NoError(t, rows.Close())
+	require.Equal(t, []int{1, 2}, got)
+
+	tx, err := timeoutDB.BeginTxx(ctx, nil)
+	require.NoError(t, err)
+	require.NoError(t, tx.Commit())
+
+	// Every way of running a query gets the deadline.
+	timeoutDB = models.NewTimeoutDB(db, time.Nanosecond)
+
+	_, err = timeoutDB.QueryContext(ctx, "SELECT 1")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	var n int
+	require.ErrorIs(t, timeoutDB.QueryRowContext(ctx, "SELECT 1").Scan(&n), context.DeadlineExceeded)
+
+	_, err = timeoutDB.BeginTxx(ctx, nil)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// A query stopped by the deadline of the connection is observed as timed out, even though the context it was
+	// given is not done.
+	timeouts := queryLatencyCount(t, "test_timeout", models.QueryStatusTimeout)
+	_, err = timeoutDB.ExecContext(models.WithQueryName(ctx, "test_timeout"), "SELECT 1")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.NoError(t, ctx.Err())
+	require.Equal(t, timeouts+1, queryLatencyCount(t, "test_timeout", models.QueryStatusTimeout))
+	require.Zero(t, queryLatencyCount(t, "test_timeout", models.QueryStatusOK))
+
+	// Queries without a name are observed by how they were run.
+	timeoutDB = models.NewTimeoutDB(db, time.Minute)
+	gets := queryLatencyCount(t, "get", models.QueryStatusOK)
+	require.NoError(t, timeoutDB.GetContext(ctx, &n, "SELECT 1"))
+	require.Equal(t, gets+1, queryLatencyCount(t, "get", models.QueryStatusOK))
+}
+
+// queryLatencyCount gets the number of queries observed in models.DatabaseLatency with the name and status.
+func queryLatencyCount(t *testing.T, query, status string) uint64 {
+	t.Helper()
+
+	families, err := prometheus.DefaultGatherer.Gather()
+	require.NoError(t, err)
+
+	for _, family := range families {
+		if family.GetName() != "database_latency" {
+			continue
+		}
+
+		for _, metric := range family.GetMetric() {
+			labels := make(map[string]string)
+			for _, label := range metric.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+
+			if labels["query"] == query && labels["status"] == status {
+				return metric.GetHistogram().GetSampleCount()
+			}
+		}
+	}
+
+	return 0
+}
+
+func TestRepositorySessions(t *testing.T) {
+	db, err := Open(MemoryPath)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	ctx := context.Background()
+	r := repo.NewRepository(db)
+
+	user := &models.User{Name: "Test User", Username: "test", Password: "password", Role: "player"}
+	require.NoError(t, r.CreateUser(ctx, user))
+
+	now := time.Now().UTC()
+	session := &models.Session{
+		UserId:           user.Id,
+		RefreshTokenHash: "first",
+		CreatedAt:        now,
+		LastUsedAt:       now,
+		ExpiresAt:        now.Add(time.Hour),
+	}
+	require.NoError(t, r.CreateSession(ctx, session))
+
+	// The first refresh rotates the token, so reusing the old token fails.
+	require.NoError(t, r.RotateSessionRefreshToken(ctx, session.Id, "first", "second", now))
+	require.ErrorIs(t, r.RotateSessionRefreshToken(ctx, session.Id, "first", "third", now), repo.ErrSessionNotFound)
+
+	got, err := r.GetSessionByRefreshTokenHash(ctx, "second")
+	require.NoError(t, err)
+	require.Equal(t, session.Id, got.Id)
+
+	// A revoked session cannot be refreshed, and revoking it again leaves it revoked.
+	require.NoError(t, r.RevokeSession(ctx, session.Id))
+	require.NoError(t, r.RevokeSession(ctx, session.Id))
+	require.ErrorIs(t, r.RotateSessionRefreshToken(ctx, session.Id, "second", "third", now), repo.ErrSessionNotFound)
+
+	got, err = r.GetSessionById(ctx, session.Id)
+	require.NoError(t, err)
+	require.True(t, got.RevokedAt.Valid)
+	require.Equal(t, "second", got.RefreshTokenHash)
+
+	// An expired session cannot be refreshed.
+	expired := &models.Session{
+		UserId:           user.Id,
+		RefreshTokenHash: "expired",
+		CreatedAt:        now.Add(-2 * time.Hour),
+		LastUsedAt:       now.Add(-2 * time.Hour),
+		ExpiresAt:        now.Add(-time.Hour),
+	}
+	require.NoError(t, r.CreateSession(ctx, expired))
+	require.ErrorIs(t, r.RotateSessionRefreshToken(ctx, expired.Id, "expired", "new", now), repo.ErrSessionNotFound)
+}
+
+func TestRepositoryApiKeys(t *testing.T) {
+	db, err := Open(MemoryPath)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	ctx := context.Background()
+	r := repo.NewRepository(db)
+
+	user := &models.User{Name: "Test User", Username: "test", Password: "password", Role: "player"}
+	require.NoError(t, r.CreateUser(ctx, user))
+
+	apiKey := &models.ApiKey{UserId: user.Id, Name: "key", KeyHash: "hash", Scopes: "rounds:read", CreatedAt: time.Now().UTC()}
+	require.NoError(t, r.CreateApiKey(ctx, apiKey))
+
+	require.NoError(t, r.UpdateApiKeyLastUsed(ctx, apiKey.Id, time.Now().UTC()))
+
+	got, err := r.GetApiKeyById(ctx, apiKey.Id)
+	require.NoError(t, err)
+	require.True(t, got.LastUsedAt.Valid)
+	require.False(t, got.RevokedAt.Valid)
+
+	// Recording a use after the key was revoked leaves it revoked.
+	require.NoError(t, r.RevokeApiKey(ctx, apiKey.Id))
+	require.NoError(t, r.UpdateApiKeyLastUsed(ctx, apiKey.Id, time.Now().UTC()))
+
+	got, err = r.GetApiKeyById(ctx, apiKey.Id)
+	require.NoError(t, err)
+	require.True(t, got.RevokedAt.Valid)
+}
+
+func TestRepositoryLoginChallenges(t *testing.T) {
+	db, err := Open(MemoryPath)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	ctx := context.Background()
+	r := repo.NewRepository(db)
+
+	user := &models.User{Name: "Test User", Username: "test", Password: "password", Role: "player"}
+	require.NoError(t, r.CreateUser(ctx, user))
+
+	now := time.Now().UTC()
+	challenge := &models.LoginChallenge{
+		UserId:    user.Id,
+		TokenHash: "challenge",
+		Details:   "oidc provider test",
+		CreatedAt: now,
+		ExpiresAt: now.Add(time.Minute),
+	}
+	require.NoError(t, r.CreateLoginChallenge(ctx, challenge))
+
+	// The challenge can only be used once.
+	got, err := r.ConsumeLoginChallenge(ctx, "challenge")
+	require.NoError(t, err)
+	require.Equal(t, user.Id, got.UserId)
+	require.Equal(t, "oidc provider test", got.Details)
+
+	_, err = r.ConsumeLoginChallenge(ctx, "challenge")
+	require.ErrorIs(t, err, repo.ErrLoginChallengeNotFound)
+}
+
+func TestRepositoryCustomCourses(t *testing.T) {
+	db, err := Open(MemoryPath)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	ctx := context.Background()
+	r := repo.NewRepository(db)
+
+	user := &models.User{Name: "Test User", Username: "test", Password: "password", Role: "player"}
+	require.NoError(t, r.CreateUser(ctx, user))
+
+	teeSet := func(externalId, version int) *repo.TeeSet {
+		return &repo.TeeSet{
+			Details: &models.CourseDetails{ExternalId: externalId, Version: version, Marker: *usql.NewNullString("White")},
+			Holes:   []*models.Hole{{Number: 1, Par: 4}, {Number: 2, Par: 3}},
+		}
+	}
+
+	counts := func() map[string]int {
+		got := make(map[string]int)
+		for _, table := range []string{"course", "course_details", "hole"} {
+			var count int
+			require.NoError(t, db.Get(&count, "SELECT COUNT(*) FROM "+table))
+			got[table] = count
+		}
+		return got
+	}
+
+	// A tee set that fails to be written leaves nothing behind.
+	course := &models.Course{UserId: *usql.NewNullInt64(int64(user.Id)), Name: "Test Course"}
+	require.Error(t, r.CreateCustomCourse(ctx, course, []*repo.TeeSet{teeSet(1, 1), teeSet(1, 1)}))
+	require.Equal(t, map[string]int{"course": 0, "course_details": 0, "hole": 0}, counts())
+
+	require.NoError(t, r.CreateCustomCourse(ctx, course, []*repo.TeeSet{teeSet(1, 1), teeSet(2, 1)}))
+	require.Equal(t, map[string]int{"course": 1, "course_details": 2, "hole": 4}, counts())
+
+	// The same applies when updating, including to the name of the course.
+	course.Name = "Renamed Course"
+	require.Error(t, r.UpdateCustomCourse(ctx, course, []*repo.TeeSet{teeSet(1, 2), teeSet(2, 1)}))
+	require.Equal(t, map[string]int{"course": 1, "course_details": 2, "hole": 4}, counts())
+
+	got, err := r.GetCourseById(ctx, course.Id)
+	require.NoError(t, err)
+	require.Equal(t, "Test Course", got.Name)
+
+	require.NoError(t, r.UpdateCustomCourse(ctx, course, []*repo.TeeSet{teeSet(1, 2)}))
+	require.Equal(t, map[string]int{"course": 1, "course_details": 3, "hole": 6}, counts())
+
+	got, err = r.GetCourseById(ctx, course.Id)
+	require.NoError(t, err)
+	require.Equal(t, "Renamed Course", got.Name)
+}
+
+func TestRepositoryImportTeeSet(t *testing.T) {
+	db, err := Open(MemoryPath)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	ctx := context.Background()
+	r := repo.NewRepository(db)
+
+	teeSet := func(par int) *repo.TeeSet {
+		return &repo.TeeSet{
+			Details: &models.CourseDetails{ExternalId: 7, Marker: *usql.NewNullString("White")},
+			Holes:   []*models.Hole{{Number: 1, Par: par}, {Number: 2, Par: 3}},
+		}
+	}
+
+	course := &models.Course{ExternalId: *usql.NewNullInt64(42), Name: "Test Course"}
+	first, err := r.ImportTeeSet(ctx, course, teeSet(4))
+	require.NoError(t, err)
+	require.NotZero(t, course.Id)
+
+	// Importing the same tee set again returns the existing version, and a renamed course keeps its ID.
+	renamed := &models.Course{ExternalId: *usql.NewNullInt64(42), Name: "Renamed Course"}
+	again, err := r.ImportTeeSet(ctx, renamed, teeSet(4))
+	require.NoError(t, err)
+	require.Equal(t, first, again)
+	require.Equal(t, course.Id, renamed.Id)
+	require.Equal(t, "Renamed Course", renamed.Name)
+
+	// A changed tee set is imported as a new version.
+	changed, err := r.ImportTeeSet(ctx, renamed, teeSet(5))
+	require.NoError(t, err)
+	require.NotEqual(t, first, changed)
+
+	latest, err := r.GetLatestCourseDetails(ctx, course.Id, 7)
+	require.NoError(t, err)
+	require.Equal(t, changed, latest.Id)
+	require.Equal(t, 2, latest.Version)
+
+	var count int
+	require.NoError(t, db.Get(&count, "SELECT COUNT(*) FROM course"))
+	require.Equal(t, 1, count)
+}
@@ -5,7 +5,9 @@ import "context"
 type contextKey string
 
 const (
-	userIdKey contextKey = "user_id"
+	userIdKey    contextKey = "user_id"
+	sessionIdKey contextKey = "session_id"
+	clientIPKey  contextKey = "client_ip"
 )
 
 // UserIdFromContext returns the user_id from the context.
@@ -21,3 +23,31 @@ func UserIdFromContext(ctx context.Context) int {
 func UserIdToContext(ctx context.Context, userId int) context.Context {
 	return context.WithValue(ctx, userIdKey, userId)
 }
+
+// SessionIdFromContext returns the session_id from the context.
+func SessionIdFromContext(ctx context.Context) int {
+	sessionId, ok := ctx.Value(sessionIdKey).(int)
+	if !ok {
+		return -1
+	}
+	return sessionId
+}
+
+// SessionIdToContext adds the session_id to the context.
+func SessionIdToContext(ctx context.Context, sessionId int) context.Context {
+	return context.WithValue(ctx, sessionIdKey, sessionId)
+}
+
+// ClientIPFromContext returns the client_ip from the context, or an empty string if it has not been added.
+func ClientIPFromContext(ctx context.Context) string {
+	clientIP, ok := ctx.Value(clientIPKey).(string)
+	if !ok {
+		return ""
+	}
+	return clientIP
+}
+
+// ClientIPToContext adds the client_ip to the context.
+func ClientIPToContext(ctx context.Context, clientIP string) context.Context {
+	return context.WithValue(ctx, clientIPKey, clientIP)
+}